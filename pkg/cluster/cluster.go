@@ -55,6 +55,8 @@ func HandleStatsAsync(c Cluster, region *core.RegionInfo) {
 	c.GetHotStat().CheckReadAsync(checkExpiredTask)
 	c.GetHotStat().CheckWriteAsync(checkWritePeerTask)
 	c.GetCoordinator().GetSchedulersController().CheckTransferWitnessLeader(region)
+	c.GetCoordinator().GetSchedulersController().ObserveRegion(region)
+	c.GetCoordinator().GetCheckerController().InspectRepairRegion(region)
 }
 
 // HandleOverlaps handles the overlap regions.
@@ -16,8 +16,11 @@ package core
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
 	"github.com/tikv/pd/pkg/core/constant"
 )
 
@@ -38,7 +41,26 @@ func NewBasicCluster() *BasicCluster {
 // UpdateStoreStatus updates the information of the store.
 func (bc *BasicCluster) UpdateStoreStatus(storeID uint64) {
 	leaderCount, regionCount, witnessCount, learnerCount, pendingPeerCount, leaderRegionSize, regionSize := bc.GetStoreStats(storeID)
-	bc.StoresInfo.UpdateStoreStatus(storeID, leaderCount, regionCount, witnessCount, learnerCount, pendingPeerCount, leaderRegionSize, regionSize)
+	leaderWriteQueryRate := bc.GetStoreLeaderWriteQueryRate(storeID)
+	bc.StoresInfo.UpdateStoreStatus(storeID, leaderCount, regionCount, witnessCount, learnerCount, pendingPeerCount, leaderRegionSize, regionSize, leaderWriteQueryRate)
+}
+
+// GetStoreLeaderReadQueryRate gets the read query rate of the store's leaders from
+// the peer stats of the store heartbeat. The read queries of the region heartbeats
+// are not used.
+func (bc *BasicCluster) GetStoreLeaderReadQueryRate(storeID uint64, stats *pdpb.StoreStats) float64 {
+	interval := stats.GetInterval().GetEndTimestamp() - stats.GetInterval().GetStartTimestamp()
+	if interval == 0 {
+		return 0
+	}
+	var queryNum uint64
+	for _, peerStat := range stats.GetPeerStats() {
+		region := bc.GetRegion(peerStat.GetRegionId())
+		if region != nil && region.GetLeader().GetStoreId() == storeID {
+			queryNum += GetReadQueryNum(peerStat.GetQueryStats())
+		}
+	}
+	return float64(queryNum) / float64(interval)
 }
 
 /* Regions read operations */
@@ -166,6 +188,24 @@ func (kr KeyRange) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// UnmarshalJSON unmarshals from json, the keys are in hex format as MarshalJSON.
+func (kr *KeyRange) UnmarshalJSON(data []byte) error {
+	m := make(map[string]string)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	startKey, err := hex.DecodeString(m["start-key"])
+	if err != nil {
+		return err
+	}
+	endKey, err := hex.DecodeString(m["end-key"])
+	if err != nil {
+		return err
+	}
+	kr.StartKey, kr.EndKey = startKey, endKey
+	return nil
+}
+
 // NewKeyRange create a KeyRange with the given start key and end key.
 func NewKeyRange(startKey, endKey string) KeyRange {
 	return KeyRange{
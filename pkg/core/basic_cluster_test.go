@@ -15,6 +15,7 @@
 package core
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -91,3 +92,20 @@ func TestMergeKeyRanges(t *testing.T) {
 		re.Equal(tc.expect, rs.Ranges(), tc.name)
 	}
 }
+
+func TestKeyRangeJSON(t *testing.T) {
+	re := require.New(t)
+	for _, kr := range []KeyRange{
+		NewKeyRange("a", "b"),
+		NewKeyRange("", "\x00\xff"),
+		NewKeyRange("", ""),
+	} {
+		data, err := json.Marshal(kr)
+		re.NoError(err)
+		var decoded KeyRange
+		re.NoError(json.Unmarshal(data, &decoded))
+		re.Equal(kr, decoded)
+	}
+	var kr KeyRange
+	re.Error(json.Unmarshal([]byte(`{"start-key":"zz"}`), &kr))
+}
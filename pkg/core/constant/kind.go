@@ -92,6 +92,8 @@ const (
 	ByCount SchedulePolicy = iota
 	// BySize indicates that balance by size
 	BySize
+	// ByLoad indicates that balance by the query rate
+	ByLoad
 )
 
 func (k SchedulePolicy) String() string {
@@ -100,6 +102,8 @@ func (k SchedulePolicy) String() string {
 		return "count"
 	case BySize:
 		return "size"
+	case ByLoad:
+		return "load"
 	default:
 		return "unknown"
 	}
@@ -112,6 +116,8 @@ func StringToSchedulePolicy(input string) SchedulePolicy {
 		return BySize
 	case ByCount.String():
 		return ByCount
+	case ByLoad.String():
+		return ByLoad
 	default:
 		panic("invalid schedule policy: " + input)
 	}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -407,6 +408,21 @@ func (r Role) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + r.String() + `"`), nil
 }
 
+// UnmarshalJSON parses the JSON encoding of Role.
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var role string
+	if err := json.Unmarshal(data, &role); err != nil {
+		return err
+	}
+	// The follower is encoded as voter.
+	if role == Follower.String() {
+		*r = Follower
+		return nil
+	}
+	*r = NewRole(role)
+	return nil
+}
+
 // GetPeersByRole returns the peers with specified role.
 func (r *RegionInfo) GetPeersByRole(role Role) []*metapb.Peer {
 	switch role {
@@ -733,6 +749,18 @@ func (r *RegionInfo) GetWriteRate() (bytesRate, keysRate float64) {
 	return 0, 0
 }
 
+// GetWriteQueryRate returns the write query rate of the region. The read queries
+// of the region heartbeat are not counted, they are got from the hot peer cache,
+// which is fed by the peer stats of the store heartbeats.
+func (r *RegionInfo) GetWriteQueryRate() float64 {
+	reportInterval := r.GetInterval()
+	interval := reportInterval.GetEndTimestamp() - reportInterval.GetStartTimestamp()
+	if interval >= statsReportMinInterval && interval <= statsReportMaxInterval {
+		return float64(r.GetWriteQueryNum()) / float64(interval)
+	}
+	return 0
+}
+
 // GetLeader returns the leader of the region.
 func (r *RegionInfo) GetLeader() *metapb.Peer {
 	return r.leader
@@ -1764,6 +1792,13 @@ func (r *RegionsInfo) GetStoreWriteRate(storeID uint64) (bytesRate, keysRate flo
 	return
 }
 
+// GetStoreLeaderWriteQueryRate gets the total write query rate of the store's leaders.
+func (r *RegionsInfo) GetStoreLeaderWriteQueryRate(storeID uint64) float64 {
+	r.st.RLock()
+	defer r.st.RUnlock()
+	return r.leaders[storeID].TotalWriteQueryRate()
+}
+
 // GetClusterNotFromStorageRegionsCnt gets the `NotFromStorageRegionsCnt` count of regions that not loaded from storage anymore.
 func (r *RegionsInfo) GetClusterNotFromStorageRegionsCnt() int {
 	r.t.RLock()
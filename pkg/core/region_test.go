@@ -543,6 +543,8 @@ func TestSetRegion(t *testing.T) {
 		SetApproximateSize(30),
 		SetWrittenBytes(40),
 		SetWrittenKeys(10),
+		SetReadQuery(15),
+		SetWrittenQuery(5),
 		SetReportInterval(0, 5))
 	origin, overlaps, rangeChanged = regions.SetRegion(region)
 	regions.UpdateSubTree(region, origin, overlaps, rangeChanged)
@@ -554,6 +556,9 @@ func TestSetRegion(t *testing.T) {
 	bytesRate, keysRate := regions.tree.TotalWriteRate()
 	re.Equal(float64(8), bytesRate)
 	re.Equal(float64(2), keysRate)
+	// The read queries of the region heartbeat are not counted.
+	re.Equal(float64(1), regions.tree.TotalWriteQueryRate())
+	re.Equal(float64(1), regions.GetStoreLeaderWriteQueryRate(region.GetLeader().GetStoreId()))
 }
 
 func TestShouldRemoveFromSubTree(t *testing.T) {
@@ -64,6 +64,7 @@ type regionTree struct {
 	totalSize           int64
 	totalWriteBytesRate float64
 	totalWriteKeysRate  float64
+	totalWriteQueryRate float64
 	// count the number of regions that not loaded from storage.
 	notFromStorageRegionsCnt int
 	// count reference of RegionInfo
@@ -76,6 +77,7 @@ func newRegionTree() *regionTree {
 		totalSize:                0,
 		totalWriteBytesRate:      0,
 		totalWriteKeysRate:       0,
+		totalWriteQueryRate:      0,
 		notFromStorageRegionsCnt: 0,
 	}
 }
@@ -86,6 +88,7 @@ func newRegionTreeWithCountRef() *regionTree {
 		totalSize:                0,
 		totalWriteBytesRate:      0,
 		totalWriteKeysRate:       0,
+		totalWriteQueryRate:      0,
 		notFromStorageRegionsCnt: 0,
 		countRef:                 true,
 	}
@@ -138,6 +141,7 @@ func (t *regionTree) update(item *regionItem, withOverlaps bool, overlaps ...*Re
 	regionWriteBytesRate, regionWriteKeysRate := region.GetWriteRate()
 	t.totalWriteBytesRate += regionWriteBytesRate
 	t.totalWriteKeysRate += regionWriteKeysRate
+	t.totalWriteQueryRate += region.GetWriteQueryRate()
 	if !region.LoadedFromStorage() {
 		t.notFromStorageRegionsCnt++
 	}
@@ -165,6 +169,7 @@ func (t *regionTree) update(item *regionItem, withOverlaps bool, overlaps ...*Re
 		regionWriteBytesRate, regionWriteKeysRate = old.GetWriteRate()
 		t.totalWriteBytesRate -= regionWriteBytesRate
 		t.totalWriteKeysRate -= regionWriteKeysRate
+		t.totalWriteQueryRate -= old.GetWriteQueryRate()
 		if !old.LoadedFromStorage() {
 			t.notFromStorageRegionsCnt--
 		}
@@ -182,11 +187,13 @@ func (t *regionTree) updateStat(origin *RegionInfo, region *RegionInfo) {
 	regionWriteBytesRate, regionWriteKeysRate := region.GetWriteRate()
 	t.totalWriteBytesRate += regionWriteBytesRate
 	t.totalWriteKeysRate += regionWriteKeysRate
+	t.totalWriteQueryRate += region.GetWriteQueryRate()
 
 	t.totalSize -= origin.approximateSize
 	regionWriteBytesRate, regionWriteKeysRate = origin.GetWriteRate()
 	t.totalWriteBytesRate -= regionWriteBytesRate
 	t.totalWriteKeysRate -= regionWriteKeysRate
+	t.totalWriteQueryRate -= origin.GetWriteQueryRate()
 
 	// If the region meta information not loaded from storage anymore, decrease the counter.
 	if origin.LoadedFromStorage() && !region.LoadedFromStorage() {
@@ -219,6 +226,7 @@ func (t *regionTree) remove(region *RegionInfo) {
 	regionWriteBytesRate, regionWriteKeysRate := result.GetWriteRate()
 	t.totalWriteBytesRate -= regionWriteBytesRate
 	t.totalWriteKeysRate -= regionWriteKeysRate
+	t.totalWriteQueryRate -= result.GetWriteQueryRate()
 	if t.countRef {
 		result.RegionInfo.DecRef()
 	}
@@ -475,3 +483,11 @@ func (t *regionTree) TotalWriteRate() (bytesRate, keysRate float64) {
 	}
 	return t.totalWriteBytesRate, t.totalWriteKeysRate
 }
+
+// TotalWriteQueryRate returns the total write query rate of all regions.
+func (t *regionTree) TotalWriteQueryRate() float64 {
+	if t.length() == 0 {
+		return 0
+	}
+	return t.totalWriteQueryRate
+}
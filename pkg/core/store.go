@@ -63,6 +63,8 @@ type StoreInfo struct {
 	witnessCount           int
 	leaderSize             int64
 	regionSize             int64
+	leaderWriteQueryRate   float64
+	leaderReadQueryRate    float64
 	pendingPeerCount       int
 	lastPersistTime        time.Time
 	leaderWeight           float64
@@ -306,6 +308,11 @@ func (s *StoreInfo) GetRegionSize() int64 {
 	return s.regionSize
 }
 
+// GetLeaderQueryRate returns the total read and write query rate of the leaders in the store.
+func (s *StoreInfo) GetLeaderQueryRate() float64 {
+	return s.leaderWriteQueryRate + s.leaderReadQueryRate
+}
+
 // GetPendingPeerCount returns the pending peer count of the store.
 func (s *StoreInfo) GetPendingPeerCount() int {
 	return s.pendingPeerCount
@@ -348,6 +355,8 @@ func (s *StoreInfo) LeaderScore(policy constant.SchedulePolicy, delta int64) flo
 		return float64(s.GetLeaderSize()+delta) / math.Max(s.GetLeaderWeight(), minWeight)
 	case constant.ByCount:
 		return float64(int64(s.GetLeaderCount())+delta) / math.Max(s.GetLeaderWeight(), minWeight)
+	case constant.ByLoad:
+		return (s.GetLeaderQueryRate() + float64(delta)) / math.Max(s.GetLeaderWeight(), minWeight)
 	default:
 		return 0
 	}
@@ -898,7 +907,7 @@ func (s *StoresInfo) DeleteStore(store *StoreInfo) {
 }
 
 // UpdateStoreStatus updates the information of the store.
-func (s *StoresInfo) UpdateStoreStatus(storeID uint64, leaderCount, regionCount, witnessCount, learnerCount, pendingPeerCount int, leaderSize int64, regionSize int64) {
+func (s *StoresInfo) UpdateStoreStatus(storeID uint64, leaderCount, regionCount, witnessCount, learnerCount, pendingPeerCount int, leaderSize int64, regionSize int64, leaderWriteQueryRate float64) {
 	s.Lock()
 	defer s.Unlock()
 	if store, ok := s.stores[storeID]; ok {
@@ -908,7 +917,8 @@ func (s *StoresInfo) UpdateStoreStatus(storeID uint64, leaderCount, regionCount,
 			SetLearnerCount(learnerCount),
 			SetPendingPeerCount(pendingPeerCount),
 			SetLeaderSize(leaderSize),
-			SetRegionSize(regionSize))
+			SetRegionSize(regionSize),
+			SetLeaderWriteQueryRate(leaderWriteQueryRate))
 		s.putStoreLocked(newStore)
 	}
 }
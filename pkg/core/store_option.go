@@ -196,6 +196,20 @@ func SetLeaderSize(leaderSize int64) StoreCreateOption {
 	}
 }
 
+// SetLeaderWriteQueryRate sets the leader write query rate for the store.
+func SetLeaderWriteQueryRate(leaderWriteQueryRate float64) StoreCreateOption {
+	return func(store *StoreInfo) {
+		store.leaderWriteQueryRate = leaderWriteQueryRate
+	}
+}
+
+// SetLeaderReadQueryRate sets the leader read query rate for the store.
+func SetLeaderReadQueryRate(leaderReadQueryRate float64) StoreCreateOption {
+	return func(store *StoreInfo) {
+		store.leaderReadQueryRate = leaderReadQueryRate
+	}
+}
+
 // SetRegionSize sets the Region size for the store.
 func SetRegionSize(regionSize int64) StoreCreateOption {
 	return func(store *StoreInfo) {
@@ -261,6 +261,14 @@ var (
 	ErrKeyspaceGroupInMerging = errors.Normalize("keyspace group %v is in merging state", errors.RFCCodeText("PD:keyspace:ErrKeyspaceGroupInMerging"))
 	// ErrKeyspaceGroupNotInMerging is used to indicate target keyspace group is not in merging state.
 	ErrKeyspaceGroupNotInMerging = errors.Normalize("keyspace group %v is not in merging state", errors.RFCCodeText("PD:keyspace:ErrKeyspaceGroupNotInMerging"))
+	// ErrResourcePoolNotFound is used to indicate target resource pool does not exist.
+	ErrResourcePoolNotFound = errors.Normalize("resource pool %s does not exist", errors.RFCCodeText("PD:keyspace:ErrResourcePoolNotFound"))
+	// ErrResourcePoolInvalid is used to indicate the resource pool is invalid.
+	ErrResourcePoolInvalid = errors.Normalize("invalid resource pool, %s", errors.RFCCodeText("PD:keyspace:ErrResourcePoolInvalid"))
+	// ErrResourcePoolOverlapped is used to indicate the keyspaces of the resource pool are in another pool.
+	ErrResourcePoolOverlapped = errors.Normalize("the keyspaces of resource pool %s overlap with resource pool %s", errors.RFCCodeText("PD:keyspace:ErrResourcePoolOverlapped"))
+	// ErrResourcePoolCapacity is used to indicate the stores of the resource pool are not enough.
+	ErrResourcePoolCapacity = errors.Normalize("resource pool %s has not enough capacity, %s", errors.RFCCodeText("PD:keyspace:ErrResourcePoolCapacity"))
 	// errKeyspaceGroupNotInMerging is used to indicate target keyspace group is not in merging state.
 )
 
@@ -274,13 +282,16 @@ var (
 
 // placement errors
 var (
-	ErrRuleContent       = errors.Normalize("invalid rule content, %s", errors.RFCCodeText("PD:placement:ErrRuleContent"))
-	ErrLoadRule          = errors.Normalize("load rule failed", errors.RFCCodeText("PD:placement:ErrLoadRule"))
-	ErrLoadRuleGroup     = errors.Normalize("load rule group failed", errors.RFCCodeText("PD:placement:ErrLoadRuleGroup"))
-	ErrBuildRuleList     = errors.Normalize("build rule list failed, %s", errors.RFCCodeText("PD:placement:ErrBuildRuleList"))
-	ErrPlacementDisabled = errors.Normalize("placement rules feature is disabled", errors.RFCCodeText("PD:placement:ErrPlacementDisabled"))
-	ErrKeyFormat         = errors.Normalize("key should be in hex format, %s", errors.RFCCodeText("PD:placement:ErrKeyFormat"))
-	ErrRuleNotFound      = errors.Normalize("rule not found", errors.RFCCodeText("PD:placement:ErrRuleNotFound"))
+	ErrRuleContent          = errors.Normalize("invalid rule content, %s", errors.RFCCodeText("PD:placement:ErrRuleContent"))
+	ErrLoadRule             = errors.Normalize("load rule failed", errors.RFCCodeText("PD:placement:ErrLoadRule"))
+	ErrLoadRuleGroup        = errors.Normalize("load rule group failed", errors.RFCCodeText("PD:placement:ErrLoadRuleGroup"))
+	ErrBuildRuleList        = errors.Normalize("build rule list failed, %s", errors.RFCCodeText("PD:placement:ErrBuildRuleList"))
+	ErrPlacementDisabled    = errors.Normalize("placement rules feature is disabled", errors.RFCCodeText("PD:placement:ErrPlacementDisabled"))
+	ErrKeyFormat            = errors.Normalize("key should be in hex format, %s", errors.RFCCodeText("PD:placement:ErrKeyFormat"))
+	ErrRuleNotFound         = errors.Normalize("rule not found", errors.RFCCodeText("PD:placement:ErrRuleNotFound"))
+	ErrLoadRuleTemplate     = errors.Normalize("load rule template failed", errors.RFCCodeText("PD:placement:ErrLoadRuleTemplate"))
+	ErrRuleTemplateContent  = errors.Normalize("invalid rule template content, %s", errors.RFCCodeText("PD:placement:ErrRuleTemplateContent"))
+	ErrRuleTemplateNotFound = errors.Normalize("rule template %s not found", errors.RFCCodeText("PD:placement:ErrRuleTemplateNotFound"))
 )
 
 // region label errors
@@ -337,6 +348,7 @@ var (
 	ErrServerNotStarted       = errors.Normalize("server not started", errors.RFCCodeText("PD:server:ErrServerNotStarted"))
 	ErrRateLimitExceeded      = errors.Normalize("rate limit exceeded", errors.RFCCodeText("PD:server:ErrRateLimitExceeded"))
 	ErrLeaderFrequentlyChange = errors.Normalize("leader %s frequently changed, leader-key is [%s]", errors.RFCCodeText("PD:server:ErrLeaderFrequentlyChange"))
+	ErrConfigSnapshotNotFound = errors.Normalize("config snapshot %v not found", errors.RFCCodeText("PD:server:ErrConfigSnapshotNotFound"))
 )
 
 // logutil errors
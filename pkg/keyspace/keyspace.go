@@ -91,6 +91,8 @@ type Manager struct {
 	kgm *GroupManager
 	// nextPatrolStartID is the next start id of keyspace assignment patrol.
 	nextPatrolStartID uint32
+	// poolLock guards the changes of the resource pools.
+	poolLock syncutil.RWMutex
 }
 
 // CreateKeyspaceRequest represents necessary arguments to create a keyspace.
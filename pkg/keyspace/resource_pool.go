@@ -0,0 +1,370 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/go-units"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+const (
+	// resourcePoolRuleGroupPrefix is used to prefix the placement rule group of a resource pool.
+	resourcePoolRuleGroupPrefix = "resource-pool-"
+	// resourcePoolRuleGroupIndex is the index of the placement rule groups of the
+	// resource pools. The groups override the rules with less indexes, such as the
+	// default rule, in the key ranges of the pools, and leave the rules with greater
+	// indexes, such as the TiFlash rules, untouched.
+	resourcePoolRuleGroupIndex = 100
+)
+
+// ResourcePool confines the regions of a range of keyspaces to the stores selected
+// by labels. It is applied as a placement rule group which covers the raw and txn
+// key ranges of the keyspaces, so the keyspaces created later in the range are
+// confined as well.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type ResourcePool struct {
+	Name string `json:"name"`
+	// StartKeyspaceID and EndKeyspaceID are the first and the last keyspace ID of the pool.
+	StartKeyspaceID uint32 `json:"start_keyspace_id"`
+	EndKeyspaceID   uint32 `json:"end_keyspace_id"`
+	// LabelConstraints select the stores of the pool.
+	LabelConstraints []placement.LabelConstraint `json:"label_constraints"`
+	// Replicas is the number of the replicas of the regions in the pool, the
+	// max replicas of the cluster is used if it is 0.
+	Replicas int `json:"replicas,omitempty"`
+	// LocationLabels are used to isolate the replicas in the pool.
+	LocationLabels []string `json:"location_labels,omitempty"`
+}
+
+// ResourcePoolStatus is the status of a resource pool.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type ResourcePoolStatus struct {
+	*ResourcePool
+	// StoreIDs are the stores which match the pool.
+	StoreIDs []uint64 `json:"store_ids"`
+	// RegionSize is the total size of the regions in the pool in MiB.
+	RegionSize int64 `json:"region_size"`
+	// Available is the total available space of the stores in bytes.
+	Available uint64 `json:"available"`
+}
+
+func (p *ResourcePool) ruleGroupID() string {
+	return resourcePoolRuleGroupPrefix + p.Name
+}
+
+func (p *ResourcePool) overlaps(other *ResourcePool) bool {
+	return p.StartKeyspaceID <= other.EndKeyspaceID && other.StartKeyspaceID <= p.EndKeyspaceID
+}
+
+// keyRanges returns the raw and the txn key ranges of the keyspaces in the pool.
+func (p *ResourcePool) keyRanges() [][2][]byte {
+	start, end := MakeRegionBound(p.StartKeyspaceID), MakeRegionBound(p.EndKeyspaceID)
+	return [][2][]byte{
+		{start.RawLeftBound, end.RawRightBound},
+		{start.TxnLeftBound, end.TxnRightBound},
+	}
+}
+
+// groupBundle returns the placement rules of the pool.
+func (p *ResourcePool) groupBundle(replicas int) placement.GroupBundle {
+	bundle := placement.GroupBundle{
+		ID:       p.ruleGroupID(),
+		Index:    resourcePoolRuleGroupIndex,
+		Override: true,
+	}
+	for i, keyRange := range p.keyRanges() {
+		bundle.Rules = append(bundle.Rules, &placement.Rule{
+			GroupID:          p.ruleGroupID(),
+			ID:               []string{"raw", "txn"}[i],
+			StartKeyHex:      hex.EncodeToString(keyRange[0]),
+			EndKeyHex:        hex.EncodeToString(keyRange[1]),
+			Role:             placement.Voter,
+			Count:            replicas,
+			LabelConstraints: p.LabelConstraints,
+			LocationLabels:   p.LocationLabels,
+		})
+	}
+	return bundle
+}
+
+func (p *ResourcePool) validate() error {
+	if err := validateName(p.Name); err != nil {
+		return errs.ErrResourcePoolInvalid.FastGenByArgs(err.Error())
+	}
+	if p.StartKeyspaceID > p.EndKeyspaceID {
+		return errs.ErrResourcePoolInvalid.FastGenByArgs(
+			fmt.Sprintf("start keyspace id %d is larger than end keyspace id %d", p.StartKeyspaceID, p.EndKeyspaceID))
+	}
+	for _, id := range []uint32{p.StartKeyspaceID, p.EndKeyspaceID} {
+		if err := validateID(id); err != nil {
+			return errs.ErrResourcePoolInvalid.FastGenByArgs(err.Error())
+		}
+	}
+	if len(p.LabelConstraints) == 0 {
+		return errs.ErrResourcePoolInvalid.FastGenByArgs("label constraints should not be empty")
+	}
+	if p.Replicas < 0 {
+		return errs.ErrResourcePoolInvalid.FastGenByArgs(fmt.Sprintf("invalid replicas %d", p.Replicas))
+	}
+	return nil
+}
+
+// SetResourcePool creates or updates the resource pool, and replaces its placement
+// rules. The pool is rejected if its keyspaces are in another pool, or if its stores
+// can not hold the replicas. The pool is persisted before its rules are applied, so
+// the rules which fail to be applied are applied again by ReconcileResourcePools.
+func (manager *Manager) SetResourcePool(pool *ResourcePool) error {
+	if err := pool.validate(); err != nil {
+		return err
+	}
+	ruleManager, err := manager.getRuleManager()
+	if err != nil {
+		return err
+	}
+	manager.poolLock.Lock()
+	defer manager.poolLock.Unlock()
+	pools, err := manager.loadResourcePools()
+	if err != nil {
+		return err
+	}
+	for _, other := range pools {
+		if other.Name != pool.Name && other.overlaps(pool) {
+			return errs.ErrResourcePoolOverlapped.FastGenByArgs(pool.Name, other.Name)
+		}
+	}
+	status := manager.getResourcePoolStatus(pool)
+	replicas := manager.getReplicas(pool)
+	if len(status.StoreIDs) < replicas {
+		return errs.ErrResourcePoolCapacity.FastGenByArgs(pool.Name,
+			fmt.Sprintf("%d stores match the pool, but %d replicas are required", len(status.StoreIDs), replicas))
+	}
+	if required := uint64(status.RegionSize*units.MiB) * uint64(replicas); required > status.Available {
+		return errs.ErrResourcePoolCapacity.FastGenByArgs(pool.Name,
+			fmt.Sprintf("%s are required by the replicas, but only %s are available",
+				units.BytesSize(float64(required)), units.BytesSize(float64(status.Available))))
+	}
+
+	err = manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		return manager.store.SaveResourcePool(txn, pool.Name, pool)
+	})
+	if err != nil {
+		return err
+	}
+	if err := ruleManager.SetGroupBundle(pool.groupBundle(replicas)); err != nil {
+		log.Warn("[keyspace] failed to apply the rules of resource pool, they will be applied when the pools are reconciled",
+			zap.String("name", pool.Name), zap.Error(err))
+		return err
+	}
+	log.Info("[keyspace] resource pool is set",
+		zap.String("name", pool.Name),
+		zap.Uint32("start-keyspace-id", pool.StartKeyspaceID),
+		zap.Uint32("end-keyspace-id", pool.EndKeyspaceID),
+		zap.Uint64s("store-ids", status.StoreIDs))
+	return nil
+}
+
+// DeleteResourcePool deletes the resource pool and its placement rules. The keyspaces
+// in the pool are placed by the other rules afterwards. The pool is removed from the
+// storage before its rules, so the rules which fail to be removed are removed by
+// ReconcileResourcePools.
+func (manager *Manager) DeleteResourcePool(name string) error {
+	ruleManager, err := manager.getRuleManager()
+	if err != nil {
+		return err
+	}
+	manager.poolLock.Lock()
+	defer manager.poolLock.Unlock()
+	pools, err := manager.loadResourcePools()
+	if err != nil {
+		return err
+	}
+	pool, ok := pools[name]
+	if !ok {
+		return errs.ErrResourcePoolNotFound.FastGenByArgs(name)
+	}
+	err = manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		return manager.store.DeleteResourcePool(txn, name)
+	})
+	if err != nil {
+		return err
+	}
+	if err := ruleManager.DeleteGroupBundle(pool.ruleGroupID(), false); err != nil {
+		log.Warn("[keyspace] failed to remove the rules of resource pool, they will be removed when the pools are reconciled",
+			zap.String("name", name), zap.Error(err))
+		return err
+	}
+	log.Info("[keyspace] resource pool is deleted", zap.String("name", name))
+	return nil
+}
+
+// ReconcileResourcePools applies the placement rules of the persisted resource pools
+// and removes the rules of the pools which do not exist anymore. It is called when
+// the PD leader starts to serve, since the rules and the pools are not changed in
+// one transaction.
+func (manager *Manager) ReconcileResourcePools() error {
+	manager.poolLock.Lock()
+	defer manager.poolLock.Unlock()
+	pools, err := manager.loadResourcePools()
+	if err != nil {
+		return err
+	}
+	ruleManager, err := manager.getRuleManager()
+	if err != nil {
+		if len(pools) == 0 {
+			return nil
+		}
+		return err
+	}
+	for _, bundle := range ruleManager.GetAllGroupBundles() {
+		if !strings.HasPrefix(bundle.ID, resourcePoolRuleGroupPrefix) {
+			continue
+		}
+		if _, ok := pools[strings.TrimPrefix(bundle.ID, resourcePoolRuleGroupPrefix)]; ok {
+			continue
+		}
+		if err := ruleManager.DeleteGroupBundle(bundle.ID, false); err != nil {
+			return err
+		}
+		log.Info("[keyspace] the rules of the deleted resource pool are removed", zap.String("group-id", bundle.ID))
+	}
+	for _, pool := range pools {
+		if err := ruleManager.SetGroupBundle(pool.groupBundle(manager.getReplicas(pool))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetResourcePool returns the status of the resource pool.
+func (manager *Manager) GetResourcePool(name string) (*ResourcePoolStatus, error) {
+	manager.poolLock.RLock()
+	defer manager.poolLock.RUnlock()
+	pools, err := manager.loadResourcePools()
+	if err != nil {
+		return nil, err
+	}
+	pool, ok := pools[name]
+	if !ok {
+		return nil, errs.ErrResourcePoolNotFound.FastGenByArgs(name)
+	}
+	return manager.getResourcePoolStatus(pool), nil
+}
+
+// GetResourcePools returns the status of all the resource pools ordered by the keyspace IDs.
+func (manager *Manager) GetResourcePools() ([]*ResourcePoolStatus, error) {
+	manager.poolLock.RLock()
+	defer manager.poolLock.RUnlock()
+	pools, err := manager.loadResourcePools()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ResourcePoolStatus, 0, len(pools))
+	for _, pool := range pools {
+		result = append(result, manager.getResourcePoolStatus(pool))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartKeyspaceID < result[j].StartKeyspaceID
+	})
+	return result, nil
+}
+
+// GetResourcePoolByKeyspaceID returns the resource pool which the keyspace is in,
+// or nil if the keyspace is not in any pool.
+func (manager *Manager) GetResourcePoolByKeyspaceID(id uint32) (*ResourcePool, error) {
+	manager.poolLock.RLock()
+	defer manager.poolLock.RUnlock()
+	pools, err := manager.loadResourcePools()
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		if pool.StartKeyspaceID <= id && id <= pool.EndKeyspaceID {
+			return pool, nil
+		}
+	}
+	return nil, nil
+}
+
+func (manager *Manager) loadResourcePools() (map[string]*ResourcePool, error) {
+	pools := make(map[string]*ResourcePool)
+	err := manager.store.RunInTxn(manager.ctx, func(txn kv.Txn) error {
+		return manager.store.LoadResourcePools(txn, func(v string) error {
+			pool := &ResourcePool{}
+			if err := json.Unmarshal([]byte(v), pool); err != nil {
+				return errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+			}
+			pools[pool.Name] = pool
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+func (manager *Manager) getRuleManager() (*placement.RuleManager, error) {
+	if manager.cluster == nil {
+		return nil, errs.ErrPlacementDisabled.FastGenByArgs()
+	}
+	ruleManager := manager.cluster.GetRuleManager()
+	if ruleManager == nil || !ruleManager.IsInitialized() {
+		return nil, errs.ErrPlacementDisabled.FastGenByArgs()
+	}
+	return ruleManager, nil
+}
+
+func (manager *Manager) getReplicas(pool *ResourcePool) int {
+	if pool.Replicas > 0 {
+		return pool.Replicas
+	}
+	return manager.cluster.GetSharedConfig().GetMaxReplicas()
+}
+
+// getResourcePoolStatus collects the stores which match the pool and can hold
+// replicas, and the size of the regions in the pool.
+func (manager *Manager) getResourcePoolStatus(pool *ResourcePool) *ResourcePoolStatus {
+	status := &ResourcePoolStatus{ResourcePool: pool, StoreIDs: []uint64{}}
+	if manager.cluster == nil {
+		return status
+	}
+	c := manager.cluster.GetBasicCluster()
+	for _, store := range c.GetStores() {
+		if store.IsRemoving() || store.IsRemoved() || !placement.MatchLabelConstraints(store, pool.LabelConstraints) {
+			continue
+		}
+		status.StoreIDs = append(status.StoreIDs, store.GetID())
+		status.Available += store.GetAvailable()
+	}
+	sort.Slice(status.StoreIDs, func(i, j int) bool { return status.StoreIDs[i] < status.StoreIDs[j] })
+	for _, keyRange := range pool.keyRanges() {
+		for _, region := range c.ScanRegions(keyRange[0], keyRange[1], -1) {
+			status.RegionSize += region.GetApproximateSize()
+		}
+	}
+	return status
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/mock/mockid"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestResourcePool(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	opts := mockconfig.NewTestOptions()
+	opts.SetPlacementRuleEnabled(true)
+	cluster := mockcluster.NewCluster(ctx, opts)
+	manager := NewKeyspaceManager(ctx, store, cluster, mockid.NewIDAllocator(), &mockConfig{}, NewKeyspaceGroupManager(ctx, store, nil))
+	for id := uint64(1); id <= 6; id++ {
+		tenant := "a"
+		if id > 3 {
+			tenant = "b"
+		}
+		cluster.AddLabelsStore(id, 0, map[string]string{"tenant": tenant})
+	}
+	constraints := func(tenant string) []placement.LabelConstraint {
+		return []placement.LabelConstraint{{Key: "tenant", Op: placement.In, Values: []string{tenant}}}
+	}
+
+	poolA := &ResourcePool{Name: "a", StartKeyspaceID: 1, EndKeyspaceID: 100, LabelConstraints: constraints("a")}
+	re.NoError(manager.SetResourcePool(poolA))
+	bundle := cluster.GetRuleManager().GetGroupBundle(poolA.ruleGroupID())
+	re.True(bundle.Override)
+	re.Len(bundle.Rules, 2)
+	// The regions of the keyspaces in the pool are placed by the rules of the pool only.
+	bound := MakeRegionBound(50)
+	for _, key := range [][]byte{bound.RawLeftBound, bound.TxnLeftBound} {
+		rules := cluster.GetRuleManager().GetRulesForApplyRange(key, append(key, 0))
+		re.Len(rules, 1)
+		re.Equal(poolA.ruleGroupID(), rules[0].GroupID)
+		re.Equal(3, rules[0].Count)
+	}
+	bound = MakeRegionBound(101)
+	rules := cluster.GetRuleManager().GetRulesForApplyRange(bound.TxnLeftBound, bound.TxnRightBound)
+	re.Len(rules, 1)
+	re.Equal(placement.DefaultGroupID, rules[0].GroupID)
+
+	// The invalid and overlapped pools are rejected.
+	re.True(errs.ErrResourcePoolInvalid.Equal(manager.SetResourcePool(&ResourcePool{Name: "b", StartKeyspaceID: 300, EndKeyspaceID: 200, LabelConstraints: constraints("b")})))
+	re.True(errs.ErrResourcePoolInvalid.Equal(manager.SetResourcePool(&ResourcePool{Name: "b", StartKeyspaceID: 200, EndKeyspaceID: 300})))
+	re.True(errs.ErrResourcePoolOverlapped.Equal(manager.SetResourcePool(&ResourcePool{Name: "b", StartKeyspaceID: 100, EndKeyspaceID: 300, LabelConstraints: constraints("b")})))
+	// The pools without enough stores or space are rejected.
+	re.True(errs.ErrResourcePoolCapacity.Equal(manager.SetResourcePool(&ResourcePool{Name: "b", StartKeyspaceID: 200, EndKeyspaceID: 300, LabelConstraints: constraints("b"), Replicas: 5})))
+	bound = MakeRegionBound(250)
+	region := core.NewTestRegionInfo(10, 4, bound.RawLeftBound, bound.RawRightBound, core.SetApproximateSize(200*1024))
+	cluster.PutRegion(region)
+	re.True(errs.ErrResourcePoolCapacity.Equal(manager.SetResourcePool(&ResourcePool{Name: "b", StartKeyspaceID: 200, EndKeyspaceID: 300, LabelConstraints: constraints("b")})))
+	cluster.PutRegion(region.Clone(core.SetApproximateSize(1024)))
+	re.NoError(manager.SetResourcePool(&ResourcePool{Name: "b", StartKeyspaceID: 200, EndKeyspaceID: 300, LabelConstraints: constraints("b")}))
+
+	pools, err := manager.GetResourcePools()
+	re.NoError(err)
+	re.Len(pools, 2)
+	re.Equal("a", pools[0].Name)
+	re.Equal([]uint64{1, 2, 3}, pools[0].StoreIDs)
+	re.Equal("b", pools[1].Name)
+	re.Equal([]uint64{4, 5, 6}, pools[1].StoreIDs)
+	re.Equal(int64(1024), pools[1].RegionSize)
+	pool, err := manager.GetResourcePoolByKeyspaceID(250)
+	re.NoError(err)
+	re.Equal("b", pool.Name)
+	pool, err = manager.GetResourcePoolByKeyspaceID(150)
+	re.NoError(err)
+	re.Nil(pool)
+
+	// Updating a pool replaces its rules.
+	poolA.EndKeyspaceID = 150
+	poolA.Replicas = 1
+	re.NoError(manager.SetResourcePool(poolA))
+	bound = MakeRegionBound(120)
+	rules = cluster.GetRuleManager().GetRulesForApplyRange(bound.TxnLeftBound, bound.TxnRightBound)
+	re.Len(rules, 1)
+	re.Equal(poolA.ruleGroupID(), rules[0].GroupID)
+	re.Equal(1, rules[0].Count)
+
+	re.NoError(manager.DeleteResourcePool("a"))
+	re.True(errs.ErrResourcePoolNotFound.Equal(manager.DeleteResourcePool("a")))
+	_, err = manager.GetResourcePool("a")
+	re.True(errs.ErrResourcePoolNotFound.Equal(err))
+	re.Empty(cluster.GetRuleManager().GetRulesByGroup(poolA.ruleGroupID()))
+
+	// The rules are reconciled with the persisted pools.
+	re.NoError(cluster.GetRuleManager().SetGroupBundle(poolA.groupBundle(1)))
+	re.NoError(cluster.GetRuleManager().DeleteGroupBundle("resource-pool-b", false))
+	re.NoError(manager.ReconcileResourcePools())
+	re.Empty(cluster.GetRuleManager().GetRulesByGroup(poolA.ruleGroupID()))
+	re.Len(cluster.GetRuleManager().GetRulesByGroup("resource-pool-b"), 2)
+}
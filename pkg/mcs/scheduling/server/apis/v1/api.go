@@ -140,8 +140,13 @@ func (s *Service) RegisterSchedulersRouter() {
 	router := s.root.Group("schedulers")
 	router.GET("", getSchedulers)
 	router.GET("/diagnostic/:name", getDiagnosticResult)
+	router.GET("/dry-run", dryRunSchedulers)
+	router.GET("/trace/:name/:region_id", traceScheduler)
 	router.GET("/config", getSchedulerConfig)
 	router.GET("/config/:name/list", getSchedulerConfigByName)
+	router.GET("/config/:name/progress", getSchedulerProgressByName)
+	router.GET("/config/:name/plan", getSchedulerPlanByName)
+	router.GET("/config/:name/report", getSchedulerReportByName)
 	// TODO: in the future, we should split pauseOrResumeScheduler to two different APIs.
 	// And we need to do one-to-two forwarding in the API middleware.
 	router.POST("/:name", pauseOrResumeScheduler)
@@ -150,6 +155,7 @@ func (s *Service) RegisterSchedulersRouter() {
 // RegisterCheckersRouter registers the router of the checkers handler.
 func (s *Service) RegisterCheckersRouter() {
 	router := s.root.Group("checkers")
+	router.GET("/repair-queue", getRepairQueue)
 	router.GET("/:name", getCheckerByName)
 	router.POST("/:name", pauseOrResumeChecker)
 }
@@ -173,6 +179,7 @@ func (s *Service) RegisterOperatorsRouter() {
 	router.GET("/:id", getOperatorByRegion)
 	router.DELETE("/:id", deleteOperatorByRegion)
 	router.GET("/records", getOperatorRecords)
+	router.GET("/budget", getOperatorBudget)
 }
 
 // RegisterStoresRouter registers the router of the stores handler.
@@ -442,6 +449,22 @@ func getOperatorRecords(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, records)
 }
 
+// @Tags     operator
+// @Summary  lists the usage of the operator slots by each scheduler and checker.
+// @Produce  json
+// @Success  200  {object}  []operator.ConsumerBudget
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/budget [get]
+func getOperatorBudget(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	budgets, err := handler.GetConsumerBudgets()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, budgets)
+}
+
 // FIXME: details of input json body params
 // @Tags     operator
 // @Summary  Create an operator.
@@ -489,6 +512,33 @@ func getCheckerByName(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, output)
 }
 
+// @Tags     checkers
+// @Summary  Get the regions at risk in the order of repairs, the region with the highest data-loss risk is the first.
+// @Param    limit  query  integer  false  "Limit count"
+// @Produce  json
+// @Success  200  {array}   checker.RegionRisk
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /checkers/repair-queue [get]
+func getRepairQueue(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			c.String(http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	queue, err := handler.GetRepairQueue(limit)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, queue)
+}
+
 // FIXME: details of input json body params
 // @Tags     checker
 // @Summary  Pause or resume region merge.
@@ -576,6 +626,43 @@ func getSchedulerConfig(c *gin.Context) {
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
 // @Router   /schedulers/config/{name}/list [get]
 func getSchedulerConfigByName(c *gin.Context) {
+	serveSchedulerHandler(c, "/list")
+}
+
+// @Tags     schedulers
+// @Summary  Get the progress of the scheduler by name, only balance-range-scheduler and evict-leader-scheduler support it now.
+// @Produce  json
+// @Success  200  {array}   any
+// @Failure  404  {string}  string  scheduler not found
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/config/{name}/progress [get]
+func getSchedulerProgressByName(c *gin.Context) {
+	serveSchedulerHandler(c, "/progress")
+}
+
+// @Tags     schedulers
+// @Summary  Get the plan of the scheduler by name, only global-balance-scheduler and cold-region-merge-scheduler support it now.
+// @Produce  json
+// @Success  200  {object}  any
+// @Failure  404  {string}  string  scheduler not found
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/config/{name}/plan [get]
+func getSchedulerPlanByName(c *gin.Context) {
+	serveSchedulerHandler(c, "/plan")
+}
+
+// @Tags     schedulers
+// @Summary  Get the report of the scheduler by name, only shuffle-chaos-scheduler supports it now.
+// @Produce  json
+// @Success  200  {object}  any
+// @Failure  404  {string}  string  scheduler not found
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/config/{name}/report [get]
+func getSchedulerReportByName(c *gin.Context) {
+	serveSchedulerHandler(c, "/report")
+}
+
+func serveSchedulerHandler(c *gin.Context, path string) {
 	handler := c.MustGet(handlerKey).(*handler.Handler)
 	sc, err := handler.GetSchedulersController()
 	if err != nil {
@@ -597,7 +684,7 @@ func getSchedulerConfigByName(c *gin.Context) {
 		c.String(http.StatusNotFound, errs.ErrSchedulerNotFound.GenWithStackByArgs().Error())
 		return
 	}
-	c.Request.URL.Path = "/list"
+	c.Request.URL.Path = path
 	handlers[name].ServeHTTP(c.Writer, c.Request)
 }
 
@@ -618,6 +705,51 @@ func getDiagnosticResult(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, result)
 }
 
+// @Tags     schedulers
+// @Summary  Run schedulers without dispatching the operators.
+// @Param    name  query  string  false  "The name of the scheduler, all schedulers are run if it is empty."
+// @Produce  json
+// @Success  200  {array}   schedulers.DryRunResult
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/dry-run [get]
+func dryRunSchedulers(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	name := c.Query("name")
+	if len(name) == 0 {
+		name = "all"
+	}
+	results, err := handler.DryRunSchedulers(name)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, results)
+}
+
+// @Tags     schedulers
+// @Summary  Run a scheduler without dispatching the operators, and trace why a region is scheduled or not by it.
+// @Param    name       path  string   true  "The name of the scheduler."
+// @Param    region_id  path  integer  true  "The ID of the region."
+// @Produce  json
+// @Success  200  {object}  schedulers.TraceResult
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/trace/{name}/{region_id} [get]
+func traceScheduler(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	regionID, err := strconv.ParseUint(c.Param("region_id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := handler.TraceScheduler(c.Param("name"), regionID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
 // FIXME: details of input json body params
 // @Tags     scheduler
 // @Summary  Pause or resume a scheduler.
@@ -66,6 +66,7 @@ type Cluster struct {
 	regionStats       *statistics.RegionStatistics
 	labelStats        *statistics.LabelStatistics
 	hotStat           *statistics.HotStat
+	slowStat          *statistics.SlowStat
 	storage           storage.Storage
 	coordinator       *schedule.Coordinator
 	checkMembershipCh chan struct{}
@@ -117,6 +118,7 @@ func NewCluster(
 		labelerManager:    labelerManager,
 		persistConfig:     persistConfig,
 		hotStat:           statistics.NewHotStat(ctx, basicCluster),
+		slowStat:          statistics.NewSlowStat(persistConfig),
 		labelStats:        statistics.NewLabelStatistics(),
 		regionStats:       statistics.NewRegionStatistics(basicCluster, persistConfig, ruleManager),
 		storage:           storage,
@@ -156,6 +158,14 @@ func (c *Cluster) GetRegionStats() *statistics.RegionStatistics {
 	return c.regionStats
 }
 
+// GetRegionStatsByType gets the status of the region by types.
+func (c *Cluster) GetRegionStatsByType(typ statistics.RegionStatisticType) []*core.RegionInfo {
+	if c.regionStats == nil {
+		return nil
+	}
+	return c.regionStats.GetRegionStatsByType(typ)
+}
+
 // GetLabelStats gets label statistics.
 func (c *Cluster) GetLabelStats() *statistics.LabelStatistics {
 	return c.labelStats
@@ -196,6 +206,11 @@ func (c *Cluster) GetStoresLoads() map[uint64][]float64 {
 	return c.hotStat.GetStoresLoads()
 }
 
+// IsSlowStore returns whether the store is detected as a slow store.
+func (c *Cluster) IsSlowStore(storeID uint64) bool {
+	return c.slowStat.IsSlowStore(storeID)
+}
+
 // IsRegionHot checks if a region is in hot state.
 func (c *Cluster) IsRegionHot(region *core.RegionInfo) bool {
 	return c.hotStat.IsRegionHot(region, c.persistConfig.GetHotRegionCacheHitsThreshold())
@@ -435,11 +450,13 @@ func (c *Cluster) HandleStoreHeartbeat(heartbeat *schedulingpb.StoreHeartbeatReq
 	}
 
 	nowTime := time.Now()
-	newStore := store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime))
+	newStore := store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime),
+		core.SetLeaderReadQueryRate(c.GetStoreLeaderReadQueryRate(storeID, stats)))
 
 	c.PutStore(newStore)
 	c.hotStat.Observe(storeID, newStore.GetStoreStats())
 	c.hotStat.FilterUnhealthyStore(c)
+	c.slowStat.ObserveSlowStore(newStore)
 	reportInterval := stats.GetInterval()
 	interval := reportInterval.GetEndTimestamp() - reportInterval.GetStartTimestamp()
 
@@ -307,6 +307,7 @@ func AdjustScheduleCfg(scheduleCfg *sc.ScheduleConfig) {
 			scheduleCfg.Schedulers = append(scheduleCfg.Schedulers, ps)
 		}
 	}
+	scheduleCfg.CompileSchedulerTimeWindows()
 }
 
 // GetReplicationConfig returns replication configurations.
@@ -372,6 +373,11 @@ func (o *PersistConfig) GetLocationLabels() []string {
 	return o.GetReplicationConfig().LocationLabels
 }
 
+// GetZoneCostMatrix returns the network cost matrix between zones.
+func (o *PersistConfig) GetZoneCostMatrix() *sc.ZoneCostMatrix {
+	return sc.NewZoneCostMatrix(o.GetReplicationConfig())
+}
+
 // IsUseJointConsensus returns if the joint consensus is enabled.
 func (o *PersistConfig) IsUseJointConsensus() bool {
 	return o.GetScheduleConfig().EnableJointConsensus
@@ -427,6 +433,11 @@ func (o *PersistConfig) GetSlowStoreEvictingAffectedStoreRatioThreshold() float6
 	return o.GetScheduleConfig().SlowStoreEvictingAffectedStoreRatioThreshold
 }
 
+// GetSlowStoreDetector returns the strategy to detect the slow stores.
+func (o *PersistConfig) GetSlowStoreDetector() string {
+	return o.GetScheduleConfig().SlowStoreDetector
+}
+
 // GetPatrolRegionInterval returns the interval of patrolling region.
 func (o *PersistConfig) GetPatrolRegionInterval() time.Duration {
 	return o.GetScheduleConfig().PatrolRegionInterval.Duration
@@ -467,6 +478,11 @@ func (o *PersistConfig) IsRemoveExtraReplicaEnabled() bool {
 	return o.GetScheduleConfig().EnableRemoveExtraReplica
 }
 
+// GetPromoteWitnessDownTime returns the minimum down time of a voter before promoting its witness.
+func (o *PersistConfig) GetPromoteWitnessDownTime() time.Duration {
+	return o.GetScheduleConfig().PromoteWitnessDownTime.Duration
+}
+
 // IsWitnessAllowed returns if the witness is allowed.
 func (o *PersistConfig) IsWitnessAllowed() bool {
 	return o.GetScheduleConfig().EnableWitness
@@ -611,6 +627,19 @@ func (o *PersistConfig) GetMaxMergeRegionKeys() uint64 {
 	return o.GetScheduleConfig().GetMaxMergeRegionKeys()
 }
 
+// IsOperatorFairShareEnabled returns whether the operator slots are shared by weights.
+func (o *PersistConfig) IsOperatorFairShareEnabled() bool {
+	return o.GetScheduleConfig().EnableOperatorFairShare
+}
+
+// GetOperatorBudgetWeight returns the weight of the scheduler or checker when sharing the operator slots.
+func (o *PersistConfig) GetOperatorBudgetWeight(name string) float64 {
+	if weight, ok := o.GetScheduleConfig().OperatorBudgetWeights[name]; ok {
+		return weight
+	}
+	return 1
+}
+
 // GetSchedulerMaxWaitingOperator returns the number of the max waiting operators.
 func (o *PersistConfig) GetSchedulerMaxWaitingOperator() uint64 {
 	return o.getTTLUintOr(sc.SchedulerMaxWaitingOperatorKey, o.GetScheduleConfig().SchedulerMaxWaitingOperator)
@@ -37,6 +37,26 @@ func (mc *Cluster) SetSplitMergeInterval(v time.Duration) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.SplitMergeInterval = typeutil.NewDuration(v) })
 }
 
+// SetPromoteWitnessDownTime updates the PromoteWitnessDownTime configuration.
+func (mc *Cluster) SetPromoteWitnessDownTime(v time.Duration) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.PromoteWitnessDownTime = typeutil.NewDuration(v) })
+}
+
+// SetEnableOperatorFairShare updates the EnableOperatorFairShare configuration.
+func (mc *Cluster) SetEnableOperatorFairShare(v bool) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.EnableOperatorFairShare = v })
+}
+
+// SetOperatorBudgetWeight updates the operator budget weight of a scheduler or checker.
+func (mc *Cluster) SetOperatorBudgetWeight(name string, weight float64) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) {
+		if s.OperatorBudgetWeights == nil {
+			s.OperatorBudgetWeights = make(map[string]float64)
+		}
+		s.OperatorBudgetWeights[name] = weight
+	})
+}
+
 // SetEnableOneWayMerge updates the EnableOneWayMerge configuration.
 func (mc *Cluster) SetEnableOneWayMerge(v bool) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.EnableOneWayMerge = v })
@@ -87,6 +107,11 @@ func (mc *Cluster) SetRegionScoreFormulaVersion(v string) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.RegionScoreFormulaVersion = v })
 }
 
+// SetSlowStoreDetector updates the SlowStoreDetector configuration.
+func (mc *Cluster) SetSlowStoreDetector(v string) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.SlowStoreDetector = v })
+}
+
 // SetLeaderScheduleLimit updates the LeaderScheduleLimit configuration.
 func (mc *Cluster) SetLeaderScheduleLimit(v int) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.LeaderScheduleLimit = uint64(v) })
@@ -130,11 +155,26 @@ func (mc *Cluster) SetLocationLabels(v []string) {
 	mc.updateReplicationConfig(func(r *sc.ReplicationConfig) { r.LocationLabels = v })
 }
 
+// SetZoneCosts updates the ZoneCosts configuration.
+func (mc *Cluster) SetZoneCosts(v sc.ZoneCosts) {
+	mc.updateReplicationConfig(func(r *sc.ReplicationConfig) { r.ZoneCosts = v })
+}
+
 // SetIsolationLevel updates the IsolationLevel configuration.
 func (mc *Cluster) SetIsolationLevel(v string) {
 	mc.updateReplicationConfig(func(r *sc.ReplicationConfig) { r.IsolationLevel = v })
 }
 
+// SetSchedulerTimeWindows updates the time windows of a scheduler.
+func (mc *Cluster) SetSchedulerTimeWindows(name string, windows sc.TimeWindows) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) {
+		if s.SchedulerTimeWindows == nil {
+			s.SchedulerTimeWindows = make(map[string]sc.TimeWindows)
+		}
+		s.SchedulerTimeWindows[name] = windows
+	})
+}
+
 func (mc *Cluster) updateScheduleConfig(f func(*sc.ScheduleConfig)) {
 	s := mc.GetScheduleConfig().Clone()
 	f(s)
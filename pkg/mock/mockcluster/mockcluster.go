@@ -57,6 +57,7 @@ type Cluster struct {
 	*placement.RuleManager
 	*labeler.RegionLabeler
 	*statistics.HotStat
+	slowStat *statistics.SlowStat
 	*config.PersistOptions
 	pendingProcessedRegions map[uint64]struct{}
 	*buckets.HotBucketCache
@@ -72,6 +73,7 @@ func NewCluster(ctx context.Context, opts *config.PersistOptions) *Cluster {
 		IDAllocator:             mockid.NewIDAllocator(),
 		HotStat:                 statistics.NewHotStat(ctx, bc),
 		HotBucketCache:          buckets.NewBucketsCache(ctx),
+		slowStat:                statistics.NewSlowStat(opts),
 		PersistOptions:          opts,
 		pendingProcessedRegions: map[uint64]struct{}{},
 		Storage:                 storage.NewStorageWithMemoryBackend(),
@@ -141,6 +143,16 @@ func (mc *Cluster) GetStoresLoads() map[uint64][]float64 {
 	return mc.HotStat.GetStoresLoads()
 }
 
+// IsSlowStore returns whether the store is detected as a slow store.
+func (mc *Cluster) IsSlowStore(storeID uint64) bool {
+	return mc.slowStat.IsSlowStore(storeID)
+}
+
+// ObserveSlowStore detects whether the store is slow by its latest stats.
+func (mc *Cluster) ObserveSlowStore(storeID uint64) {
+	mc.slowStat.ObserveSlowStore(mc.GetStore(storeID))
+}
+
 // IsRegionHot checks if the region is hot.
 func (mc *Cluster) IsRegionHot(region *core.RegionInfo) bool {
 	return mc.HotCache.IsRegionHot(region, mc.GetHotRegionCacheHitsThreshold())
@@ -160,6 +172,15 @@ func (mc *Cluster) BucketsStats(degree int, regions ...uint64) map[uint64][]*buc
 	return task.WaitRet(mc.ctx)
 }
 
+// GetRegionStatsByType gets the status of the region by types.
+func (mc *Cluster) GetRegionStatsByType(typ statistics.RegionStatisticType) []*core.RegionInfo {
+	regionStats := statistics.NewRegionStatistics(mc.BasicCluster, mc.PersistOptions, mc.RuleManager)
+	for _, region := range mc.GetRegions() {
+		regionStats.Observe(region, mc.GetRegionStores(region))
+	}
+	return regionStats.GetRegionStatsByType(typ)
+}
+
 // GetHotPeerStats returns the read or write statistics for hot regions.
 // It returns a map where the keys are store IDs and the values are slices of HotPeerStat.
 // The result only includes peers that are hot enough.
@@ -611,6 +632,26 @@ func (mc *Cluster) UpdateLeaderCount(storeID uint64, leaderCount int) {
 	mc.PutStore(newStore)
 }
 
+// UpdateLeaderWriteQueryRate updates store leader write query rate.
+func (mc *Cluster) UpdateLeaderWriteQueryRate(storeID uint64, leaderWriteQueryRate float64) {
+	store := mc.GetStore(storeID)
+	newStore := store.Clone(core.SetLeaderWriteQueryRate(leaderWriteQueryRate))
+	mc.PutStore(newStore)
+}
+
+// UpdateStorePeerStats updates the peer stats of the store as the store heartbeat does.
+func (mc *Cluster) UpdateStorePeerStats(storeID uint64, peerStats []*pdpb.PeerStat, reportInterval uint64) {
+	store := mc.GetStore(storeID)
+	newStats := store.CloneStoreStats()
+	newStats.PeerStats = peerStats
+	newStats.Interval = &pdpb.TimeInterval{StartTimestamp: 0, EndTimestamp: reportInterval}
+	newStore := store.Clone(
+		core.SetStoreStats(newStats),
+		core.SetLeaderReadQueryRate(mc.GetStoreLeaderReadQueryRate(storeID, newStats)),
+	)
+	mc.PutStore(newStore)
+}
+
 // UpdateRegionCount updates store region count.
 func (mc *Cluster) UpdateRegionCount(storeID uint64, regionCount int) {
 	store := mc.GetStore(storeID)
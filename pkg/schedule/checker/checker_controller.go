@@ -35,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/utils/keyutil"
 	"github.com/tikv/pd/pkg/utils/logutil"
 )
@@ -58,6 +59,7 @@ var (
 	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
 	pendingProcessedRegionsGauge = regionListGauge.WithLabelValues("pending_processed_regions")
 	priorityListGauge            = regionListGauge.WithLabelValues("priority_list")
+	repairQueueGauge             = regionListGauge.WithLabelValues("repair_queue")
 	denyCheckersByLabelerCounter = labeler.LabelerEventCounter.WithLabelValues("checkers", "deny")
 )
 
@@ -74,6 +76,7 @@ type Controller struct {
 	mergeChecker            *MergeChecker
 	jointStateChecker       *JointStateChecker
 	priorityInspector       *PriorityInspector
+	repairQueue             *RepairQueue
 	pendingProcessedRegions *cache.TTLUint64
 	suspectKeyRanges        *cache.TTLString // suspect key-range regions that may need fix
 	patrolRegionContext     *PatrolRegionContext
@@ -108,6 +111,7 @@ func NewController(ctx context.Context, cluster sche.CheckerCluster, conf config
 		mergeChecker:            NewMergeChecker(ctx, cluster, conf),
 		jointStateChecker:       NewJointStateChecker(cluster),
 		priorityInspector:       NewPriorityInspector(cluster, conf),
+		repairQueue:             NewRepairQueue(cluster, conf),
 		pendingProcessedRegions: pendingProcessedRegions,
 		suspectKeyRanges:        cache.NewStringTTL(ctx, time.Minute, 3*time.Minute),
 		patrolRegionContext:     &PatrolRegionContext{},
@@ -149,6 +153,8 @@ func (c *Controller) PatrolRegions() {
 				continue
 			}
 
+			// Check the regions at risk in the risk order first.
+			c.checkRepairRegions()
 			// Check priority regions first.
 			c.checkPriorityRegions()
 			// Check pending processed regions first.
@@ -259,8 +265,8 @@ func (c *Controller) checkPriorityRegions() {
 		if len(ops) == 0 || ops[0].Kind()&operator.OpMerge != 0 {
 			continue
 		}
-		if !c.opController.ExceedStoreLimit(ops...) {
-			c.opController.AddWaitingOperator(ops...)
+		if !c.opController.ExceedStoreLimit(ops...) && c.opController.AddWaitingOperator(ops...) > 0 {
+			c.repairQueue.Dispatched(id)
 		}
 	}
 	for _, v := range removes {
@@ -268,6 +274,20 @@ func (c *Controller) checkPriorityRegions() {
 	}
 }
 
+// checkRepairRegions checks the regions at risk in the risk order.
+func (c *Controller) checkRepairRegions() {
+	c.repairQueue.InspectStores()
+	repairQueueGauge.Set(float64(c.repairQueue.Len()))
+	for _, id := range c.repairQueue.GetRegionsToRepair(repairCheckLimit, 10*c.conf.GetPatrolRegionInterval()) {
+		region := c.cluster.GetRegion(id)
+		if region == nil {
+			c.repairQueue.Remove(id)
+			continue
+		}
+		c.tryAddOperators(region)
+	}
+}
+
 // CheckRegion will check the region and add a new operator if needed.
 // The function is exposed for test purpose.
 func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
@@ -276,14 +296,18 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 	opController := c.opController
 
 	if op := c.jointStateChecker.Check(region); op != nil {
+		op.SetConsumer(types.JointStateChecker.String())
 		return []*operator.Operator{op}
 	}
 
 	if op := c.splitChecker.Check(region); op != nil {
+		op.SetConsumer(c.splitChecker.GetType())
 		return []*operator.Operator{op}
 	}
 
+	c.repairQueue.Inspect(region)
 	if c.conf.IsPlacementRulesEnabled() {
+		c.ruleChecker.UpdateRuleConditions(region)
 		skipRuleCheck := c.cluster.GetCheckerConfig().IsPlacementRulesCacheEnabled() &&
 			c.cluster.GetRuleManager().IsRegionFitCached(c.cluster, region)
 		if skipRuleCheck {
@@ -298,22 +322,33 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 			})
 			fit := c.priorityInspector.Inspect(region)
 			if op := c.ruleChecker.CheckWithFit(region, fit); op != nil {
+				op.SetConsumer(c.ruleChecker.GetType().String())
+				if c.deferRepair(region) {
+					return nil
+				}
 				if opController.OperatorCount(operator.OpReplica) < c.conf.GetReplicaScheduleLimit() {
 					return []*operator.Operator{op}
 				}
 				operator.IncOperatorLimitCounter(c.ruleChecker.GetType(), operator.OpReplica)
+				opController.RecordConsumerDemand(op.Consumer(), op.SchedulerKind())
 				c.pendingProcessedRegions.Put(region.GetID(), nil)
 			}
 		}
 	} else {
 		if op := c.learnerChecker.Check(region); op != nil {
+			op.SetConsumer(types.LearnerChecker.String())
 			return []*operator.Operator{op}
 		}
 		if op := c.replicaChecker.Check(region); op != nil {
+			op.SetConsumer(c.replicaChecker.GetType().String())
+			if c.deferRepair(region) {
+				return nil
+			}
 			if opController.OperatorCount(operator.OpReplica) < c.conf.GetReplicaScheduleLimit() {
 				return []*operator.Operator{op}
 			}
 			operator.IncOperatorLimitCounter(c.replicaChecker.GetType(), operator.OpReplica)
+			opController.RecordConsumerDemand(op.Consumer(), op.SchedulerKind())
 			c.pendingProcessedRegions.Put(region.GetID(), nil)
 		}
 	}
@@ -331,7 +366,11 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 		allowed := opController.OperatorCount(operator.OpMerge) < c.conf.GetMergeScheduleLimit()
 		if !allowed {
 			operator.IncOperatorLimitCounter(c.mergeChecker.GetType(), operator.OpMerge)
+			opController.RecordConsumerDemand(c.mergeChecker.GetType().String(), operator.OpMerge)
 		} else if ops := c.mergeChecker.Check(region); ops != nil {
+			for _, op := range ops {
+				op.SetConsumer(c.mergeChecker.GetType().String())
+			}
 			// It makes sure that two operators can be added successfully altogether.
 			return ops
 		}
@@ -339,6 +378,18 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 	return nil
 }
 
+// deferRepair returns true if the repair of the region should be deferred
+// because a region with higher risk is not dispatched yet, and the region
+// is checked again later.
+func (c *Controller) deferRepair(region *core.RegionInfo) bool {
+	if !c.repairQueue.ShouldDefer(region.GetID(), func(id uint64) bool { return c.opController.GetOperator(id) != nil }) {
+		return false
+	}
+	repairDeferredCounter.Inc()
+	c.pendingProcessedRegions.Put(region.GetID(), nil)
+	return true
+}
+
 func (c *Controller) tryAddOperators(region *core.RegionInfo) {
 	if region == nil {
 		// the region could be recent split, continue to wait.
@@ -355,7 +406,9 @@ func (c *Controller) tryAddOperators(region *core.RegionInfo) {
 	}
 
 	if !c.opController.ExceedStoreLimit(ops...) {
-		c.opController.AddWaitingOperator(ops...)
+		if c.opController.AddWaitingOperator(ops...) > 0 {
+			c.repairQueue.Dispatched(id)
+		}
 		c.RemovePendingProcessedRegion(id)
 	} else {
 		c.AddPendingProcessedRegions(true, id)
@@ -397,6 +450,21 @@ func (c *Controller) GetPriorityRegions() []uint64 {
 	return c.priorityInspector.GetPriorityRegions()
 }
 
+// InspectRepairRegion is called on region heartbeats. It puts the region with
+// down peers into the repair queue before the patrol reaches it, and updates the
+// risk of the queued region.
+func (c *Controller) InspectRepairRegion(region *core.RegionInfo) {
+	if len(region.GetDownPeers()) == 0 && !c.repairQueue.Has(region.GetID()) {
+		return
+	}
+	c.repairQueue.Inspect(region)
+}
+
+// GetRepairQueue returns at most limit regions at risk in the risk order.
+func (c *Controller) GetRepairQueue(limit int) []*RegionRisk {
+	return c.repairQueue.GetQueue(limit)
+}
+
 // RemovePriorityRegions removes priority region from priority queue
 func (c *Controller) RemovePriorityRegions(id uint64) {
 	c.priorityInspector.RemovePriorityRegion(id)
@@ -472,6 +540,9 @@ func (c *Controller) IsPendingRegion(regionID uint64) bool {
 	return exist
 }
 
+// PausableCheckerNames is the names of the checkers which can be paused.
+var PausableCheckerNames = []string{"learner", "replica", "rule", "split", "merge", "joint-state"}
+
 // GetPauseController returns pause controller of the checker
 func (c *Controller) GetPauseController(name string) (*PauseController, error) {
 	switch name {
@@ -35,3 +35,11 @@ func (c *PauseController) PauseOrResume(t int64) {
 	delayUntil := time.Now().Unix() + t
 	atomic.StoreInt64(&c.delayUntil, delayUntil)
 }
+
+// GetDelayUntil returns the resume timestamp of the checker, or 0 if it is not paused.
+func (c *PauseController) GetDelayUntil() int64 {
+	if c.IsPaused() {
+		return atomic.LoadInt64(&c.delayUntil)
+	}
+	return 0
+}
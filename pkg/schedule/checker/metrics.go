@@ -56,8 +56,13 @@ const (
 	mergeChecker      = "merge_checker"
 	replicaChecker    = "replica_checker"
 	splitChecker      = "split_checker"
+	repairQueue       = "repair_queue"
 )
 
+func repairQueueCounterWithEvent(event string) prometheus.Counter {
+	return checkerCounter.WithLabelValues(repairQueue, event)
+}
+
 func ruleCheckerCounterWithEvent(event string) prometheus.Counter {
 	return checkerCounter.WithLabelValues(ruleChecker, event)
 }
@@ -84,6 +89,7 @@ var (
 	ruleCheckerSetCacheCounter                    = ruleCheckerCounterWithEvent("set-cache")
 	ruleCheckerReplaceDownCounter                 = ruleCheckerCounterWithEvent("replace-down")
 	ruleCheckerPromoteWitnessCounter              = ruleCheckerCounterWithEvent("promote-witness")
+	ruleCheckerWaitPromoteWitnessCounter          = ruleCheckerCounterWithEvent("wait-promote-witness")
 	ruleCheckerReplaceOfflineCounter              = ruleCheckerCounterWithEvent("replace-offline")
 	ruleCheckerAddRulePeerCounter                 = ruleCheckerCounterWithEvent("add-rule-peer")
 	ruleCheckerNoStoreAddCounter                  = ruleCheckerCounterWithEvent("no-store-add")
@@ -106,6 +112,9 @@ var (
 	ruleCheckerRemoveOrphanPeerCounter            = ruleCheckerCounterWithEvent("remove-orphan-peer")
 	ruleCheckerReplaceOrphanPeerCounter           = ruleCheckerCounterWithEvent("replace-orphan-peer")
 	ruleCheckerReplaceOrphanPeerNoFitCounter      = ruleCheckerCounterWithEvent("replace-orphan-peer-no-fit")
+	ruleCheckerConditionChangedCounter            = ruleCheckerCounterWithEvent("condition-changed")
+
+	repairDeferredCounter = repairQueueCounterWithEvent("deferred")
 
 	jointCheckCounter                 = jointStateCheckerCounterWithEvent("check")
 	jointCheckerPausedCounter         = jointStateCheckerCounterWithEvent("paused")
@@ -0,0 +1,340 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/tikv/pd/pkg/btree"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/config"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// repairQueueCapacity is the max number of regions in the repair queue, the
+	// regions with the lowest risk are dropped when it is exceeded.
+	repairQueueCapacity = DefaultPendingRegionCacheSize
+	// repairBlockingTimeout is how long a region keeps blocking the repairs of the
+	// lower risk regions before its repair operator is dispatched, which avoids
+	// starving the others if the region can not be repaired.
+	repairBlockingTimeout = time.Minute
+	// repairCheckLimit is the max number of regions in the repair queue to check
+	// in each patrol round.
+	repairCheckLimit  = 64
+	repairQueueDegree = 4
+)
+
+// RiskLevel is the level of the data-loss risk of a region.
+type RiskLevel int
+
+const (
+	// RiskNone means the region has enough alive voters.
+	RiskNone RiskLevel = iota
+	// RiskUnderReplicated means some voters are lost, but the region can still
+	// tolerate another failure.
+	RiskUnderReplicated
+	// RiskNoFaultTolerance means the region loses the quorum if one more voter
+	// is lost.
+	RiskNoFaultTolerance
+	// RiskQuorumLost means the majority of the voters are lost.
+	RiskQuorumLost
+	// RiskNoLeader means the region has no leader.
+	RiskNoLeader
+)
+
+func (l RiskLevel) String() string {
+	switch l {
+	case RiskUnderReplicated:
+		return "under-replicated"
+	case RiskNoFaultTolerance:
+		return "no-fault-tolerance"
+	case RiskQuorumLost:
+		return "quorum-lost"
+	case RiskNoLeader:
+		return "no-leader"
+	default:
+		return "none"
+	}
+}
+
+// RegionRisk is the data-loss risk of a region in the repair queue.
+type RegionRisk struct {
+	RegionID       uint64    `json:"region_id"`
+	Level          string    `json:"level"`
+	Score          int       `json:"score"`
+	AliveVoters    int       `json:"alive_voters"`
+	ExpectedVoters int       `json:"expected_voters"`
+	Since          time.Time `json:"since"`
+	// Blocking means the repair operator of the region is not dispatched yet, so
+	// the repairs of the lower risk regions are deferred.
+	Blocking bool `json:"blocking"`
+}
+
+type repairItem struct {
+	score         int
+	regionID      uint64
+	level         RiskLevel
+	alive         int
+	expected      int
+	since         time.Time
+	lastCheck     time.Time
+	blockingUntil time.Time
+}
+
+// Less implements btree.Item interface, the item with higher score is less, so
+// it is iterated first.
+func (r *repairItem) Less(other *repairItem) bool {
+	if r.score != other.score {
+		return r.score > other.score
+	}
+	return r.regionID < other.regionID
+}
+
+// RepairQueue scores the regions by the data-loss risk and orders the repairs.
+// The repair operator of a region is deferred if any region with higher risk is
+// not dispatched yet, so the repairs are dispatched strictly in risk order
+// across all stores.
+type RepairQueue struct {
+	cluster sche.CheckerCluster
+	conf    config.CheckerConfigProvider
+	// downStores is the disconnected stores found by InspectStores, it is only
+	// accessed by the patrol goroutine.
+	downStores map[uint64]struct{}
+	mu         struct {
+		syncutil.RWMutex
+		items map[uint64]*repairItem
+		tree  *btree.BTreeG[*repairItem]
+	}
+}
+
+// NewRepairQueue creates a repair queue.
+func NewRepairQueue(cluster sche.CheckerCluster, conf config.CheckerConfigProvider) *RepairQueue {
+	q := &RepairQueue{
+		cluster:    cluster,
+		conf:       conf,
+		downStores: make(map[uint64]struct{}),
+	}
+	q.mu.items = make(map[uint64]*repairItem)
+	q.mu.tree = btree.NewG[*repairItem](repairQueueDegree)
+	return q
+}
+
+// evaluate returns the risk level, the alive voters and the expected voters of
+// the region.
+func (q *RepairQueue) evaluate(region *core.RegionInfo) (level RiskLevel, alive, expected int) {
+	voters := region.GetVoters()
+	for _, peer := range voters {
+		store := q.cluster.GetStore(peer.GetStoreId())
+		if store == nil || store.IsRemoved() || store.IsDisconnected() || region.GetDownPeer(peer.GetId()) != nil {
+			continue
+		}
+		alive++
+	}
+	if q.conf.IsPlacementRulesEnabled() {
+		for _, rule := range q.cluster.GetRuleManager().GetRulesForApplyRegion(region) {
+			if rule.Role != placement.Learner {
+				expected += rule.Count
+			}
+		}
+	} else {
+		expected = q.conf.GetMaxReplicas()
+	}
+	quorum := len(voters)/2 + 1
+	switch {
+	case region.GetLeader() == nil:
+		level = RiskNoLeader
+	case alive < quorum:
+		level = RiskQuorumLost
+	case alive >= expected:
+		level = RiskNone
+	case alive <= quorum:
+		level = RiskNoFaultTolerance
+	default:
+		level = RiskUnderReplicated
+	}
+	return
+}
+
+// Inspect scores the region and puts it into the queue if it is at risk, or
+// removes it from the queue otherwise.
+func (q *RepairQueue) Inspect(region *core.RegionInfo) RiskLevel {
+	level, alive, expected := q.evaluate(region)
+	id := region.GetID()
+	now := time.Now()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	old, ok := q.mu.items[id]
+	if level == RiskNone {
+		if ok {
+			q.removeLocked(old)
+		}
+		return level
+	}
+	// More lost voters are more urgent in the same level.
+	score := int(level)*100 + min(max(expected-alive, 0), 99)
+	item := &repairItem{
+		score:         score,
+		regionID:      id,
+		level:         level,
+		alive:         alive,
+		expected:      expected,
+		since:         now,
+		lastCheck:     now,
+		blockingUntil: now.Add(repairBlockingTimeout),
+	}
+	if ok {
+		q.removeLocked(old)
+		if old.level == level {
+			item.since = old.since
+			item.blockingUntil = old.blockingUntil
+		}
+	} else if len(q.mu.items) >= repairQueueCapacity {
+		lowest, found := q.mu.tree.Max()
+		if !found || !item.Less(lowest) {
+			return level
+		}
+		q.removeLocked(lowest)
+	}
+	q.mu.items[id] = item
+	q.mu.tree.ReplaceOrInsert(item)
+	return level
+}
+
+func (q *RepairQueue) removeLocked(item *repairItem) {
+	q.mu.tree.Delete(item)
+	delete(q.mu.items, item.regionID)
+}
+
+// Remove removes the region from the queue.
+func (q *RepairQueue) Remove(regionID uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item, ok := q.mu.items[regionID]; ok {
+		q.removeLocked(item)
+	}
+}
+
+// Has returns whether the region is in the queue.
+func (q *RepairQueue) Has(regionID uint64) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	_, ok := q.mu.items[regionID]
+	return ok
+}
+
+// InspectStores inspects all the regions of the newly disconnected stores, so
+// the regions at risk are queued before the patrol reaches them.
+func (q *RepairQueue) InspectStores() {
+	for _, store := range q.cluster.GetStores() {
+		id := store.GetID()
+		if store.IsRemoved() || !store.IsDisconnected() {
+			delete(q.downStores, id)
+			continue
+		}
+		if _, ok := q.downStores[id]; ok {
+			continue
+		}
+		q.downStores[id] = struct{}{}
+		for _, region := range q.cluster.GetBasicCluster().GetStoreRegions(id) {
+			q.Inspect(region)
+		}
+	}
+}
+
+// ShouldDefer is called when a repair operator of the region is created. It
+// returns true if any region with higher risk is not dispatched yet, which
+// means the operator should be deferred.
+func (q *RepairQueue) ShouldDefer(regionID uint64, hasOperator func(regionID uint64) bool) bool {
+	now := time.Now()
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	item, ok := q.mu.items[regionID]
+	if !ok {
+		return false
+	}
+	deferred := false
+	q.mu.tree.AscendLessThan(item, func(higher *repairItem) bool {
+		if now.Before(higher.blockingUntil) && !hasOperator(higher.regionID) {
+			deferred = true
+			return false
+		}
+		return true
+	})
+	return deferred
+}
+
+// Dispatched is called when the repair operator of the region is dispatched, so
+// the region no longer blocks the repairs of the lower risk regions.
+func (q *RepairQueue) Dispatched(regionID uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item, ok := q.mu.items[regionID]; ok {
+		item.blockingUntil = time.Time{}
+	}
+}
+
+// GetRegionsToRepair returns at most limit regions in the risk order, which are
+// not checked within the interval.
+func (q *RepairQueue) GetRegionsToRepair(limit int, interval time.Duration) []uint64 {
+	now := time.Now()
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	ids := make([]uint64, 0, min(limit, len(q.mu.items)))
+	q.mu.tree.Ascend(func(item *repairItem) bool {
+		if len(ids) >= limit {
+			return false
+		}
+		if now.Sub(item.lastCheck) >= interval {
+			ids = append(ids, item.regionID)
+		}
+		return true
+	})
+	return ids
+}
+
+// GetQueue returns at most limit regions in the risk order, all the regions are
+// returned if limit is not positive.
+func (q *RepairQueue) GetQueue(limit int) []*RegionRisk {
+	now := time.Now()
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	risks := make([]*RegionRisk, 0)
+	q.mu.tree.Ascend(func(item *repairItem) bool {
+		if limit > 0 && len(risks) >= limit {
+			return false
+		}
+		risks = append(risks, &RegionRisk{
+			RegionID:       item.regionID,
+			Level:          item.level.String(),
+			Score:          item.score,
+			AliveVoters:    item.alive,
+			ExpectedVoters: item.expected,
+			Since:          item.since,
+			Blocking:       now.Before(item.blockingUntil),
+		})
+		return true
+	})
+	return risks
+}
+
+// Len returns the number of regions in the queue.
+func (q *RepairQueue) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.mu.items)
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/operator"
+)
+
+func TestRepairQueueRiskLevel(t *testing.T) {
+	re := require.New(t)
+	opt := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, opt)
+	for id := uint64(1); id <= 5; id++ {
+		tc.AddRegionStore(id, 0)
+	}
+	tc.SetStoreDown(4)
+	tc.SetStoreDown(5)
+	tc.AddLeaderRegion(1, 1, 2, 3)
+	tc.AddLeaderRegion(2, 1, 2, 4)
+	tc.AddLeaderRegion(3, 1, 4, 5)
+	tc.AddLeaderRegion(4, 1, 2)
+	tc.AddLeaderRegion(5, 1, 2, 3)
+	tc.PutRegion(tc.GetRegion(5).Clone(core.WithLeader(nil)))
+
+	checkRepairQueueRiskLevel(re, tc)
+	opt.SetPlacementRuleEnabled(true)
+	re.True(opt.IsPlacementRulesEnabled())
+	checkRepairQueueRiskLevel(re, tc)
+}
+
+func checkRepairQueueRiskLevel(re *require.Assertions, tc *mockcluster.Cluster) {
+	q := NewRepairQueue(tc, tc.GetCheckerConfig())
+	re.Equal(RiskNone, q.Inspect(tc.GetRegion(1)))
+	// 2 of 3 voters are alive.
+	re.Equal(RiskNoFaultTolerance, q.Inspect(tc.GetRegion(2)))
+	// 1 of 3 voters is alive.
+	re.Equal(RiskQuorumLost, q.Inspect(tc.GetRegion(3)))
+	// 2 of 2 voters are alive, but 3 voters are expected.
+	re.Equal(RiskNoFaultTolerance, q.Inspect(tc.GetRegion(4)))
+	re.Equal(RiskNoLeader, q.Inspect(tc.GetRegion(5)))
+	re.Equal(4, q.Len())
+
+	queue := q.GetQueue(0)
+	re.Len(queue, 4)
+	expected := []struct {
+		regionID uint64
+		level    RiskLevel
+		alive    int
+	}{
+		{5, RiskNoLeader, 3},
+		{3, RiskQuorumLost, 1},
+		{2, RiskNoFaultTolerance, 2},
+		{4, RiskNoFaultTolerance, 2},
+	}
+	for i, e := range expected {
+		re.Equal(e.regionID, queue[i].RegionID)
+		re.Equal(e.level.String(), queue[i].Level)
+		re.Equal(e.alive, queue[i].AliveVoters)
+		re.Equal(3, queue[i].ExpectedVoters)
+	}
+	re.Len(q.GetQueue(2), 2)
+
+	// The region is removed after it is repaired.
+	q.Inspect(tc.GetRegion(1).Clone(core.WithNewRegionID(3)))
+	re.Equal(3, q.Len())
+	q.Remove(2)
+	re.Equal([]uint64{5, 4}, q.GetRegionsToRepair(repairCheckLimit, 0))
+}
+
+func TestRepairQueueOrder(t *testing.T) {
+	re := require.New(t)
+	opt := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, opt)
+	for id := uint64(1); id <= 4; id++ {
+		tc.AddRegionStore(id, 0)
+	}
+	tc.SetStoreDown(4)
+	tc.AddLeaderRegion(1, 1, 2, 4)
+	tc.AddLeaderRegion(2, 1, 4)
+	tc.AddLeaderRegion(3, 1, 2, 3)
+
+	q := NewRepairQueue(tc, tc.GetCheckerConfig())
+	for id := uint64(1); id <= 3; id++ {
+		q.Inspect(tc.GetRegion(id))
+	}
+	re.Equal(2, q.Len())
+	noOperator := func(uint64) bool { return false }
+	// All the queued regions block the lower risk regions until dispatched.
+	for _, risk := range q.GetQueue(0) {
+		re.True(risk.Blocking)
+	}
+	// The region with the highest risk is never deferred.
+	re.False(q.ShouldDefer(2, noOperator))
+	// The lower risk region is deferred even if the higher risk region has not
+	// created its repair operator yet.
+	re.True(q.ShouldDefer(1, noOperator))
+	re.False(q.ShouldDefer(1, func(id uint64) bool { return id == 2 }))
+	q.Dispatched(2)
+	re.False(q.GetQueue(1)[0].Blocking)
+	re.False(q.ShouldDefer(1, noOperator))
+	// The region out of the queue is not deferred.
+	re.False(q.ShouldDefer(3, noOperator))
+
+	// The region blocks again when its risk level is changed.
+	tc.SetStoreDown(2)
+	q.Inspect(tc.GetRegion(1))
+	re.Equal(RiskQuorumLost.String(), q.GetQueue(1)[0].Level)
+	re.True(q.GetQueue(1)[0].Blocking)
+	re.True(q.ShouldDefer(2, noOperator))
+	// The region does not block the others after the timeout.
+	q.mu.Lock()
+	q.mu.items[1].blockingUntil = time.Now()
+	q.mu.Unlock()
+	re.False(q.ShouldDefer(2, noOperator))
+}
+
+func TestRepairQueueInspectStores(t *testing.T) {
+	re := require.New(t)
+	opt := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, opt)
+	for id := uint64(1); id <= 4; id++ {
+		tc.AddRegionStore(id, 0)
+	}
+	tc.AddLeaderRegion(1, 1, 2, 4)
+	tc.AddLeaderRegion(2, 1, 2, 3)
+
+	q := NewRepairQueue(tc, tc.GetCheckerConfig())
+	q.InspectStores()
+	re.Zero(q.Len())
+	// The regions of the newly disconnected store are queued.
+	tc.SetStoreDown(4)
+	q.InspectStores()
+	re.Equal([]uint64{1}, q.GetRegionsToRepair(repairCheckLimit, 0))
+	// The store is inspected only once until it is up again.
+	q.Remove(1)
+	q.InspectStores()
+	re.Zero(q.Len())
+	tc.SetStoreUp(4)
+	q.InspectStores()
+	tc.SetStoreDown(4)
+	q.InspectStores()
+	re.Equal(1, q.Len())
+}
+
+func TestInspectRepairRegion(t *testing.T) {
+	re := require.New(t)
+	opt := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, opt)
+	for id := uint64(1); id <= 3; id++ {
+		tc.AddRegionStore(id, 0)
+	}
+	oc := operator.NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), nil)
+	c := NewController(ctx, tc, tc.GetCheckerConfig(), tc.GetRuleManager(), tc.GetRegionLabeler(), oc)
+	region := tc.AddLeaderRegion(1, 1, 2, 3)
+	c.InspectRepairRegion(region)
+	re.Empty(c.GetRepairQueue(0))
+	// The region with down peers in the heartbeat is queued.
+	region = region.Clone(core.WithDownPeers([]*pdpb.PeerStats{{Peer: region.GetStorePeer(3), DownSeconds: 3600}}))
+	c.InspectRepairRegion(region)
+	queue := c.GetRepairQueue(0)
+	re.Len(queue, 1)
+	re.Equal(RiskNoFaultTolerance.String(), queue[0].Level)
+	// The queued region is removed after the down peer is recovered.
+	c.InspectRepairRegion(region.Clone(core.WithDownPeers(nil)))
+	re.Empty(c.GetRepairQueue(0))
+}
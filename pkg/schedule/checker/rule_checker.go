@@ -35,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 )
@@ -83,6 +84,33 @@ func (c *RuleChecker) Check(region *core.RegionInfo) *operator.Operator {
 	return c.CheckWithFit(region, fit)
 }
 
+// UpdateRuleConditions evaluates the load conditions of the rules applied to the
+// region with the hot statistics. It is called by the checker controller before
+// fitting the region, so the cached fit is invalidated if any rule is changed.
+func (c *RuleChecker) UpdateRuleConditions(region *core.RegionInfo) {
+	changed := c.ruleManager.UpdateLoadConditions(region, func(rw utils.RWType, dim int) float64 {
+		var load float64
+		switch rw {
+		case utils.Read:
+			// The reads are served by all the peers.
+			for _, peer := range region.GetPeers() {
+				if stat := c.cluster.GetHotPeerStat(rw, region.GetID(), peer.GetStoreId()); stat != nil {
+					load += stat.GetLoad(dim)
+				}
+			}
+		case utils.Write:
+			if stat := c.cluster.GetHotPeerStat(rw, region.GetID(), region.GetLeader().GetStoreId()); stat != nil {
+				load = stat.GetLoad(dim)
+			}
+		}
+		return load
+	})
+	if changed {
+		ruleCheckerConditionChangedCounter.Inc()
+		c.ruleManager.InvalidCache(region.GetID())
+	}
+}
+
 // CheckWithFit is similar with Checker with placement.RegionFit
 func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.RegionFit) (op *operator.Operator) {
 	// checker is paused
@@ -169,8 +197,11 @@ func (c *RuleChecker) fixRulePeer(region *core.RegionInfo, fit *placement.Region
 			// When witness placement rule is enabled, promotes the witness to voter when region has down voter.
 			if c.isWitnessEnabled() && core.IsVoter(peer) {
 				if witness, ok := c.hasAvailableWitness(region, peer); ok {
-					ruleCheckerPromoteWitnessCounter.Inc()
-					return operator.CreateNonWitnessPeerOperator("promote-witness-for-down", c.cluster, region, witness)
+					if c.isPeerDownTimeHitPromoteWitnessTime(region, peer) {
+						ruleCheckerPromoteWitnessCounter.Inc()
+						return operator.CreateNonWitnessPeerOperator("promote-witness-for-down", c.cluster, region, witness)
+					}
+					ruleCheckerWaitPromoteWitnessCounter.Inc()
 				}
 			}
 		}
@@ -583,6 +614,16 @@ func (c *RuleChecker) isStoreDownTimeHitMaxDownTime(storeID uint64) bool {
 	return store.DownTime() >= c.cluster.GetCheckerConfig().GetMaxStoreDownTime()
 }
 
+// isPeerDownTimeHitPromoteWitnessTime returns true if the peer has been down long enough to promote the witness.
+func (c *RuleChecker) isPeerDownTimeHitPromoteWitnessTime(region *core.RegionInfo, peer *metapb.Peer) bool {
+	for _, stats := range region.GetDownPeers() {
+		if stats.GetPeer().GetId() == peer.GetId() {
+			return time.Duration(stats.GetDownSeconds())*time.Second >= c.cluster.GetCheckerConfig().GetPromoteWitnessDownTime()
+		}
+	}
+	return false
+}
+
 func (c *RuleChecker) isOfflinePeer(peer *metapb.Peer) bool {
 	store := c.cluster.GetStore(peer.GetStoreId())
 	if store == nil {
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/pingcap/failpoint"
@@ -34,9 +35,12 @@ import (
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/pkg/utils/operatorutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 )
 
@@ -83,6 +87,55 @@ func (suite *ruleCheckerTestSuite) TestAddRulePeer() {
 	re.Equal(uint64(3), op.Step(0).(operator.AddLearner).ToStore)
 }
 
+func (suite *ruleCheckerTestSuite) TestConditionalRule() {
+	re := suite.Require()
+	for storeID := uint64(1); storeID <= 5; storeID++ {
+		suite.cluster.AddLeaderStore(storeID, 1)
+	}
+	suite.cluster.AddLeaderRegion(1, 1, 2, 3)
+	rule := &placement.Rule{
+		GroupID: placement.DefaultGroupID,
+		ID:      "hot-learners",
+		Index:   100,
+		Role:    placement.Learner,
+		Count:   2,
+		Condition: &placement.RuleCondition{
+			Load: &placement.LoadCondition{Type: "read", Dim: "byte", Threshold: 100 * units.KiB},
+		},
+	}
+	re.NoError(suite.ruleManager.SetRule(rule))
+	suite.rc.UpdateRuleConditions(suite.cluster.GetRegion(1))
+	re.Nil(suite.rc.Check(suite.cluster.GetRegion(1)))
+
+	// The learners are added when the region becomes read-hot.
+	suite.cluster.AddRegionWithReadInfo(1, 1, 512*units.KiB*utils.RegionHeartBeatReportInterval, 0, 0,
+		utils.RegionHeartBeatReportInterval, []uint64{2, 3})
+	suite.rc.UpdateRuleConditions(suite.cluster.GetRegion(1))
+	op := suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal("add-rule-peer", op.Desc())
+	re.Contains([]uint64{4, 5}, op.Step(0).(operator.AddLearner).ToStore)
+	suite.cluster.AddLeaderRegion(1, 1, 2, 3)
+	region := suite.cluster.GetRegion(1).Clone(
+		core.WithAddPeer(&metapb.Peer{Id: 4, StoreId: 4, Role: metapb.PeerRole_Learner}),
+		core.WithAddPeer(&metapb.Peer{Id: 5, StoreId: 5, Role: metapb.PeerRole_Learner}),
+	)
+	suite.cluster.PutRegion(region)
+	suite.rc.UpdateRuleConditions(region)
+	re.Nil(suite.rc.Check(region))
+
+	// The learners become orphan peers and are removed out of the time window.
+	next := time.Now().Add(time.Hour)
+	rule.Condition = &placement.RuleCondition{TimeWindows: config.TimeWindows{{
+		Cron:     fmt.Sprintf("%d %d * * *", next.Minute(), next.Hour()),
+		Duration: typeutil.NewDuration(time.Hour),
+	}}}
+	re.NoError(suite.ruleManager.SetRule(rule))
+	op = suite.rc.Check(region)
+	re.NotNil(op)
+	re.Equal("remove-orphan-peer", op.Desc())
+}
+
 func (suite *ruleCheckerTestSuite) TestAddRulePeerWithIsolationLevel() {
 	re := suite.Require()
 	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1", "rack": "r1", "host": "h1"})
@@ -1438,6 +1491,46 @@ func (suite *ruleCheckerTestSuite) TestFixDownPeerWithAvailableWitness() {
 	re.Equal(uint64(3), op.Step(3).(operator.PromoteLearner).ToStore)
 }
 
+func (suite *ruleCheckerTestSuite) TestFixDownPeerWithAvailableWitnessAfterDownTime() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	suite.cluster.AddLabelsStore(2, 1, map[string]string{"zone": "z2"})
+	suite.cluster.AddLabelsStore(3, 1, map[string]string{"zone": "z3"})
+	suite.cluster.AddLeaderRegion(1, 1, 2, 3)
+
+	suite.cluster.SetStoreDown(2)
+	suite.cluster.GetStore(2).GetMeta().LastHeartbeat = time.Now().Add(-11 * time.Minute).UnixNano()
+	r := suite.cluster.GetRegion(1)
+	// set peer2 to down
+	r = r.Clone(core.WithDownPeers([]*pdpb.PeerStats{{Peer: r.GetStorePeer(2), DownSeconds: 600}}))
+	// set peer3 to witness
+	r = r.Clone(core.WithWitnesses([]*metapb.Peer{r.GetPeer(3)}))
+
+	suite.ruleManager.SetRule(&placement.Rule{
+		GroupID: placement.DefaultGroupID,
+		ID:      placement.DefaultRuleID,
+		Role:    placement.Voter,
+		Count:   2,
+	})
+	suite.ruleManager.SetRule(&placement.Rule{
+		GroupID:   placement.DefaultGroupID,
+		ID:        "r1",
+		Role:      placement.Voter,
+		Count:     1,
+		IsWitness: true,
+	})
+
+	// the voter is not down long enough to promote the witness.
+	suite.cluster.SetPromoteWitnessDownTime(20 * time.Minute)
+	re.Nil(suite.rc.Check(r))
+
+	suite.cluster.SetPromoteWitnessDownTime(10 * time.Minute)
+	op := suite.rc.Check(r)
+	re.NotNil(op)
+	re.Equal("promote-witness-for-down", op.Desc())
+	re.Equal(uint64(3), op.Step(2).(operator.BecomeNonWitness).StoreID)
+}
+
 func (suite *ruleCheckerTestSuite) TestFixDownPeerWithAvailableWitness2() {
 	re := suite.Require()
 	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1"})
@@ -53,6 +53,7 @@ const (
 	// When a slow store affected more than 30% of total stores, it will trigger evicting.
 	defaultSlowStoreEvictingAffectedStoreRatioThreshold = 0.3
 	defaultMaxMovableHotPeerSize                        = int64(512)
+	defaultSlowStoreDetector                            = SlowScoreDetector
 
 	defaultEnableJointConsensus            = true
 	defaultEnableTiKVSplitRegion           = true
@@ -89,6 +90,14 @@ var (
 	DefaultTiFlashStoreLimit = StoreLimit{AddPeer: 30, RemovePeer: 30}
 )
 
+const (
+	// SlowScoreDetector detects the slow stores by the slow score reported by TiKV.
+	SlowScoreDetector = "slow-score"
+	// LatencyDetector detects the slow stores by comparing the latencies reported
+	// by TiKV with the stores in the same zone.
+	LatencyDetector = "latency"
+)
+
 // The following consts are used to identify the config item that needs to set TTL.
 const (
 	// TTLConfigPrefix is the prefix of the config item that needs to set TTL.
@@ -177,6 +186,9 @@ type ScheduleConfig struct {
 	SplitMergeInterval typeutil.Duration `toml:"split-merge-interval" json:"split-merge-interval"`
 	// SwitchWitnessInterval is the minimum interval that allows a peer to become a witness again after it is promoted to non-witness.
 	SwitchWitnessInterval typeutil.Duration `toml:"switch-witness-interval" json:"switch-witness-interval"`
+	// PromoteWitnessDownTime is the minimum time a voter should be down before its witness is promoted to a voter.
+	// 0 means the witness is promoted as soon as the voter is down.
+	PromoteWitnessDownTime typeutil.Duration `toml:"promote-witness-down-time" json:"promote-witness-down-time"`
 	// EnableOneWayMerge is the option to enable one way merge. This means a Region can only be merged into the next region of it.
 	EnableOneWayMerge bool `toml:"enable-one-way-merge" json:"enable-one-way-merge,string"`
 	// EnableCrossTableMerge is the option to enable cross table merge. This means two Regions can be merged with different table IDs.
@@ -192,7 +204,7 @@ type ScheduleConfig struct {
 	MaxStorePreparingTime typeutil.Duration `toml:"max-store-preparing-time" json:"max-store-preparing-time"`
 	// LeaderScheduleLimit is the max coexist leader schedules.
 	LeaderScheduleLimit uint64 `toml:"leader-schedule-limit" json:"leader-schedule-limit"`
-	// LeaderSchedulePolicy is the option to balance leader, there are some policies supported: ["count", "size"], default: "count"
+	// LeaderSchedulePolicy is the option to balance leader, there are some policies supported: ["count", "size", "load"], default: "count"
 	LeaderSchedulePolicy string `toml:"leader-schedule-policy" json:"leader-schedule-policy"`
 	// RegionScheduleLimit is the max coexist region schedules.
 	RegionScheduleLimit uint64 `toml:"region-schedule-limit" json:"region-schedule-limit"`
@@ -282,6 +294,17 @@ type ScheduleConfig struct {
 	// Schedulers support for loading customized schedulers
 	Schedulers SchedulerConfigs `toml:"schedulers" json:"schedulers-v2"` // json v2 is for the sake of compatible upgrade
 
+	// SchedulerTimeWindows is the map from the scheduler name to the time windows it is
+	// allowed to schedule in. The scheduler is paused outside its time windows.
+	SchedulerTimeWindows map[string]TimeWindows `toml:"scheduler-time-windows" json:"scheduler-time-windows,omitempty"`
+
+	// EnableOperatorFairShare is the option to share the operator slots of each schedule limit
+	// among the schedulers and checkers which are competing for them, by their weights.
+	EnableOperatorFairShare bool `toml:"enable-operator-fair-share" json:"enable-operator-fair-share,string"`
+	// OperatorBudgetWeights is the map from the scheduler or checker name to its weight
+	// when sharing the operator slots. The weight of the one not in the map is 1.
+	OperatorBudgetWeights map[string]float64 `toml:"operator-budget-weights" json:"operator-budget-weights,omitempty"`
+
 	// Controls the time interval between write hot regions info into leveldb.
 	HotRegionsWriteInterval typeutil.Duration `toml:"hot-regions-write-interval" json:"hot-regions-write-interval"`
 
@@ -302,6 +325,11 @@ type ScheduleConfig struct {
 	// A store's slowness must affect more than `store-count * SlowStoreEvictingAffectedStoreRatioThreshold` to trigger evicting.
 	SlowStoreEvictingAffectedStoreRatioThreshold float64 `toml:"slow-store-evicting-affected-store-ratio-threshold" json:"slow-store-evicting-affected-store-ratio-threshold,omitempty"`
 
+	// SlowStoreDetector is the strategy to detect the slow stores.
+	// slow-score: which is based on the slow score reported by TiKV.
+	// latency: which compares the latencies reported by TiKV with the stores in the same zone.
+	SlowStoreDetector string `toml:"slow-store-detector" json:"slow-store-detector"`
+
 	// StoreLimitVersion is the version of store limit.
 	// v1: which is based on the region count by rate limit.
 	// v2: which is based on region size by window size.
@@ -325,9 +353,25 @@ func (c *ScheduleConfig) Clone() *ScheduleConfig {
 			storeLimit[k] = v
 		}
 	}
+	var timeWindows map[string]TimeWindows
+	if c.SchedulerTimeWindows != nil {
+		timeWindows = make(map[string]TimeWindows, len(c.SchedulerTimeWindows))
+		for k, v := range c.SchedulerTimeWindows {
+			timeWindows[k] = v.Clone()
+		}
+	}
+	var budgetWeights map[string]float64
+	if c.OperatorBudgetWeights != nil {
+		budgetWeights = make(map[string]float64, len(c.OperatorBudgetWeights))
+		for k, v := range c.OperatorBudgetWeights {
+			budgetWeights[k] = v
+		}
+	}
 	cfg := *c
 	cfg.StoreLimit = storeLimit
 	cfg.Schedulers = schedulers
+	cfg.SchedulerTimeWindows = timeWindows
+	cfg.OperatorBudgetWeights = budgetWeights
 	return &cfg
 }
 
@@ -423,6 +467,7 @@ func (c *ScheduleConfig) Adjust(meta *configutil.ConfigMetaData, reloading bool)
 	}
 
 	adjustSchedulers(&c.Schedulers, DefaultSchedulers)
+	c.CompileSchedulerTimeWindows()
 
 	for k, b := range c.migrateConfigurationMap() {
 		v, err := parseDeprecatedFlag(meta, k, *b[0], *b[1])
@@ -452,6 +497,10 @@ func (c *ScheduleConfig) Adjust(meta *configutil.ConfigMetaData, reloading bool)
 	if !meta.IsDefined("slow-store-evicting-affected-store-ratio-threshold") {
 		configutil.AdjustFloat64(&c.SlowStoreEvictingAffectedStoreRatioThreshold, defaultSlowStoreEvictingAffectedStoreRatioThreshold)
 	}
+
+	if !meta.IsDefined("slow-store-detector") {
+		configutil.AdjustString(&c.SlowStoreDetector, defaultSlowStoreDetector)
+	}
 	return c.Validate()
 }
 
@@ -508,6 +557,14 @@ func (c *ScheduleConfig) MigrateDeprecatedFlags() {
 	}
 }
 
+// CompileSchedulerTimeWindows parses and caches the time windows of schedulers,
+// which should be called when the config is loaded.
+func (c *ScheduleConfig) CompileSchedulerTimeWindows() {
+	for _, windows := range c.SchedulerTimeWindows {
+		windows.Compile()
+	}
+}
+
 // Validate is used to validate if some scheduling configurations are right.
 func (c *ScheduleConfig) Validate() error {
 	if c.TolerantSizeRatio < 0 {
@@ -522,15 +579,31 @@ func (c *ScheduleConfig) Validate() error {
 	if c.LowSpaceRatio <= c.HighSpaceRatio {
 		return errors.New("low-space-ratio should be larger than high-space-ratio")
 	}
-	if c.LeaderSchedulePolicy != "count" && c.LeaderSchedulePolicy != "size" {
+	if c.LeaderSchedulePolicy != "count" && c.LeaderSchedulePolicy != "size" && c.LeaderSchedulePolicy != "load" {
 		return errors.Errorf("leader-schedule-policy %v is invalid", c.LeaderSchedulePolicy)
 	}
 	if c.SlowStoreEvictingAffectedStoreRatioThreshold == 0 {
 		return errors.Errorf("slow-store-evicting-affected-store-ratio-threshold is not set")
 	}
+	if c.SlowStoreDetector != SlowScoreDetector && c.SlowStoreDetector != LatencyDetector {
+		return errors.Errorf("slow-store-detector %v is invalid", c.SlowStoreDetector)
+	}
+	if c.PromoteWitnessDownTime.Duration < 0 {
+		return errors.New("promote-witness-down-time should be non-negative")
+	}
 	if c.PatrolRegionWorkerCount > maxPatrolRegionWorkerCount || c.PatrolRegionWorkerCount < 1 {
 		return errors.Errorf("patrol-region-worker-count should be between 1 and %d", maxPatrolRegionWorkerCount)
 	}
+	for name, windows := range c.SchedulerTimeWindows {
+		if err := windows.Validate(); err != nil {
+			return errors.Errorf("time windows of %s are invalid: %v", name, err)
+		}
+	}
+	for name, weight := range c.OperatorBudgetWeights {
+		if weight <= 0 {
+			return errors.Errorf("operator budget weight of %s should be positive", name)
+		}
+	}
 	return nil
 }
 
@@ -626,6 +699,15 @@ type ReplicationConfig struct {
 	// Even if a zone is down, PD will not try to make up replicas in other zone
 	// because other zones already have replicas on it.
 	IsolationLevel string `toml:"isolation-level" json:"isolation-level"`
+
+	// ZoneLabel is the label key which specifies the zone of a store for the network cost.
+	// Its value must be empty or one of LocationLabels, the first one of LocationLabels
+	// is used if it is empty.
+	ZoneLabel string `toml:"zone-label" json:"zone-label"`
+	// ZoneCosts is the optional network cost of sending data between zones, which is
+	// used to prefer moving data within a zone. The cost between two zones which are
+	// not specified is 1.
+	ZoneCosts ZoneCosts `toml:"zone-costs" json:"zone-costs"`
 }
 
 // Clone makes a deep copy of the config.
@@ -633,12 +715,13 @@ func (c *ReplicationConfig) Clone() *ReplicationConfig {
 	locationLabels := append(c.LocationLabels[:0:0], c.LocationLabels...)
 	cfg := *c
 	cfg.LocationLabels = locationLabels
+	cfg.ZoneCosts = c.ZoneCosts.Clone()
 	return &cfg
 }
 
 // Validate is used to validate if some replication configurations are right.
 func (c *ReplicationConfig) Validate() error {
-	foundIsolationLevel := false
+	foundIsolationLevel, foundZoneLabel := false, false
 	for _, label := range c.LocationLabels {
 		err := ValidateLabels([]*metapb.StoreLabel{{Key: label}})
 		if err != nil {
@@ -648,11 +731,17 @@ func (c *ReplicationConfig) Validate() error {
 		if !foundIsolationLevel && label == c.IsolationLevel {
 			foundIsolationLevel = true
 		}
+		if !foundZoneLabel && label == c.ZoneLabel {
+			foundZoneLabel = true
+		}
 	}
 	if c.IsolationLevel != "" && !foundIsolationLevel {
 		return errors.New("isolation-level must be one of location-labels or empty")
 	}
-	return nil
+	if c.ZoneLabel != "" && !foundZoneLabel {
+		return errors.New("zone-label must be one of location-labels or empty")
+	}
+	return c.ZoneCosts.Validate()
 }
 
 // Adjust adjusts the config.
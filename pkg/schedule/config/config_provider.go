@@ -51,21 +51,18 @@ type SchedulerConfigProvider interface {
 	RemoveSchedulerCfg(types.CheckerSchedulerType)
 	Persist(endpoint.ConfigStorage) error
 
-	GetRegionScheduleLimit() uint64
-	GetLeaderScheduleLimit() uint64
-	GetHotRegionScheduleLimit() uint64
-	GetWitnessScheduleLimit() uint64
-
 	GetHotRegionCacheHitsThreshold() int
 	GetMaxMovableHotPeerSize() int64
 	IsTraceRegionFlow() bool
 
 	GetTolerantSizeRatio() float64
 	GetLeaderSchedulePolicy() constant.SchedulePolicy
+	GetSplitMergeInterval() time.Duration
 
 	IsDebugMetricsEnabled() bool
 	IsDiagnosticAllowed() bool
 	GetSlowStoreEvictingAffectedStoreRatioThreshold() float64
+	GetSlowStoreDetector() string
 
 	GetScheduleConfig() *ScheduleConfig
 	SetScheduleConfig(*ScheduleConfig)
@@ -77,6 +74,7 @@ type CheckerConfigProvider interface {
 	StoreConfigProvider
 
 	GetSwitchWitnessInterval() time.Duration
+	GetPromoteWitnessDownTime() time.Duration
 	IsRemoveExtraReplicaEnabled() bool
 	IsRemoveDownReplicaEnabled() bool
 	IsReplaceOfflineReplicaEnabled() bool
@@ -88,7 +86,6 @@ type CheckerConfigProvider interface {
 	GetPatrolRegionWorkerCount() int
 	GetMaxMergeRegionSize() uint64
 	GetMaxMergeRegionKeys() uint64
-	GetReplicaScheduleLimit() uint64
 }
 
 // SharedConfigProvider is the interface for shared configurations.
@@ -101,13 +98,21 @@ type SharedConfigProvider interface {
 	GetHighSpaceRatio() float64
 	GetMaxStoreDownTime() time.Duration
 	GetLocationLabels() []string
+	GetZoneCostMatrix() *ZoneCostMatrix
 	CheckLabelProperty(string, []*metapb.StoreLabel) bool
 	GetClusterVersion() *semver.Version
 	IsUseJointConsensus() bool
 	GetKeyType() constant.KeyType
 	IsCrossTableMergeEnabled() bool
 	IsOneWayMergeEnabled() bool
+	GetRegionScheduleLimit() uint64
+	GetLeaderScheduleLimit() uint64
+	GetHotRegionScheduleLimit() uint64
+	GetReplicaScheduleLimit() uint64
 	GetMergeScheduleLimit() uint64
+	GetWitnessScheduleLimit() uint64
+	IsOperatorFairShareEnabled() bool
+	GetOperatorBudgetWeight(string) float64
 	GetRegionScoreFormulaVersion() string
 	GetSchedulerMaxWaitingOperator() uint64
 	GetStoreLimitByType(uint64, storelimit.Type) float64
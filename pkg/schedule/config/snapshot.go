@@ -0,0 +1,124 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// Snapshot is a versioned snapshot of all scheduling related configurations,
+// which can be exported, compared with another snapshot and applied as a whole.
+type Snapshot struct {
+	// Version is 0 if the snapshot is not saved yet.
+	Version   uint64    `json:"version"`
+	CreatedAt time.Time `json:"created-at"`
+	Comment   string    `json:"comment,omitempty"`
+
+	Schedule *ScheduleConfig `json:"schedule"`
+	// Schedulers is the map from the scheduler name to its independent config.
+	Schedulers map[string]json.RawMessage `json:"schedulers"`
+	// PausedCheckers is the map from the paused checker name to the unix
+	// timestamp when it will be resumed.
+	PausedCheckers map[string]int64 `json:"paused-checkers,omitempty"`
+}
+
+// SnapshotDiff is a difference between two snapshots on a config item.
+// Old or New is nil if the item does not exist in the snapshot.
+type SnapshotDiff struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// DiffSnapshots returns the differences from the old snapshot to the new one,
+// which are sorted by the path. The metadata such as the version is ignored.
+func DiffSnapshots(old, new *Snapshot) ([]*SnapshotDiff, error) {
+	oldItems, err := old.flatten()
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := new.flatten()
+	if err != nil {
+		return nil, err
+	}
+	var diffs []*SnapshotDiff
+	for path, oldValue := range oldItems {
+		newValue, ok := newItems[path]
+		if !ok {
+			diffs = append(diffs, &SnapshotDiff{Path: path, Old: oldValue})
+			continue
+		}
+		if !equalJSON(oldValue, newValue) {
+			diffs = append(diffs, &SnapshotDiff{Path: path, Old: oldValue, New: newValue})
+		}
+	}
+	for path, newValue := range newItems {
+		if _, ok := oldItems[path]; !ok {
+			diffs = append(diffs, &SnapshotDiff{Path: path, New: newValue})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
+
+// flatten converts the configurations of the snapshot to a map from the path
+// of each config item, like "schedule/leader-schedule-limit", to its value.
+// Arrays are regarded as a single item.
+func (s *Snapshot) flatten() (map[string]any, error) {
+	data, err := json.Marshal(struct {
+		Schedule       *ScheduleConfig            `json:"schedule"`
+		Schedulers     map[string]json.RawMessage `json:"schedulers"`
+		PausedCheckers map[string]int64           `json:"paused-checkers"`
+	}{s.Schedule, s.Schedulers, s.PausedCheckers})
+	if err != nil {
+		return nil, errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	items := make(map[string]any)
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		// An empty object is still an item, such as a scheduler without config.
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			for k, child := range m {
+				walk(prefix+"/"+k, child)
+			}
+			return
+		}
+		items[prefix] = v
+	}
+	for k, v := range root {
+		if v == nil {
+			continue
+		}
+		walk(k, v)
+	}
+	return items, nil
+}
+
+func equalJSON(a, b any) bool {
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	re := require.New(t)
+	oldCfg := &ScheduleConfig{LeaderScheduleLimit: 4, RegionScheduleLimit: 2048}
+	old := &Snapshot{
+		Version:  1,
+		Schedule: oldCfg,
+		Schedulers: map[string]json.RawMessage{
+			"balance-leader-scheduler": json.RawMessage(`{"batch":4,"ranges":[{"start-key":"","end-key":""}]}`),
+			"balance-region-scheduler": json.RawMessage(`{}`),
+		},
+	}
+	newCfg := oldCfg.Clone()
+	newCfg.LeaderScheduleLimit = 8
+	new := &Snapshot{
+		Version:  2,
+		Comment:  "more leader scheduling",
+		Schedule: newCfg,
+		Schedulers: map[string]json.RawMessage{
+			"balance-leader-scheduler": json.RawMessage(`{"ranges":[{"start-key":"","end-key":""}],"batch":8}`),
+			"evict-leader-scheduler":   json.RawMessage(`{"store-id-ranges":{"1":[]}}`),
+		},
+		PausedCheckers: map[string]int64{"merge": 1700000000},
+	}
+
+	diffs, err := DiffSnapshots(old, old)
+	re.NoError(err)
+	re.Empty(diffs)
+
+	diffs, err = DiffSnapshots(old, new)
+	re.NoError(err)
+	re.Len(diffs, 5)
+	expected := []struct {
+		path     string
+		old, new any
+	}{
+		{"paused-checkers/merge", nil, float64(1700000000)},
+		{"schedule/leader-schedule-limit", float64(4), float64(8)},
+		{"schedulers/balance-leader-scheduler/batch", float64(4), float64(8)},
+		{"schedulers/balance-region-scheduler", map[string]any{}, nil},
+		{"schedulers/evict-leader-scheduler/store-id-ranges/1", nil, []any{}},
+	}
+	for i, e := range expected {
+		re.Equal(e.path, diffs[i].Path)
+		re.Equal(e.old, diffs[i].Old)
+		re.Equal(e.new, diffs[i].New)
+	}
+
+	// The differences are reversed if the snapshots are swapped.
+	reversed, err := DiffSnapshots(new, old)
+	re.NoError(err)
+	re.Len(reversed, len(diffs))
+	for i := range diffs {
+		re.Equal(diffs[i].Path, reversed[i].Path)
+		re.Equal(diffs[i].Old, reversed[i].New)
+		re.Equal(diffs[i].New, reversed[i].Old)
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	maxTimeWindowDuration = 7 * 24 * time.Hour
+	// maxTimeWindowSearchDays is the max number of days to search the next or
+	// the previous start time of a time window.
+	maxTimeWindowSearchDays = 366
+)
+
+// TimeWindow is a cron-like time window. It starts at each time matched by the
+// cron expression and lasts for the duration.
+type TimeWindow struct {
+	// Cron is a standard cron expression with 5 fields: minute, hour, day of month,
+	// month and day of week, such as "0 1 * * 1-5" for 01:00 on weekdays.
+	Cron     string            `toml:"cron" json:"cron"`
+	Duration typeutil.Duration `toml:"duration" json:"duration"`
+	// TimeZone is the IANA time zone name, such as "Asia/Shanghai".
+	// The local time zone of PD is used if it is empty.
+	TimeZone string `toml:"time-zone" json:"time-zone,omitempty"`
+
+	// compiled caches the parsed time window. It is set when the config is
+	// validated or adjusted, which happens before the config is shared.
+	compiled *compiledTimeWindow
+}
+
+// Validate checks if the time window is valid.
+func (w *TimeWindow) Validate() error {
+	cw, err := w.compile()
+	if err != nil {
+		return err
+	}
+	w.compiled = cw
+	return nil
+}
+
+// getCompiled returns the cached parsed time window, and parses it if not cached.
+func (w *TimeWindow) getCompiled() (*compiledTimeWindow, error) {
+	if w.compiled != nil {
+		return w.compiled, nil
+	}
+	return w.compile()
+}
+
+// compile parses the time window to a form that can be matched with time.
+func (w *TimeWindow) compile() (*compiledTimeWindow, error) {
+	if w.Duration.Duration < time.Minute || w.Duration.Duration > maxTimeWindowDuration {
+		return nil, errors.Errorf("duration of time window should be between %v and %v", time.Minute, maxTimeWindowDuration)
+	}
+	cron, err := parseCron(w.Cron)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.Local
+	if len(w.TimeZone) > 0 {
+		if loc, err = time.LoadLocation(w.TimeZone); err != nil {
+			return nil, errors.Errorf("time zone %s is invalid", w.TimeZone)
+		}
+	}
+	return &compiledTimeWindow{cron: cron, duration: w.Duration.Duration, loc: loc}, nil
+}
+
+// TimeWindows is a set of time windows, which is active if any of them is active.
+type TimeWindows []*TimeWindow
+
+// Validate checks if all time windows are valid.
+func (ws TimeWindows) Validate() error {
+	for _, w := range ws {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compile parses the valid time windows and caches the results, so that they are
+// not parsed again when checked. The invalid ones are left to Validate.
+func (ws TimeWindows) Compile() {
+	for _, w := range ws {
+		_ = w.Validate()
+	}
+}
+
+// Clone returns a deep copy of the time windows.
+func (ws TimeWindows) Clone() TimeWindows {
+	if ws == nil {
+		return nil
+	}
+	cloned := make(TimeWindows, 0, len(ws))
+	for _, w := range ws {
+		c := *w
+		cloned = append(cloned, &c)
+	}
+	return cloned
+}
+
+// IsActive returns if the time is in any of the time windows. The invalid time
+// windows are ignored, and it is always active if there is no time window.
+func (ws TimeWindows) IsActive(t time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		cw, err := w.getCompiled()
+		if err != nil {
+			continue
+		}
+		if cw.isActive(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastEnd returns the latest end time of the time windows before the time.
+// It returns a zero time if not found.
+func (ws TimeWindows) LastEnd(t time.Time) time.Time {
+	var last time.Time
+	for _, w := range ws {
+		cw, err := w.getCompiled()
+		if err != nil {
+			continue
+		}
+		start, ok := cw.cron.prev(t.In(cw.loc), t.In(cw.loc).AddDate(0, 0, -maxTimeWindowSearchDays))
+		if !ok {
+			continue
+		}
+		end := start.Add(cw.duration)
+		if !end.After(t) && end.After(last) {
+			last = end
+		}
+	}
+	return last
+}
+
+// NextStart returns the earliest start time of the time windows after the time.
+// It returns a zero time if not found.
+func (ws TimeWindows) NextStart(t time.Time) time.Time {
+	var next time.Time
+	for _, w := range ws {
+		cw, err := w.getCompiled()
+		if err != nil {
+			continue
+		}
+		start, ok := cw.cron.next(t.In(cw.loc), t.In(cw.loc).AddDate(0, 0, maxTimeWindowSearchDays))
+		if ok && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
+
+type compiledTimeWindow struct {
+	cron     *cronSpec
+	duration time.Duration
+	loc      *time.Location
+}
+
+func (w *compiledTimeWindow) isActive(t time.Time) bool {
+	t = t.In(w.loc)
+	start, ok := w.cron.prev(t, t.Add(-w.duration))
+	return ok && t.Before(start.Add(w.duration))
+}
+
+// cronSpec is a parsed cron expression, each field is a bitmap of the allowed values.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if the day of month or the day of week is "*".
+	// As in the standard cron, a day matches if either of them matches when both
+	// of them are restricted.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// Both 0 and 7 are Sunday.
+	{"day of week", 0, 7},
+}
+
+func parseCron(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("cron %q should have %d fields", expr, len(cronFields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, errors.Errorf("cron %q is invalid: %v", expr, err)
+		}
+		bits[i] = b
+	}
+	spec := &cronSpec{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	if spec.dow&(1<<7) != 0 {
+		spec.dow |= 1
+	}
+	return spec, nil
+}
+
+// parseCronField parses a field consisting of comma separated items,
+// each of them is "*", "n" or "a-b", optionally followed by "/step".
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rng, step, hasStep := item, 1, false
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return 0, errors.Errorf("invalid step in %s %q", f.name, item)
+			}
+			rng, step, hasStep = item[:i], s, true
+		}
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.Errorf("invalid %s %q", f.name, item)
+			}
+			hi = lo
+			if hasStep {
+				// "n/step" means from n to the max value.
+				hi = f.max
+			}
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.Errorf("invalid %s %q", f.name, item)
+				}
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, errors.Errorf("%s %q should be between %d and %d", f.name, item, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cronSpec) matchDay(t time.Time) bool {
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// prev returns the latest time matched by the cron which is not after t and not before limit.
+func (c *cronSpec) prev(t, limit time.Time) (time.Time, bool) {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	for !t.Before(limit) {
+		var candidate time.Time
+		switch {
+		case !c.matchDay(t):
+			candidate = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			candidate = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			candidate = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+		// The wall clock may be ambiguous around the daylight saving time
+		// transitions, make sure the search always moves backward.
+		if !candidate.Before(t) {
+			candidate = t.Add(-time.Minute)
+		}
+		t = candidate
+	}
+	return time.Time{}, false
+}
+
+// next returns the earliest time matched by the cron which is after t and not after limit.
+func (c *cronSpec) next(t, limit time.Time) (time.Time, bool) {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	for !t.After(limit) {
+		var candidate time.Time
+		switch {
+		case !c.matchDay(t):
+			candidate = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			candidate = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			candidate = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+		// The same as prev, make sure the search always moves forward.
+		if !candidate.After(t) {
+			candidate = t.Add(time.Minute)
+		}
+		t = candidate
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/utils/configutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func TestTimeWindowValidate(t *testing.T) {
+	re := require.New(t)
+	tests := []struct {
+		cron     string
+		duration time.Duration
+		timeZone string
+		hasErr   bool
+	}{
+		{"0 1 * * *", 4 * time.Hour, "", false},
+		{"*/15 0-6 1,15 * 1-5", time.Minute, "UTC", false},
+		{"30 22 * * 0,7", 7 * 24 * time.Hour, "Asia/Shanghai", false},
+		{"5/10 * * * *", time.Hour, "", false},
+		{"0 1 * *", time.Hour, "", true},
+		{"60 1 * * *", time.Hour, "", true},
+		{"0 24 * * *", time.Hour, "", true},
+		{"0 1 0 * *", time.Hour, "", true},
+		{"0 1 * 13 *", time.Hour, "", true},
+		{"0 1 * * 8", time.Hour, "", true},
+		{"0 5-1 * * *", time.Hour, "", true},
+		{"*/0 * * * *", time.Hour, "", true},
+		{"a * * * *", time.Hour, "", true},
+		{"0 1 * * *", 0, "", true},
+		{"0 1 * * *", 8 * 24 * time.Hour, "", true},
+		{"0 1 * * *", time.Hour, "Mars/Olympus", true},
+	}
+	for _, test := range tests {
+		w := &TimeWindow{Cron: test.cron, Duration: typeutil.NewDuration(test.duration), TimeZone: test.timeZone}
+		if test.hasErr {
+			re.Error(w.Validate(), test.cron)
+			re.Nil(w.compiled)
+		} else {
+			re.NoError(w.Validate(), test.cron)
+			re.NotNil(w.compiled)
+		}
+	}
+
+	cfg := &ScheduleConfig{}
+	re.NoError(cfg.Adjust(configutil.NewConfigMetadata(nil), false))
+	re.NoError(cfg.Validate())
+	cfg.SchedulerTimeWindows = map[string]TimeWindows{
+		"balance-region-scheduler": {{Cron: "0 1 * *", Duration: typeutil.NewDuration(time.Hour)}},
+	}
+	re.Error(cfg.Validate())
+
+	// The time windows are parsed once when the config is adjusted, and the cached
+	// results are kept after cloning.
+	cfg = &ScheduleConfig{SchedulerTimeWindows: map[string]TimeWindows{
+		"balance-region-scheduler": {{Cron: "0 1 * * *", Duration: typeutil.NewDuration(time.Hour)}},
+	}}
+	re.NoError(cfg.Adjust(configutil.NewConfigMetadata(nil), false))
+	compiled := cfg.SchedulerTimeWindows["balance-region-scheduler"][0].compiled
+	re.NotNil(compiled)
+	re.Same(compiled, cfg.Clone().SchedulerTimeWindows["balance-region-scheduler"][0].compiled)
+}
+
+func TestTimeWindowIsActive(t *testing.T) {
+	re := require.New(t)
+	at := func(s string) time.Time {
+		tm, err := time.ParseInLocation(time.DateTime, s, time.UTC)
+		re.NoError(err)
+		return tm
+	}
+	// 2025-01-06 is a Monday.
+	windows := TimeWindows{
+		// 01:00 - 05:00 on weekdays.
+		{Cron: "0 1 * * 1-5", Duration: typeutil.NewDuration(4 * time.Hour), TimeZone: "UTC"},
+		// 22:00 on Saturday to 02:00 on Sunday.
+		{Cron: "0 22 * * 6", Duration: typeutil.NewDuration(4 * time.Hour), TimeZone: "UTC"},
+	}
+	re.NoError(windows.Validate())
+	tests := []struct {
+		now    string
+		active bool
+	}{
+		{"2025-01-06 00:59:59", false},
+		{"2025-01-06 01:00:00", true},
+		{"2025-01-06 04:59:59", true},
+		{"2025-01-06 05:00:00", false},
+		{"2025-01-10 03:00:00", true},
+		{"2025-01-11 03:00:00", false},
+		{"2025-01-11 22:30:00", true},
+		{"2025-01-12 01:59:00", true},
+		{"2025-01-12 02:00:00", false},
+	}
+	for _, test := range tests {
+		re.Equal(test.active, windows.IsActive(at(test.now)), test.now)
+	}
+	// It is always active without time windows.
+	re.True(TimeWindows(nil).IsActive(at("2025-01-06 00:00:00")))
+
+	re.Equal(at("2025-01-06 05:00:00"), windows.LastEnd(at("2025-01-06 12:00:00")))
+	re.Equal(at("2025-01-07 01:00:00"), windows.NextStart(at("2025-01-06 12:00:00")))
+	re.Equal(at("2025-01-10 05:00:00"), windows.LastEnd(at("2025-01-11 12:00:00")))
+	re.Equal(at("2025-01-11 22:00:00"), windows.NextStart(at("2025-01-11 12:00:00")))
+
+	// The time zone of the window is used to match the cron.
+	windows = TimeWindows{{Cron: "0 1 * * *", Duration: typeutil.NewDuration(time.Hour), TimeZone: "Asia/Shanghai"}}
+	re.True(windows.IsActive(at("2025-01-05 17:30:00")))
+	re.False(windows.IsActive(at("2025-01-06 01:30:00")))
+
+	// Both the day of month and the day of week are restricted, either of them matches.
+	windows = TimeWindows{{Cron: "0 0 1 * 1", Duration: typeutil.NewDuration(time.Hour), TimeZone: "UTC"}}
+	re.True(windows.IsActive(at("2025-01-01 00:30:00")))
+	re.True(windows.IsActive(at("2025-01-06 00:30:00")))
+	re.False(windows.IsActive(at("2025-01-07 00:30:00")))
+}
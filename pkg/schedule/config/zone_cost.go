@@ -0,0 +1,122 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/core"
+)
+
+// defaultZoneCost is the cost between two different zones which are not specified
+// in the zone costs.
+const defaultZoneCost = 1.0
+
+// ZoneCost is the network cost of sending data from a zone to another one.
+// The cost is the same in both directions if only one of them is specified.
+type ZoneCost struct {
+	From string  `toml:"from" json:"from"`
+	To   string  `toml:"to" json:"to"`
+	Cost float64 `toml:"cost" json:"cost"`
+}
+
+// ZoneCosts is the network costs between zones.
+type ZoneCosts []ZoneCost
+
+// Validate checks if the zone costs are valid.
+func (cs ZoneCosts) Validate() error {
+	for _, c := range cs {
+		if len(c.From) == 0 || len(c.To) == 0 {
+			return errors.New("zone of zone-costs should not be empty")
+		}
+		if c.From == c.To {
+			return errors.Errorf("zone-costs from %s to itself is not allowed", c.From)
+		}
+		if c.Cost < 0 {
+			return errors.Errorf("cost from %s to %s should not be negative", c.From, c.To)
+		}
+	}
+	return nil
+}
+
+// Clone returns a copy of the zone costs.
+func (cs ZoneCosts) Clone() ZoneCosts {
+	if cs == nil {
+		return nil
+	}
+	return append(cs[:0:0], cs...)
+}
+
+type zonePair struct {
+	from, to string
+}
+
+// ZoneCostMatrix is used to get the network cost between stores. The zone of a
+// store is the value of its zone label. It costs nothing within a zone, and costs
+// nothing everywhere if no zone cost is configured.
+type ZoneCostMatrix struct {
+	label string
+	costs map[zonePair]float64
+}
+
+// NewZoneCostMatrix creates a ZoneCostMatrix from the replication config.
+func NewZoneCostMatrix(cfg *ReplicationConfig) *ZoneCostMatrix {
+	m := &ZoneCostMatrix{
+		label: cfg.ZoneLabel,
+		costs: make(map[zonePair]float64, len(cfg.ZoneCosts)),
+	}
+	if len(m.label) == 0 && len(cfg.LocationLabels) > 0 {
+		m.label = cfg.LocationLabels[0]
+	}
+	for _, c := range cfg.ZoneCosts {
+		m.costs[zonePair{c.From, c.To}] = c.Cost
+	}
+	return m
+}
+
+// IsEnabled returns true if any zone cost is configured.
+func (m *ZoneCostMatrix) IsEnabled() bool {
+	return m != nil && len(m.label) > 0 && len(m.costs) > 0
+}
+
+// GetZone returns the zone of the store.
+func (m *ZoneCostMatrix) GetZone(store *core.StoreInfo) string {
+	if m == nil || len(m.label) == 0 {
+		return ""
+	}
+	return store.GetLabelValue(m.label)
+}
+
+// GetZoneCost returns the cost of sending data from a zone to another one.
+func (m *ZoneCostMatrix) GetZoneCost(from, to string) float64 {
+	if !m.IsEnabled() || from == to {
+		return 0
+	}
+	if cost, ok := m.costs[zonePair{from, to}]; ok {
+		return cost
+	}
+	if cost, ok := m.costs[zonePair{to, from}]; ok {
+		return cost
+	}
+	return defaultZoneCost
+}
+
+// GetCost returns the cost of sending data from a store to another one.
+func (m *ZoneCostMatrix) GetCost(from, to *core.StoreInfo) float64 {
+	if !m.IsEnabled() {
+		return 0
+	}
+	return m.GetZoneCost(m.GetZone(from), m.GetZone(to))
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+)
+
+func TestZoneCostValidate(t *testing.T) {
+	re := require.New(t)
+	cfg := &ReplicationConfig{
+		LocationLabels: []string{"zone", "host"},
+		ZoneLabel:      "zone",
+		ZoneCosts:      ZoneCosts{{From: "z1", To: "z2", Cost: 2}},
+	}
+	re.NoError(cfg.Validate())
+	cfg.ZoneLabel = "rack"
+	re.Error(cfg.Validate())
+	cfg.ZoneLabel = ""
+	re.NoError(cfg.Validate())
+
+	for _, costs := range []ZoneCosts{
+		{{From: "", To: "z2", Cost: 1}},
+		{{From: "z1", To: "z1", Cost: 1}},
+		{{From: "z1", To: "z2", Cost: -1}},
+	} {
+		cfg.ZoneCosts = costs
+		re.Error(cfg.Validate())
+	}
+
+	// The zone costs are deeply copied.
+	cfg.ZoneCosts = ZoneCosts{{From: "z1", To: "z2", Cost: 2}}
+	cloned := cfg.Clone()
+	cloned.ZoneCosts[0].Cost = 3
+	re.Equal(float64(2), cfg.ZoneCosts[0].Cost)
+}
+
+func TestZoneCostMatrix(t *testing.T) {
+	re := require.New(t)
+	newStore := func(id uint64, zone string) *core.StoreInfo {
+		return core.NewStoreInfo(&metapb.Store{Id: id, Labels: []*metapb.StoreLabel{{Key: "zone", Value: zone}}})
+	}
+	s1, s2, s3, s4 := newStore(1, "z1"), newStore(2, "z1"), newStore(3, "z2"), newStore(4, "z3")
+
+	// It costs nothing if no zone cost is configured.
+	m := NewZoneCostMatrix(&ReplicationConfig{LocationLabels: []string{"zone", "host"}})
+	re.False(m.IsEnabled())
+	re.Equal("z2", m.GetZone(s3))
+	re.Zero(m.GetCost(s1, s3))
+
+	m = NewZoneCostMatrix(&ReplicationConfig{
+		LocationLabels: []string{"zone", "host"},
+		ZoneCosts: ZoneCosts{
+			{From: "z1", To: "z2", Cost: 5},
+			{From: "z2", To: "z1", Cost: 3},
+			{From: "z3", To: "z1", Cost: 8},
+		},
+	})
+	re.True(m.IsEnabled())
+	re.Zero(m.GetCost(s1, s2))
+	re.Equal(float64(5), m.GetCost(s1, s3))
+	re.Equal(float64(3), m.GetCost(s3, s1))
+	// The cost is symmetric if only one direction is specified.
+	re.Equal(float64(8), m.GetCost(s1, s4))
+	re.Equal(float64(8), m.GetCost(s4, s1))
+	// The cost is 1 if it is not specified.
+	re.Equal(defaultZoneCost, m.GetCost(s3, s4))
+
+	// The zone label is used instead of the first location label.
+	m = NewZoneCostMatrix(&ReplicationConfig{
+		LocationLabels: []string{"host", "zone"},
+		ZoneLabel:      "zone",
+		ZoneCosts:      ZoneCosts{{From: "z1", To: "z2", Cost: 5}},
+	})
+	re.Equal("z1", m.GetZone(s1))
+	re.Equal(float64(5), m.GetCost(s1, s3))
+}
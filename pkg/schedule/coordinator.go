@@ -587,6 +587,20 @@ func (c *Coordinator) IsCheckerPaused(name string) (bool, error) {
 	return p.IsPaused(), nil
 }
 
+// GetCheckerDelayUntil returns the resume timestamp of a checker, or 0 if it is not paused.
+func (c *Coordinator) GetCheckerDelayUntil(name string) (int64, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cluster == nil {
+		return 0, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	p, err := c.checkers.GetPauseController(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.GetDelayUntil(), nil
+}
+
 // GetRegionScatterer returns the region scatterer.
 func (c *Coordinator) GetRegionScatterer() *scatter.RegionScatterer {
 	return c.regionScatterer
@@ -42,6 +42,7 @@ type SchedulerCluster interface {
 	GetSchedulerConfig() sc.SchedulerConfigProvider
 	GetRegionLabeler() *labeler.RegionLabeler
 	GetStoreConfig() sc.StoreConfigProvider
+	GetRegionStatsByType(typ statistics.RegionStatisticType) []*core.RegionInfo
 }
 
 // CheckerCluster is an aggregate interface that wraps multiple interfaces
@@ -0,0 +1,3041 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: externalpb.proto
+
+package externalpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type RequestType int32
+
+const (
+	// SNAPSHOT carries a part of the snapshot of the cluster. The first request of a
+	// stream carries all the stores, and the cluster known before is out of date.
+	RequestType_SNAPSHOT RequestType = 0
+	// SNAPSHOT_END carries the last part of the snapshot.
+	RequestType_SNAPSHOT_END RequestType = 1
+	// DELTA carries the stores and the regions changed since the previous request.
+	RequestType_DELTA RequestType = 2
+)
+
+var RequestType_name = map[int32]string{
+	0: "SNAPSHOT",
+	1: "SNAPSHOT_END",
+	2: "DELTA",
+}
+
+var RequestType_value = map[string]int32{
+	"SNAPSHOT":     0,
+	"SNAPSHOT_END": 1,
+	"DELTA":        2,
+}
+
+func (x RequestType) String() string {
+	return proto.EnumName(RequestType_name, int32(x))
+}
+
+func (RequestType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{0}
+}
+
+type ProposalType int32
+
+const (
+	ProposalType_UNKNOWN ProposalType = 0
+	// TRANSFER_LEADER transfers the leader of the region to the target store which has a peer of it.
+	ProposalType_TRANSFER_LEADER ProposalType = 1
+	// MOVE_PEER moves the peer of the region from the source store to the target store.
+	ProposalType_MOVE_PEER ProposalType = 2
+	// MOVE_LEADER moves the leader of the region from the source store to the target store.
+	ProposalType_MOVE_LEADER ProposalType = 3
+	// ADD_PEER adds a voter of the region on the target store.
+	ProposalType_ADD_PEER ProposalType = 4
+	// REMOVE_PEER removes the peer of the region on the source store.
+	ProposalType_REMOVE_PEER ProposalType = 5
+)
+
+var ProposalType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "TRANSFER_LEADER",
+	2: "MOVE_PEER",
+	3: "MOVE_LEADER",
+	4: "ADD_PEER",
+	5: "REMOVE_PEER",
+}
+
+var ProposalType_value = map[string]int32{
+	"UNKNOWN":         0,
+	"TRANSFER_LEADER": 1,
+	"MOVE_PEER":       2,
+	"MOVE_LEADER":     3,
+	"ADD_PEER":        4,
+	"REMOVE_PEER":     5,
+}
+
+func (x ProposalType) String() string {
+	return proto.EnumName(ProposalType_name, int32(x))
+}
+
+func (ProposalType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{1}
+}
+
+type PeerRole int32
+
+const (
+	PeerRole_VOTER   PeerRole = 0
+	PeerRole_LEARNER PeerRole = 1
+	PeerRole_WITNESS PeerRole = 2
+)
+
+var PeerRole_name = map[int32]string{
+	0: "VOTER",
+	1: "LEARNER",
+	2: "WITNESS",
+}
+
+var PeerRole_value = map[string]int32{
+	"VOTER":   0,
+	"LEARNER": 1,
+	"WITNESS": 2,
+}
+
+func (x PeerRole) String() string {
+	return proto.EnumName(PeerRole_name, int32(x))
+}
+
+func (PeerRole) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{2}
+}
+
+type ScheduleRequest struct {
+	Type   RequestType `protobuf:"varint,1,opt,name=type,proto3,enum=externalpb.RequestType" json:"type,omitempty"`
+	Stores []*Store    `protobuf:"bytes,2,rep,name=stores,proto3" json:"stores,omitempty"`
+	// regions are sent in the order of the keys in a snapshot. The regions overlapping
+	// with the ones in a delta are out of date, as they are replaced after a split or
+	// a merge.
+	Regions          []*Region `protobuf:"bytes,3,rep,name=regions,proto3" json:"regions,omitempty"`
+	RemovedStoreIds  []uint64  `protobuf:"varint,4,rep,packed,name=removed_store_ids,json=removedStoreIds,proto3" json:"removed_store_ids,omitempty"`
+	RemovedRegionIds []uint64  `protobuf:"varint,5,rep,packed,name=removed_region_ids,json=removedRegionIds,proto3" json:"removed_region_ids,omitempty"`
+	// limit is the max number of the operators PD accepts in a round.
+	Limit uint32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *ScheduleRequest) Reset()         { *m = ScheduleRequest{} }
+func (m *ScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*ScheduleRequest) ProtoMessage()    {}
+func (*ScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{0}
+}
+func (m *ScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduleRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleRequest.Merge(m, src)
+}
+func (m *ScheduleRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleRequest proto.InternalMessageInfo
+
+func (m *ScheduleRequest) GetType() RequestType {
+	if m != nil {
+		return m.Type
+	}
+	return RequestType_SNAPSHOT
+}
+
+func (m *ScheduleRequest) GetStores() []*Store {
+	if m != nil {
+		return m.Stores
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetRegions() []*Region {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetRemovedStoreIds() []uint64 {
+	if m != nil {
+		return m.RemovedStoreIds
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetRemovedRegionIds() []uint64 {
+	if m != nil {
+		return m.RemovedRegionIds
+	}
+	return nil
+}
+
+func (m *ScheduleRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ScheduleResponse struct {
+	Proposals []*Proposal `protobuf:"bytes,1,rep,name=proposals,proto3" json:"proposals,omitempty"`
+}
+
+func (m *ScheduleResponse) Reset()         { *m = ScheduleResponse{} }
+func (m *ScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*ScheduleResponse) ProtoMessage()    {}
+func (*ScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{1}
+}
+func (m *ScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduleResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleResponse.Merge(m, src)
+}
+func (m *ScheduleResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleResponse proto.InternalMessageInfo
+
+func (m *ScheduleResponse) GetProposals() []*Proposal {
+	if m != nil {
+		return m.Proposals
+	}
+	return nil
+}
+
+type Proposal struct {
+	Type     ProposalType `protobuf:"varint,1,opt,name=type,proto3,enum=externalpb.ProposalType" json:"type,omitempty"`
+	RegionId uint64       `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	// region_version and conf_ver are the epoch of the region seen by the external
+	// scheduler. The proposal is rejected if the region has been changed.
+	RegionVersion uint64 `protobuf:"varint,3,opt,name=region_version,json=regionVersion,proto3" json:"region_version,omitempty"`
+	ConfVer       uint64 `protobuf:"varint,4,opt,name=conf_ver,json=confVer,proto3" json:"conf_ver,omitempty"`
+	SourceStoreId uint64 `protobuf:"varint,5,opt,name=source_store_id,json=sourceStoreId,proto3" json:"source_store_id,omitempty"`
+	TargetStoreId uint64 `protobuf:"varint,6,opt,name=target_store_id,json=targetStoreId,proto3" json:"target_store_id,omitempty"`
+}
+
+func (m *Proposal) Reset()         { *m = Proposal{} }
+func (m *Proposal) String() string { return proto.CompactTextString(m) }
+func (*Proposal) ProtoMessage()    {}
+func (*Proposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{2}
+}
+func (m *Proposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Proposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Proposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Proposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Proposal.Merge(m, src)
+}
+func (m *Proposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *Proposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Proposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Proposal proto.InternalMessageInfo
+
+func (m *Proposal) GetType() ProposalType {
+	if m != nil {
+		return m.Type
+	}
+	return ProposalType_UNKNOWN
+}
+
+func (m *Proposal) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
+	}
+	return 0
+}
+
+func (m *Proposal) GetRegionVersion() uint64 {
+	if m != nil {
+		return m.RegionVersion
+	}
+	return 0
+}
+
+func (m *Proposal) GetConfVer() uint64 {
+	if m != nil {
+		return m.ConfVer
+	}
+	return 0
+}
+
+func (m *Proposal) GetSourceStoreId() uint64 {
+	if m != nil {
+		return m.SourceStoreId
+	}
+	return 0
+}
+
+func (m *Proposal) GetTargetStoreId() uint64 {
+	if m != nil {
+		return m.TargetStoreId
+	}
+	return 0
+}
+
+type StoreLabel struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *StoreLabel) Reset()         { *m = StoreLabel{} }
+func (m *StoreLabel) String() string { return proto.CompactTextString(m) }
+func (*StoreLabel) ProtoMessage()    {}
+func (*StoreLabel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{3}
+}
+func (m *StoreLabel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreLabel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreLabel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StoreLabel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreLabel.Merge(m, src)
+}
+func (m *StoreLabel) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreLabel) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreLabel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreLabel proto.InternalMessageInfo
+
+func (m *StoreLabel) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StoreLabel) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type Store struct {
+	Id          uint64        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address     string        `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	State       string        `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Labels      []*StoreLabel `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	LeaderCount uint64        `protobuf:"varint,5,opt,name=leader_count,json=leaderCount,proto3" json:"leader_count,omitempty"`
+	RegionCount uint64        `protobuf:"varint,6,opt,name=region_count,json=regionCount,proto3" json:"region_count,omitempty"`
+	LeaderSize  int64         `protobuf:"varint,7,opt,name=leader_size,json=leaderSize,proto3" json:"leader_size,omitempty"`
+	RegionSize  int64         `protobuf:"varint,8,opt,name=region_size,json=regionSize,proto3" json:"region_size,omitempty"`
+	Capacity    uint64        `protobuf:"varint,9,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Available   uint64        `protobuf:"varint,10,opt,name=available,proto3" json:"available,omitempty"`
+}
+
+func (m *Store) Reset()         { *m = Store{} }
+func (m *Store) String() string { return proto.CompactTextString(m) }
+func (*Store) ProtoMessage()    {}
+func (*Store) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{4}
+}
+func (m *Store) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Store) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Store.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Store) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Store.Merge(m, src)
+}
+func (m *Store) XXX_Size() int {
+	return m.Size()
+}
+func (m *Store) XXX_DiscardUnknown() {
+	xxx_messageInfo_Store.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Store proto.InternalMessageInfo
+
+func (m *Store) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Store) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Store) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Store) GetLabels() []*StoreLabel {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Store) GetLeaderCount() uint64 {
+	if m != nil {
+		return m.LeaderCount
+	}
+	return 0
+}
+
+func (m *Store) GetRegionCount() uint64 {
+	if m != nil {
+		return m.RegionCount
+	}
+	return 0
+}
+
+func (m *Store) GetLeaderSize() int64 {
+	if m != nil {
+		return m.LeaderSize
+	}
+	return 0
+}
+
+func (m *Store) GetRegionSize() int64 {
+	if m != nil {
+		return m.RegionSize
+	}
+	return 0
+}
+
+func (m *Store) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *Store) GetAvailable() uint64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
+type Peer struct {
+	Id      uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StoreId uint64   `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	Role    PeerRole `protobuf:"varint,3,opt,name=role,proto3,enum=externalpb.PeerRole" json:"role,omitempty"`
+	IsDown  bool     `protobuf:"varint,4,opt,name=is_down,json=isDown,proto3" json:"is_down,omitempty"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{5}
+}
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(m, src)
+}
+func (m *Peer) XXX_Size() int {
+	return m.Size()
+}
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Peer proto.InternalMessageInfo
+
+func (m *Peer) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Peer) GetStoreId() uint64 {
+	if m != nil {
+		return m.StoreId
+	}
+	return 0
+}
+
+func (m *Peer) GetRole() PeerRole {
+	if m != nil {
+		return m.Role
+	}
+	return PeerRole_VOTER
+}
+
+func (m *Peer) GetIsDown() bool {
+	if m != nil {
+		return m.IsDown
+	}
+	return false
+}
+
+type Region struct {
+	Id            uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartKey      []byte  `protobuf:"bytes,2,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey        []byte  `protobuf:"bytes,3,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	RegionVersion uint64  `protobuf:"varint,4,opt,name=region_version,json=regionVersion,proto3" json:"region_version,omitempty"`
+	ConfVer       uint64  `protobuf:"varint,5,opt,name=conf_ver,json=confVer,proto3" json:"conf_ver,omitempty"`
+	LeaderStoreId uint64  `protobuf:"varint,6,opt,name=leader_store_id,json=leaderStoreId,proto3" json:"leader_store_id,omitempty"`
+	Peers         []*Peer `protobuf:"bytes,7,rep,name=peers,proto3" json:"peers,omitempty"`
+	// approximate_size is in MiB.
+	ApproximateSize int64  `protobuf:"varint,8,opt,name=approximate_size,json=approximateSize,proto3" json:"approximate_size,omitempty"`
+	ApproximateKeys int64  `protobuf:"varint,9,opt,name=approximate_keys,json=approximateKeys,proto3" json:"approximate_keys,omitempty"`
+	WrittenBytes    uint64 `protobuf:"varint,10,opt,name=written_bytes,json=writtenBytes,proto3" json:"written_bytes,omitempty"`
+	ReadBytes       uint64 `protobuf:"varint,11,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+}
+
+func (m *Region) Reset()         { *m = Region{} }
+func (m *Region) String() string { return proto.CompactTextString(m) }
+func (*Region) ProtoMessage()    {}
+func (*Region) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef184dd976a66fb8, []int{6}
+}
+func (m *Region) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Region) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Region.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Region) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Region.Merge(m, src)
+}
+func (m *Region) XXX_Size() int {
+	return m.Size()
+}
+func (m *Region) XXX_DiscardUnknown() {
+	xxx_messageInfo_Region.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Region proto.InternalMessageInfo
+
+func (m *Region) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Region) GetStartKey() []byte {
+	if m != nil {
+		return m.StartKey
+	}
+	return nil
+}
+
+func (m *Region) GetEndKey() []byte {
+	if m != nil {
+		return m.EndKey
+	}
+	return nil
+}
+
+func (m *Region) GetRegionVersion() uint64 {
+	if m != nil {
+		return m.RegionVersion
+	}
+	return 0
+}
+
+func (m *Region) GetConfVer() uint64 {
+	if m != nil {
+		return m.ConfVer
+	}
+	return 0
+}
+
+func (m *Region) GetLeaderStoreId() uint64 {
+	if m != nil {
+		return m.LeaderStoreId
+	}
+	return 0
+}
+
+func (m *Region) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *Region) GetApproximateSize() int64 {
+	if m != nil {
+		return m.ApproximateSize
+	}
+	return 0
+}
+
+func (m *Region) GetApproximateKeys() int64 {
+	if m != nil {
+		return m.ApproximateKeys
+	}
+	return 0
+}
+
+func (m *Region) GetWrittenBytes() uint64 {
+	if m != nil {
+		return m.WrittenBytes
+	}
+	return 0
+}
+
+func (m *Region) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("externalpb.RequestType", RequestType_name, RequestType_value)
+	proto.RegisterEnum("externalpb.ProposalType", ProposalType_name, ProposalType_value)
+	proto.RegisterEnum("externalpb.PeerRole", PeerRole_name, PeerRole_value)
+	proto.RegisterType((*ScheduleRequest)(nil), "externalpb.ScheduleRequest")
+	proto.RegisterType((*ScheduleResponse)(nil), "externalpb.ScheduleResponse")
+	proto.RegisterType((*Proposal)(nil), "externalpb.Proposal")
+	proto.RegisterType((*StoreLabel)(nil), "externalpb.StoreLabel")
+	proto.RegisterType((*Store)(nil), "externalpb.Store")
+	proto.RegisterType((*Peer)(nil), "externalpb.Peer")
+	proto.RegisterType((*Region)(nil), "externalpb.Region")
+}
+
+func init() { proto.RegisterFile("externalpb.proto", fileDescriptor_ef184dd976a66fb8) }
+
+var fileDescriptor_ef184dd976a66fb8 = []byte{
+	// 912 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x95, 0xcf, 0x73, 0xdb, 0x44,
+	0x14, 0xc7, 0x2d, 0x59, 0xb6, 0xa5, 0x67, 0x27, 0x56, 0x97, 0x0e, 0x55, 0x9b, 0x62, 0x8c, 0x19,
+	0x18, 0x37, 0x74, 0x02, 0x13, 0x38, 0x70, 0x75, 0xb1, 0x3a, 0x74, 0x12, 0x9c, 0xcc, 0xda, 0xa4,
+	0xdc, 0x34, 0xb2, 0xf5, 0x28, 0x9a, 0x2a, 0x92, 0xd8, 0x5d, 0x27, 0x75, 0xff, 0x01, 0xae, 0xfc,
+	0x3b, 0xfc, 0x07, 0x1c, 0x7b, 0xe4, 0xc8, 0x24, 0x07, 0xfe, 0x07, 0x4e, 0xcc, 0xfe, 0x50, 0xec,
+	0xc4, 0x9d, 0xe1, 0xe6, 0xf7, 0xfd, 0x7e, 0xf6, 0xed, 0xee, 0x7b, 0xcf, 0x2b, 0xf0, 0xf1, 0x8d,
+	0x40, 0x96, 0xc7, 0x59, 0x39, 0x3f, 0x28, 0x59, 0x21, 0x0a, 0x02, 0x6b, 0x65, 0xf0, 0x9b, 0x0d,
+	0xdd, 0xe9, 0xe2, 0x17, 0x4c, 0x96, 0x19, 0x52, 0xfc, 0x75, 0x89, 0x5c, 0x90, 0x2f, 0xc0, 0x11,
+	0xab, 0x12, 0x03, 0xab, 0x6f, 0x0d, 0x77, 0x0f, 0x1f, 0x1c, 0x6c, 0x24, 0x30, 0xc8, 0x6c, 0x55,
+	0x22, 0x55, 0x10, 0x79, 0x02, 0x4d, 0x2e, 0x0a, 0x86, 0x3c, 0xb0, 0xfb, 0xf5, 0x61, 0xfb, 0xf0,
+	0xde, 0x26, 0x3e, 0x95, 0x0e, 0x35, 0x00, 0x79, 0x0a, 0x2d, 0x86, 0xaf, 0xd2, 0x22, 0xe7, 0x41,
+	0x5d, 0xb1, 0xe4, 0x76, 0x6a, 0x69, 0xd1, 0x0a, 0x21, 0xfb, 0x70, 0x8f, 0xe1, 0x79, 0x71, 0x81,
+	0x49, 0xa4, 0xd6, 0x47, 0x69, 0xc2, 0x03, 0xa7, 0x5f, 0x1f, 0x3a, 0xb4, 0x6b, 0x0c, 0x95, 0xfe,
+	0x45, 0x22, 0x33, 0x93, 0x8a, 0xd5, 0xcb, 0x15, 0xdc, 0x50, 0xb0, 0x6f, 0x1c, 0x9d, 0x5f, 0xd2,
+	0xf7, 0xa1, 0x91, 0xa5, 0xe7, 0xa9, 0x08, 0x9a, 0x7d, 0x6b, 0xb8, 0x43, 0x75, 0x30, 0x78, 0x0e,
+	0xfe, 0xba, 0x10, 0xbc, 0x2c, 0x72, 0x8e, 0xe4, 0x10, 0xbc, 0x92, 0x15, 0x65, 0xc1, 0xe3, 0x8c,
+	0x07, 0x96, 0x3a, 0xf3, 0xfd, 0xcd, 0x33, 0x9f, 0x1a, 0x93, 0xae, 0xb1, 0xc1, 0x3f, 0x16, 0xb8,
+	0x95, 0x4e, 0x9e, 0xde, 0x2a, 0x65, 0xf0, 0xbe, 0xb5, 0x1b, 0xb5, 0xdc, 0x03, 0xef, 0xe6, 0xf8,
+	0x81, 0xdd, 0xb7, 0x86, 0x0e, 0x75, 0x99, 0x39, 0x36, 0xf9, 0x0c, 0x76, 0x8d, 0x79, 0x81, 0x8c,
+	0xa7, 0x45, 0x1e, 0xd4, 0x15, 0xb1, 0xa3, 0xd5, 0x33, 0x2d, 0x92, 0x87, 0xe0, 0x2e, 0x8a, 0xfc,
+	0x67, 0x09, 0x05, 0x8e, 0x02, 0x5a, 0x32, 0x3e, 0x43, 0x46, 0x3e, 0x87, 0x2e, 0x2f, 0x96, 0x6c,
+	0x81, 0x37, 0x05, 0x0d, 0x1a, 0x3a, 0x85, 0x96, 0x4d, 0x39, 0x25, 0x27, 0x62, 0xf6, 0x0a, 0xc5,
+	0x9a, 0x6b, 0x6a, 0x4e, 0xcb, 0x86, 0x1b, 0x7c, 0x03, 0xa0, 0x7e, 0x1e, 0xc7, 0x73, 0xcc, 0x88,
+	0x0f, 0xf5, 0xd7, 0xb8, 0x52, 0x37, 0xf5, 0xa8, 0xfc, 0x29, 0xeb, 0x7c, 0x11, 0x67, 0x4b, 0x54,
+	0x57, 0xf1, 0xa8, 0x0e, 0x06, 0x7f, 0xd8, 0xd0, 0x50, 0xcb, 0xc8, 0x2e, 0xd8, 0x69, 0xa2, 0x16,
+	0x38, 0xd4, 0x4e, 0x13, 0x12, 0x40, 0x2b, 0x4e, 0x12, 0x86, 0x9c, 0x9b, 0x15, 0x55, 0x28, 0x33,
+	0x71, 0x11, 0x0b, 0x54, 0x57, 0xf6, 0xa8, 0x0e, 0xc8, 0x01, 0x34, 0x33, 0xb9, 0xb5, 0x1e, 0x8b,
+	0xf6, 0xe1, 0x87, 0x5b, 0xa3, 0xa7, 0x4e, 0x46, 0x0d, 0x45, 0x3e, 0x81, 0x4e, 0x86, 0x71, 0x82,
+	0x2c, 0x5a, 0x14, 0xcb, 0x5c, 0x98, 0xcb, 0xb7, 0xb5, 0xf6, 0x9d, 0x94, 0x24, 0x62, 0x8a, 0xac,
+	0x11, 0x7d, 0xef, 0xb6, 0xd6, 0x34, 0xf2, 0x31, 0x98, 0x15, 0x11, 0x4f, 0xdf, 0x62, 0xd0, 0xea,
+	0x5b, 0xc3, 0x3a, 0x05, 0x2d, 0x4d, 0xd3, 0xb7, 0x28, 0x01, 0x93, 0x43, 0x01, 0xae, 0x06, 0xb4,
+	0xa4, 0x80, 0x47, 0xe0, 0x2e, 0xe2, 0x32, 0x5e, 0xa4, 0x62, 0x15, 0x78, 0xba, 0xcb, 0x55, 0x4c,
+	0x1e, 0x83, 0x17, 0x5f, 0xc4, 0x69, 0x16, 0xcf, 0x33, 0x0c, 0x40, 0x99, 0x6b, 0x61, 0x20, 0xc0,
+	0x39, 0x45, 0x64, 0x5b, 0x95, 0x7b, 0x08, 0xee, 0x4d, 0xab, 0xf4, 0xdc, 0xb4, 0xb8, 0x69, 0xe6,
+	0x10, 0x1c, 0x56, 0x64, 0xba, 0x72, 0xbb, 0x77, 0xa6, 0x17, 0x91, 0xd1, 0x22, 0x43, 0xaa, 0x08,
+	0xf2, 0x00, 0x5a, 0x29, 0x8f, 0x92, 0xe2, 0x32, 0x57, 0x83, 0xe3, 0xd2, 0x66, 0xca, 0xc7, 0xc5,
+	0x65, 0x3e, 0xf8, 0xd7, 0x86, 0xa6, 0xfe, 0xf7, 0x6c, 0x6d, 0xbc, 0x07, 0x1e, 0x17, 0x31, 0x13,
+	0x91, 0x6c, 0xbd, 0xdc, 0xb9, 0x43, 0x5d, 0x25, 0x1c, 0xe1, 0x4a, 0x26, 0xc4, 0x3c, 0x51, 0x56,
+	0x5d, 0x59, 0x4d, 0xcc, 0x13, 0x69, 0x6c, 0x8f, 0xb2, 0xf3, 0x7f, 0xa3, 0xdc, 0xd8, 0x1a, 0xe5,
+	0xaa, 0x09, 0x77, 0x46, 0xd4, 0x34, 0xe2, 0x66, 0x94, 0x1b, 0x25, 0x22, 0xe3, 0x41, 0x4b, 0x4d,
+	0x88, 0xbf, 0x75, 0x7d, 0x6d, 0x93, 0x27, 0xe0, 0xc7, 0x65, 0xc9, 0x8a, 0x37, 0xe9, 0x79, 0x2c,
+	0x70, 0xb3, 0x71, 0xdd, 0x0d, 0x5d, 0x75, 0xef, 0x0e, 0xfa, 0x1a, 0x57, 0x3c, 0xf0, 0xb6, 0xd0,
+	0x23, 0x5c, 0x71, 0xf2, 0x29, 0xec, 0x5c, 0xb2, 0x54, 0x08, 0xcc, 0xa3, 0xf9, 0x4a, 0x20, 0x37,
+	0x0d, 0xed, 0x18, 0xf1, 0x99, 0xd4, 0xc8, 0x47, 0x00, 0x0c, 0xe3, 0xc4, 0x10, 0x6d, 0xdd, 0x72,
+	0xa9, 0x28, 0x7b, 0xff, 0x5b, 0x68, 0x6f, 0x3c, 0xba, 0xa4, 0x03, 0xee, 0x74, 0x32, 0x3a, 0x9d,
+	0x7e, 0x7f, 0x32, 0xf3, 0x6b, 0xc4, 0x87, 0x4e, 0x15, 0x45, 0xe1, 0x64, 0xec, 0x5b, 0xc4, 0x83,
+	0xc6, 0x38, 0x3c, 0x9e, 0x8d, 0x7c, 0x7b, 0xbf, 0x80, 0xce, 0xe6, 0x1b, 0x43, 0xda, 0xd0, 0xfa,
+	0x71, 0x72, 0x34, 0x39, 0x79, 0x39, 0xf1, 0x6b, 0xe4, 0x03, 0xe8, 0xce, 0xe8, 0x68, 0x32, 0x7d,
+	0x1e, 0xd2, 0xe8, 0x38, 0x1c, 0x8d, 0x43, 0xea, 0x5b, 0x64, 0x07, 0xbc, 0x1f, 0x4e, 0xce, 0xc2,
+	0xe8, 0x34, 0x0c, 0xa9, 0x6f, 0x93, 0x2e, 0xb4, 0x55, 0x68, 0xfc, 0xba, 0xdc, 0x7c, 0x34, 0x1e,
+	0x6b, 0xdb, 0x91, 0x36, 0x0d, 0xd7, 0x7c, 0x63, 0xff, 0x4b, 0x70, 0xab, 0x91, 0x92, 0xe7, 0x38,
+	0x3b, 0x99, 0x85, 0xd4, 0xaf, 0xc9, 0x7d, 0x8f, 0xc3, 0x11, 0x9d, 0xa8, 0x2d, 0xda, 0xd0, 0x7a,
+	0xf9, 0x62, 0x36, 0x09, 0xa7, 0x53, 0xdf, 0x3e, 0xfc, 0x09, 0xbc, 0xea, 0xc9, 0x65, 0xe4, 0x08,
+	0xdc, 0x2a, 0x20, 0x7b, 0xb7, 0xfe, 0xc9, 0xb7, 0x3f, 0x4f, 0x8f, 0x1e, 0xbf, 0xdf, 0xd4, 0x4f,
+	0xf6, 0xa0, 0x36, 0xb4, 0xbe, 0xb2, 0x9e, 0x05, 0x7f, 0x5e, 0xf5, 0xac, 0x77, 0x57, 0x3d, 0xeb,
+	0xef, 0xab, 0x9e, 0xf5, 0xfb, 0x75, 0xaf, 0xf6, 0xee, 0xba, 0x57, 0xfb, 0xeb, 0xba, 0x57, 0x9b,
+	0x37, 0xd5, 0x37, 0xf0, 0xeb, 0xff, 0x06, 0x00, 0x70, 0xa8, 0x67, 0x8f, 0x17, 0x07, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SchedulerClient is the client API for Scheduler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SchedulerClient interface {
+	Schedule(ctx context.Context, opts ...grpc.CallOption) (Scheduler_ScheduleClient, error)
+}
+
+type schedulerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSchedulerClient(cc *grpc.ClientConn) SchedulerClient {
+	return &schedulerClient{cc}
+}
+
+func (c *schedulerClient) Schedule(ctx context.Context, opts ...grpc.CallOption) (Scheduler_ScheduleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Scheduler_serviceDesc.Streams[0], "/externalpb.Scheduler/Schedule", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &schedulerScheduleClient{stream}
+	return x, nil
+}
+
+type Scheduler_ScheduleClient interface {
+	Send(*ScheduleRequest) error
+	Recv() (*ScheduleResponse, error)
+	grpc.ClientStream
+}
+
+type schedulerScheduleClient struct {
+	grpc.ClientStream
+}
+
+func (x *schedulerScheduleClient) Send(m *ScheduleRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *schedulerScheduleClient) Recv() (*ScheduleResponse, error) {
+	m := new(ScheduleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SchedulerServer is the server API for Scheduler service.
+type SchedulerServer interface {
+	Schedule(Scheduler_ScheduleServer) error
+}
+
+// UnimplementedSchedulerServer can be embedded to have forward compatible implementations.
+type UnimplementedSchedulerServer struct {
+}
+
+func (*UnimplementedSchedulerServer) Schedule(srv Scheduler_ScheduleServer) error {
+	return status.Errorf(codes.Unimplemented, "method Schedule not implemented")
+}
+
+func RegisterSchedulerServer(s *grpc.Server, srv SchedulerServer) {
+	s.RegisterService(&_Scheduler_serviceDesc, srv)
+}
+
+func _Scheduler_Schedule_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SchedulerServer).Schedule(&schedulerScheduleServer{stream})
+}
+
+type Scheduler_ScheduleServer interface {
+	Send(*ScheduleResponse) error
+	Recv() (*ScheduleRequest, error)
+	grpc.ServerStream
+}
+
+type schedulerScheduleServer struct {
+	grpc.ServerStream
+}
+
+func (x *schedulerScheduleServer) Send(m *ScheduleResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *schedulerScheduleServer) Recv() (*ScheduleRequest, error) {
+	m := new(ScheduleRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Scheduler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "externalpb.Scheduler",
+	HandlerType: (*SchedulerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Schedule",
+			Handler:       _Scheduler_Schedule_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "externalpb.proto",
+}
+
+func (m *ScheduleRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduleRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduleRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.RemovedRegionIds) > 0 {
+		dAtA2 := make([]byte, len(m.RemovedRegionIds)*10)
+		var j1 int
+		for _, num := range m.RemovedRegionIds {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintExternalpb(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.RemovedStoreIds) > 0 {
+		dAtA4 := make([]byte, len(m.RemovedStoreIds)*10)
+		var j3 int
+		for _, num := range m.RemovedStoreIds {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintExternalpb(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Regions) > 0 {
+		for iNdEx := len(m.Regions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Regions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExternalpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Stores) > 0 {
+		for iNdEx := len(m.Stores) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Stores[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExternalpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Type != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ScheduleResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduleResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduleResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Proposals) > 0 {
+		for iNdEx := len(m.Proposals) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Proposals[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExternalpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Proposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Proposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Proposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TargetStoreId != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.TargetStoreId))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.SourceStoreId != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.SourceStoreId))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ConfVer != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.ConfVer))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.RegionVersion != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.RegionVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.RegionId != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.RegionId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StoreLabel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreLabel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreLabel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintExternalpb(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintExternalpb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Store) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Store) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Store) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Available != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Available))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Capacity != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Capacity))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.RegionSize != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.RegionSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.LeaderSize != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.LeaderSize))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.RegionCount != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.RegionCount))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LeaderCount != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.LeaderCount))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Labels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExternalpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintExternalpb(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintExternalpb(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Peer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Peer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IsDown {
+		i--
+		if m.IsDown {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Role != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.StoreId != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.StoreId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Id != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Region) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Region) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Region) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ReadBytes != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.ReadBytes))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.WrittenBytes != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.WrittenBytes))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ApproximateKeys != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.ApproximateKeys))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.ApproximateSize != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.ApproximateSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExternalpb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.LeaderStoreId != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.LeaderStoreId))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ConfVer != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.ConfVer))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.RegionVersion != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.RegionVersion))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.EndKey) > 0 {
+		i -= len(m.EndKey)
+		copy(dAtA[i:], m.EndKey)
+		i = encodeVarintExternalpb(dAtA, i, uint64(len(m.EndKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.StartKey) > 0 {
+		i -= len(m.StartKey)
+		copy(dAtA[i:], m.StartKey)
+		i = encodeVarintExternalpb(dAtA, i, uint64(len(m.StartKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintExternalpb(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintExternalpb(dAtA []byte, offset int, v uint64) int {
+	offset -= sovExternalpb(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ScheduleRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovExternalpb(uint64(m.Type))
+	}
+	if len(m.Stores) > 0 {
+		for _, e := range m.Stores {
+			l = e.Size()
+			n += 1 + l + sovExternalpb(uint64(l))
+		}
+	}
+	if len(m.Regions) > 0 {
+		for _, e := range m.Regions {
+			l = e.Size()
+			n += 1 + l + sovExternalpb(uint64(l))
+		}
+	}
+	if len(m.RemovedStoreIds) > 0 {
+		l = 0
+		for _, e := range m.RemovedStoreIds {
+			l += sovExternalpb(uint64(e))
+		}
+		n += 1 + sovExternalpb(uint64(l)) + l
+	}
+	if len(m.RemovedRegionIds) > 0 {
+		l = 0
+		for _, e := range m.RemovedRegionIds {
+			l += sovExternalpb(uint64(e))
+		}
+		n += 1 + sovExternalpb(uint64(l)) + l
+	}
+	if m.Limit != 0 {
+		n += 1 + sovExternalpb(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *ScheduleResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Proposals) > 0 {
+		for _, e := range m.Proposals {
+			l = e.Size()
+			n += 1 + l + sovExternalpb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Proposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovExternalpb(uint64(m.Type))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovExternalpb(uint64(m.RegionId))
+	}
+	if m.RegionVersion != 0 {
+		n += 1 + sovExternalpb(uint64(m.RegionVersion))
+	}
+	if m.ConfVer != 0 {
+		n += 1 + sovExternalpb(uint64(m.ConfVer))
+	}
+	if m.SourceStoreId != 0 {
+		n += 1 + sovExternalpb(uint64(m.SourceStoreId))
+	}
+	if m.TargetStoreId != 0 {
+		n += 1 + sovExternalpb(uint64(m.TargetStoreId))
+	}
+	return n
+}
+
+func (m *StoreLabel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovExternalpb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovExternalpb(uint64(l))
+	}
+	return n
+}
+
+func (m *Store) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovExternalpb(uint64(m.Id))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovExternalpb(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovExternalpb(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovExternalpb(uint64(l))
+		}
+	}
+	if m.LeaderCount != 0 {
+		n += 1 + sovExternalpb(uint64(m.LeaderCount))
+	}
+	if m.RegionCount != 0 {
+		n += 1 + sovExternalpb(uint64(m.RegionCount))
+	}
+	if m.LeaderSize != 0 {
+		n += 1 + sovExternalpb(uint64(m.LeaderSize))
+	}
+	if m.RegionSize != 0 {
+		n += 1 + sovExternalpb(uint64(m.RegionSize))
+	}
+	if m.Capacity != 0 {
+		n += 1 + sovExternalpb(uint64(m.Capacity))
+	}
+	if m.Available != 0 {
+		n += 1 + sovExternalpb(uint64(m.Available))
+	}
+	return n
+}
+
+func (m *Peer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovExternalpb(uint64(m.Id))
+	}
+	if m.StoreId != 0 {
+		n += 1 + sovExternalpb(uint64(m.StoreId))
+	}
+	if m.Role != 0 {
+		n += 1 + sovExternalpb(uint64(m.Role))
+	}
+	if m.IsDown {
+		n += 2
+	}
+	return n
+}
+
+func (m *Region) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovExternalpb(uint64(m.Id))
+	}
+	l = len(m.StartKey)
+	if l > 0 {
+		n += 1 + l + sovExternalpb(uint64(l))
+	}
+	l = len(m.EndKey)
+	if l > 0 {
+		n += 1 + l + sovExternalpb(uint64(l))
+	}
+	if m.RegionVersion != 0 {
+		n += 1 + sovExternalpb(uint64(m.RegionVersion))
+	}
+	if m.ConfVer != 0 {
+		n += 1 + sovExternalpb(uint64(m.ConfVer))
+	}
+	if m.LeaderStoreId != 0 {
+		n += 1 + sovExternalpb(uint64(m.LeaderStoreId))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovExternalpb(uint64(l))
+		}
+	}
+	if m.ApproximateSize != 0 {
+		n += 1 + sovExternalpb(uint64(m.ApproximateSize))
+	}
+	if m.ApproximateKeys != 0 {
+		n += 1 + sovExternalpb(uint64(m.ApproximateKeys))
+	}
+	if m.WrittenBytes != 0 {
+		n += 1 + sovExternalpb(uint64(m.WrittenBytes))
+	}
+	if m.ReadBytes != 0 {
+		n += 1 + sovExternalpb(uint64(m.ReadBytes))
+	}
+	return n
+}
+
+func sovExternalpb(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozExternalpb(x uint64) (n int) {
+	return sovExternalpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ScheduleRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduleRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduleRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= RequestType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stores", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stores = append(m.Stores, &Store{})
+			if err := m.Stores[len(m.Stores)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regions = append(m.Regions, &Region{})
+			if err := m.Regions[len(m.Regions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExternalpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RemovedStoreIds = append(m.RemovedStoreIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExternalpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthExternalpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthExternalpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RemovedStoreIds) == 0 {
+					m.RemovedStoreIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExternalpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RemovedStoreIds = append(m.RemovedStoreIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedStoreIds", wireType)
+			}
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExternalpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RemovedRegionIds = append(m.RemovedRegionIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowExternalpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthExternalpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthExternalpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RemovedRegionIds) == 0 {
+					m.RemovedRegionIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowExternalpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RemovedRegionIds = append(m.RemovedRegionIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedRegionIds", wireType)
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduleResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduleResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduleResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposals", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proposals = append(m.Proposals, &Proposal{})
+			if err := m.Proposals[len(m.Proposals)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Proposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Proposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Proposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ProposalType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+			}
+			m.RegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionVersion", wireType)
+			}
+			m.RegionVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfVer", wireType)
+			}
+			m.ConfVer = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfVer |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceStoreId", wireType)
+			}
+			m.SourceStoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SourceStoreId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetStoreId", wireType)
+			}
+			m.TargetStoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetStoreId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreLabel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreLabel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreLabel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Store) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Store: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Store: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, &StoreLabel{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderCount", wireType)
+			}
+			m.LeaderCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionCount", wireType)
+			}
+			m.RegionCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderSize", wireType)
+			}
+			m.LeaderSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionSize", wireType)
+			}
+			m.RegionSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			m.Capacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capacity |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+			}
+			m.Available = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Available |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Peer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Peer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Peer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+			}
+			m.StoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= PeerRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsDown", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsDown = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Region) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Region: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Region: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.StartKey == nil {
+				m.StartKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EndKey == nil {
+				m.EndKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionVersion", wireType)
+			}
+			m.RegionVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfVer", wireType)
+			}
+			m.ConfVer = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfVer |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderStoreId", wireType)
+			}
+			m.LeaderStoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderStoreId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateSize", wireType)
+			}
+			m.ApproximateSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApproximateSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateKeys", wireType)
+			}
+			m.ApproximateKeys = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApproximateKeys |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WrittenBytes", wireType)
+			}
+			m.WrittenBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WrittenBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadBytes", wireType)
+			}
+			m.ReadBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExternalpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExternalpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipExternalpb(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowExternalpb
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowExternalpb
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthExternalpb
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupExternalpb
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthExternalpb
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthExternalpb        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowExternalpb          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupExternalpb = fmt.Errorf("proto: unexpected end of group")
+)
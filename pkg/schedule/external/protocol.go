@@ -0,0 +1,80 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external converts the cluster information to the messages of the
+// protocol between PD and the schedulers running out of the PD process, which
+// is defined in externalpb.
+package external
+
+import (
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/external/externalpb"
+)
+
+// NewStore converts the store to the message sent to the external scheduler.
+func NewStore(store *core.StoreInfo) *externalpb.Store {
+	s := &externalpb.Store{
+		Id:          store.GetID(),
+		Address:     store.GetAddress(),
+		State:       store.GetNodeState().String(),
+		LeaderCount: uint64(store.GetLeaderCount()),
+		RegionCount: uint64(store.GetRegionCount()),
+		LeaderSize:  store.GetLeaderSize(),
+		RegionSize:  store.GetRegionSize(),
+		Capacity:    store.GetCapacity(),
+		Available:   store.GetAvailable(),
+	}
+	for _, label := range store.GetLabels() {
+		s.Labels = append(s.Labels, &externalpb.StoreLabel{Key: label.GetKey(), Value: label.GetValue()})
+	}
+	return s
+}
+
+// NewRegion converts the region to the message sent to the external scheduler.
+func NewRegion(region *core.RegionInfo) *externalpb.Region {
+	r := &externalpb.Region{
+		Id:              region.GetID(),
+		StartKey:        region.GetStartKey(),
+		EndKey:          region.GetEndKey(),
+		RegionVersion:   region.GetRegionEpoch().GetVersion(),
+		ConfVer:         region.GetRegionEpoch().GetConfVer(),
+		LeaderStoreId:   region.GetLeader().GetStoreId(),
+		Peers:           make([]*externalpb.Peer, 0, len(region.GetPeers())),
+		ApproximateSize: region.GetApproximateSize(),
+		ApproximateKeys: region.GetApproximateKeys(),
+		WrittenBytes:    region.GetBytesWritten(),
+		ReadBytes:       region.GetBytesRead(),
+	}
+	for _, peer := range region.GetPeers() {
+		r.Peers = append(r.Peers, &externalpb.Peer{
+			Id:      peer.GetId(),
+			StoreId: peer.GetStoreId(),
+			Role:    peerRole(peer),
+			IsDown:  region.GetDownPeer(peer.GetId()) != nil,
+		})
+	}
+	return r
+}
+
+func peerRole(peer *metapb.Peer) externalpb.PeerRole {
+	if peer.GetIsWitness() {
+		return externalpb.PeerRole_WITNESS
+	}
+	if peer.GetRole() == metapb.PeerRole_Learner {
+		return externalpb.PeerRole_LEARNER
+	}
+	return externalpb.PeerRole_VOTER
+}
@@ -53,6 +53,13 @@ func (c *StoreCandidates) Sort(less StoreComparer) *StoreCandidates {
 	return c
 }
 
+// StableSort sorts store list by given comparer in ascending order, and keeps
+// the original order of the equal stores.
+func (c *StoreCandidates) StableSort(less StoreComparer) *StoreCandidates {
+	sort.SliceStable(c.Stores, func(i, j int) bool { return less(c.Stores[i], c.Stores[j]) < 0 })
+	return c
+}
+
 // Shuffle reorders all candidates randomly.
 func (c *StoreCandidates) Shuffle() *StoreCandidates {
 	c.r.Shuffle(len(c.Stores), func(i, j int) { c.Stores[i], c.Stores[j] = c.Stores[j], c.Stores[i] })
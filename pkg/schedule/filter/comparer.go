@@ -55,3 +55,20 @@ func IsolationComparer(locationLabels []string, regionStores []*core.StoreInfo)
 		}
 	}
 }
+
+// ZoneCostComparer creates a StoreComparer to sort store by the network cost
+// of sending data from the source store.
+func ZoneCostComparer(matrix *config.ZoneCostMatrix, source *core.StoreInfo) StoreComparer {
+	return func(a, b *core.StoreInfo) int {
+		ca := matrix.GetCost(source, a)
+		cb := matrix.GetCost(source, b)
+		switch {
+		case ca > cb:
+			return 1
+		case ca < cb:
+			return -1
+		default:
+			return 0
+		}
+	}
+}
@@ -36,6 +36,7 @@ import (
 func SelectSourceStores(stores []*core.StoreInfo, filters []Filter, conf config.SharedConfigProvider, collector *plan.Collector,
 	counter *Counter) []*core.StoreInfo {
 	return filterStoresBy(stores, func(s *core.StoreInfo) bool {
+		traceFilters(source, conf, s, filters)
 		return slice.AllOf(filters, func(i int) bool {
 			status := filters[i].Source(conf, s)
 			if !status.IsOK() {
@@ -60,6 +61,7 @@ func SelectSourceStores(stores []*core.StoreInfo, filters []Filter, conf config.
 func SelectUnavailableTargetStores(stores []*core.StoreInfo, filters []Filter, conf config.SharedConfigProvider,
 	collector *plan.Collector, counter *Counter) []*core.StoreInfo {
 	return filterStoresBy(stores, func(s *core.StoreInfo) bool {
+		traceFilters(target, conf, s, filters)
 		targetID := strconv.FormatUint(s.GetID(), 10)
 		return slice.AnyOf(filters, func(i int) bool {
 			status := filters[i].Target(conf, s)
@@ -94,6 +96,7 @@ func SelectTargetStores(stores []*core.StoreInfo, filters []Filter, conf config.
 	}
 
 	return filterStoresBy(stores, func(s *core.StoreInfo) bool {
+		traceFilters(target, conf, s, filters)
 		return slice.AllOf(filters, func(i int) bool {
 			filter := filters[i]
 			status := filter.Target(conf, s)
@@ -149,6 +152,7 @@ type comparingFilter interface {
 
 // Target checks if store can pass all Filters as target store.
 func Target(conf config.SharedConfigProvider, store *core.StoreInfo, filters []Filter) bool {
+	traceFilters(target, conf, store, filters)
 	storeID := strconv.FormatUint(store.GetID(), 10)
 	for _, filter := range filters {
 		status := filter.Target(conf, store)
@@ -685,6 +689,11 @@ func (f *ruleFitFilter) getSourceStoreID() uint64 {
 	return f.srcStore
 }
 
+// getRegionID implements the regionFilter interface.
+func (f *ruleFitFilter) getRegionID() uint64 {
+	return f.region.GetID()
+}
+
 type ruleLeaderFitFilter struct {
 	scope            string
 	cluster          *core.BasicCluster
@@ -745,6 +754,11 @@ func (f *ruleLeaderFitFilter) getSourceStoreID() uint64 {
 	return f.srcLeaderStoreID
 }
 
+// getRegionID implements the regionFilter interface.
+func (f *ruleLeaderFitFilter) getRegionID() uint64 {
+	return f.region.GetID()
+}
+
 type ruleWitnessFitFilter struct {
 	scope       string
 	cluster     *core.BasicCluster
@@ -801,6 +815,11 @@ func (f *ruleWitnessFitFilter) Target(_ config.SharedConfigProvider, store *core
 	return statusStoreNotMatchRule
 }
 
+// getRegionID implements the regionFilter interface.
+func (f *ruleWitnessFitFilter) getRegionID() uint64 {
+	return f.region.GetID()
+}
+
 // NewPlacementSafeguard creates a filter that ensures after replace a peer with new
 // peer, the placement restriction will not become worse.
 func NewPlacementSafeguard(scope string, conf config.SharedConfigProvider, cluster *core.BasicCluster, ruleManager *placement.RuleManager,
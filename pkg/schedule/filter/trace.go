@@ -0,0 +1,141 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// regionFilter is an interface of the filters which are created for a region.
+type regionFilter interface {
+	Filter
+	// getRegionID returns the region the filter is created for.
+	getRegionID() uint64
+}
+
+// TraceRecord is the result of a filter on a store.
+type TraceRecord struct {
+	// Action is either "filter-source" or "filter-target".
+	Action        string `json:"action"`
+	StoreID       uint64 `json:"store-id"`
+	SourceStoreID uint64 `json:"source-store-id,omitempty"`
+	Filter        string `json:"filter"`
+	Status        string `json:"status"`
+}
+
+// Trace records the results of all the filters which decide where the peers of
+// a region can be moved from and to. The stores are traced as the source if they
+// have a peer of the region, and as the target unless they are checked with the
+// filters created for another region, such as the placement safeguards.
+//
+// A trace is the token of a request, it is carried by the configuration passed
+// into the filters, so the filters called with other configurations, such as the
+// ones of the real scheduling, are never traced.
+type Trace struct {
+	regionID uint64
+	stores   map[uint64]struct{}
+
+	mu      syncutil.Mutex
+	seen    map[TraceRecord]struct{}
+	records []*TraceRecord
+}
+
+// NewTrace creates a trace of the filters for the region.
+func NewTrace(region *core.RegionInfo) *Trace {
+	return &Trace{
+		regionID: region.GetID(),
+		stores:   region.GetStoreIDs(),
+		seen:     make(map[TraceRecord]struct{}),
+	}
+}
+
+// tracedConfig is the configuration carrying a trace.
+type tracedConfig struct {
+	config.SchedulerConfigProvider
+	trace *Trace
+}
+
+// WrapConfig returns the configuration carrying the trace, the filters called
+// with it record their results into the trace.
+func (t *Trace) WrapConfig(conf config.SchedulerConfigProvider) config.SchedulerConfigProvider {
+	return &tracedConfig{SchedulerConfigProvider: conf, trace: t}
+}
+
+// GetRecords returns the records in the order they are recorded.
+func (t *Trace) GetRecords() []*TraceRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*TraceRecord(nil), t.records...)
+}
+
+func (t *Trace) record(r TraceRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seen[r]; ok {
+		return
+	}
+	t.seen[r] = struct{}{}
+	t.records = append(t.records, &r)
+}
+
+// traceFilters records the results of all the filters on the store if they are
+// traced. Unlike the selection, it does not stop at the first filter which the
+// store fails to pass, so the whole chain can be seen.
+func traceFilters(act action, conf config.SharedConfigProvider, store *core.StoreInfo, filters []Filter) {
+	tc, ok := conf.(*tracedConfig)
+	if !ok || len(filters) == 0 {
+		return
+	}
+	t := tc.trace
+	var sourceID uint64
+	switch act {
+	case source:
+		if _, ok := t.stores[store.GetID()]; !ok {
+			return
+		}
+	case target:
+		var regionID uint64
+		for _, f := range filters {
+			if rf, ok := f.(regionFilter); ok && regionID == 0 {
+				regionID = rf.getRegionID()
+			}
+			if cf, ok := f.(comparingFilter); ok && sourceID == 0 {
+				sourceID = cf.getSourceStoreID()
+			}
+		}
+		// The filters which are not created for a region are about the stores
+		// only, they decide whether the stores can be the target of any region.
+		if regionID != 0 && regionID != t.regionID {
+			return
+		}
+	}
+	for _, f := range filters {
+		r := TraceRecord{
+			Action:  act.String(),
+			StoreID: store.GetID(),
+		}
+		if act == source {
+			r.Status = f.Source(conf, store).String()
+		} else {
+			r.SourceStoreID = sourceID
+			r.Status = f.Target(conf, store).String()
+		}
+		// The type of some filters depends on the result, so it is got after the filtering.
+		r.Filter = f.Type().String()
+		t.record(r)
+	}
+}
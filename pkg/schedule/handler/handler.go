@@ -34,6 +34,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/labeler"
@@ -240,6 +241,15 @@ func (h *Handler) GetRecords(from time.Time) ([]*operator.OpRecord, error) {
 	return records, nil
 }
 
+// GetConsumerBudgets returns the usage of the operator slots by each scheduler and checker.
+func (h *Handler) GetConsumerBudgets() ([]*operator.ConsumerBudget, error) {
+	c, err := h.GetOperatorController()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetConsumerBudgets(), nil
+}
+
 // HandleOperatorCreation processes the request and creates an operator based on the provided input.
 // It supports various types of operators such as transfer-leader, transfer-region, add-peer, remove-peer, merge-region, split-region, scatter-region, and scatter-regions.
 // The function validates the input, performs the corresponding operation, and returns the HTTP status code, response body, and any error encountered during the process.
@@ -788,6 +798,15 @@ func (h *Handler) GetCheckerStatus(name string) (map[string]bool, error) {
 	}, nil
 }
 
+// GetRepairQueue returns at most limit regions at risk in the order of repairs.
+func (h *Handler) GetRepairQueue(limit int) ([]*checker.RegionRisk, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetRepairQueue(limit), nil
+}
+
 // GetSchedulersController returns controller of schedulers.
 func (h *Handler) GetSchedulersController() (*schedulers.Controller, error) {
 	co := h.GetCoordinator()
@@ -902,6 +921,25 @@ func (h *Handler) GetDiagnosticResult(name string) (*schedulers.DiagnosticResult
 	return result, nil
 }
 
+// DryRunSchedulers runs the specified scheduler, or all schedulers if the name is "all",
+// and returns the operators and plans they would produce without dispatching them.
+func (h *Handler) DryRunSchedulers(name string) ([]*schedulers.DryRunResult, error) {
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return nil, err
+	}
+	return sc.DryRun(name)
+}
+
+// TraceScheduler runs the scheduler and returns why the region is scheduled or not by it.
+func (h *Handler) TraceScheduler(name string, regionID uint64) (*schedulers.TraceResult, error) {
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return nil, err
+	}
+	return sc.Trace(name, regionID)
+}
+
 // PauseOrResumeScheduler pauses a scheduler for delay seconds or resume a paused scheduler.
 // t == 0 : resume scheduler.
 // t > 0 : scheduler delays t seconds.
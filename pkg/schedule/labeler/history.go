@@ -0,0 +1,168 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labeler
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+const (
+	// LabelRuleActionSet means the rule is created or updated.
+	LabelRuleActionSet = "set"
+	// LabelRuleActionDelete means the rule is deleted.
+	LabelRuleActionDelete = "delete"
+	// LabelRuleActionExpire means the labels of the rule are expired, and the
+	// rule is deleted if all the labels are expired.
+	LabelRuleActionExpire = "expire"
+
+	// SystemOperator is the operator of the changes made by PD itself.
+	SystemOperator = "pd"
+
+	// maxLabelRuleHistory is the max number of the changes kept in the history,
+	// the oldest changes are removed when it is exceeded.
+	maxLabelRuleHistory = 1000
+)
+
+// LabelRuleChange is a change of the label rules in the history.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type LabelRuleChange struct {
+	Seq      uint64     `json:"seq"`
+	RuleID   string     `json:"rule_id"`
+	Action   string     `json:"action"`
+	Operator string     `json:"operator"`
+	Time     time.Time  `json:"time"`
+	Old      *LabelRule `json:"old,omitempty"` // nil if the rule is created
+	New      *LabelRule `json:"new,omitempty"` // nil if the rule is deleted
+}
+
+func (rule *LabelRule) clone() *LabelRule {
+	if rule == nil {
+		return nil
+	}
+	r := *rule
+	r.Labels = slices.Clone(rule.Labels)
+	return &r
+}
+
+func (l *RegionLabeler) loadHistory() error {
+	err := l.storage.LoadRegionRuleHistory(func(k, v string) {
+		change := &LabelRuleChange{}
+		if err := json.Unmarshal([]byte(v), change); err != nil {
+			log.Error("failed to unmarshal label rule change", zap.String("change-key", k), zap.String("change-value", v), errs.ZapError(errs.ErrLoadRule))
+			return
+		}
+		l.history = append(l.history, change)
+	})
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l.history, func(a, b *LabelRuleChange) int { return cmp.Compare(a.Seq, b.Seq) })
+	if len(l.history) > maxLabelRuleHistory {
+		l.history = l.history[len(l.history)-maxLabelRuleHistory:]
+	}
+	return nil
+}
+
+// historyBatch collects the changes of the label rules, which are saved in the
+// same transaction as the rules.
+type historyBatch struct {
+	operator string
+	action   string
+	now      time.Time
+	changes  []*LabelRuleChange
+}
+
+func newHistoryBatch(operator, action string) *historyBatch {
+	return &historyBatch{operator: operator, action: action, now: time.Now()}
+}
+
+// add records the change of a rule, the rules are cloned because they may be
+// modified in place later.
+func (b *historyBatch) add(id string, oldRule, newRule *LabelRule) {
+	action := b.action
+	if action == LabelRuleActionSet && newRule == nil {
+		action = LabelRuleActionDelete
+	}
+	b.changes = append(b.changes, &LabelRuleChange{
+		RuleID:   id,
+		Action:   action,
+		Operator: b.operator,
+		Time:     b.now,
+		Old:      oldRule.clone(),
+		New:      newRule.clone(),
+	})
+}
+
+// historyOpsLocked assigns the sequences to the changes and returns the operations to
+// save them and remove the oldest changes out of the limit.
+func (l *RegionLabeler) historyOpsLocked(b *historyBatch) []func(kv.Txn) error {
+	var seq uint64
+	if n := len(l.history); n > 0 {
+		seq = l.history[n-1].Seq
+	}
+	ops := make([]func(kv.Txn) error, 0, len(b.changes))
+	for _, change := range b.changes {
+		seq++
+		change.Seq = seq
+		localChange := change
+		ops = append(ops, func(txn kv.Txn) error {
+			return l.storage.SaveRegionRuleHistory(txn, localChange.Seq, localChange)
+		})
+	}
+	for _, change := range l.history[:l.historyOverflowLocked(b)] {
+		localSeq := change.Seq
+		ops = append(ops, func(txn kv.Txn) error {
+			return l.storage.DeleteRegionRuleHistory(txn, localSeq)
+		})
+	}
+	return ops
+}
+
+func (l *RegionLabeler) historyOverflowLocked(b *historyBatch) int {
+	return max(min(len(l.history)+len(b.changes)-maxLabelRuleHistory, len(l.history)), 0)
+}
+
+// commitHistoryLocked appends the changes to the history after they are saved.
+func (l *RegionLabeler) commitHistoryLocked(b *historyBatch) {
+	l.history = append(l.history[l.historyOverflowLocked(b):], b.changes...)
+}
+
+// GetLabelRuleHistory returns the changes of the label rules from the newest to
+// the oldest. The changes are filtered by the rule ID if it is not empty, and
+// at most limit changes are returned if limit is positive.
+func (l *RegionLabeler) GetLabelRuleHistory(ruleID string, limit int) []*LabelRuleChange {
+	l.RLock()
+	defer l.RUnlock()
+	changes := make([]*LabelRuleChange, 0)
+	for i := len(l.history) - 1; i >= 0; i-- {
+		if limit > 0 && len(changes) >= limit {
+			break
+		}
+		if len(ruleID) == 0 || l.history[i].RuleID == ruleID {
+			changes = append(changes, l.history[i])
+		}
+	}
+	return changes
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labeler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestLabelRuleHistory(t *testing.T) {
+	re := require.New(t)
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	labeler, err := NewRegionLabeler(context.Background(), store, time.Hour)
+	re.NoError(err)
+	newRule := func(id, value string) *LabelRule {
+		return &LabelRule{ID: id, Labels: []RegionLabel{{Key: "k", Value: value}}, RuleType: KeyRange, Data: MakeKeyRanges("1234", "5678")}
+	}
+	re.NoError(labeler.SetLabelRuleBy(newRule("rule1", "v1"), "user1"))
+	re.NoError(labeler.SetLabelRuleBy(newRule("rule1", "v2"), "user2"))
+	re.NoError(labeler.PatchBy(LabelRulePatch{
+		SetRules:    []*LabelRule{newRule("rule2", "v1"), newRule("rule2", "v2")},
+		DeleteRules: []string{"rule1", "unknown"},
+	}, "user3"))
+	re.NoError(labeler.DeleteLabelRule("rule2"))
+
+	history := labeler.GetLabelRuleHistory("", 0)
+	re.Len(history, 5)
+	expected := []struct {
+		ruleID, action, operator string
+		oldValue, newValue       string
+	}{
+		{"rule2", LabelRuleActionDelete, SystemOperator, "v2", ""},
+		{"rule2", LabelRuleActionSet, "user3", "", "v2"},
+		{"rule1", LabelRuleActionDelete, "user3", "v2", ""},
+		{"rule1", LabelRuleActionSet, "user2", "v1", "v2"},
+		{"rule1", LabelRuleActionSet, "user1", "", "v1"},
+	}
+	for i, e := range expected {
+		change := history[i]
+		re.Equal(uint64(len(expected)-i), change.Seq)
+		re.Equal(e.ruleID, change.RuleID)
+		re.Equal(e.action, change.Action)
+		re.Equal(e.operator, change.Operator)
+		if e.oldValue == "" {
+			re.Nil(change.Old)
+		} else {
+			re.Equal(e.oldValue, change.Old.Labels[0].Value)
+		}
+		if e.newValue == "" {
+			re.Nil(change.New)
+		} else {
+			re.Equal(e.newValue, change.New.Labels[0].Value)
+		}
+	}
+	re.Len(labeler.GetLabelRuleHistory("rule1", 0), 3)
+	re.Equal(history[:2], labeler.GetLabelRuleHistory("", 2))
+
+	// The expired labels are recorded.
+	rule := newRule("rule3", "v1")
+	rule.Labels = append(rule.Labels, RegionLabel{Key: "k2", Value: "v2", TTL: "1h"})
+	re.NoError(labeler.SetLabelRule(rule))
+	labeler.getAndCheckRule("rule3", time.Now().Add(2*time.Hour))
+	change := labeler.GetLabelRuleHistory("rule3", 1)[0]
+	re.Equal(LabelRuleActionExpire, change.Action)
+	re.Len(change.Old.Labels, 2)
+	re.Len(change.New.Labels, 1)
+
+	// The history is persisted, and only the latest changes are kept.
+	labeler2, err := NewRegionLabeler(context.Background(), store, time.Hour)
+	re.NoError(err)
+	re.Len(labeler2.GetLabelRuleHistory("", 0), 7)
+	re.Equal(uint64(7), labeler2.GetLabelRuleHistory("", 1)[0].Seq)
+	for i := range maxLabelRuleHistory {
+		re.NoError(labeler2.SetLabelRule(newRule(fmt.Sprintf("rule-%d", i), "v")))
+	}
+	history = labeler2.GetLabelRuleHistory("", 0)
+	re.Len(history, maxLabelRuleHistory)
+	re.Equal(uint64(maxLabelRuleHistory+7), history[0].Seq)
+	re.Equal(uint64(8), history[len(history)-1].Seq)
+	count := 0
+	re.NoError(store.LoadRegionRuleHistory(func(string, string) { count++ }))
+	re.Equal(maxLabelRuleHistory, count)
+}
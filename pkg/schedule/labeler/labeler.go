@@ -40,6 +40,7 @@ type RegionLabeler struct {
 	rangeList  rangelist.List // sorted LabelRules of the type `KeyRange`
 	ctx        context.Context
 	minExpire  *time.Time
+	history    []*LabelRuleChange // sorted by the sequence, only the latest changes are kept.
 }
 
 // NewRegionLabeler creates a Labeler instance.
@@ -54,6 +55,9 @@ func NewRegionLabeler(ctx context.Context, storage endpoint.RuleStorage, gcInter
 	if err := l.loadRules(); err != nil {
 		return nil, err
 	}
+	if err := l.loadHistory(); err != nil {
+		return nil, err
+	}
 	go l.doGC(gcInterval)
 	return l, nil
 }
@@ -83,23 +87,16 @@ func (l *RegionLabeler) checkAndClearExpiredLabels() {
 	if l.minExpire == nil || l.minExpire.After(now) {
 		return
 	}
-	var err error
 	deleted := false
 
 	for key, rule := range l.labelRules {
-		if !rule.checkAndRemoveExpireLabels(now) {
-			continue
-		}
-		if len(rule.Labels) == 0 {
-			err = l.DeleteLabelRuleLocked(key)
-			if err == nil {
-				deleted = true
-			}
-		} else {
-			err = l.SaveLabelRuleLocked(rule)
-		}
+		changed, err := l.expireLabelsLocked(rule, now)
 		if err != nil {
 			log.Error("failed to save rule expired label rule", zap.String("rule-key", key), zap.Error(err))
+			continue
+		}
+		if changed && len(rule.Labels) == 0 {
+			deleted = true
 		}
 	}
 	if deleted {
@@ -107,6 +104,43 @@ func (l *RegionLabeler) checkAndClearExpiredLabels() {
 	}
 }
 
+// expireLabelsLocked removes the expired labels of the rule, and the rule is
+// deleted if all the labels are expired. It returns whether the rule is changed.
+func (l *RegionLabeler) expireLabelsLocked(rule *LabelRule, now time.Time) (bool, error) {
+	// The labels are replaced rather than modified in place, so a shallow copy
+	// is enough to keep the old rule.
+	old := *rule
+	if !rule.checkAndRemoveExpireLabels(now) {
+		return false, nil
+	}
+	history := newHistoryBatch(SystemOperator, LabelRuleActionExpire)
+	if len(rule.Labels) > 0 {
+		history.add(rule.ID, &old, rule)
+		return true, l.runInTxnWithHistoryLocked(history, func(txn kv.Txn) error {
+			return l.storage.SaveRegionRule(txn, rule.ID, rule)
+		})
+	}
+	history.add(rule.ID, &old, nil)
+	if err := l.runInTxnWithHistoryLocked(history, func(txn kv.Txn) error {
+		return l.storage.DeleteRegionRule(txn, rule.ID)
+	}); err != nil {
+		return true, err
+	}
+	delete(l.labelRules, rule.ID)
+	return true, nil
+}
+
+// runInTxnWithHistoryLocked runs the operations along with saving the changes
+// to the history.
+func (l *RegionLabeler) runInTxnWithHistoryLocked(history *historyBatch, batch ...func(kv.Txn) error) error {
+	batch = append(batch, l.historyOpsLocked(history)...)
+	if err := endpoint.RunBatchOpInTxn(l.ctx, l.storage, batch); err != nil {
+		return err
+	}
+	l.commitHistoryLocked(history)
+	return nil
+}
+
 func (l *RegionLabeler) loadRules() error {
 	var toDelete []string
 	err := l.storage.LoadRegionRules(func(k, v string) {
@@ -146,11 +180,8 @@ func (l *RegionLabeler) BuildRangeListLocked() {
 		if l.minExpire == nil || rule.expireBefore(*l.minExpire) {
 			l.minExpire = rule.minExpire
 		}
-		if rule.RuleType == KeyRange {
-			rs := rule.Data.([]*KeyRangeRule)
-			for _, r := range rs {
-				builder.AddItem(r.StartKey, r.EndKey, rule)
-			}
+		for _, r := range rule.ranges {
+			builder.AddItem(r.StartKey, r.EndKey, rule)
 		}
 	}
 	l.rangeList = builder.Build()
@@ -199,26 +230,36 @@ func (l *RegionLabeler) getAndCheckRule(id string, now time.Time) *LabelRule {
 	if !ok {
 		return nil
 	}
-	if !rule.checkAndRemoveExpireLabels(now) {
-		return rule
+	if _, err := l.expireLabelsLocked(rule, now); err != nil {
+		log.Error("failed to save rule expired label rule", zap.String("rule-key", id), zap.Error(err))
 	}
 	if len(rule.Labels) == 0 {
-		if err := l.DeleteLabelRuleLocked(id); err != nil {
-			log.Error("failed to delete label rule", zap.String("rule-key", id), zap.Error(err))
-		}
 		return nil
 	}
-	_ = l.SaveLabelRuleLocked(rule)
 	return rule
 }
 
 // SetLabelRule inserts or updates a LabelRule.
 func (l *RegionLabeler) SetLabelRule(rule *LabelRule) error {
+	return l.SetLabelRuleBy(rule, SystemOperator)
+}
+
+// SetLabelRuleBy inserts or updates a LabelRule, and records the change made
+// by the operator in the history.
+func (l *RegionLabeler) SetLabelRuleBy(rule *LabelRule, operator string) error {
 	l.Lock()
 	defer l.Unlock()
-	if err := l.SetLabelRuleLocked(rule); err != nil {
+	if err := rule.checkAndAdjust(); err != nil {
 		return err
 	}
+	history := newHistoryBatch(operator, LabelRuleActionSet)
+	history.add(rule.ID, l.labelRules[rule.ID], rule)
+	if err := l.runInTxnWithHistoryLocked(history, func(txn kv.Txn) error {
+		return l.storage.SaveRegionRule(txn, rule.ID, rule)
+	}); err != nil {
+		return err
+	}
+	l.labelRules[rule.ID] = rule
 	l.BuildRangeListLocked()
 	return nil
 }
@@ -245,14 +286,26 @@ func (l *RegionLabeler) SaveLabelRuleLocked(rule *LabelRule) error {
 
 // DeleteLabelRule removes a LabelRule.
 func (l *RegionLabeler) DeleteLabelRule(id string) error {
+	return l.DeleteLabelRuleBy(id, SystemOperator)
+}
+
+// DeleteLabelRuleBy removes a LabelRule, and records the change made by the
+// operator in the history.
+func (l *RegionLabeler) DeleteLabelRuleBy(id, operator string) error {
 	l.Lock()
 	defer l.Unlock()
-	if _, ok := l.labelRules[id]; !ok {
+	old, ok := l.labelRules[id]
+	if !ok {
 		return errs.ErrRegionRuleNotFound.FastGenByArgs(id)
 	}
-	if err := l.DeleteLabelRuleLocked(id); err != nil {
+	history := newHistoryBatch(operator, LabelRuleActionDelete)
+	history.add(id, old, nil)
+	if err := l.runInTxnWithHistoryLocked(history, func(txn kv.Txn) error {
+		return l.storage.DeleteRegionRule(txn, id)
+	}); err != nil {
 		return err
 	}
+	delete(l.labelRules, id)
 	l.BuildRangeListLocked()
 	return nil
 }
@@ -270,6 +323,12 @@ func (l *RegionLabeler) DeleteLabelRuleLocked(id string) error {
 
 // Patch updates multiple region rules in a batch.
 func (l *RegionLabeler) Patch(patch LabelRulePatch) error {
+	return l.PatchBy(patch, SystemOperator)
+}
+
+// PatchBy updates multiple region rules in a batch, and records the changes made
+// by the operator in the history.
+func (l *RegionLabeler) PatchBy(patch LabelRulePatch, operator string) error {
 	// setRulesMap is used to solve duplicate entries in DeleteRules and SetRules.
 	// Note: We maintain compatibility with the previous behavior, which is to process DeleteRules before SetRules
 	// If there are duplicate rules, we will prioritize SetRules and select the last one from SetRules.
@@ -282,31 +341,40 @@ func (l *RegionLabeler) Patch(patch LabelRulePatch) error {
 		setRulesMap[rule.ID] = rule
 	}
 
+	l.Lock()
+	defer l.Unlock()
+
 	// save to storage
 	var batch []func(kv.Txn) error
+	history := newHistoryBatch(operator, LabelRuleActionSet)
 	for _, key := range patch.DeleteRules {
 		if _, ok := setRulesMap[key]; ok {
 			continue
 		}
+		if old, ok := l.labelRules[key]; ok {
+			history.add(key, old, nil)
+		}
 		localKey := key
 		batch = append(batch, func(txn kv.Txn) error {
 			return l.storage.DeleteRegionRule(txn, localKey)
 		})
 	}
-	for _, rule := range setRulesMap {
+	for _, rule := range patch.SetRules {
+		// Only the last one of the duplicate rules is set.
+		if setRulesMap[rule.ID] != rule {
+			continue
+		}
+		history.add(rule.ID, l.labelRules[rule.ID], rule)
 		localID, localRule := rule.ID, rule
 		batch = append(batch, func(txn kv.Txn) error {
 			return l.storage.SaveRegionRule(txn, localID, localRule)
 		})
 	}
-	if err := endpoint.RunBatchOpInTxn(l.ctx, l.storage, batch); err != nil {
+	if err := l.runInTxnWithHistoryLocked(history, batch...); err != nil {
 		return err
 	}
 
 	// update in-memory states.
-	l.Lock()
-	defer l.Unlock()
-
 	for _, key := range patch.DeleteRules {
 		delete(l.labelRules, key)
 	}
@@ -340,6 +340,66 @@ func TestKeyRange(t *testing.T) {
 	}
 }
 
+func TestKeyPrefixAndKeyspace(t *testing.T) {
+	re := require.New(t)
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	labeler, err := NewRegionLabeler(context.Background(), store, time.Millisecond*10)
+	re.NoError(err)
+	rules := []*LabelRule{
+		{ID: "prefix", Labels: []RegionLabel{{Key: "k1", Value: "v1"}}, RuleType: KeyPrefix, Data: []any{"12ff", "ff"}},
+		{ID: "keyspace", Labels: []RegionLabel{{Key: "k2", Value: "v2"}}, RuleType: Keyspace, Data: []any{float64(1)}},
+	}
+	for _, r := range rules {
+		re.NoError(labeler.SetLabelRule(r))
+	}
+	re.Equal([]string{"12ff", "ff"}, labeler.GetLabelRule("prefix").Data)
+	re.Equal([]uint32{1}, labeler.GetLabelRule("keyspace").Data)
+
+	keyspaceRanges := keyspaceKeyRanges(1)
+	re.Len(keyspaceRanges, 2)
+	type testCase struct {
+		start, end string
+		labels     map[string]string
+	}
+	testCases := []testCase{
+		{"12ff", "13", map[string]string{"k1": "v1"}},
+		{"12ff00", "12ff01", map[string]string{"k1": "v1"}},
+		{"12fe", "13", map[string]string{}},
+		{"ff01", "", map[string]string{"k1": "v1"}},
+		{keyspaceRanges[0].StartKeyHex, keyspaceRanges[0].EndKeyHex, map[string]string{"k2": "v2"}},
+		{keyspaceRanges[1].StartKeyHex, keyspaceRanges[1].EndKeyHex, map[string]string{"k2": "v2"}},
+		{keyspaceKeyRanges(2)[1].StartKeyHex, keyspaceKeyRanges(2)[1].EndKeyHex, map[string]string{}},
+	}
+	for _, testCase := range testCases {
+		start, _ := hex.DecodeString(testCase.start)
+		end, _ := hex.DecodeString(testCase.end)
+		region := core.NewTestRegionInfo(1, 1, start, end)
+		labels := labeler.GetRegionLabels(region)
+		re.Len(labels, len(testCase.labels))
+		for _, l := range labels {
+			re.Equal(testCase.labels[l.Key], l.Value)
+		}
+	}
+
+	// The rules are loaded from the storage.
+	labeler, err = NewRegionLabeler(context.Background(), store, time.Millisecond*10)
+	re.NoError(err)
+	re.Equal([]uint32{1}, labeler.GetLabelRule("keyspace").Data)
+	re.Len(labeler.GetSplitKeys([]byte(""), []byte("")), 7)
+
+	// The invalid rules are rejected.
+	invalidData := map[string][]any{
+		KeyPrefix: {nil, []any{}, []any{""}, []any{"zz"}, []any{1}},
+		Keyspace:  {nil, []any{}, []any{"1"}, []any{float64(-1)}, []any{1.5}, []any{float64(1 << 24)}},
+	}
+	for ruleType, data := range invalidData {
+		for _, d := range data {
+			rule := &LabelRule{ID: "invalid", Labels: []RegionLabel{{Key: "k", Value: "v"}}, RuleType: ruleType, Data: d}
+			re.Error(labeler.SetLabelRule(rule))
+		}
+	}
+}
+
 func TestLabelerRuleTTL(t *testing.T) {
 	re := require.New(t)
 	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
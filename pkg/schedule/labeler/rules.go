@@ -16,6 +16,7 @@ package labeler
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -28,6 +29,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 
+	"github.com/tikv/pd/pkg/codec"
 	"github.com/tikv/pd/pkg/errs"
 )
 
@@ -54,6 +56,7 @@ type LabelRule struct {
 	RuleType  string        `json:"rule_type"`
 	Data      any           `json:"data"`
 	minExpire *time.Time
+	ranges    []*KeyRangeRule // only set at runtime, the key ranges matched by the rule.
 }
 
 func (rule *LabelRule) String() string {
@@ -71,14 +74,16 @@ func (rule *LabelRule) String() string {
 			b.WriteString(", ")
 		}
 	}
+	if rule.RuleType != KeyRange {
+		b.WriteString(fmt.Sprintf(", %s: %v", rule.RuleType, rule.Data))
+	}
 	b.WriteString(", data: ")
-	ranges := rule.Data.([]*KeyRangeRule)
-	for i, r := range ranges {
+	for i, r := range rule.ranges {
 		if i == 0 {
 			b.WriteString("[")
 		}
 		b.WriteString(fmt.Sprintf("startKey: {%s}, endKey: {%s}", r.StartKeyHex, r.EndKeyHex))
-		if i == len(ranges)-1 {
+		if i == len(rule.ranges)-1 {
 			b.WriteString("]")
 		} else {
 			b.WriteString(", ")
@@ -100,8 +105,17 @@ func NewLabelRuleFromJSON(data []byte) (*LabelRule, error) {
 const (
 	// KeyRange is the rule type that specifies a list of key ranges.
 	KeyRange = "key-range"
+	// KeyPrefix is the rule type that specifies a list of hex format key prefixes,
+	// each of which matches the key range starting with it.
+	KeyPrefix = "key-prefix"
+	// Keyspace is the rule type that specifies a list of keyspace IDs, each of
+	// which matches the raw and txn key ranges of the keyspace.
+	Keyspace = "keyspace"
 )
 
+// maxKeyspaceID is the maximum value of the keyspace ID, which is uint24max.
+const maxKeyspaceID = ^uint32(0) >> 8
+
 const (
 	scheduleOptionLabel     = "schedule"
 	scheduleOptionValueDeny = "deny"
@@ -203,14 +217,30 @@ func (rule *LabelRule) checkAndAdjust() error {
 		return errs.ErrRegionRuleContent.FastGenByArgs("region label with expired ttl")
 	}
 
-	// TODO: change it to switch statement once we support more types.
-	if rule.RuleType == KeyRange {
-		var err error
-		rule.Data, err = initKeyRangeRulesFromLabelRuleData(rule.Data)
-		return err
+	switch rule.RuleType {
+	case KeyRange:
+		ranges, err := initKeyRangeRulesFromLabelRuleData(rule.Data)
+		if err != nil {
+			return err
+		}
+		rule.Data, rule.ranges = ranges, ranges
+	case KeyPrefix:
+		prefixes, ranges, err := initKeyPrefixRulesFromLabelRuleData(rule.Data)
+		if err != nil {
+			return err
+		}
+		rule.Data, rule.ranges = prefixes, ranges
+	case Keyspace:
+		ids, ranges, err := initKeyspaceRulesFromLabelRuleData(rule.Data)
+		if err != nil {
+			return err
+		}
+		rule.Data, rule.ranges = ids, ranges
+	default:
+		log.Error("invalid rule type", zap.String("rule-type", rule.RuleType))
+		return errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid rule type: %s", rule.RuleType))
 	}
-	log.Error("invalid rule type", zap.String("rule-type", rule.RuleType))
-	return errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid rule type: %s", rule.RuleType))
+	return nil
 }
 
 func (rule *LabelRule) expireBefore(t time.Time) bool {
@@ -270,3 +300,96 @@ func initAndAdjustKeyRangeRule(rule any) (*KeyRangeRule, error) {
 	}
 	return &r, nil
 }
+
+// initKeyPrefixRulesFromLabelRuleData inits the key prefixes from `LabelRule.Data`
+// and returns the key ranges of them.
+func initKeyPrefixRulesFromLabelRuleData(data any) ([]string, []*KeyRangeRule, error) {
+	items, ok := data.([]any)
+	if !ok {
+		return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid rule type: %T", data))
+	}
+	if len(items) == 0 {
+		return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs("no key prefixes")
+	}
+	prefixes := make([]string, 0, len(items))
+	ranges := make([]*KeyRangeRule, 0, len(items))
+	for _, item := range items {
+		prefixHex, ok := item.(string)
+		if !ok {
+			return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid key prefix type: %T", item))
+		}
+		prefix, err := hex.DecodeString(prefixHex)
+		if err != nil {
+			return nil, nil, errs.ErrHexDecodingString.FastGenByArgs(prefixHex)
+		}
+		if len(prefix) == 0 {
+			return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs("empty key prefix")
+		}
+		prefixes = append(prefixes, prefixHex)
+		ranges = append(ranges, newKeyRangeRule(prefix, prefixEnd(prefix)))
+	}
+	return prefixes, ranges, nil
+}
+
+// initKeyspaceRulesFromLabelRuleData inits the keyspace IDs from `LabelRule.Data`
+// and returns the key ranges of them.
+func initKeyspaceRulesFromLabelRuleData(data any) ([]uint32, []*KeyRangeRule, error) {
+	items, ok := data.([]any)
+	if !ok {
+		return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid rule type: %T", data))
+	}
+	if len(items) == 0 {
+		return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs("no keyspace ids")
+	}
+	ids := make([]uint32, 0, len(items))
+	ranges := make([]*KeyRangeRule, 0, 2*len(items))
+	for _, item := range items {
+		// The numbers are decoded as float64 from JSON.
+		id, ok := item.(float64)
+		if !ok || id < 0 || id > float64(maxKeyspaceID) || id != float64(uint32(id)) {
+			return nil, nil, errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid keyspace id: %v", item))
+		}
+		ids = append(ids, uint32(id))
+		ranges = append(ranges, keyspaceKeyRanges(uint32(id))...)
+	}
+	return ids, ranges, nil
+}
+
+// keyspaceKeyRanges returns the raw and txn key ranges of the keyspace, which
+// are the same as the region bounds of the keyspace.
+func keyspaceKeyRanges(id uint32) []*KeyRangeRule {
+	idBytes := make([]byte, 4)
+	nextIDBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(idBytes, id)
+	binary.BigEndian.PutUint32(nextIDBytes, id+1)
+	ranges := make([]*KeyRangeRule, 0, 2)
+	for _, mode := range []byte{'r', 'x'} {
+		ranges = append(ranges, newKeyRangeRule(
+			codec.EncodeBytes(append([]byte{mode}, idBytes[1:]...)),
+			codec.EncodeBytes(append([]byte{mode}, nextIDBytes[1:]...)),
+		))
+	}
+	return ranges
+}
+
+func newKeyRangeRule(startKey, endKey []byte) *KeyRangeRule {
+	return &KeyRangeRule{
+		StartKey:    startKey,
+		StartKeyHex: hex.EncodeToString(startKey),
+		EndKey:      endKey,
+		EndKeyHex:   hex.EncodeToString(endKey),
+	}
+}
+
+// prefixEnd returns the smallest key which is greater than all the keys with the
+// prefix, or the empty key if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return []byte{}
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// consumerDemandTTL is the time a consumer is still regarded as competing for the
+// operator slots of a kind after it asked for them the last time.
+const consumerDemandTTL = 3 * time.Minute
+
+// budgetKinds are the operator kinds whose slots are limited by the schedule limits.
+var budgetKinds = []OpKind{OpMerge, OpReplica, OpHotRegion, OpRegion, OpLeader, OpWitness}
+
+// consumerBudget records the running operators and the demands of each consumer,
+// which is the scheduler or checker creating the operators.
+type consumerBudget struct {
+	syncutil.RWMutex
+	running map[string]map[OpKind]uint64
+	demands map[string]map[OpKind]time.Time
+	// kinds is the mask of the kinds which the consumer has ever created.
+	kinds map[string]OpKind
+	// lastGC is the last time the inactive consumers are cleaned up.
+	lastGC time.Time
+}
+
+func newConsumerBudget() *consumerBudget {
+	return &consumerBudget{
+		running: make(map[string]map[OpKind]uint64),
+		demands: make(map[string]map[OpKind]time.Time),
+		kinds:   make(map[string]OpKind),
+	}
+}
+
+func (b *consumerBudget) inc(consumer string, kind OpKind) {
+	if len(consumer) == 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.running[consumer] == nil {
+		b.running[consumer] = make(map[OpKind]uint64)
+	}
+	b.running[consumer][kind]++
+	b.kinds[consumer] |= kind
+	operatorBudgetRunningGauge.WithLabelValues(consumer, kind.String()).Set(float64(b.running[consumer][kind]))
+}
+
+func (b *consumerBudget) dec(consumer string, kind OpKind) {
+	if len(consumer) == 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.running[consumer][kind] > 0 {
+		b.running[consumer][kind]--
+		operatorBudgetRunningGauge.WithLabelValues(consumer, kind.String()).Set(float64(b.running[consumer][kind]))
+		b.tryRemoveLocked(consumer, kind, time.Now())
+	}
+}
+
+// recordDemand records that the consumer is waiting for the slots of the kinds.
+func (b *consumerBudget) recordDemand(consumer string, kinds OpKind, now time.Time) {
+	if len(consumer) == 0 || kinds == 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.demands[consumer] == nil {
+		b.demands[consumer] = make(map[OpKind]time.Time)
+	}
+	for _, kind := range budgetKinds {
+		if kinds&kind != 0 {
+			b.demands[consumer][kind] = now
+		}
+	}
+	if now.Sub(b.lastGC) >= consumerDemandTTL {
+		b.gcLocked(now)
+	}
+}
+
+// gc removes the consumers which have no running operators of a kind and have
+// not asked for its slots recently, along with their metrics.
+func (b *consumerBudget) gc(now time.Time) {
+	b.Lock()
+	defer b.Unlock()
+	b.gcLocked(now)
+}
+
+func (b *consumerBudget) gcLocked(now time.Time) {
+	b.lastGC = now
+	for consumer, running := range b.running {
+		for kind := range running {
+			b.tryRemoveLocked(consumer, kind, now)
+		}
+	}
+	for consumer, demands := range b.demands {
+		for kind := range demands {
+			b.tryRemoveLocked(consumer, kind, now)
+		}
+	}
+}
+
+// tryRemoveLocked removes the state and the metrics of the kind of the consumer
+// if it has no running operators of the kind and its demand has expired.
+func (b *consumerBudget) tryRemoveLocked(consumer string, kind OpKind, now time.Time) {
+	if b.running[consumer][kind] > 0 {
+		return
+	}
+	if t, ok := b.demands[consumer][kind]; ok && now.Sub(t) < consumerDemandTTL {
+		return
+	}
+	b.removeLocked(consumer, kind)
+}
+
+func (b *consumerBudget) removeLocked(consumer string, kind OpKind) {
+	operatorBudgetRunningGauge.DeleteLabelValues(consumer, kind.String())
+	operatorBudgetShareGauge.DeleteLabelValues(consumer, kind.String())
+	if running, ok := b.running[consumer]; ok {
+		delete(running, kind)
+		if len(running) == 0 {
+			delete(b.running, consumer)
+		}
+	}
+	if demands, ok := b.demands[consumer]; ok {
+		delete(demands, kind)
+		if len(demands) == 0 {
+			delete(b.demands, consumer)
+		}
+	}
+	if len(b.running[consumer]) == 0 && len(b.demands[consumer]) == 0 {
+		delete(b.kinds, consumer)
+	}
+}
+
+// remove removes the demands of the consumer and the state of the kinds it has
+// no running operators of. The kinds with running operators are removed once
+// their operators finish.
+func (b *consumerBudget) remove(consumer string) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.demands, consumer)
+	for _, kind := range budgetKinds {
+		if b.running[consumer][kind] == 0 {
+			b.removeLocked(consumer, kind)
+		}
+	}
+}
+
+func (b *consumerBudget) getKinds(consumer string) OpKind {
+	b.RLock()
+	defer b.RUnlock()
+	return b.kinds[consumer]
+}
+
+func (b *consumerBudget) getRunning(consumer string, kind OpKind) uint64 {
+	b.RLock()
+	defer b.RUnlock()
+	return b.running[consumer][kind]
+}
+
+// getActiveConsumers returns the consumers which have running operators of the kind
+// or have asked for its slots recently.
+func (b *consumerBudget) getActiveConsumers(kind OpKind, now time.Time) []string {
+	b.RLock()
+	defer b.RUnlock()
+	consumers := make([]string, 0, len(b.running))
+	for consumer, running := range b.running {
+		if running[kind] > 0 {
+			consumers = append(consumers, consumer)
+		}
+	}
+	for consumer, demands := range b.demands {
+		if b.running[consumer][kind] > 0 {
+			continue
+		}
+		if t, ok := demands[kind]; ok && now.Sub(t) < consumerDemandTTL {
+			consumers = append(consumers, consumer)
+		}
+	}
+	sort.Strings(consumers)
+	return consumers
+}
+
+// ConsumerBudget is the usage of the operator slots of a kind by a consumer.
+type ConsumerBudget struct {
+	Consumer string  `json:"consumer"`
+	Kind     string  `json:"kind"`
+	Weight   float64 `json:"weight"`
+	Running  uint64  `json:"running"`
+	Limit    uint64  `json:"limit"`
+	// Share is the number of slots the consumer can take when fair share is enabled.
+	Share uint64 `json:"share"`
+}
+
+// getScheduleLimit returns the schedule limit of the kind, it returns false if
+// the kind is not limited.
+func (oc *Controller) getScheduleLimit(kind OpKind) (uint64, bool) {
+	switch kind {
+	case OpMerge:
+		return oc.config.GetMergeScheduleLimit(), true
+	case OpReplica:
+		return oc.config.GetReplicaScheduleLimit(), true
+	case OpHotRegion:
+		return oc.config.GetHotRegionScheduleLimit(), true
+	case OpRegion:
+		return oc.config.GetRegionScheduleLimit(), true
+	case OpLeader:
+		return oc.config.GetLeaderScheduleLimit(), true
+	case OpWitness:
+		return oc.config.GetWitnessScheduleLimit(), true
+	default:
+		return 0, false
+	}
+}
+
+// getFairShare returns the slots of the kind the consumer can take, which is
+// divided by the weights of the active consumers. Each active consumer can take
+// one slot at least.
+func (oc *Controller) getFairShare(consumer string, kind OpKind, limit uint64, now time.Time) uint64 {
+	consumers := oc.budget.getActiveConsumers(kind, now)
+	weight := oc.config.GetOperatorBudgetWeight(consumer)
+	total := weight
+	for _, c := range consumers {
+		if c != consumer {
+			total += oc.config.GetOperatorBudgetWeight(c)
+		}
+	}
+	share := uint64(math.Floor(float64(limit) * weight / total))
+	if share < 1 {
+		share = 1
+	}
+	operatorBudgetShareGauge.WithLabelValues(consumer, kind.String()).Set(float64(share))
+	return share
+}
+
+// exceedFairShare returns true if the consumer of the operator has taken its fair
+// share of the operator slots.
+func (oc *Controller) exceedFairShare(op *Operator) bool {
+	if !oc.config.IsOperatorFairShareEnabled() || len(op.Consumer()) == 0 {
+		return false
+	}
+	kind := op.SchedulerKind()
+	limit, ok := oc.getScheduleLimit(kind)
+	if !ok {
+		return false
+	}
+	share := oc.getFairShare(op.Consumer(), kind, limit, time.Now())
+	return oc.budget.getRunning(op.Consumer(), kind) >= share
+}
+
+// RecordConsumerDemand records that the consumer is waiting for the operator slots
+// of the kind, so the other consumers will leave its share to it. If the kind is 0,
+// the kinds the consumer has created before are used, or the kinds whose slots are
+// used up if it has created nothing.
+func (oc *Controller) RecordConsumerDemand(consumer string, kind OpKind) {
+	if kind == 0 {
+		kind = oc.budget.getKinds(consumer)
+	}
+	if kind == 0 {
+		for _, k := range budgetKinds {
+			if limit, _ := oc.getScheduleLimit(k); limit > 0 && oc.OperatorCount(k) >= limit {
+				kind |= k
+			}
+		}
+	}
+	oc.budget.recordDemand(consumer, kind, time.Now())
+}
+
+// RemoveConsumer removes the budget state and the metrics of the consumer, it is
+// called when the scheduler is removed.
+func (oc *Controller) RemoveConsumer(consumer string) {
+	oc.budget.remove(consumer)
+}
+
+// GetConsumerBudgets returns the usage of the operator slots by the active consumers.
+func (oc *Controller) GetConsumerBudgets() []*ConsumerBudget {
+	now := time.Now()
+	var budgets []*ConsumerBudget
+	for _, kind := range budgetKinds {
+		limit, _ := oc.getScheduleLimit(kind)
+		for _, consumer := range oc.budget.getActiveConsumers(kind, now) {
+			budget := &ConsumerBudget{
+				Consumer: consumer,
+				Kind:     kind.String(),
+				Weight:   oc.config.GetOperatorBudgetWeight(consumer),
+				Running:  oc.budget.getRunning(consumer, kind),
+				Limit:    limit,
+				Share:    limit,
+			}
+			if oc.config.IsOperatorFairShareEnabled() {
+				budget.Share = oc.getFairShare(consumer, kind, limit, now)
+			}
+			budgets = append(budgets, budget)
+		}
+	}
+	return budgets
+}
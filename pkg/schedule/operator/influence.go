@@ -51,10 +51,12 @@ func (m *OpInfluence) GetStoreInfluence(id uint64) *StoreInfluence {
 
 // StoreInfluence records influences that pending operators will make.
 type StoreInfluence struct {
-	RegionSize   int64
-	RegionCount  int64
-	LeaderSize   int64
-	LeaderCount  int64
+	RegionSize  int64
+	RegionCount int64
+	LeaderSize  int64
+	LeaderCount int64
+	// LeaderLoad is the query rate of the leaders, it is rounded up to an integer.
+	LeaderLoad   int64
 	WitnessCount int64
 	StepCost     map[storelimit.Type]int64
 }
@@ -78,6 +80,7 @@ func (s *StoreInfluence) add(other *StoreInfluence) {
 	s.RegionSize += other.RegionSize
 	s.LeaderSize += other.LeaderSize
 	s.LeaderCount += other.LeaderCount
+	s.LeaderLoad += other.LeaderLoad
 	s.WitnessCount += other.WitnessCount
 	for _, v := range storelimit.TypeNameValue {
 		s.AddStepCost(v, other.GetStepCost(v))
@@ -93,6 +96,8 @@ func (s *StoreInfluence) ResourceProperty(kind constant.ScheduleKind) int64 {
 			return s.LeaderCount
 		case constant.BySize:
 			return s.LeaderSize
+		case constant.ByLoad:
+			return s.LeaderLoad
 		default:
 			return 0
 		}
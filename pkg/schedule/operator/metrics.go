@@ -81,6 +81,22 @@ var (
 			Help:      "Bucketed histogram of the operator region size.",
 			Buckets:   prometheus.ExponentialBuckets(1, 2, 20), // 1MB~1TB
 		}, []string{"type"})
+
+	operatorBudgetRunningGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operator_budget_running",
+			Help:      "Number of the running operators of each scheduler or checker.",
+		}, []string{"consumer", "kind"})
+
+	operatorBudgetShareGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operator_budget_share",
+			Help:      "Number of the operator slots shared to each scheduler or checker.",
+		}, []string{"consumer", "kind"})
 )
 
 func init() {
@@ -91,6 +107,8 @@ func init() {
 	prometheus.MustRegister(operatorDuration)
 	prometheus.MustRegister(operatorSizeHist)
 	prometheus.MustRegister(storeLimitCostCounter)
+	prometheus.MustRegister(operatorBudgetRunningGauge)
+	prometheus.MustRegister(operatorBudgetShareGauge)
 }
 
 // IncOperatorLimitCounter increases the counter of operator meeting limit.
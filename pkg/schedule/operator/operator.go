@@ -63,8 +63,12 @@ var (
 	ExceedStoreLimit CancelReasonType = "exceed store limit"
 	// ExceedWaitLimit is the cancel reason when the operator exceeds the waiting queue limit.
 	ExceedWaitLimit CancelReasonType = "exceed wait limit"
+	// ExceedFairShare is the cancel reason when the creator of the operator has taken its share of the operator slots.
+	ExceedFairShare CancelReasonType = "exceed fair share"
 	// RelatedMergeRegion is the cancel reason when the operator is cancelled by related merge region.
 	RelatedMergeRegion CancelReasonType = "related merge region"
+	// DryRun is the cancel reason when the operator is only created in dry-run mode.
+	DryRun CancelReasonType = "dry run"
 	// Unknown is the cancel reason when the operator is cancelled by an unknown reason.
 	Unknown CancelReasonType = "unknown"
 )
@@ -84,10 +88,17 @@ type Operator struct {
 	level            constant.PriorityLevel
 	Counters         []prometheus.Counter
 	FinishedCounters []prometheus.Counter
-	additionalInfos  opAdditionalInfo
-	ApproximateSize  int64
-	timeout          time.Duration
-	influence        *OpInfluence
+	// AddedHooks are called when the operator is accepted by the operator controller,
+	// and FinishedHooks are called when the operator finishes successfully. They are
+	// called by the operator controller, so they should be light and must not call it.
+	AddedHooks      []func()
+	FinishedHooks   []func()
+	additionalInfos opAdditionalInfo
+	ApproximateSize int64
+	timeout         time.Duration
+	influence       *OpInfluence
+	// consumer is the name of the scheduler or checker which creates the operator.
+	consumer string
 }
 
 // NewOperator creates a new operator.
@@ -161,6 +172,7 @@ type OpObject struct {
 	Kind        OpKind              `json:"kind"`
 	Timeout     string              `json:"timeout"`
 	Status      OpStatus            `json:"status"`
+	Consumer    string              `json:"consumer,omitempty"`
 }
 
 // ToJSONObject serializes Operator as JSON object.
@@ -182,6 +194,7 @@ func (o *Operator) ToJSONObject() *OpObject {
 		Kind:        o.kind,
 		Timeout:     o.timeout.String(),
 		Status:      status,
+		Consumer:    o.consumer,
 	}
 }
 
@@ -190,6 +203,16 @@ func (o *Operator) Desc() string {
 	return o.desc
 }
 
+// Consumer returns the name of the scheduler or checker which creates the operator.
+func (o *Operator) Consumer() string {
+	return o.consumer
+}
+
+// SetConsumer sets the name of the scheduler or checker which creates the operator.
+func (o *Operator) SetConsumer(consumer string) {
+	o.consumer = consumer
+}
+
 // SetDesc sets the description for the operator.
 func (o *Operator) SetDesc(desc string) {
 	o.desc = desc
@@ -415,6 +438,17 @@ func (o *Operator) GetPriorityLevel() constant.PriorityLevel {
 	return o.level
 }
 
+// SetLeaderLoad sets the query rate of the leader moved by the transfer leader
+// steps, so it is counted in the influence of the operator.
+func (o *Operator) SetLeaderLoad(load int64) {
+	for i, step := range o.steps {
+		if tl, ok := step.(TransferLeader); ok {
+			tl.Load = load
+			o.steps[i] = tl
+		}
+	}
+}
+
 // UnfinishedInfluence calculates the store difference which unfinished operator steps make.
 func (o *Operator) UnfinishedInfluence(opInfluence OpInfluence, region *core.RegionInfo) {
 	for step := atomic.LoadInt32(&o.currentStep); int(step) < len(o.steps); step++ {
@@ -100,6 +100,7 @@ type Controller struct {
 	wop       WaitingOperator
 	wopStatus *waitingOperatorStatus
 	counts    *opCounter
+	budget    *consumerBudget
 }
 
 // NewController creates a Controller.
@@ -116,6 +117,7 @@ func NewController(ctx context.Context, cluster *core.BasicCluster, config confi
 		wop:       newRandBuckets(),
 		wopStatus: newWaitingOperatorStatus(),
 		counts:    &opCounter{count: make(map[OpKind]uint64)},
+		budget:    newConsumerBudget(),
 	}
 }
 
@@ -315,6 +317,7 @@ func (oc *Controller) AddWaitingOperator(ops ...*Operator) int {
 			}
 			isMerge = true
 		}
+		oc.RecordConsumerDemand(op.Consumer(), op.SchedulerKind())
 		if pass, reason := oc.checkAddOperator(false, op); !pass {
 			_ = op.Cancel(reason)
 			oc.buryOperator(op)
@@ -419,21 +422,58 @@ func (oc *Controller) PromoteWaitingOperator() {
 	}
 }
 
-// checkAddOperator checks if the operator can be added.
+// CheckAddOperator checks if the operators can be added without adding them. It is
+// used to validate the operators created out of PD.
+func (oc *Controller) CheckAddOperator(ops ...*Operator) (bool, CancelReasonType) {
+	return oc.checkAddOperator(false, ops...)
+}
+
+// cancelReasonMetricLabels are the labels of the operator counter for the reasons
+// why the operators can't be added.
+var cancelReasonMetricLabels = map[CancelReasonType]string{
+	RegionNotFound:    "not-found",
+	EpochNotMatch:     "epoch-not-match",
+	AlreadyExist:      "already-have",
+	NotInCreateStatus: "unexpected-status",
+	ExceedWaitLimit:   "exceed-max-waiting",
+	ExceedFairShare:   "exceed-fair-share",
+	Expired:           "expired",
+}
+
+// checkAddOperator checks if the operator can be added, and records the reason if it can't.
 // There are several situations that cannot be added:
 // - There is no such region in the cluster
 // - The epoch of the operator and the epoch of the corresponding region are no longer consistent.
 // - The region already has a higher priority or same priority
 // - Exceed the max number of waiting operators
+// - Exceed the fair share of the operator slots of its consumer
 // - At least one operator is expired.
 func (oc *Controller) checkAddOperator(isPromoting bool, ops ...*Operator) (bool, CancelReasonType) {
+	op, reason := oc.checkAddOperatorInner(isPromoting, ops...)
+	if op == nil {
+		return true, reason
+	}
+	if reason != Expired {
+		operatorCounter.WithLabelValues(op.Desc(), cancelReasonMetricLabels[reason]).Inc()
+		return false, reason
+	}
+	for _, op := range ops {
+		if op.Status() == EXPIRED {
+			operatorCounter.WithLabelValues(op.Desc(), cancelReasonMetricLabels[reason]).Inc()
+		}
+	}
+	return false, reason
+}
+
+// checkAddOperatorInner checks if the operator can be added without recording any metrics,
+// and returns the operator which can't be added with the reason.
+func (oc *Controller) checkAddOperatorInner(isPromoting bool, ops ...*Operator) (*Operator, CancelReasonType) {
 	for _, op := range ops {
 		region := oc.cluster.GetRegion(op.RegionID())
 		if region == nil {
 			log.Debug("region not found, cancel add operator",
 				zap.Uint64("region-id", op.RegionID()))
-			operatorCounter.WithLabelValues(op.Desc(), "not-found").Inc()
-			return false, RegionNotFound
+			return op, RegionNotFound
 		}
 		if region.GetRegionEpoch().GetVersion() != op.RegionEpoch().GetVersion() ||
 			region.GetRegionEpoch().GetConfVer() != op.RegionEpoch().GetConfVer() {
@@ -441,16 +481,14 @@ func (oc *Controller) checkAddOperator(isPromoting bool, ops ...*Operator) (bool
 				zap.Uint64("region-id", op.RegionID()),
 				zap.Reflect("old", region.GetRegionEpoch()),
 				zap.Reflect("new", op.RegionEpoch()))
-			operatorCounter.WithLabelValues(op.Desc(), "epoch-not-match").Inc()
-			return false, EpochNotMatch
+			return op, EpochNotMatch
 		}
 		if oldi, ok := oc.operators.Load(op.RegionID()); ok && oldi.(*Operator) != nil && !isHigherPriorityOperator(op, oldi.(*Operator)) {
 			old := oldi.(*Operator)
 			log.Debug("already have operator, cancel add operator",
 				zap.Uint64("region-id", op.RegionID()),
 				zap.Reflect("old", old))
-			operatorCounter.WithLabelValues(op.Desc(), "already-have").Inc()
-			return false, AlreadyExist
+			return op, AlreadyExist
 		}
 		if op.Status() != CREATED {
 			log.Error("trying to add operator with unexpected status",
@@ -460,27 +498,34 @@ func (oc *Controller) checkAddOperator(isPromoting bool, ops ...*Operator) (bool
 			failpoint.Inject("unexpectedOperator", func() {
 				panic(op)
 			})
-			operatorCounter.WithLabelValues(op.Desc(), "unexpected-status").Inc()
-			return false, NotInCreateStatus
+			return op, NotInCreateStatus
 		}
 		if !isPromoting && oc.wopStatus.getCount(op.Desc()) >= oc.config.GetSchedulerMaxWaitingOperator() {
 			log.Debug("exceed max return false", zap.Uint64("waiting", oc.wopStatus.getCount(op.Desc())), zap.String("desc", op.Desc()), zap.Uint64("max", oc.config.GetSchedulerMaxWaitingOperator()))
-			operatorCounter.WithLabelValues(op.Desc(), "exceed-max-waiting").Inc()
-			return false, ExceedWaitLimit
+			return op, ExceedWaitLimit
 		}
 
 		if op.SchedulerKind() == OpAdmin || op.IsLeaveJointStateOperator() {
 			continue
 		}
+		if oc.exceedFairShare(op) {
+			log.Debug("exceed fair share, cancel add operator",
+				zap.Uint64("region-id", op.RegionID()),
+				zap.String("consumer", op.Consumer()))
+			return op, ExceedFairShare
+		}
 	}
-	var reason CancelReasonType
+	// All the operators are checked, so that every expired operator is marked.
+	var expired *Operator
 	for _, op := range ops {
-		if op.CheckExpired() {
-			reason = Expired
-			operatorCounter.WithLabelValues(op.Desc(), "expired").Inc()
+		if op.CheckExpired() && expired == nil {
+			expired = op
 		}
 	}
-	return reason != Expired, reason
+	if expired != nil {
+		return expired, Expired
+	}
+	return nil, ""
 }
 
 // checkOperatorLightly checks whether the ops can be dispatched in Controller::pollNeedDispatchRegion.
@@ -504,6 +549,76 @@ func (oc *Controller) checkOperatorLightly(op *Operator) (*core.RegionInfo, Canc
 	return region, ""
 }
 
+// DryRunResult is the result of checking an operator in dry-run mode.
+type DryRunResult struct {
+	*OpObject
+	Steps           []string `json:"steps"`
+	ApproximateSize int64    `json:"approximate_size"`
+	// Accepted indicates whether the operator would be added to the controller.
+	Accepted bool             `json:"accepted"`
+	Reason   CancelReasonType `json:"reason,omitempty"`
+}
+
+// DryRunOperators checks whether the operators would be accepted by the controller,
+// but never adds them, so nothing is sent to TiKV through the heartbeat streams.
+// The operators are checked in order as if they were added one by one, and all of
+// them are canceled with the DryRun reason afterwards. The results are in the same
+// order as the operators.
+func (oc *Controller) DryRunOperators(ops ...*Operator) []*DryRunResult {
+	results := make([]*DryRunResult, 0, len(ops))
+	regions := make(map[uint64]struct{}, len(ops))
+	waiting := make(map[string]uint64)
+	for i := 0; i < len(ops); i++ {
+		batch := ops[i : i+1]
+		// Merge operators are always paired, check them together.
+		if ops[i].Kind()&OpMerge != 0 && i+1 < len(ops) {
+			batch = ops[i : i+2]
+			i++
+		}
+		reason := oc.dryRunCheck(regions, waiting, batch...)
+		for _, op := range batch {
+			steps := make([]string, 0, op.Len())
+			for j := range op.Len() {
+				steps = append(steps, op.Step(j).String())
+			}
+			results = append(results, &DryRunResult{
+				OpObject:        op.ToJSONObject(),
+				Steps:           steps,
+				ApproximateSize: op.ApproximateSize,
+				Accepted:        len(reason) == 0,
+				Reason:          reason,
+			})
+			_ = op.Cancel(DryRun)
+		}
+	}
+	return results
+}
+
+// dryRunCheck checks the operators with the regions and waiting counts which are
+// taken by the previous accepted operators of the same dry-run.
+func (oc *Controller) dryRunCheck(regions map[uint64]struct{}, waiting map[string]uint64, ops ...*Operator) CancelReasonType {
+	for _, op := range ops {
+		if _, ok := regions[op.RegionID()]; ok {
+			return AlreadyExist
+		}
+	}
+	desc := ops[0].Desc()
+	if oc.wopStatus.getCount(desc)+waiting[desc] >= oc.config.GetSchedulerMaxWaitingOperator() {
+		return ExceedWaitLimit
+	}
+	if op, reason := oc.checkAddOperatorInner(false, ops...); op != nil {
+		return reason
+	}
+	if oc.exceedStoreLimitInner(ops...) {
+		return ExceedStoreLimit
+	}
+	for _, op := range ops {
+		regions[op.RegionID()] = struct{}{}
+	}
+	waiting[desc]++
+	return ""
+}
+
 func isHigherPriorityOperator(new, old *Operator) bool {
 	return new.GetPriorityLevel() > old.GetPriorityLevel()
 }
@@ -537,6 +652,7 @@ func (oc *Controller) addOperatorInner(op *Operator) bool {
 	}
 	oc.operators.Store(regionID, op)
 	oc.counts.inc(op.SchedulerKind())
+	oc.budget.inc(op.Consumer(), op.SchedulerKind())
 	operatorCounter.WithLabelValues(op.Desc(), "start").Inc()
 	operatorSizeHist.WithLabelValues(op.Desc()).Observe(float64(op.ApproximateSize))
 	opInfluence := NewTotalOpInfluence([]*Operator{op}, oc.cluster)
@@ -569,6 +685,9 @@ func (oc *Controller) addOperatorInner(op *Operator) bool {
 	for _, counter := range op.Counters {
 		counter.Inc()
 	}
+	for _, hook := range op.AddedHooks {
+		hook()
+	}
 	return true
 }
 
@@ -610,6 +729,7 @@ func (oc *Controller) removeOperatorsInner() []*Operator {
 		op := value.(*Operator)
 		oc.operators.Delete(regionID)
 		oc.counts.dec(op.SchedulerKind())
+		oc.budget.dec(op.Consumer(), op.SchedulerKind())
 		operatorCounter.WithLabelValues(op.Desc(), "remove").Inc()
 		oc.ack(op)
 		if op.Kind()&OpMerge != 0 {
@@ -649,6 +769,7 @@ func (oc *Controller) removeOperatorInner(op *Operator) bool {
 	if cur, ok := oc.operators.Load(regionID); ok && cur.(*Operator) == op {
 		oc.operators.Delete(regionID)
 		oc.counts.dec(op.SchedulerKind())
+		oc.budget.dec(op.Consumer(), op.SchedulerKind())
 		operatorCounter.WithLabelValues(op.Desc(), "remove").Inc()
 		oc.ack(op)
 		if op.Kind()&OpMerge != 0 {
@@ -704,6 +825,9 @@ func (oc *Controller) buryOperator(op *Operator) {
 		for _, counter := range op.FinishedCounters {
 			counter.Inc()
 		}
+		for _, hook := range op.FinishedHooks {
+			hook()
+		}
 	case REPLACED:
 		log.Info("replace old operator",
 			zap.Uint64("region-id", op.RegionID()),
@@ -920,6 +1044,7 @@ func NewTotalOpInfluence(operators []*Operator, cluster *core.BasicCluster) OpIn
 func (oc *Controller) SetOperator(op *Operator) {
 	oc.operators.Store(op.RegionID(), op)
 	oc.counts.inc(op.SchedulerKind())
+	oc.budget.inc(op.Consumer(), op.SchedulerKind())
 }
 
 // OpWithStatus records the operator and its status.
@@ -975,13 +1100,18 @@ func (o *records) Put(op *Operator) {
 
 // ExceedStoreLimit returns true if the store exceeds the cost limit after adding the  Otherwise, returns false.
 func (oc *Controller) ExceedStoreLimit(ops ...*Operator) bool {
+	if oc.exceedStoreLimitInner(ops...) {
+		OperatorExceededStoreLimitCounter.WithLabelValues(ops[0].Desc()).Inc()
+		return true
+	}
+	return false
+}
+
+// exceedStoreLimitInner returns true if the store limit would be exceeded without recording any metrics.
+func (oc *Controller) exceedStoreLimitInner(ops ...*Operator) bool {
 	// The operator with Urgent priority, like admin operators, should ignore the store limit check.
-	var desc string
-	if len(ops) != 0 {
-		desc = ops[0].Desc()
-		if ops[0].GetPriorityLevel() == constant.Urgent {
-			return false
-		}
+	if len(ops) != 0 && ops[0].GetPriorityLevel() == constant.Urgent {
+		return false
 	}
 	opInfluence := NewTotalOpInfluence(ops, oc.cluster)
 	for storeID := range opInfluence.StoresInfluence {
@@ -995,7 +1125,6 @@ func (oc *Controller) ExceedStoreLimit(ops ...*Operator) bool {
 				return false
 			}
 			if !limiter.Available(stepCost, v, ops[0].GetPriorityLevel()) {
-				OperatorExceededStoreLimitCounter.WithLabelValues(desc).Inc()
 				return true
 			}
 		}
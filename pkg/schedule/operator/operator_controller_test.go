@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -170,6 +171,36 @@ func (suite *operatorControllerTestSuite) TestOperatorStatus() {
 	re.Equal(pdpb.OperatorStatus_SUCCESS, oc.GetOperatorStatus(2).Status)
 }
 
+func (suite *operatorControllerTestSuite) TestOperatorHooks() {
+	re := suite.Require()
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc, false /* no need to run */)
+	oc := NewController(suite.ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddLeaderStore(1, 1)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderRegion(1, 1, 2)
+	var added, finished int
+	newOp := func(regionID uint64) *Operator {
+		op := NewTestOperator(regionID, tc.GetRegion(1).GetRegionEpoch(), OpRegion, RemovePeer{FromStore: 2})
+		op.AddedHooks = append(op.AddedHooks, func() { added++ })
+		op.FinishedHooks = append(op.FinishedHooks, func() { finished++ })
+		return op
+	}
+	// The hooks are not called for the rejected operator.
+	re.False(oc.AddOperator(newOp(2)))
+	re.Zero(added)
+	op := newOp(1)
+	re.True(oc.AddOperator(op))
+	re.Equal(1, added)
+	re.Zero(finished)
+	ApplyOperator(tc, op)
+	oc.Dispatch(tc.GetRegion(1), "test", nil)
+	re.Equal(SUCCESS, op.Status())
+	re.Equal(1, added)
+	re.Equal(1, finished)
+}
+
 func (suite *operatorControllerTestSuite) TestFastFailOperator() {
 	re := suite.Require()
 	opt := mockconfig.NewTestOptions()
@@ -961,6 +992,134 @@ func (suite *operatorControllerTestSuite) TestAddWaitingOperator() {
 	re.Equal(2, controller.AddWaitingOperator(ops...))
 }
 
+func (suite *operatorControllerTestSuite) TestDryRunOperators() {
+	re := suite.Require()
+	opts := mockconfig.NewTestOptions()
+	cluster := mockcluster.NewCluster(suite.ctx, opts)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, cluster, false /* no need to run */)
+	controller := NewController(suite.ctx, cluster.GetBasicCluster(), cluster.GetSharedConfig(), stream)
+	cluster.AddLabelsStore(1, 1, map[string]string{"host": "host1"})
+	cluster.AddLabelsStore(2, 1, map[string]string{"host": "host2"})
+	addPeerOp := func(i uint64) *Operator {
+		region := newRegionInfo(i, fmt.Sprintf("%da", i), fmt.Sprintf("%db", i), 1, 1, []uint64{101, 1}, []uint64{101, 1})
+		cluster.PutRegion(region)
+		op, err := CreateAddPeerOperator("add-peer", cluster, region, &metapb.Peer{StoreId: 2}, OpKind(0))
+		re.NoError(err)
+		return op
+	}
+
+	ops := []*Operator{addPeerOp(1), addPeerOp(2), addPeerOp(1)}
+	results := controller.DryRunOperators(ops...)
+	re.Len(results, 3)
+	re.True(results[0].Accepted)
+	re.Equal(uint64(1), results[0].RegionID)
+	re.NotEmpty(results[0].Steps)
+	re.True(results[1].Accepted)
+	// the region already has an operator in the same dry-run
+	re.False(results[2].Accepted)
+	re.Equal(AlreadyExist, results[2].Reason)
+	// nothing is added, and all operators are canceled
+	re.Empty(controller.GetOperators())
+	re.Empty(controller.GetWaitingOperators())
+	for _, op := range ops {
+		re.Equal(CANCELED, op.Status())
+	}
+
+	// the waiting limit is also checked within the dry-run
+	scheduleCfg := opts.GetScheduleConfig().Clone()
+	scheduleCfg.SchedulerMaxWaitingOperator = 1
+	opts.SetScheduleConfig(scheduleCfg)
+	results = controller.DryRunOperators(addPeerOp(3), addPeerOp(4))
+	re.True(results[0].Accepted)
+	re.False(results[1].Accepted)
+	re.Equal(ExceedWaitLimit, results[1].Reason)
+
+	// an operator of a region which already has an operator is rejected
+	op := addPeerOp(5)
+	re.True(controller.AddOperator(op))
+	counter := operatorCounter.WithLabelValues("add-peer", "already-have")
+	before := testutil.ToFloat64(counter)
+	results = controller.DryRunOperators(addPeerOp(5))
+	re.False(results[0].Accepted)
+	re.Equal(AlreadyExist, results[0].Reason)
+	re.Equal(op, controller.GetOperator(5))
+	// the metrics are not changed by the dry-run
+	re.Equal(before, testutil.ToFloat64(counter))
+	re.False(controller.AddOperator(addPeerOp(5)))
+	re.Equal(before+1, testutil.ToFloat64(counter))
+}
+
+func (suite *operatorControllerTestSuite) TestOperatorFairShare() {
+	re := suite.Require()
+	opt := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, opt)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc, false /* no need to run */)
+	oc := NewController(suite.ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddRegionStore(1, 10)
+	tc.AddRegionStore(2, 10)
+	tc.SetRegionScheduleLimit(4)
+	tc.SetOperatorBudgetWeight("hot", 3)
+	newOp := func(regionID uint64, consumer string) *Operator {
+		tc.AddLeaderRegion(regionID, 1, 2)
+		op := NewTestOperator(regionID, &metapb.RegionEpoch{}, OpRegion, TransferLeader{FromStore: 1, ToStore: 2})
+		op.SetConsumer(consumer)
+		return op
+	}
+
+	// The slots are not shared if fair share is disabled.
+	re.True(oc.AddOperator(newOp(1, "hot")))
+	tc.SetEnableOperatorFairShare(true)
+	// The only active consumer can take all the slots.
+	for id := uint64(2); id <= 4; id++ {
+		re.True(oc.AddOperator(newOp(id, "hot")))
+	}
+	re.Equal(uint64(4), oc.OperatorCount(OpRegion))
+
+	// Once another consumer asks for the slots, they are shared by the weights.
+	oc.RecordConsumerDemand("balance", 0)
+	op := newOp(5, "hot")
+	re.False(oc.AddOperator(op))
+	re.Equal(string(ExceedFairShare), op.GetAdditionalInfo(cancelReason))
+	oc.RemoveOperator(oc.GetOperator(1))
+	re.False(oc.AddOperator(newOp(5, "hot")))
+	re.True(oc.AddOperator(newOp(6, "balance")))
+	re.False(oc.AddOperator(newOp(7, "balance")))
+	// The operators without a consumer are not limited.
+	re.True(oc.AddOperator(newOp(8, "")))
+
+	budgets := oc.GetConsumerBudgets()
+	re.Len(budgets, 2)
+	re.Equal("balance", budgets[0].Consumer)
+	re.Equal(OpRegion.String(), budgets[0].Kind)
+	re.Equal(uint64(1), budgets[0].Running)
+	re.Equal(uint64(1), budgets[0].Share)
+	re.Equal("hot", budgets[1].Consumer)
+	re.Equal(float64(3), budgets[1].Weight)
+	re.Equal(uint64(3), budgets[1].Running)
+	re.Equal(uint64(3), budgets[1].Share)
+	re.Equal(uint64(4), budgets[1].Limit)
+
+	// The metrics of the consumers are removed once they become inactive.
+	countSeries := func() int {
+		return testutil.CollectAndCount(operatorBudgetRunningGauge) + testutil.CollectAndCount(operatorBudgetShareGauge)
+	}
+	re.Equal(4, countSeries())
+	re.True(oc.RemoveOperator(oc.GetOperator(6)))
+	// The demand of balance has not expired yet.
+	re.Equal(4, countSeries())
+	oc.budget.gc(time.Now().Add(consumerDemandTTL))
+	re.Equal(2, countSeries())
+	re.Len(oc.GetConsumerBudgets(), 1)
+	// The removed consumer is cleaned up once its operators finish.
+	oc.RemoveConsumer("hot")
+	re.Equal(2, countSeries())
+	for _, id := range []uint64{2, 3, 4} {
+		re.True(oc.RemoveOperator(oc.GetOperator(id)))
+	}
+	re.Equal(0, countSeries())
+	re.Empty(oc.GetConsumerBudgets())
+}
+
 // issue #5279
 func (suite *operatorControllerTestSuite) TestInvalidStoreId() {
 	re := suite.Require()
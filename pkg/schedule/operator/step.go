@@ -69,6 +69,9 @@ type TransferLeader struct {
 	FromStore, ToStore uint64
 	// Multi-target transfer leader.
 	ToStores []uint64
+	// Load is the query rate of the leader rounded up to an integer, it is only
+	// set by the schedulers balancing the leaders by load.
+	Load int64
 }
 
 // ConfVerChanged returns the delta value for version increased by this step.
@@ -119,8 +122,10 @@ func (tl TransferLeader) Influence(opInfluence OpInfluence, region *core.RegionI
 
 	from.LeaderSize -= region.GetApproximateSize()
 	from.LeaderCount--
+	from.LeaderLoad -= tl.Load
 	to.LeaderSize += region.GetApproximateSize()
 	to.LeaderCount++
+	to.LeaderLoad += tl.Load
 }
 
 // Timeout returns duration that current step may take.
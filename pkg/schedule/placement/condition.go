@@ -0,0 +1,213 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// ruleConditionCoolDown is how long a met load condition is kept, which avoids
+// adding and removing peers repeatedly when the load fluctuates.
+const ruleConditionCoolDown = 5 * time.Minute
+
+// RuleCondition is the condition for a rule to apply. A rule whose condition is
+// not met is skipped when fitting regions, so the peers placed by it become
+// orphan peers and are removed by the rule checker. Only the learner rules can
+// have conditions, so a range never loses its leader or voters.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type RuleCondition struct {
+	// TimeWindows are the cron-like time windows in which the rule applies.
+	TimeWindows config.TimeWindows `json:"time_windows,omitempty"`
+	// Load is met when the load of the region from the hot statistics reaches
+	// the threshold. It is evaluated by the rule checker.
+	Load *LoadCondition `json:"load,omitempty"`
+}
+
+// LoadCondition is the load threshold of a region.
+type LoadCondition struct {
+	Type      string  `json:"type"`      // "read" or "write"
+	Dim       string  `json:"dim"`       // "byte", "key" or "query"
+	Threshold float64 `json:"threshold"` // the rate per second
+}
+
+func (c *RuleCondition) validate() error {
+	if len(c.TimeWindows) == 0 && c.Load == nil {
+		return errs.ErrRuleContent.FastGenByArgs("condition should have a time window or a load threshold")
+	}
+	if err := c.TimeWindows.Validate(); err != nil {
+		return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid time window of condition: %v", err))
+	}
+	if c.Load != nil {
+		if c.Load.Type != utils.Read.String() && c.Load.Type != utils.Write.String() {
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid load type %s of condition", c.Load.Type))
+		}
+		switch c.Load.Dim {
+		case utils.BytePriority, utils.KeyPriority, utils.QueryPriority:
+		default:
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid load dim %s of condition", c.Load.Dim))
+		}
+		if c.Load.Threshold <= 0 {
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid load threshold %v of condition", c.Load.Threshold))
+		}
+	}
+	return nil
+}
+
+// inTimeWindow returns whether the time is in any of the time windows, it is
+// always true if there is no time window.
+func (c *RuleCondition) inTimeWindow(now time.Time) bool {
+	return c.TimeWindows.IsActive(now)
+}
+
+func (c *LoadCondition) rwType() utils.RWType {
+	if c.Type == utils.Write.String() {
+		return utils.Write
+	}
+	return utils.Read
+}
+
+// RegionLoadFunc returns the load of a region in the dim of the read or write
+// statistics.
+type RegionLoadFunc func(rw utils.RWType, dim int) float64
+
+// ruleLoadStates records the last time the load conditions are met for the
+// regions, which is updated by the rule checker.
+type ruleLoadStates struct {
+	syncutil.RWMutex
+	lastMet   map[[2]string]map[uint64]time.Time // {group, id} => region id => time
+	lastSweep time.Time
+}
+
+func newRuleLoadStates() *ruleLoadStates {
+	return &ruleLoadStates{lastMet: make(map[[2]string]map[uint64]time.Time)}
+}
+
+func (s *ruleLoadStates) isMetLocked(key [2]string, regionID uint64, now time.Time) bool {
+	t, ok := s.lastMet[key][regionID]
+	return ok && now.Sub(t) < ruleConditionCoolDown
+}
+
+// isActive returns whether the condition of the rule is met for the region.
+func (s *ruleLoadStates) isActive(r *Rule, regionID uint64, now time.Time) bool {
+	if r.Condition == nil {
+		return true
+	}
+	if !r.Condition.inTimeWindow(now) {
+		return false
+	}
+	if r.Condition.Load == nil {
+		return true
+	}
+	s.RLock()
+	defer s.RUnlock()
+	return s.isMetLocked(r.Key(), regionID, now)
+}
+
+// filterActiveRules returns the rules whose conditions are met for the region.
+// The rules are returned directly if none of them has a condition.
+func (s *ruleLoadStates) filterActiveRules(regionID uint64, rules []*Rule, now time.Time) []*Rule {
+	hasCondition := false
+	for _, r := range rules {
+		if r.Condition != nil {
+			hasCondition = true
+			break
+		}
+	}
+	if !hasCondition {
+		return rules
+	}
+	active := make([]*Rule, 0, len(rules))
+	for _, r := range rules {
+		if s.isActive(r, regionID, now) {
+			active = append(active, r)
+		}
+	}
+	return active
+}
+
+// update records whether the load condition is met, and returns whether the
+// state is changed. A met state is kept until it expires, and the expired state
+// is removed by the next update of the region.
+func (s *ruleLoadStates) update(key [2]string, regionID uint64, met bool, now time.Time) bool {
+	s.Lock()
+	defer s.Unlock()
+	_, existed := s.lastMet[key][regionID]
+	changed := false
+	if met {
+		if s.lastMet[key] == nil {
+			s.lastMet[key] = make(map[uint64]time.Time)
+		}
+		s.lastMet[key][regionID] = now
+		changed = !existed
+	} else if existed && !s.isMetLocked(key, regionID, now) {
+		delete(s.lastMet[key], regionID)
+		changed = true
+	}
+	if now.Sub(s.lastSweep) >= ruleConditionCoolDown {
+		s.sweepLocked(now)
+	}
+	return changed
+}
+
+// sweepLocked removes the states which are expired for a long time, such as the
+// states of merged regions.
+func (s *ruleLoadStates) sweepLocked(now time.Time) {
+	for key, regions := range s.lastMet {
+		for regionID, t := range regions {
+			if now.Sub(t) >= 2*ruleConditionCoolDown {
+				delete(regions, regionID)
+			}
+		}
+		if len(regions) == 0 {
+			delete(s.lastMet, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// reset removes the states of the rule when it is changed or deleted.
+func (s *ruleLoadStates) reset(key [2]string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.lastMet, key)
+}
+
+// UpdateLoadConditions evaluates the load conditions of the rules applied to
+// the region, and returns whether any rule is activated or deactivated.
+func (m *RuleManager) UpdateLoadConditions(region *core.RegionInfo, load RegionLoadFunc) bool {
+	m.RLock()
+	rules := m.ruleList.getRulesForApplyRange(region.GetStartKey(), region.GetEndKey())
+	m.RUnlock()
+	now := time.Now()
+	changed := false
+	for _, r := range rules {
+		if r.Condition == nil || r.Condition.Load == nil {
+			continue
+		}
+		c := r.Condition.Load
+		met := load(c.rwType(), utils.StringToDim(c.Dim)) >= c.Threshold
+		if m.loadStates.update(r.Key(), region.GetID(), met, now) {
+			changed = true
+		}
+	}
+	return changed
+}
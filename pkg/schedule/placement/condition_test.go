@@ -0,0 +1,150 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+func TestRuleConditionValidate(t *testing.T) {
+	re := require.New(t)
+	_, manager := newTestManager(t, false)
+	newRule := func(c *RuleCondition) *Rule {
+		return &Rule{GroupID: "g", ID: "hot", Role: Learner, Count: 2, Condition: c}
+	}
+	windows := config.TimeWindows{{Cron: "0 8 * * *", Duration: typeutil.NewDuration(12 * time.Hour), TimeZone: "UTC"}}
+	testCases := []struct {
+		condition *RuleCondition
+		valid     bool
+	}{
+		{&RuleCondition{}, false},
+		{&RuleCondition{TimeWindows: config.TimeWindows{{Cron: "0 8 * *", Duration: typeutil.NewDuration(time.Hour)}}}, false},
+		{&RuleCondition{TimeWindows: config.TimeWindows{{Cron: "0 8 * * *"}}}, false},
+		{&RuleCondition{TimeWindows: config.TimeWindows{{Cron: "0 20 * * *", Duration: typeutil.NewDuration(12 * time.Hour)}}}, true},
+		{&RuleCondition{Load: &LoadCondition{Type: "read", Dim: "byte"}}, false},
+		{&RuleCondition{Load: &LoadCondition{Type: "read", Dim: "cpu", Threshold: 1}}, false},
+		{&RuleCondition{Load: &LoadCondition{Type: "scan", Dim: "byte", Threshold: 1}}, false},
+		{&RuleCondition{Load: &LoadCondition{Type: "write", Dim: "query", Threshold: 1}}, true},
+		{&RuleCondition{TimeWindows: windows, Load: &LoadCondition{Type: "read", Dim: "key", Threshold: 1}}, true},
+	}
+	for _, tc := range testCases {
+		err := manager.AdjustRule(newRule(tc.condition), "")
+		if tc.valid {
+			re.NoError(err)
+		} else {
+			re.True(errs.ErrRuleContent.Equal(err))
+		}
+	}
+	r := newRule(&RuleCondition{TimeWindows: windows})
+	r.Override = true
+	re.True(errs.ErrRuleContent.Equal(manager.AdjustRule(r, "")))
+	// Only the learner rules can have conditions.
+	for _, role := range []PeerRoleType{Voter, Leader, Follower} {
+		r = newRule(&RuleCondition{TimeWindows: windows})
+		r.Role = role
+		re.True(errs.ErrRuleContent.Equal(manager.AdjustRule(r, "")))
+	}
+}
+
+func TestRuleConditionTimeWindow(t *testing.T) {
+	re := require.New(t)
+	at := func(hour, minute int) time.Time { return time.Date(2025, 1, 1, hour, minute, 0, 0, time.UTC) }
+	c := &RuleCondition{TimeWindows: config.TimeWindows{
+		{Cron: "0 8 * * *", Duration: typeutil.NewDuration(12*time.Hour + 30*time.Minute), TimeZone: "UTC"},
+	}}
+	re.False(c.inTimeWindow(at(7, 59)))
+	re.True(c.inTimeWindow(at(8, 0)))
+	re.True(c.inTimeWindow(at(20, 29)))
+	re.False(c.inTimeWindow(at(20, 30)))
+	// The window crosses midnight, and it is matched in its own time zone.
+	c = &RuleCondition{TimeWindows: config.TimeWindows{
+		{Cron: "0 6 * * *", Duration: typeutil.NewDuration(8 * time.Hour), TimeZone: "Asia/Shanghai"},
+	}}
+	re.True(c.inTimeWindow(at(23, 0)))
+	re.True(c.inTimeWindow(at(5, 59)))
+	re.False(c.inTimeWindow(at(6, 0)))
+	re.False(c.inTimeWindow(at(12, 0)))
+	re.True((&RuleCondition{Load: &LoadCondition{}}).inTimeWindow(at(12, 0)))
+}
+
+func TestRuleLoadStates(t *testing.T) {
+	re := require.New(t)
+	s := newRuleLoadStates()
+	now := time.Now()
+	rule := &Rule{GroupID: "g", ID: "hot", Condition: &RuleCondition{Load: &LoadCondition{Type: "read", Dim: "byte", Threshold: 100}}}
+	plain := &Rule{GroupID: "g", ID: "plain"}
+	rules := []*Rule{plain, rule}
+	re.Equal([]*Rule{plain}, s.filterActiveRules(1, rules, now))
+	re.Equal([]*Rule{plain}, s.filterActiveRules(1, []*Rule{plain}, now))
+
+	re.False(s.update(rule.Key(), 1, false, now))
+	re.True(s.update(rule.Key(), 1, true, now))
+	re.False(s.update(rule.Key(), 1, true, now))
+	re.Equal(rules, s.filterActiveRules(1, rules, now))
+	re.Equal([]*Rule{plain}, s.filterActiveRules(2, rules, now))
+	// The condition is kept during the cool down after the load drops.
+	now = now.Add(ruleConditionCoolDown / 2)
+	re.False(s.update(rule.Key(), 1, false, now))
+	re.Equal(rules, s.filterActiveRules(1, rules, now))
+	now = now.Add(ruleConditionCoolDown / 2)
+	re.True(s.update(rule.Key(), 1, false, now))
+	re.Equal([]*Rule{plain}, s.filterActiveRules(1, rules, now))
+	// The expired states are swept.
+	re.True(s.update(rule.Key(), 2, true, now))
+	re.False(s.update(rule.Key(), 3, false, now.Add(2*ruleConditionCoolDown)))
+	re.Empty(s.lastMet)
+	// The states are reset when the rule is changed.
+	re.True(s.update(rule.Key(), 1, true, now))
+	s.reset(rule.Key())
+	re.Equal([]*Rule{plain}, s.filterActiveRules(1, rules, now))
+}
+
+func TestUpdateLoadConditions(t *testing.T) {
+	re := require.New(t)
+	_, manager := newTestManager(t, false)
+	re.NoError(manager.SetRule(&Rule{
+		GroupID: "pd", ID: "hot-learners", Role: Learner, Count: 2,
+		Condition: &RuleCondition{Load: &LoadCondition{Type: "read", Dim: "byte", Threshold: 100}},
+	}))
+	region := core.NewRegionInfo(&metapb.Region{Id: 1}, nil)
+	var load float64
+	loadFunc := func(rw utils.RWType, dim int) float64 {
+		re.Equal(utils.Read, rw)
+		re.Equal(utils.ByteDim, dim)
+		return load
+	}
+	re.Len(manager.GetRulesForApplyRegion(region), 1)
+	re.False(manager.UpdateLoadConditions(region, loadFunc))
+	load = 100
+	re.True(manager.UpdateLoadConditions(region, loadFunc))
+	re.Len(manager.GetRulesForApplyRegion(region), 2)
+	// The state is reset after the rule is updated.
+	re.NoError(manager.SetRule(&Rule{
+		GroupID: "pd", ID: "hot-learners", Role: Learner, Count: 1,
+		Condition: &RuleCondition{Load: &LoadCondition{Type: "read", Dim: "byte", Threshold: 100}},
+	}))
+	re.Len(manager.GetRulesForApplyRegion(region), 1)
+}
@@ -24,6 +24,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -60,6 +61,11 @@ type RuleManager struct {
 	initialized bool
 	ruleConfig  *ruleConfig
 	ruleList    ruleList
+	// templates and templateInstances are only loaded when the rules are loaded,
+	// the rendered rules are saved as the normal rules.
+	templates         map[string]*RuleTemplate         // id => RuleTemplate
+	templateInstances map[string]*RuleTemplateInstance // group id => RuleTemplateInstance
+	loadStates        *ruleLoadStates
 
 	// used for rule validation
 	keyType          string
@@ -71,12 +77,15 @@ type RuleManager struct {
 // NewRuleManager creates a RuleManager instance.
 func NewRuleManager(ctx context.Context, storage endpoint.RuleStorage, storeSetInformer core.StoreSetInformer, conf config.SharedConfigProvider) *RuleManager {
 	return &RuleManager{
-		ctx:              ctx,
-		storage:          storage,
-		storeSetInformer: storeSetInformer,
-		conf:             conf,
-		ruleConfig:       newRuleConfig(),
-		cache:            NewRegionRuleFitCacheManager(),
+		ctx:               ctx,
+		storage:           storage,
+		storeSetInformer:  storeSetInformer,
+		conf:              conf,
+		ruleConfig:        newRuleConfig(),
+		templates:         make(map[string]*RuleTemplate),
+		templateInstances: make(map[string]*RuleTemplateInstance),
+		loadStates:        newRuleLoadStates(),
+		cache:             NewRegionRuleFitCacheManager(),
 	}
 }
 
@@ -104,6 +113,9 @@ func (m *RuleManager) Initialize(maxReplica int, locationLabels []string, isolat
 	if err := m.loadGroups(); err != nil {
 		return err
 	}
+	if err := m.loadTemplates(); err != nil {
+		return err
+	}
 	if len(m.ruleConfig.rules) == 0 {
 		// migrate from old config.
 		var defaultRules []*Rule
@@ -160,6 +172,12 @@ func (m *RuleManager) Initialize(maxReplica int, locationLabels []string, isolat
 		return err
 	}
 	m.ruleList = ruleList
+	// Resume the rendering of the templates which is stopped by errors.
+	for _, t := range m.templates {
+		if _, err := m.renderTemplateInstancesLocked(t); err != nil {
+			return err
+		}
+	}
 	m.initialized = true
 	return nil
 }
@@ -279,6 +297,19 @@ func (m *RuleManager) AdjustRule(r *Rule, groupID string) (err error) {
 	if r.IsWitness && r.Count > m.conf.GetMaxReplicas()/2 {
 		return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("define too many witness by count %d", r.Count))
 	}
+	if r.Condition != nil {
+		// The leader and voter rules are always applied, otherwise a range may
+		// have no leader or voter when the conditions are not met.
+		if r.Role != Learner {
+			return errs.ErrRuleContent.FastGenByArgs("only learner rule can have condition")
+		}
+		if r.Override {
+			return errs.ErrRuleContent.FastGenByArgs("rule with condition can not override other rules")
+		}
+		if err := r.Condition.validate(); err != nil {
+			return err
+		}
+	}
 	for _, c := range r.LabelConstraints {
 		if !validateOp(c.Op) {
 			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid op %s", c.Op))
@@ -322,6 +353,9 @@ func (m *RuleManager) SetRule(rule *Rule) error {
 	defer m.Unlock()
 	p := m.BeginPatch()
 	p.SetRule(rule)
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
@@ -335,6 +369,9 @@ func (m *RuleManager) DeleteRule(group, id string) error {
 	defer m.Unlock()
 	p := m.BeginPatch()
 	p.DeleteRule(group, id)
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
@@ -407,10 +444,12 @@ func (m *RuleManager) GetRulesByKey(key []byte) []*Rule {
 }
 
 // GetRulesForApplyRegion returns the rules list that should be applied to a region.
+// The rules whose conditions are not met are skipped.
 func (m *RuleManager) GetRulesForApplyRegion(region *core.RegionInfo) []*Rule {
 	m.RLock()
 	defer m.RUnlock()
-	return m.ruleList.getRulesForApplyRange(region.GetStartKey(), region.GetEndKey())
+	rules := m.ruleList.getRulesForApplyRange(region.GetStartKey(), region.GetEndKey())
+	return m.loadStates.filterActiveRules(region.GetID(), rules, time.Now())
 }
 
 // GetRulesForApplyRange returns the rules list that should be applied to a range.
@@ -482,6 +521,12 @@ func (m *RuleManager) BeginPatch() *RuleConfigPatch {
 
 // TryCommitPatchLocked tries to commit a patch.
 func (m *RuleManager) TryCommitPatchLocked(patch *RuleConfigPatch) error {
+	return m.tryCommitPatchLocked(patch)
+}
+
+// tryCommitPatchLocked tries to commit a patch, the extra operations are saved
+// with the patch.
+func (m *RuleManager) tryCommitPatchLocked(patch *RuleConfigPatch, ops ...func(kv.Txn) error) error {
 	patch.adjust()
 
 	ruleList, err := buildRuleList(patch)
@@ -492,18 +537,21 @@ func (m *RuleManager) TryCommitPatchLocked(patch *RuleConfigPatch) error {
 	patch.trim()
 
 	// save updates
-	err = m.savePatch(patch.mut)
+	err = m.savePatch(patch.mut, ops...)
 	if err != nil {
 		return err
 	}
 
 	// update in-memory state
+	for key := range patch.mut.rules {
+		m.loadStates.reset(key)
+	}
 	patch.commit()
 	m.ruleList = ruleList
 	return nil
 }
 
-func (m *RuleManager) savePatch(p *ruleConfig) error {
+func (m *RuleManager) savePatch(p *ruleConfig, ops ...func(kv.Txn) error) error {
 	var batch []func(kv.Txn) error
 	// add rules to batch
 	for key, r := range p.rules {
@@ -532,6 +580,7 @@ func (m *RuleManager) savePatch(p *ruleConfig) error {
 			})
 		}
 	}
+	batch = append(batch, ops...)
 	return endpoint.RunBatchOpInTxn(m.ctx, m.storage, batch)
 }
 
@@ -546,6 +595,9 @@ func (m *RuleManager) SetRules(rules []*Rule) error {
 		}
 		p.SetRule(r)
 	}
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
@@ -591,6 +643,21 @@ func (m *RuleManager) Batch(todo []RuleOp) error {
 	m.Lock()
 	defer m.Unlock()
 
+	patch := m.batchPatchLocked(todo)
+	if err := m.checkTemplateGroupsLocked(patch); err != nil {
+		return err
+	}
+	if err := m.TryCommitPatchLocked(patch); err != nil {
+		return err
+	}
+
+	log.Info("placement rules updated", zap.String("batch", fmt.Sprint(todo)))
+	return nil
+}
+
+// batchPatchLocked returns the patch of the actions, the rules to add should
+// have been adjusted.
+func (m *RuleManager) batchPatchLocked(todo []RuleOp) *RuleConfigPatch {
 	patch := m.BeginPatch()
 	for _, t := range todo {
 		switch t.Action {
@@ -608,13 +675,7 @@ func (m *RuleManager) Batch(todo []RuleOp) error {
 			}
 		}
 	}
-
-	if err := m.TryCommitPatchLocked(patch); err != nil {
-		return err
-	}
-
-	log.Info("placement rules updated", zap.String("batch", fmt.Sprint(todo)))
-	return nil
+	return patch
 }
 
 // GetRuleGroup returns a RuleGroup configuration.
@@ -645,6 +706,9 @@ func (m *RuleManager) SetRuleGroup(group *RuleGroup) error {
 	defer m.Unlock()
 	p := m.BeginPatch()
 	p.SetGroup(group)
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
@@ -658,6 +722,9 @@ func (m *RuleManager) DeleteRuleGroup(id string) error {
 	defer m.Unlock()
 	p := m.BeginPatch()
 	p.DeleteGroup(id)
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
@@ -716,6 +783,23 @@ func (m *RuleManager) GetGroupBundle(id string) (b GroupBundle) {
 func (m *RuleManager) SetAllGroupBundles(groups []GroupBundle, override bool) error {
 	m.Lock()
 	defer m.Unlock()
+	p, err := m.groupBundlesPatchLocked(groups, override)
+	if err != nil {
+		return err
+	}
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
+	if err := m.TryCommitPatchLocked(p); err != nil {
+		return err
+	}
+	log.Info("full config reset", zap.String("config", fmt.Sprint(groups)))
+	return nil
+}
+
+// groupBundlesPatchLocked returns the patch to reset the groups. If override is
+// true, all old configurations are dropped.
+func (m *RuleManager) groupBundlesPatchLocked(groups []GroupBundle, override bool) (*RuleConfigPatch, error) {
 	p := m.BeginPatch()
 	matchID := func(a string) bool {
 		for _, g := range groups {
@@ -743,16 +827,12 @@ func (m *RuleManager) SetAllGroupBundles(groups []GroupBundle, override bool) er
 		})
 		for _, r := range g.Rules {
 			if err := m.AdjustRule(r, g.ID); err != nil {
-				return err
+				return nil, err
 			}
 			p.SetRule(r)
 		}
 	}
-	if err := m.TryCommitPatchLocked(p); err != nil {
-		return err
-	}
-	log.Info("full config reset", zap.String("config", fmt.Sprint(groups)))
-	return nil
+	return p, nil
 }
 
 // SetGroupBundle resets a Group and all rules belong to it. All old rules
@@ -779,6 +859,9 @@ func (m *RuleManager) SetGroupBundle(group GroupBundle) error {
 		}
 		p.SetRule(r)
 	}
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
@@ -811,6 +894,9 @@ func (m *RuleManager) DeleteGroupBundle(id string, regex bool) error {
 			p.DeleteGroup(g.ID)
 		}
 	}
+	if err := m.checkTemplateGroupsLocked(p); err != nil {
+		return err
+	}
 	if err := m.TryCommitPatchLocked(p); err != nil {
 		return err
 	}
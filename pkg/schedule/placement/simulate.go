@@ -0,0 +1,341 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// RuleChange is a proposed change of the placement rules. It is made in the same
+// way as SetRules, Batch or SetAllGroupBundles according to the field which is set,
+// and only one of them should be set.
+type RuleChange struct {
+	Rules   []*Rule       `json:"rules,omitempty"`
+	Batch   []RuleOp      `json:"batch,omitempty"`
+	Bundles []GroupBundle `json:"bundles,omitempty"`
+	// Override drops all the old configurations when the bundles are set.
+	Override bool `json:"override,omitempty"`
+}
+
+// RegionScanner scans the regions in a key range.
+type RegionScanner interface {
+	ScanRegions(startKey, endKey []byte, limit int) []*core.RegionInfo
+}
+
+// SimulationResult is the impact of a rule change on the current regions. The
+// regions in the affected ranges are fitted against the proposed rules, and the
+// peers to be added are assigned to the matched stores with the least region
+// size, which is an estimation of what the rule checker does.
+type SimulationResult struct {
+	AffectedRanges       []core.KeyRange      `json:"affected-ranges"`
+	RegionCount          int                  `json:"region-count"`
+	RegionsToAddPeers    int                  `json:"regions-to-add-peers"`
+	RegionsToRemovePeers int                  `json:"regions-to-remove-peers"`
+	RegionsToChangeRoles int                  `json:"regions-to-change-roles"`
+	PeersToAdd           int                  `json:"peers-to-add"`
+	PeersToRemove        int                  `json:"peers-to-remove"`
+	Stores               []*StoreImpact       `json:"stores"`
+	UnsatisfiableRules   []*UnsatisfiableRule `json:"unsatisfiable-rules"`
+}
+
+// StoreImpact is the data a store would gain or lose after a rule change, the
+// sizes are in MB.
+type StoreImpact struct {
+	StoreID       uint64 `json:"store-id"`
+	PeersToAdd    int    `json:"peers-to-add"`
+	PeersToRemove int    `json:"peers-to-remove"`
+	SizeToAdd     int64  `json:"size-to-add"`
+	SizeToRemove  int64  `json:"size-to-remove"`
+}
+
+// UnsatisfiableRule is a proposed rule which can not be satisfied by the labels
+// of the current stores.
+type UnsatisfiableRule struct {
+	GroupID string `json:"group-id"`
+	ID      string `json:"id"`
+	Reason  string `json:"reason"`
+}
+
+// Simulate fits the regions affected by the change against the proposed rules
+// without applying the change.
+func (m *RuleManager) Simulate(change *RuleChange, scanner RegionScanner) (*SimulationResult, error) {
+	m.RLock()
+	patch, err := m.changePatchLocked(change)
+	if err != nil {
+		m.RUnlock()
+		return nil, err
+	}
+	if err := m.checkTemplateGroupsLocked(patch); err != nil {
+		m.RUnlock()
+		return nil, err
+	}
+	proposed, err := buildProposedConfig(patch)
+	if err != nil {
+		m.RUnlock()
+		return nil, err
+	}
+	newList, err := buildRuleList(proposed)
+	if err != nil {
+		m.RUnlock()
+		return nil, err
+	}
+	ranges, changedRules := getChangedRules(patch, proposed)
+	m.RUnlock()
+
+	stores := m.storeSetInformer.GetStores()
+	result := &SimulationResult{
+		AffectedRanges:     ranges,
+		Stores:             make([]*StoreImpact, 0),
+		UnsatisfiableRules: make([]*UnsatisfiableRule, 0),
+	}
+	for _, r := range changedRules {
+		if reason := checkRuleSatisfiable(r, stores); len(reason) > 0 {
+			result.UnsatisfiableRules = append(result.UnsatisfiableRules, &UnsatisfiableRule{GroupID: r.GroupID, ID: r.ID, Reason: reason})
+		}
+	}
+
+	impacts := make(map[uint64]*StoreImpact)
+	getImpact := func(storeID uint64) *StoreImpact {
+		impact, ok := impacts[storeID]
+		if !ok {
+			impact = &StoreImpact{StoreID: storeID}
+			impacts[storeID] = impact
+		}
+		return impact
+	}
+	visited := make(map[uint64]struct{})
+	for _, kr := range ranges {
+		for _, region := range scanner.ScanRegions(kr.StartKey, kr.EndKey, 0) {
+			if _, ok := visited[region.GetID()]; ok {
+				continue
+			}
+			visited[region.GetID()] = struct{}{}
+			result.RegionCount++
+			rules := newList.getRulesForApplyRange(region.GetStartKey(), region.GetEndKey())
+			fit := fitRegion(getStoresByRegion(m.storeSetInformer, region), region, rules, m.conf.IsWitnessAllowed())
+			size := region.GetApproximateSize()
+			if len(fit.OrphanPeers) > 0 {
+				result.RegionsToRemovePeers++
+				for _, p := range fit.OrphanPeers {
+					impact := getImpact(p.GetStoreId())
+					impact.PeersToRemove++
+					impact.SizeToRemove += size
+					result.PeersToRemove++
+				}
+			}
+			var toAdd, toChangeRole bool
+			excluded := region.GetStoreIDs()
+			for _, rf := range fit.RuleFits {
+				if len(rf.PeersWithDifferentRole) > 0 {
+					toChangeRole = true
+				}
+				for range rf.Rule.Count - len(rf.Peers) {
+					toAdd = true
+					result.PeersToAdd++
+					target := pickSimulatedTarget(rf.Rule, stores, excluded, impacts)
+					if target == nil {
+						continue
+					}
+					excluded[target.GetID()] = struct{}{}
+					impact := getImpact(target.GetID())
+					impact.PeersToAdd++
+					impact.SizeToAdd += size
+				}
+			}
+			if toAdd {
+				result.RegionsToAddPeers++
+			}
+			if toChangeRole {
+				result.RegionsToChangeRoles++
+			}
+		}
+	}
+	for _, impact := range impacts {
+		result.Stores = append(result.Stores, impact)
+	}
+	sort.Slice(result.Stores, func(i, j int) bool { return result.Stores[i].StoreID < result.Stores[j].StoreID })
+	return result, nil
+}
+
+// changePatchLocked returns the patch of the change in the same way as the
+// change is applied.
+func (m *RuleManager) changePatchLocked(change *RuleChange) (*RuleConfigPatch, error) {
+	set := 0
+	for _, ok := range []bool{len(change.Rules) > 0, len(change.Batch) > 0, len(change.Bundles) > 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 && !(set == 0 && change.Override) {
+		return nil, errs.ErrRuleContent.FastGenByArgs("one and only one of rules, batch and bundles should be set")
+	}
+	switch {
+	case len(change.Rules) > 0:
+		p := m.BeginPatch()
+		for _, r := range change.Rules {
+			if err := m.AdjustRule(r, ""); err != nil {
+				return nil, err
+			}
+			p.SetRule(r)
+		}
+		return p, nil
+	case len(change.Batch) > 0:
+		for _, t := range change.Batch {
+			if t.Action == RuleOpAdd {
+				if err := m.AdjustRule(t.Rule, ""); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return m.batchPatchLocked(change.Batch), nil
+	default:
+		return m.groupBundlesPatchLocked(change.Bundles, change.Override)
+	}
+}
+
+// buildProposedConfig returns the config after the patch is applied. The rules
+// are cloned, so the current config is not changed.
+func buildProposedConfig(patch *RuleConfigPatch) (*ruleConfig, error) {
+	c := newRuleConfig()
+	patch.iterateRules(func(r *Rule) { c.setRule(r.Clone()) })
+	for id, g := range patch.c.groups {
+		c.groups[id] = g
+	}
+	for id, g := range patch.mut.groups {
+		c.groups[id] = g
+	}
+	c.adjust()
+	if len(c.rules) == 0 {
+		return nil, errs.ErrBuildRuleList.FastGenByArgs("no rule left")
+	}
+	return c, nil
+}
+
+// getChangedRules returns the merged key ranges of the rules which are changed,
+// including the rules of the changed groups, and the changed rules in the proposed
+// config.
+func getChangedRules(patch *RuleConfigPatch, proposed *ruleConfig) ([]core.KeyRange, []*Rule) {
+	patch.trim()
+	var (
+		ranges  []core.KeyRange
+		changed []*Rule
+		seen    = make(map[[2]string]struct{})
+	)
+	addRule := func(r *Rule, isProposed bool) {
+		if r == nil {
+			return
+		}
+		ranges = append(ranges, core.KeyRange{StartKey: r.StartKey, EndKey: r.EndKey})
+		if _, ok := seen[r.Key()]; isProposed && !ok {
+			seen[r.Key()] = struct{}{}
+			changed = append(changed, r)
+		}
+	}
+	for key := range patch.mut.rules {
+		addRule(patch.c.getRule(key), false)
+		addRule(proposed.getRule(key), true)
+	}
+	for id := range patch.mut.groups {
+		for _, r := range patch.c.rules {
+			if r.GroupID == id {
+				addRule(r, false)
+			}
+		}
+		for _, r := range proposed.rules {
+			if r.GroupID == id {
+				addRule(r, true)
+			}
+		}
+	}
+	sortRules(changed)
+	return mergeKeyRanges(ranges), changed
+}
+
+// mergeKeyRanges merges the overlapped or adjacent ranges, the empty end key
+// means the end of the key space.
+func mergeKeyRanges(ranges []core.KeyRange) []core.KeyRange {
+	sort.Slice(ranges, func(i, j int) bool { return bytes.Compare(ranges[i].StartKey, ranges[j].StartKey) < 0 })
+	merged := make([]core.KeyRange, 0, len(ranges))
+	for _, kr := range ranges {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if len(last.EndKey) == 0 {
+				continue
+			}
+			if bytes.Compare(kr.StartKey, last.EndKey) <= 0 {
+				if len(kr.EndKey) == 0 || bytes.Compare(kr.EndKey, last.EndKey) > 0 {
+					last.EndKey = kr.EndKey
+				}
+				continue
+			}
+		}
+		merged = append(merged, kr)
+	}
+	return merged
+}
+
+// checkRuleSatisfiable returns the reason if the rule can not be satisfied by
+// the stores which are not being removed.
+func checkRuleSatisfiable(rule *Rule, stores []*core.StoreInfo) string {
+	matched := 0
+	isolationValues := make(map[string]struct{})
+	for _, store := range stores {
+		if store.IsRemoving() || store.IsRemoved() || !MatchLabelConstraints(store, rule.LabelConstraints) {
+			continue
+		}
+		matched++
+		if len(rule.IsolationLevel) > 0 {
+			isolationValues[store.GetLabelValue(rule.IsolationLevel)] = struct{}{}
+		}
+	}
+	if matched < rule.Count {
+		return fmt.Sprintf("%d stores match the label constraints, but the count is %d", matched, rule.Count)
+	}
+	if len(rule.IsolationLevel) > 0 && len(isolationValues) < rule.Count {
+		return fmt.Sprintf("%d values of the isolation label %s are found in the matched stores, but the count is %d",
+			len(isolationValues), rule.IsolationLevel, rule.Count)
+	}
+	return ""
+}
+
+// pickSimulatedTarget picks the up store with the least region size, including
+// the size to add in the simulation, from the stores matching the rule.
+func pickSimulatedTarget(rule *Rule, stores []*core.StoreInfo, excluded map[uint64]struct{}, impacts map[uint64]*StoreImpact) *core.StoreInfo {
+	var (
+		target     *core.StoreInfo
+		targetSize int64
+	)
+	for _, store := range stores {
+		if _, ok := excluded[store.GetID()]; ok {
+			continue
+		}
+		if !store.IsUp() || !MatchLabelConstraints(store, rule.LabelConstraints) {
+			continue
+		}
+		size := store.GetRegionSize()
+		if impact, ok := impacts[store.GetID()]; ok {
+			size += impact.SizeToAdd - impact.SizeToRemove
+		}
+		if target == nil || size < targetSize {
+			target, targetSize = store, size
+		}
+	}
+	return target
+}
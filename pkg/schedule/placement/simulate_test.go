@@ -0,0 +1,122 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+func TestSimulate(t *testing.T) {
+	re := require.New(t)
+	cluster := core.NewBasicCluster()
+	for id := uint64(1); id <= 6; id++ {
+		labels := map[string]string{"zone": fmt.Sprintf("z%d", (id+1)/2)}
+		if id == 6 {
+			labels["disk"] = "ssd"
+		}
+		cluster.PutStore(core.NewStoreInfoWithLabel(id, labels))
+	}
+	// The regions [a, b), [b, c), [c, d) and [d, "") have peers on the stores 1, 3 and 5.
+	keys := []string{"a", "b", "c", "d", ""}
+	for i := range 4 {
+		peers := []*metapb.Peer{{Id: uint64(i*10 + 1), StoreId: 1}, {Id: uint64(i*10 + 2), StoreId: 3}, {Id: uint64(i*10 + 3), StoreId: 5}}
+		region := core.NewRegionInfo(&metapb.Region{
+			Id:       uint64(i + 1),
+			StartKey: []byte(keys[i]),
+			EndKey:   []byte(keys[i+1]),
+			Peers:    peers,
+		}, peers[0], core.SetApproximateSize(10))
+		cluster.PutRegion(region)
+	}
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	manager := NewRuleManager(context.Background(), storage, cluster, mockconfig.NewTestOptions())
+	re.NoError(manager.Initialize(3, []string{"zone"}, "", false))
+	hexKey := func(k string) string { return hex.EncodeToString([]byte(k)) }
+
+	// An extra SSD replica is added to the regions in [a, c).
+	result, err := manager.Simulate(&RuleChange{Rules: []*Rule{{
+		GroupID: DefaultGroupID, ID: "ssd", StartKeyHex: hexKey("a"), EndKeyHex: hexKey("c"), Role: Voter, Count: 1,
+		LabelConstraints: []LabelConstraint{{Key: "disk", Op: In, Values: []string{"ssd"}}},
+	}}}, cluster)
+	re.NoError(err)
+	re.Equal([]core.KeyRange{core.NewKeyRange("a", "c")}, result.AffectedRanges)
+	re.Equal(2, result.RegionCount)
+	re.Equal(2, result.RegionsToAddPeers)
+	re.Equal(2, result.PeersToAdd)
+	re.Zero(result.PeersToRemove)
+	re.Equal([]*StoreImpact{{StoreID: 6, PeersToAdd: 2, SizeToAdd: 20}}, result.Stores)
+	re.Empty(result.UnsatisfiableRules)
+	// The change is not applied.
+	re.Nil(manager.GetRule(DefaultGroupID, "ssd"))
+	re.Len(manager.GetRulesForApplyRange([]byte("a"), []byte("b")), 1)
+
+	// The default rule is reduced to 2 replicas, so a peer of every region is removed.
+	result, err = manager.Simulate(&RuleChange{Batch: []RuleOp{{Action: RuleOpAdd, Rule: &Rule{
+		GroupID: DefaultGroupID, ID: DefaultRuleID, Role: Voter, Count: 2, LocationLabels: []string{"zone"},
+	}}}}, cluster)
+	re.NoError(err)
+	re.Len(result.AffectedRanges, 1)
+	re.Empty(result.AffectedRanges[0].StartKey)
+	re.Empty(result.AffectedRanges[0].EndKey)
+	re.Equal(4, result.RegionCount)
+	re.Equal(4, result.RegionsToRemovePeers)
+	re.Equal(4, result.PeersToRemove)
+	re.Zero(result.PeersToAdd)
+	re.Len(result.Stores, 1)
+	re.Equal(40, int(result.Stores[0].SizeToRemove))
+	re.Equal(3, manager.GetRule(DefaultGroupID, DefaultRuleID).Count)
+
+	// The rule with 5 replicas isolated by zones can not be satisfied by 3 zones,
+	// and the regions in [a, c) have no rule after the default rule is overridden.
+	result, err = manager.Simulate(&RuleChange{Bundles: []GroupBundle{{ID: "g", Rules: []*Rule{{
+		ID: "zones", StartKeyHex: hexKey("c"), Role: Voter, Count: 5, LocationLabels: []string{"zone"}, IsolationLevel: "zone",
+	}}}}, Override: true}, cluster)
+	re.NoError(err)
+	re.Equal(4, result.RegionCount)
+	re.Equal(2, result.RegionsToRemovePeers)
+	re.Equal(6, result.PeersToRemove)
+	re.Equal(2, result.RegionsToAddPeers)
+	re.Equal(4, result.PeersToAdd)
+	re.Len(result.UnsatisfiableRules, 1)
+	re.Equal("g", result.UnsatisfiableRules[0].GroupID)
+	re.Contains(result.UnsatisfiableRules[0].Reason, "isolation label zone")
+	peersToAdd := 0
+	for _, impact := range result.Stores {
+		if impact.PeersToAdd > 0 {
+			re.NotContains([]uint64{1, 3, 5}, impact.StoreID)
+		}
+		peersToAdd += impact.PeersToAdd
+	}
+	re.Equal(4, peersToAdd)
+
+	// The invalid changes are rejected.
+	_, err = manager.Simulate(&RuleChange{}, cluster)
+	re.True(errs.ErrRuleContent.Equal(err))
+	_, err = manager.Simulate(&RuleChange{Batch: []RuleOp{{Action: RuleOpDel, Rule: &Rule{GroupID: DefaultGroupID, ID: DefaultRuleID}}}}, cluster)
+	re.True(errs.ErrBuildRuleList.Equal(err))
+}
@@ -0,0 +1,516 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+)
+
+// templateParamPattern matches the placeholders like `${leader_zone}` in the rule templates.
+var templateParamPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_\-]+)\}`)
+
+// maxTemplateRules is the max number of the rules of a template, so that the old
+// and the new rules of an instance can be saved in one transaction with its group
+// and itself.
+const maxTemplateRules = (etcdutil.MaxEtcdTxnOps - 2) / 2
+
+// RuleTemplate is a named rule group whose rules contain placeholders like
+// `${name}`. It is instantiated per key range by RuleTemplateInstance. A string
+// which is exactly a placeholder of a non-string field is replaced by the JSON
+// value of the parameter, so that `"count": "${replicas}"` works, while the
+// parameter of a string field is always kept as a string.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type RuleTemplate struct {
+	ID       string            `json:"id"`
+	Index    int               `json:"group_index,omitempty"`
+	Override bool              `json:"group_override,omitempty"`
+	Defaults map[string]string `json:"defaults,omitempty"` // default values of the parameters
+	// The group ID and the key range of the rules are set by the instance.
+	Rules []json.RawMessage `json:"rules"`
+	// Version is increased each time the template is updated, it is set by PD.
+	Version uint64 `json:"version,omitempty"`
+}
+
+// RuleTemplateInstance instantiates a template as a rule group on a key range.
+// The rules of the group are owned by the instance and are re-rendered when the
+// template is updated.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type RuleTemplateInstance struct {
+	GroupID     string            `json:"group_id"`
+	TemplateID  string            `json:"template_id"`
+	StartKeyHex string            `json:"start_key"`
+	EndKeyHex   string            `json:"end_key"`
+	Params      map[string]string `json:"params,omitempty"`
+	// TemplateVersion is the version of the template which the rules of the
+	// group are rendered from, it is set by PD.
+	TemplateVersion uint64 `json:"template_version,omitempty"`
+}
+
+func (t *RuleTemplate) String() string {
+	b, _ := json.Marshal(t)
+	return string(b)
+}
+
+func (i *RuleTemplateInstance) String() string {
+	b, _ := json.Marshal(i)
+	return string(b)
+}
+
+func (t *RuleTemplate) validate() error {
+	if t.ID == "" {
+		return errs.ErrRuleTemplateContent.FastGenByArgs("ID should not be empty")
+	}
+	if len(t.Rules) == 0 {
+		return errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("template %s has no rule", t.ID))
+	}
+	if len(t.Rules) > maxTemplateRules {
+		return errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("template %s has %d rules, which exceeds the limit %d", t.ID, len(t.Rules), maxTemplateRules))
+	}
+	for _, raw := range t.Rules {
+		var rule map[string]any
+		if err := json.Unmarshal(raw, &rule); err != nil {
+			return errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("rule %s is not a JSON object", raw))
+		}
+	}
+	return nil
+}
+
+// render renders the rules of the template with the parameters of the instance.
+// The rules are not adjusted.
+func (t *RuleTemplate) render(inst *RuleTemplateInstance) ([]*Rule, error) {
+	lookup := func(name string) (string, error) {
+		if v, ok := inst.Params[name]; ok {
+			return v, nil
+		}
+		if v, ok := t.Defaults[name]; ok {
+			return v, nil
+		}
+		return "", errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("parameter %s of template %s is not set", name, t.ID))
+	}
+	rules := make([]*Rule, 0, len(t.Rules))
+	ids := make(map[string]struct{}, len(t.Rules))
+	for _, raw := range t.Rules {
+		var v any
+		d := json.NewDecoder(bytes.NewReader(raw))
+		d.UseNumber()
+		if err := d.Decode(&v); err != nil {
+			return nil, errs.ErrRuleTemplateContent.FastGenByArgs(err.Error())
+		}
+		v, err := renderTemplateValue(v, reflect.TypeOf(Rule{}), lookup)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, errs.ErrRuleTemplateContent.FastGenByArgs(err.Error())
+		}
+		r, err := NewRuleFromJSON(data)
+		if err != nil {
+			return nil, errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("rendered rule %s is invalid, %v", data, err))
+		}
+		if _, ok := ids[r.ID]; ok {
+			return nil, errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("rule ID %s is duplicated", r.ID))
+		}
+		ids[r.ID] = struct{}{}
+		r.GroupID, r.StartKeyHex, r.EndKeyHex = inst.GroupID, inst.StartKeyHex, inst.EndKeyHex
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// renderTemplateValue replaces the placeholders in the value decoded from the
+// JSON of a rule, typ is the type of the field which the value is decoded to.
+func renderTemplateValue(v any, typ reflect.Type, lookup func(string) (string, error)) (any, error) {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch v := v.(type) {
+	case string:
+		if m := templateParamPattern.FindStringSubmatch(v); m != nil && m[0] == v {
+			value, err := lookup(m[1])
+			if err != nil {
+				return nil, err
+			}
+			// The parameter is kept as a string for the string fields, such as
+			// the zone "1" of a label constraint.
+			if typ == nil || typ.Kind() == reflect.String {
+				return value, nil
+			}
+			var decoded any
+			d := json.NewDecoder(bytes.NewReader([]byte(value)))
+			d.UseNumber()
+			if err := d.Decode(&decoded); err == nil {
+				return decoded, nil
+			}
+			return value, nil
+		}
+		var err error
+		rendered := templateParamPattern.ReplaceAllStringFunc(v, func(p string) string {
+			value, e := lookup(templateParamPattern.FindStringSubmatch(p)[1])
+			if e != nil && err == nil {
+				err = e
+			}
+			return value
+		})
+		return rendered, err
+	case []any:
+		var elem reflect.Type
+		if typ != nil && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+			elem = typ.Elem()
+		}
+		for i := range v {
+			rendered, err := renderTemplateValue(v[i], elem, lookup)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	case map[string]any:
+		for k := range v {
+			rendered, err := renderTemplateValue(v[k], jsonFieldType(typ, k), lookup)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = rendered
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// jsonFieldType returns the type of the field with the JSON name in the struct
+// or the map type, it returns nil if not found.
+func jsonFieldType(typ reflect.Type, name string) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+	switch typ.Kind() {
+	case reflect.Map:
+		return typ.Elem()
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			f := typ.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if tag == name || (tag == "" && strings.EqualFold(f.Name, name)) {
+				return f.Type
+			}
+		}
+	}
+	return nil
+}
+
+func (m *RuleManager) loadTemplates() error {
+	err := m.storage.LoadRuleTemplates(func(k, v string) {
+		t := &RuleTemplate{}
+		if err := json.Unmarshal([]byte(v), t); err != nil {
+			log.Error("failed to unmarshal rule template", zap.String("template-key", k), errs.ZapError(errs.ErrLoadRuleTemplate, err))
+			return
+		}
+		m.templates[t.ID] = t
+	})
+	if err != nil {
+		return err
+	}
+	return m.storage.LoadRuleTemplateInstances(func(k, v string) {
+		inst := &RuleTemplateInstance{}
+		if err := json.Unmarshal([]byte(v), inst); err != nil {
+			log.Error("failed to unmarshal rule template instance", zap.String("instance-key", k), errs.ZapError(errs.ErrLoadRuleTemplate, err))
+			return
+		}
+		m.templateInstances[inst.GroupID] = inst
+	})
+}
+
+// GetRuleTemplate returns the template with the ID.
+func (m *RuleManager) GetRuleTemplate(id string) *RuleTemplate {
+	m.RLock()
+	defer m.RUnlock()
+	return m.templates[id]
+}
+
+// GetRuleTemplates returns all templates sorted by ID.
+func (m *RuleManager) GetRuleTemplates() []*RuleTemplate {
+	m.RLock()
+	defer m.RUnlock()
+	templates := make([]*RuleTemplate, 0, len(m.templates))
+	for _, t := range m.templates {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].ID < templates[j].ID })
+	return templates
+}
+
+// SetRuleTemplate inserts or updates a template. The template is saved once all
+// its instances can be rendered, and then the instances are re-rendered in batches.
+func (m *RuleManager) SetRuleTemplate(t *RuleTemplate) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+	m.Lock()
+	defer m.Unlock()
+	p := m.BeginPatch()
+	groupRules := m.groupRuleKeysLocked()
+	for _, inst := range m.templateInstances {
+		if inst.TemplateID != t.ID {
+			continue
+		}
+		if err := m.instancePatchLocked(p, t, inst, groupRules[inst.GroupID]); err != nil {
+			return err
+		}
+	}
+	p.adjust()
+	if _, err := buildRuleList(p); err != nil {
+		return err
+	}
+	t.Version = 1
+	if old, ok := m.templates[t.ID]; ok {
+		t.Version = old.Version + 1
+	}
+	if err := m.storage.RunInTxn(m.ctx, func(txn kv.Txn) error {
+		return m.storage.SaveRuleTemplate(txn, t.ID, t)
+	}); err != nil {
+		return err
+	}
+	m.templates[t.ID] = t
+	groups, err := m.renderTemplateInstancesLocked(t)
+	if err != nil {
+		log.Warn("failed to render the rule template, it will be rendered again when the rules are loaded",
+			zap.String("template", t.ID), zap.Strings("rendered-groups", groups), errs.ZapError(err))
+		return err
+	}
+	log.Info("placement rule template updated", zap.String("template", t.String()), zap.Strings("rendered-groups", groups))
+	return nil
+}
+
+// renderTemplateInstancesLocked renders the instances which are not rendered from
+// the current version of the template, and returns the rendered groups. The
+// instances are committed in batches which fit in one transaction, and each one is
+// saved with the version of the template in the same transaction as its rules, so
+// the rendering stopped by an error can be resumed by rendering the template again.
+func (m *RuleManager) renderTemplateInstancesLocked(t *RuleTemplate) ([]string, error) {
+	var (
+		p          = m.BeginPatch()
+		groupRules = m.groupRuleKeysLocked()
+		batch      []*RuleTemplateInstance
+		batchOps   int
+		groups     []string
+	)
+	commit := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		saves := make([]func(kv.Txn) error, 0, len(batch))
+		for _, inst := range batch {
+			saves = append(saves, func(txn kv.Txn) error {
+				return m.storage.SaveRuleTemplateInstance(txn, inst.GroupID, inst)
+			})
+		}
+		if err := m.tryCommitPatchLocked(p, saves...); err != nil {
+			return err
+		}
+		for _, inst := range batch {
+			m.templateInstances[inst.GroupID] = inst
+			groups = append(groups, inst.GroupID)
+		}
+		p, batch, batchOps = m.BeginPatch(), nil, 0
+		return nil
+	}
+	instances := make([]*RuleTemplateInstance, 0, len(m.templateInstances))
+	for _, inst := range m.templateInstances {
+		if inst.TemplateID == t.ID && inst.TemplateVersion != t.Version {
+			instances = append(instances, inst)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].GroupID < instances[j].GroupID })
+	for _, inst := range instances {
+		// The old rules and the new rules of the group, the group and the instance.
+		ops := len(groupRules[inst.GroupID]) + len(t.Rules) + 2
+		if batchOps+ops > etcdutil.MaxEtcdTxnOps {
+			if err := commit(); err != nil {
+				return groups, err
+			}
+		}
+		rendered := *inst
+		rendered.TemplateVersion = t.Version
+		if err := m.instancePatchLocked(p, t, &rendered, groupRules[inst.GroupID]); err != nil {
+			return groups, err
+		}
+		batch = append(batch, &rendered)
+		batchOps += ops
+	}
+	return groups, commit()
+}
+
+// DeleteRuleTemplate removes a template which is not used by any instance.
+func (m *RuleManager) DeleteRuleTemplate(id string) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.templates[id]; !ok {
+		return errs.ErrRuleTemplateNotFound.FastGenByArgs(id)
+	}
+	for _, inst := range m.templateInstances {
+		if inst.TemplateID == id {
+			return errs.ErrRuleTemplateContent.FastGenByArgs(fmt.Sprintf("template %s is used by group %s", id, inst.GroupID))
+		}
+	}
+	if err := m.storage.RunInTxn(m.ctx, func(txn kv.Txn) error {
+		return m.storage.DeleteRuleTemplate(txn, id)
+	}); err != nil {
+		return err
+	}
+	delete(m.templates, id)
+	log.Info("placement rule template is removed", zap.String("id", id))
+	return nil
+}
+
+// GetRuleTemplateInstance returns the instance of the group.
+func (m *RuleManager) GetRuleTemplateInstance(groupID string) *RuleTemplateInstance {
+	m.RLock()
+	defer m.RUnlock()
+	return m.templateInstances[groupID]
+}
+
+// GetRuleTemplateInstances returns all instances sorted by group ID.
+func (m *RuleManager) GetRuleTemplateInstances() []*RuleTemplateInstance {
+	m.RLock()
+	defer m.RUnlock()
+	instances := make([]*RuleTemplateInstance, 0, len(m.templateInstances))
+	for _, inst := range m.templateInstances {
+		instances = append(instances, inst)
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].GroupID < instances[j].GroupID })
+	return instances
+}
+
+// SetRuleTemplateInstance instantiates a template as a rule group, the old rules
+// of the group are dropped.
+func (m *RuleManager) SetRuleTemplateInstance(inst *RuleTemplateInstance) error {
+	if inst.GroupID == "" {
+		return errs.ErrRuleTemplateContent.FastGenByArgs("group ID should not be empty")
+	}
+	m.Lock()
+	defer m.Unlock()
+	t, ok := m.templates[inst.TemplateID]
+	if !ok {
+		return errs.ErrRuleTemplateNotFound.FastGenByArgs(inst.TemplateID)
+	}
+	inst.TemplateVersion = t.Version
+	p := m.BeginPatch()
+	if err := m.instancePatchLocked(p, t, inst, m.groupRuleKeysLocked()[inst.GroupID]); err != nil {
+		return err
+	}
+	if err := m.tryCommitPatchLocked(p, func(txn kv.Txn) error {
+		return m.storage.SaveRuleTemplateInstance(txn, inst.GroupID, inst)
+	}); err != nil {
+		return err
+	}
+	m.templateInstances[inst.GroupID] = inst
+	log.Info("placement rule template instance updated", zap.String("instance", inst.String()))
+	return nil
+}
+
+// DeleteRuleTemplateInstance removes an instance and the rule group of it.
+func (m *RuleManager) DeleteRuleTemplateInstance(groupID string) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.templateInstances[groupID]; !ok {
+		return errs.ErrRuleTemplateNotFound.FastGenByArgs("instance of group " + groupID)
+	}
+	p := m.BeginPatch()
+	for k := range m.ruleConfig.rules {
+		if k[0] == groupID {
+			p.DeleteRule(k[0], k[1])
+		}
+	}
+	p.DeleteGroup(groupID)
+	if err := m.tryCommitPatchLocked(p, func(txn kv.Txn) error {
+		return m.storage.DeleteRuleTemplateInstance(txn, groupID)
+	}); err != nil {
+		return err
+	}
+	delete(m.templateInstances, groupID)
+	log.Info("placement rule template instance is removed", zap.String("group", groupID))
+	return nil
+}
+
+// checkTemplateGroupsLocked returns an error if the patch changes the groups or
+// the rules owned by the template instances, which can only be changed by
+// updating the templates or the instances.
+func (m *RuleManager) checkTemplateGroupsLocked(p *RuleConfigPatch) error {
+	p.trim()
+	for key := range p.mut.rules {
+		if inst, ok := m.templateInstances[key[0]]; ok {
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("group %s is owned by rule template %s", key[0], inst.TemplateID))
+		}
+	}
+	for id := range p.mut.groups {
+		if inst, ok := m.templateInstances[id]; ok {
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("group %s is owned by rule template %s", id, inst.TemplateID))
+		}
+	}
+	return nil
+}
+
+// groupRuleKeysLocked returns the keys of the rules of each group.
+func (m *RuleManager) groupRuleKeysLocked() map[string][][2]string {
+	keys := make(map[string][][2]string)
+	for k := range m.ruleConfig.rules {
+		keys[k[0]] = append(keys[k[0]], k)
+	}
+	return keys
+}
+
+// instancePatchLocked adds the rendered group of the instance to the patch, the
+// old rules of the group are dropped.
+func (m *RuleManager) instancePatchLocked(p *RuleConfigPatch, t *RuleTemplate, inst *RuleTemplateInstance, oldRules [][2]string) error {
+	rules, err := t.render(inst)
+	if err != nil {
+		return err
+	}
+	for _, k := range oldRules {
+		p.DeleteRule(k[0], k[1])
+	}
+	p.SetGroup(&RuleGroup{
+		ID:       inst.GroupID,
+		Index:    t.Index,
+		Override: t.Override,
+	})
+	for _, r := range rules {
+		if err := m.AdjustRule(r, inst.GroupID); err != nil {
+			return err
+		}
+		p.SetRule(r)
+	}
+	return nil
+}
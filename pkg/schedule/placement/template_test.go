@@ -0,0 +1,198 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/etcdutil"
+)
+
+func TestRuleTemplate(t *testing.T) {
+	re := require.New(t)
+	store, manager := newTestManager(t, false)
+	newTemplate := func(rules ...string) *RuleTemplate {
+		tmpl := &RuleTemplate{ID: "zones", Index: 10, Defaults: map[string]string{"replicas": "2"}}
+		for _, r := range rules {
+			tmpl.Rules = append(tmpl.Rules, json.RawMessage(r))
+		}
+		return tmpl
+	}
+	leaderRule := `{"id": "leader", "role": "leader", "count": 1, "label_constraints": [{"key": "zone", "op": "in", "values": ["${leader_zone}"]}]}`
+	followerRule := `{"id": "follower-${leader_zone}", "role": "follower", "count": "${replicas}", "location_labels": ["zone"]}`
+	re.NoError(manager.SetRuleTemplate(newTemplate(leaderRule, followerRule)))
+	re.Len(manager.GetRuleTemplates(), 1)
+
+	// The template is rendered with the parameters and the defaults.
+	re.True(errs.ErrRuleTemplateNotFound.Equal(manager.SetRuleTemplateInstance(&RuleTemplateInstance{GroupID: "t1", TemplateID: "unknown"})))
+	err := manager.SetRuleTemplateInstance(&RuleTemplateInstance{GroupID: "t1", TemplateID: "zones"})
+	re.True(errs.ErrRuleTemplateContent.Equal(err))
+	re.Contains(err.Error(), "parameter leader_zone")
+	re.NoError(manager.SetRuleTemplateInstance(&RuleTemplateInstance{
+		GroupID: "t1", TemplateID: "zones", StartKeyHex: "61", EndKeyHex: "62", Params: map[string]string{"leader_zone": "z1"},
+	}))
+	re.NoError(manager.SetRuleTemplateInstance(&RuleTemplateInstance{
+		GroupID: "t2", TemplateID: "zones", StartKeyHex: "62", EndKeyHex: "63", Params: map[string]string{"leader_zone": "z2", "replicas": "3"},
+	}))
+	bundle := manager.GetGroupBundle("t1")
+	re.Equal(10, bundle.Index)
+	re.Len(bundle.Rules, 2)
+	re.Equal("follower-z1", bundle.Rules[0].ID)
+	re.Equal(2, bundle.Rules[0].Count)
+	re.Equal("61", bundle.Rules[0].StartKeyHex)
+	re.Equal("leader", bundle.Rules[1].ID)
+	re.Equal([]string{"z1"}, bundle.Rules[1].LabelConstraints[0].Values)
+	re.Equal("t1", bundle.Rules[1].GroupID)
+	bundle = manager.GetGroupBundle("t2")
+	re.Equal("follower-z2", bundle.Rules[0].ID)
+	re.Equal(3, bundle.Rules[0].Count)
+	re.Equal("63", bundle.Rules[1].EndKeyHex)
+	re.Len(manager.GetRulesByKey([]byte("a")), 3)
+
+	// Updating the template re-renders all the instances.
+	re.True(errs.ErrRuleTemplateContent.Equal(manager.SetRuleTemplate(newTemplate(`{"id": "leader", "count": "${replicas}", "role": "voter", "index": "${unknown}"}`))))
+	re.True(errs.ErrRuleContent.Equal(manager.SetRuleTemplate(newTemplate(`{"id": "leader", "role": "leader", "count": "${replicas}"}`))))
+	re.Len(manager.GetRulesByGroup("t1"), 2)
+	re.NoError(manager.SetRuleTemplate(newTemplate(`{"id": "voters", "role": "voter", "count": "${replicas}", "isolation_level": "zone"}`)))
+	for _, group := range []string{"t1", "t2"} {
+		rules := manager.GetRulesByGroup(group)
+		re.Len(rules, 1)
+		re.Equal("voters", rules[0].ID)
+		re.Equal("zone", rules[0].IsolationLevel)
+	}
+	re.Equal(2, manager.GetRule("t1", "voters").Count)
+	re.Equal(3, manager.GetRule("t2", "voters").Count)
+
+	// The templates and instances are persisted.
+	manager2 := NewRuleManager(context.Background(), store, nil, mockconfig.NewTestOptions())
+	re.NoError(manager2.Initialize(3, []string{"zone", "rack", "host"}, "", false))
+	re.JSONEq(jsonString(re, manager.GetRuleTemplates()), jsonString(re, manager2.GetRuleTemplates()))
+	re.Equal(manager.GetRuleTemplateInstances(), manager2.GetRuleTemplateInstances())
+	re.Equal(3, manager2.GetRule("t2", "voters").Count)
+	re.Equal(10, manager2.GetRuleGroup("t2").Index)
+
+	// The groups of the instances can only be changed by the templates.
+	re.True(errs.ErrRuleContent.Equal(manager.DeleteRuleGroup("t1")))
+	re.True(errs.ErrRuleContent.Equal(manager.DeleteGroupBundle("t.*", true)))
+	re.True(errs.ErrRuleContent.Equal(manager.SetAllGroupBundles(nil, true)))
+	re.True(errs.ErrRuleContent.Equal(manager.SetRule(&Rule{GroupID: "t1", ID: "voters", Role: Voter, Count: 1})))
+	re.NoError(manager.SetAllGroupBundles(manager.GetAllGroupBundles(), true))
+	re.Len(manager.GetRulesByGroup("t1"), 1)
+	re.NotNil(manager.GetRuleGroup("t2"))
+
+	// The template with too many rules is rejected.
+	rules := []string{`{"id": "voters", "role": "voter", "count": 3}`}
+	for i := range maxTemplateRules {
+		rules = append(rules, fmt.Sprintf(`{"id": "learner-%d", "role": "learner", "count": 1}`, i))
+	}
+	err = manager.SetRuleTemplate(newTemplate(rules...))
+	re.True(errs.ErrRuleTemplateContent.Equal(err))
+	re.Contains(err.Error(), "exceeds the limit")
+	re.Len(manager.GetRulesByGroup("t1"), 1)
+
+	// The template can not be deleted until all the instances are deleted.
+	re.True(errs.ErrRuleTemplateContent.Equal(manager.DeleteRuleTemplate("zones")))
+	re.NoError(manager.DeleteRuleTemplateInstance("t1"))
+	re.NoError(manager.DeleteRuleTemplateInstance("t2"))
+	re.True(errs.ErrRuleTemplateNotFound.Equal(manager.DeleteRuleTemplateInstance("t2")))
+	re.Empty(manager.GetRulesByGroup("t1"))
+	re.Nil(manager.GetRuleGroup("t2"))
+	re.NoError(manager.DeleteRuleTemplate("zones"))
+	re.Empty(manager.GetRuleTemplates())
+	re.True(errs.ErrRuleTemplateNotFound.Equal(manager.DeleteRuleTemplate("zones")))
+}
+
+func TestRuleTemplateManyInstances(t *testing.T) {
+	re := require.New(t)
+	store, manager := newTestManager(t, false)
+	newTemplate := func(count int) *RuleTemplate {
+		return &RuleTemplate{ID: "zones", Index: 10, Override: true, Rules: []json.RawMessage{
+			json.RawMessage(fmt.Sprintf(`{"id": "voters", "role": "voter", "count": %d, "label_constraints": [{"key": "zone", "op": "in", "values": ["${zone}"]}]}`, count)),
+			json.RawMessage(`{"id": "learner", "role": "learner", "count": 1}`),
+		}}
+	}
+	re.NoError(manager.SetRuleTemplate(newTemplate(3)))
+	// The instances are more than the operations of one transaction.
+	instanceCount := etcdutil.MaxEtcdTxnOps * 2
+	for i := range instanceCount {
+		re.NoError(manager.SetRuleTemplateInstance(&RuleTemplateInstance{
+			GroupID:     fmt.Sprintf("t%03d", i),
+			TemplateID:  "zones",
+			StartKeyHex: fmt.Sprintf("%04x", i),
+			EndKeyHex:   fmt.Sprintf("%04x", i+1),
+			Params:      map[string]string{"zone": fmt.Sprintf("z%d", i%3)},
+		}))
+	}
+	re.NoError(manager.SetRuleTemplate(newTemplate(5)))
+	re.Equal(uint64(2), manager.GetRuleTemplate("zones").Version)
+	for _, inst := range manager.GetRuleTemplateInstances() {
+		re.Equal(uint64(2), inst.TemplateVersion)
+		re.Equal(5, manager.GetRule(inst.GroupID, "voters").Count)
+	}
+
+	// The rendering stopped by an error is resumed when the rules are loaded.
+	tmpl := newTemplate(4)
+	tmpl.Version = 3
+	re.NoError(store.RunInTxn(context.Background(), func(txn kv.Txn) error {
+		return store.SaveRuleTemplate(txn, tmpl.ID, tmpl)
+	}))
+	manager2 := NewRuleManager(context.Background(), store, nil, mockconfig.NewTestOptions())
+	re.NoError(manager2.Initialize(3, []string{"zone", "rack", "host"}, "", false))
+	instances := manager2.GetRuleTemplateInstances()
+	re.Len(instances, instanceCount)
+	for _, inst := range instances {
+		re.Equal(uint64(3), inst.TemplateVersion)
+		re.Equal(4, manager2.GetRule(inst.GroupID, "voters").Count)
+		re.Equal([]string{inst.Params["zone"]}, manager2.GetRule(inst.GroupID, "voters").LabelConstraints[0].Values)
+	}
+	// The rendered rules are persisted.
+	manager3 := NewRuleManager(context.Background(), store, nil, mockconfig.NewTestOptions())
+	re.NoError(manager3.Initialize(3, []string{"zone", "rack", "host"}, "", false))
+	re.Equal(4, manager3.GetRule("t000", "voters").Count)
+}
+
+func TestRenderRuleTemplate(t *testing.T) {
+	re := require.New(t)
+	tmpl := &RuleTemplate{ID: "zones", Rules: []json.RawMessage{
+		json.RawMessage(`{"id": "voters-${zone}", "role": "voter", "count": "${replicas}", "override": "${override}",
+			"label_constraints": [{"key": "zone", "op": "in", "values": ["${zone}"]}, {"key": "host", "op": "notIn", "values": "${hosts}"}]}`),
+	}}
+	rules, err := tmpl.render(&RuleTemplateInstance{GroupID: "g", Params: map[string]string{
+		"zone": "1", "replicas": "3", "override": "true", "hosts": `["h1", "2"]`,
+	}})
+	re.NoError(err)
+	re.Len(rules, 1)
+	r := rules[0]
+	re.Equal("voters-1", r.ID)
+	re.Equal(3, r.Count)
+	re.True(r.Override)
+	// The parameters of the string fields are not decoded as JSON.
+	re.Equal([]string{"1"}, r.LabelConstraints[0].Values)
+	re.Equal([]string{"h1", "2"}, r.LabelConstraints[1].Values)
+}
+
+func jsonString(re *require.Assertions, v any) string {
+	b, err := json.Marshal(v)
+	re.NoError(err)
+	return string(b)
+}
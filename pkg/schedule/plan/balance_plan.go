@@ -76,14 +76,21 @@ func (p *BalanceSchedulerPlan) GetResource(step int) uint64 {
 	if p.Step < step {
 		return 0
 	}
-	// Please use with care. Add a nil check if need in the future
+	// The resources of the previous steps may be nil, such as the plans of the
+	// unavailable target stores which are collected before the source is picked.
 	switch step {
 	case pickSource:
-		return p.Source.GetID()
+		if p.Source != nil {
+			return p.Source.GetID()
+		}
 	case pickRegion:
-		return p.Region.GetID()
+		if p.Region != nil {
+			return p.Region.GetID()
+		}
 	case pickTarget:
-		return p.Target.GetID()
+		if p.Target != nil {
+			return p.Target.GetID()
+		}
 	}
 	return 0
 }
@@ -0,0 +1,609 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// balanceCPUBatchSize is the default number of operators to be created by one scheduling.
+	balanceCPUBatchSize = 4
+	// maxBalanceCPUBatchSize is the maximum of the batch size.
+	maxBalanceCPUBatchSize = 10
+	// balanceCPUBalancedRatio is the default ratio of the low CPU usage to the high one,
+	// above which the two stores are considered balanced.
+	balanceCPUBalancedRatio = 0.9
+	// balanceCPUMinRegionUsage is the minimum CPU usage of a region to be scheduled, in percent of a core.
+	balanceCPUMinRegionUsage = 1
+	// balanceCPUTopnPosition is the position of the region whose CPU usage limits the minimum
+	// better rate, which avoids scheduling the small regions. It is the same as the hot peers.
+	balanceCPUTopnPosition = 10
+	// balanceCPUCandidateLimit is the max number of the regions of a store tried by one scheduling.
+	balanceCPUCandidateLimit = 20
+	// balanceCPULeaderLimit is the max number of the leader regions of a store kept for
+	// scheduling, which leaves room for the used and unhealthy regions skipped by the candidates.
+	balanceCPULeaderLimit = 64
+)
+
+// balanceCPUThreadPrefixes are the prefixes of the names of the TiKV threads whose CPU
+// usage is balanced, which are the unified read pool and gRPC threads.
+var balanceCPUThreadPrefixes = []string{"unified-read-po", "grpc-server"}
+
+type balanceCPUSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// Batch is used to generate multiple operators by one scheduling.
+	Batch int `json:"batch"`
+	// BalancedRatio is the ratio of the low CPU usage to the high one, above which
+	// the two stores are considered balanced.
+	BalancedRatio float64 `json:"balanced-ratio"`
+}
+
+func (conf *balanceCPUSchedulerConfig) update(data []byte) (int, any) {
+	conf.Lock()
+	defer conf.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if msg := conf.validateLocked(); len(msg) > 0 {
+			if err := json.Unmarshal(oldc, conf); err != nil {
+				return http.StatusInternalServerError, err.Error()
+			}
+			return http.StatusBadRequest, msg
+		}
+		if err := conf.save(); err != nil {
+			log.Warn("failed to persist config", zap.Error(err))
+		}
+		log.Info("balance-cpu-scheduler config is updated", zap.ByteString("old", oldc), zap.ByteString("new", newc))
+		return http.StatusOK, "Config is updated."
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	ok := reflectutil.FindSameFieldByJSON(conf, m)
+	if ok {
+		return http.StatusOK, "Config is the same with origin, so do nothing."
+	}
+	return http.StatusBadRequest, "Config item is not found."
+}
+
+func (conf *balanceCPUSchedulerConfig) validateLocked() string {
+	if conf.Batch < 1 || conf.Batch > maxBalanceCPUBatchSize {
+		return "invalid batch size which should be an integer between 1 and 10"
+	}
+	if conf.BalancedRatio < 0.7 || conf.BalancedRatio > 0.95 {
+		return "invalid balanced-ratio which should be in [0.7, 0.95]"
+	}
+	return ""
+}
+
+func (conf *balanceCPUSchedulerConfig) clone() *balanceCPUSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return &balanceCPUSchedulerConfig{
+		Batch:         conf.Batch,
+		BalancedRatio: conf.BalancedRatio,
+	}
+}
+
+func (conf *balanceCPUSchedulerConfig) getBatch() int {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.Batch
+}
+
+func (conf *balanceCPUSchedulerConfig) getBalancedRatio() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.BalancedRatio
+}
+
+type balanceCPUHandler struct {
+	rd     *render.Render
+	config *balanceCPUSchedulerConfig
+}
+
+func newBalanceCPUHandler(conf *balanceCPUSchedulerConfig) http.Handler {
+	handler := &balanceCPUHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *balanceCPUHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *balanceCPUHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := handler.config.clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+// balanceCPUStore is the CPU load of a store.
+type balanceCPUStore struct {
+	*core.StoreInfo
+	// load is the CPU usage of the unified read pool and gRPC threads, including
+	// the influence of the running operators of the scheduler.
+	load float64
+	// leaders are the top leader regions of the store sorted by the CPU usage in descending order.
+	leaders []*core.RegionInfo
+	// topnRate is the CPU usage of the topn leader region.
+	topnRate float64
+}
+
+// getStoreCPUUsage returns the CPU usage of the unified read pool and gRPC threads
+// of the store reported by the latest heartbeat.
+func getStoreCPUUsage(store *core.StoreInfo) float64 {
+	var usage uint64
+	for _, record := range store.GetStoreStats().GetCpuUsages() {
+		for _, prefix := range balanceCPUThreadPrefixes {
+			if strings.HasPrefix(record.GetKey(), prefix) {
+				usage += record.GetValue()
+				break
+			}
+		}
+	}
+	return float64(usage)
+}
+
+// cpuLeaderCache keeps the leader regions with the highest CPU usage of each store,
+// which is updated by the region heartbeats, so the scheduler does not need to go
+// through all the leaders of the stores.
+type cpuLeaderCache struct {
+	syncutil.RWMutex
+	// leaders are the CPU usages of the kept regions of each store.
+	leaders map[uint64]map[uint64]uint64
+	// stores are the leader stores of the kept regions.
+	stores map[uint64]uint64
+	// limit is the max number of the regions kept for each store.
+	limit int
+}
+
+func newCPULeaderCache(limit int) *cpuLeaderCache {
+	return &cpuLeaderCache{
+		leaders: make(map[uint64]map[uint64]uint64),
+		stores:  make(map[uint64]uint64),
+		limit:   limit,
+	}
+}
+
+// update updates the cache with the region reported by the heartbeat. The region is
+// kept if its CPU usage is among the highest ones of its leader store.
+func (c *cpuLeaderCache) update(region *core.RegionInfo) {
+	regionID, storeID, usage := region.GetID(), region.GetLeader().GetStoreId(), region.GetCPUUsage()
+	c.Lock()
+	defer c.Unlock()
+	if old, ok := c.stores[regionID]; ok && old != storeID {
+		c.removeLocked(regionID)
+	}
+	if storeID == 0 || usage < balanceCPUMinRegionUsage {
+		c.removeLocked(regionID)
+		return
+	}
+	leaders, ok := c.leaders[storeID]
+	if !ok {
+		leaders = make(map[uint64]uint64, c.limit)
+		c.leaders[storeID] = leaders
+	}
+	if _, ok := leaders[regionID]; !ok && len(leaders) >= c.limit {
+		// Replace the region with the lowest CPU usage.
+		minID, minUsage := uint64(0), uint64(math.MaxUint64)
+		for id, u := range leaders {
+			if u < minUsage {
+				minID, minUsage = id, u
+			}
+		}
+		if usage <= minUsage {
+			return
+		}
+		c.removeLocked(minID)
+	}
+	leaders[regionID] = usage
+	c.stores[regionID] = storeID
+}
+
+// remove removes the regions from the cache, such as the regions which are merged.
+func (c *cpuLeaderCache) remove(regionIDs ...uint64) {
+	c.Lock()
+	defer c.Unlock()
+	for _, id := range regionIDs {
+		c.removeLocked(id)
+	}
+}
+
+func (c *cpuLeaderCache) removeLocked(regionID uint64) {
+	storeID, ok := c.stores[regionID]
+	if !ok {
+		return
+	}
+	delete(c.stores, regionID)
+	delete(c.leaders[storeID], regionID)
+	if len(c.leaders[storeID]) == 0 {
+		delete(c.leaders, storeID)
+	}
+}
+
+// get returns the IDs of the kept regions of the store.
+func (c *cpuLeaderCache) get(storeID uint64) []uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	ids := make([]uint64, 0, len(c.leaders[storeID]))
+	for id := range c.leaders[storeID] {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+type balanceCPUScheduler struct {
+	*BaseScheduler
+	conf          *balanceCPUSchedulerConfig
+	handler       http.Handler
+	filters       []filter.Filter
+	filterCounter *filter.Counter
+	leaders       *cpuLeaderCache
+}
+
+// newBalanceCPUScheduler creates a scheduler that moves the leaders with high CPU usage
+// to balance the CPU usage of the unified read pool and gRPC threads across stores.
+func newBalanceCPUScheduler(opController *operator.Controller, conf *balanceCPUSchedulerConfig) Scheduler {
+	s := &balanceCPUScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.BalanceCPUScheduler, conf),
+		conf:          conf,
+		handler:       newBalanceCPUHandler(conf),
+		filterCounter: filter.NewCounter(types.BalanceCPUScheduler.String()),
+		leaders:       newCPULeaderCache(balanceCPULeaderLimit),
+	}
+	s.filters = []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true, OperatorLevel: constant.Medium},
+		filter.NewSpecialUseFilter(s.GetName()),
+		filter.NewEngineFilter(s.GetName(), filter.NotSpecialEngines),
+	}
+	return s
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *balanceCPUScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *balanceCPUScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.RLock()
+	defer s.conf.RUnlock()
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *balanceCPUScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+
+	newCfg := &balanceCPUSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.Batch = newCfg.Batch
+	s.conf.BalancedRatio = newCfg.BalancedRatio
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *balanceCPUScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	conf := cluster.GetSchedulerConfig()
+	leaderAllowed := s.OpController.OperatorCount(operator.OpLeader) < conf.GetLeaderScheduleLimit()
+	regionAllowed := s.OpController.OperatorCount(operator.OpRegion) < conf.GetRegionScheduleLimit()
+	if !leaderAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpLeader)
+	}
+	if !regionAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	return leaderAllowed && regionAllowed
+}
+
+// ObserveRegion updates the leaders with the highest CPU usage with the region
+// reported by the heartbeat.
+func (s *balanceCPUScheduler) ObserveRegion(region *core.RegionInfo) {
+	s.leaders.update(region)
+}
+
+// Schedule implements the Scheduler interface.
+func (s *balanceCPUScheduler) Schedule(cluster sche.SchedulerCluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	basePlan := plan.NewBalanceSchedulerPlan()
+	defer s.filterCounter.Flush()
+	var collector *plan.Collector
+	if dryRun {
+		collector = plan.NewCollector(basePlan)
+	}
+	balanceCPUScheduleCounter.Inc()
+	stores := s.getStoreLoads(cluster, filter.SelectSourceStores(cluster.GetStores(), s.filters, cluster.GetSchedulerConfig(), collector, s.filterCounter), !dryRun)
+	if len(stores) < 2 {
+		return nil, collector.GetPlans()
+	}
+	// The CPU usage is ranked in the same way as the first priority of the hot region scheduler.
+	rs := newRankRatios(s.conf.getBalancedRatio(), firstPriorityPerceivedRatio, firstPriorityMinHotRatio)
+	batch := s.conf.getBatch()
+	usedRegions := make(map[uint64]struct{})
+	ops := make([]*operator.Operator, 0, batch)
+	for len(ops) < batch {
+		op := s.scheduleOnce(cluster, rs, stores, usedRegions)
+		if op == nil {
+			break
+		}
+		usedRegions[op.RegionID()] = struct{}{}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		balanceCPUNoOperatorCounter.Inc()
+	}
+	return ops, collector.GetPlans()
+}
+
+// getStoreLoads returns the CPU loads of the stores with the influence of the running
+// operators. The leaders of the stores are the ones kept by the cache, and the regions
+// which are not led by the stores anymore are removed from the cache if prune is true.
+func (s *balanceCPUScheduler) getStoreLoads(cluster sche.SchedulerCluster, stores []*core.StoreInfo, prune bool) []*balanceCPUStore {
+	loads := make(map[uint64]*balanceCPUStore, len(stores))
+	ret := make([]*balanceCPUStore, 0, len(stores))
+	var stale []uint64
+	for _, store := range stores {
+		ids := s.leaders.get(store.GetID())
+		leaders := make([]*core.RegionInfo, 0, len(ids))
+		for _, id := range ids {
+			region := cluster.GetRegion(id)
+			if region == nil || region.GetLeader().GetStoreId() != store.GetID() {
+				stale = append(stale, id)
+				continue
+			}
+			leaders = append(leaders, region)
+		}
+		leaders = topCPULeaders(leaders, balanceCPULeaderLimit)
+		load := &balanceCPUStore{
+			StoreInfo: store,
+			load:      getStoreCPUUsage(store),
+			leaders:   leaders,
+		}
+		if len(leaders) > balanceCPUTopnPosition {
+			load.topnRate = float64(leaders[balanceCPUTopnPosition].GetCPUUsage())
+		} else if len(leaders) > 0 {
+			load.topnRate = float64(leaders[len(leaders)-1].GetCPUUsage())
+		}
+		loads[store.GetID()] = load
+		ret = append(ret, load)
+	}
+	if prune {
+		s.leaders.remove(stale...)
+	}
+	// The CPU usage of a region moves with its leader, and the heartbeats report it
+	// after the leader is transferred, so the running operators are considered.
+	for _, op := range s.OpController.GetOperators() {
+		if op.Desc() != s.GetName() {
+			continue
+		}
+		region := cluster.GetRegion(op.RegionID())
+		if region == nil {
+			continue
+		}
+		for i := range op.Len() {
+			if step, ok := op.Step(i).(operator.TransferLeader); ok && region.GetLeader().GetStoreId() == step.FromStore {
+				usage := float64(region.GetCPUUsage())
+				if from, ok := loads[step.FromStore]; ok {
+					from.load -= usage
+				}
+				if to, ok := loads[step.ToStore]; ok {
+					to.load += usage
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// cpuLeaderHeap is a min-heap of the leader regions ordered by the CPU usage.
+type cpuLeaderHeap []*core.RegionInfo
+
+func (h cpuLeaderHeap) Len() int           { return len(h) }
+func (h cpuLeaderHeap) Less(i, j int) bool { return h[i].GetCPUUsage() < h[j].GetCPUUsage() }
+func (h cpuLeaderHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *cpuLeaderHeap) Push(x any) {
+	*h = append(*h, x.(*core.RegionInfo))
+}
+
+func (h *cpuLeaderHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// topCPULeaders returns at most k leader regions with the highest CPU usage in
+// descending order, which avoids sorting all the leaders of a store.
+func topCPULeaders(leaders []*core.RegionInfo, k int) []*core.RegionInfo {
+	h := make(cpuLeaderHeap, 0, k)
+	for _, region := range leaders {
+		if len(h) < k {
+			heap.Push(&h, region)
+		} else if region.GetCPUUsage() > h[0].GetCPUUsage() {
+			h[0] = region
+			heap.Fix(&h, 0)
+		}
+	}
+	sort.Slice(h, func(i, j int) bool {
+		return h[i].GetCPUUsage() > h[j].GetCPUUsage()
+	})
+	return h
+}
+
+// scheduleOnce picks a leader region from the store with the highest CPU usage, and moves
+// it to the store with the lowest CPU usage which makes the two stores more balanced.
+func (s *balanceCPUScheduler) scheduleOnce(cluster sche.SchedulerCluster, rs *rankRatios,
+	stores []*balanceCPUStore, usedRegions map[uint64]struct{}) *operator.Operator {
+	sort.SliceStable(stores, func(i, j int) bool {
+		return stores[i].load > stores[j].load
+	})
+	for i := 0; i < len(stores); i++ {
+		src := stores[i]
+		for j := len(stores) - 1; j > i; j-- {
+			dst := stores[j]
+			if src.load <= dst.load {
+				break
+			}
+			var best *core.RegionInfo
+			bestScore := 0
+			for _, region := range s.getCandidates(cluster, src, usedRegions) {
+				score := rs.getScore(src.load, dst.load, float64(region.GetCPUUsage()), src.topnRate, dst.topnRate, balanceCPUMinRegionUsage,
+					func(reverse, _ bool) bool { return !reverse })
+				if score > bestScore {
+					best, bestScore = region, score
+				}
+			}
+			if best == nil {
+				continue
+			}
+			op := s.createOperator(cluster, best, src, dst)
+			if op == nil {
+				usedRegions[best.GetID()] = struct{}{}
+				continue
+			}
+			usage := float64(best.GetCPUUsage())
+			src.load -= usage
+			dst.load += usage
+			return op
+		}
+	}
+	return nil
+}
+
+// getCandidates returns the leader regions of the store with the highest CPU usage
+// which can be scheduled.
+func (*balanceCPUScheduler) getCandidates(cluster sche.SchedulerCluster, store *balanceCPUStore, usedRegions map[uint64]struct{}) []*core.RegionInfo {
+	candidates := make([]*core.RegionInfo, 0, balanceCPUCandidateLimit)
+	for _, region := range store.leaders {
+		if len(candidates) >= balanceCPUCandidateLimit || region.GetCPUUsage() < balanceCPUMinRegionUsage {
+			break
+		}
+		if _, ok := usedRegions[region.GetID()]; ok {
+			continue
+		}
+		if !filter.IsRegionHealthy(region) || !filter.IsRegionReplicated(cluster, region) {
+			balanceCPUUnhealthyCounter.Inc()
+			continue
+		}
+		candidates = append(candidates, region)
+	}
+	return candidates
+}
+
+// createOperator transfers the leader of the region to the target store if it has a peer
+// of the region, otherwise moves the leader to it.
+func (s *balanceCPUScheduler) createOperator(cluster sche.SchedulerCluster, region *core.RegionInfo, src, dst *balanceCPUStore) *operator.Operator {
+	conf := cluster.GetSchedulerConfig()
+	var (
+		op  *operator.Operator
+		err error
+		typ string
+	)
+	if peer := region.GetStorePeer(dst.GetID()); peer != nil {
+		if region.GetStoreWitness(dst.GetID()) != nil {
+			return nil
+		}
+		filters := []filter.Filter{
+			&filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true, OperatorLevel: constant.Medium},
+		}
+		if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, src.StoreInfo, false /*allowMoveLeader*/); leaderFilter != nil {
+			filters = append(filters, leaderFilter)
+		}
+		if !filter.Target(conf, dst.StoreInfo, filters) {
+			balanceCPUNoTargetCounter.Inc()
+			return nil
+		}
+		typ = "transfer-leader"
+		op, err = operator.CreateTransferLeaderOperator(s.GetName(), cluster, region, dst.GetID(), []uint64{}, operator.OpLeader)
+	} else {
+		filters := []filter.Filter{
+			&filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.Medium},
+			filter.NewPlacementSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, src.StoreInfo, nil),
+		}
+		if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, src.StoreInfo, true /*allowMoveLeader*/); leaderFilter != nil {
+			filters = append(filters, leaderFilter)
+		}
+		if !filter.Target(conf, dst.StoreInfo, filters) {
+			balanceCPUNoTargetCounter.Inc()
+			return nil
+		}
+		typ = "move-leader"
+		srcPeer := region.GetStorePeer(src.GetID())
+		dstPeer := &metapb.Peer{StoreId: dst.GetID(), Role: srcPeer.Role}
+		op, err = operator.CreateMoveLeaderOperator(s.GetName(), cluster, region, operator.OpRegion, src.GetID(), dstPeer)
+	}
+	if err != nil {
+		log.Debug("fail to create balance cpu operator", zap.String("type", typ), errs.ZapError(err))
+		balanceCPUCreateOpFailCounter.Inc()
+		return nil
+	}
+	sourceLabel := strconv.FormatUint(src.GetID(), 10)
+	targetLabel := strconv.FormatUint(dst.GetID(), 10)
+	op.Counters = append(op.Counters, balanceCPUCounterWithEvent(typ))
+	op.FinishedCounters = append(op.FinishedCounters,
+		balanceDirectionCounter.WithLabelValues(s.GetName(), sourceLabel, targetLabel),
+	)
+	op.SetAdditionalInfo("sourceCPU", strconv.FormatFloat(src.load, 'f', 2, 64))
+	op.SetAdditionalInfo("targetCPU", strconv.FormatFloat(dst.load, 'f', 2, 64))
+	op.SetAdditionalInfo("regionCPU", strconv.FormatUint(region.GetCPUUsage(), 10))
+	return op
+}
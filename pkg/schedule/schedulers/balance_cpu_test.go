@@ -0,0 +1,199 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func setStoreCPUUsage(tc *mockcluster.Cluster, storeID uint64, readPool, grpc, others uint64) {
+	store := tc.GetStore(storeID)
+	stats := &pdpb.StoreStats{
+		StoreId: storeID,
+		CpuUsages: []*pdpb.RecordPair{
+			{Key: "unified-read-po-0", Value: readPool},
+			{Key: "grpc-server-0", Value: grpc},
+			{Key: "raftstore-0", Value: others},
+		},
+	}
+	tc.PutStore(store.Clone(core.SetStoreStats(stats)))
+}
+
+func addCPULeaderRegion(tc *mockcluster.Cluster, regionID uint64, cpuUsage uint64, leaderStoreID uint64, followerStoreIDs ...uint64) {
+	region := tc.AddLeaderRegion(regionID, leaderStoreID, followerStoreIDs...)
+	tc.PutRegion(region.Clone(core.SetCPUUsage(cpuUsage)))
+}
+
+func TestBalanceCPUScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(true)
+	defer cancel()
+
+	for id := uint64(1); id <= 4; id++ {
+		tc.AddRegionStore(id, 10)
+	}
+	// Only the unified read pool and gRPC threads are counted.
+	setStoreCPUUsage(tc, 1, 250, 50, 500)
+	setStoreCPUUsage(tc, 2, 80, 20, 0)
+	setStoreCPUUsage(tc, 3, 80, 20, 0)
+	setStoreCPUUsage(tc, 4, 250, 50, 0)
+	re.Equal(float64(300), getStoreCPUUsage(tc.GetStore(1)))
+	for i := range uint64(6) {
+		addCPULeaderRegion(tc, i+1, 40, 1, 2, 3)
+	}
+	// The regions with little CPU usage are not scheduled.
+	addCPULeaderRegion(tc, 7, 0, 1, 2, 3)
+	addCPULeaderRegion(tc, 8, 40, 4, 2, 3)
+
+	sb, err := CreateScheduler(types.BalanceCPUScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceCPUScheduler, nil))
+	re.NoError(err)
+	// The leaders are only known from the heartbeats.
+	ops, _ := sb.Schedule(tc, false)
+	re.Empty(ops)
+	for _, region := range tc.GetRegions() {
+		sb.(RegionObserver).ObserveRegion(region)
+	}
+	re.True(sb.IsScheduleAllowed(tc))
+	ops, _ = sb.Schedule(tc, false)
+	re.NotEmpty(ops)
+	re.LessOrEqual(len(ops), balanceCPUBatchSize)
+	moved := make(map[uint64]float64)
+	for _, op := range ops {
+		re.Equal(operator.OpLeader, op.Kind()&operator.OpLeader)
+		re.NotEqual(uint64(7), op.RegionID())
+		step, ok := op.Step(0).(operator.TransferLeader)
+		re.True(ok)
+		re.Contains([]uint64{1, 4}, step.FromStore)
+		re.Contains([]uint64{2, 3}, step.ToStore)
+		moved[step.FromStore] -= 40
+		moved[step.ToStore] += 40
+	}
+	// The stores are more balanced, but not reversed.
+	for _, id := range []uint64{1, 4} {
+		re.GreaterOrEqual(300+moved[id], 100+moved[2])
+		re.GreaterOrEqual(300+moved[id], 100+moved[3])
+	}
+
+	// The running operators are considered.
+	oc.AddWaitingOperator(ops...)
+	loads := sb.(*balanceCPUScheduler).getStoreLoads(tc, tc.GetStores(), false)
+	for _, load := range loads {
+		re.Equal(getStoreCPUUsage(load.StoreInfo)+moved[load.GetID()], load.load)
+	}
+
+	// The leader is moved to the store without a peer of the region.
+	for _, op := range ops {
+		oc.RemoveOperator(op)
+	}
+	setStoreCPUUsage(tc, 2, 250, 50, 0)
+	setStoreCPUUsage(tc, 3, 250, 50, 0)
+	setStoreCPUUsage(tc, 4, 0, 0, 0)
+	tc.PutRegion(tc.GetRegion(8).Clone(core.SetCPUUsage(0)))
+	sb.(RegionObserver).ObserveRegion(tc.GetRegion(8))
+	ops, _ = sb.Schedule(tc, false)
+	re.NotEmpty(ops)
+	for _, op := range ops {
+		re.Equal(operator.OpRegion, op.Kind()&operator.OpRegion)
+		re.Equal(uint64(4), op.Step(0).(operator.AddLearner).ToStore)
+	}
+
+	// It does nothing if the stores are balanced.
+	setStoreCPUUsage(tc, 1, 100, 0, 0)
+	setStoreCPUUsage(tc, 2, 95, 0, 0)
+	setStoreCPUUsage(tc, 3, 95, 0, 0)
+	setStoreCPUUsage(tc, 4, 95, 0, 0)
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+
+	// Update the config.
+	req, _ := http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"balanced-ratio": 0.5}`))
+	resp := httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusBadRequest, resp.Code)
+	req, _ = http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"batch": 2, "balanced-ratio": 0.8}`))
+	resp = httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusOK, resp.Code)
+	conf := sb.(*balanceCPUScheduler).conf.clone()
+	re.Equal(2, conf.Batch)
+	re.Equal(0.8, conf.BalancedRatio)
+}
+
+func TestTopCPULeaders(t *testing.T) {
+	re := require.New(t)
+	var leaders []*core.RegionInfo
+	for _, usage := range []uint64{3, 9, 1, 7, 5, 8, 2} {
+		leaders = append(leaders, core.NewTestRegionInfo(usage, 1, nil, nil, core.SetCPUUsage(usage)))
+	}
+	getUsages := func(regions []*core.RegionInfo) []uint64 {
+		usages := make([]uint64, 0, len(regions))
+		for _, region := range regions {
+			usages = append(usages, region.GetCPUUsage())
+		}
+		return usages
+	}
+	re.Equal([]uint64{9, 8, 7}, getUsages(topCPULeaders(leaders, 3)))
+	re.Equal([]uint64{9, 8, 7, 5, 3, 2, 1}, getUsages(topCPULeaders(leaders, 10)))
+	re.Empty(topCPULeaders(nil, 3))
+}
+
+func TestCPULeaderCache(t *testing.T) {
+	re := require.New(t)
+	cache := newCPULeaderCache(3)
+	newRegion := func(id, leaderStoreID, usage uint64) *core.RegionInfo {
+		return core.NewTestRegionInfo(id, leaderStoreID, nil, nil, core.SetCPUUsage(usage))
+	}
+	getIDs := func(storeID uint64) []uint64 {
+		ids := cache.get(storeID)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+	for id, usage := range []uint64{5, 3, 8, 0} {
+		cache.update(newRegion(uint64(id+1), 1, usage))
+	}
+	// The regions with little CPU usage are not kept.
+	re.Equal([]uint64{1, 2, 3}, getIDs(1))
+	// The region with the lowest CPU usage is replaced once the store is full.
+	cache.update(newRegion(5, 1, 2))
+	re.Equal([]uint64{1, 2, 3}, getIDs(1))
+	cache.update(newRegion(5, 1, 4))
+	re.Equal([]uint64{1, 3, 5}, getIDs(1))
+	// The region moves with its leader.
+	cache.update(newRegion(3, 2, 8))
+	re.Equal([]uint64{1, 5}, getIDs(1))
+	re.Equal([]uint64{3}, getIDs(2))
+	// The region is removed once it becomes cold.
+	cache.update(newRegion(1, 1, 0))
+	re.Equal([]uint64{5}, getIDs(1))
+	cache.remove(3, 5)
+	re.Empty(getIDs(1))
+	re.Empty(getIDs(2))
+	re.Empty(cache.stores)
+	re.Empty(cache.leaders)
+}
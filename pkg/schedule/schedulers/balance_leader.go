@@ -545,6 +545,9 @@ func (s *balanceLeaderScheduler) createOperator(solver *solver, collector *plan.
 		}
 		return nil
 	}
+	if solver.kind.Policy == constant.ByLoad {
+		op.SetLeaderLoad(solver.getRegionLeaderLoad())
+	}
 	op.Counters = append(op.Counters,
 		balanceLeaderNewOpCounter,
 	)
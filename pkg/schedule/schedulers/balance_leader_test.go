@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
@@ -34,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/utils/operatorutil"
 	"github.com/tikv/pd/pkg/versioninfo"
@@ -155,6 +157,51 @@ func (suite *balanceLeaderSchedulerTestSuite) TestBalanceLeaderSchedulePolicy()
 	re.NotEmpty(suite.schedule())
 }
 
+func (suite *balanceLeaderSchedulerTestSuite) TestBalanceLeaderByLoad() {
+	re := suite.Require()
+	// Stores:          1       2       3       4
+	// Leader Count:    10      10      10      10
+	// Leader QPS  :    5000    10      10      10
+	// Region1:         L       F       F       F
+	suite.tc.AddLeaderStore(1, 10)
+	suite.tc.AddLeaderStore(2, 10)
+	suite.tc.AddLeaderStore(3, 10)
+	suite.tc.AddLeaderStore(4, 10)
+	// The read queries of the leaders are reported by the store heartbeats.
+	suite.tc.AddLeaderRegion(1, 1, 2, 3, 4)
+	suite.tc.UpdateStorePeerStats(1, []*pdpb.PeerStat{{RegionId: 1, QueryStats: &pdpb.QueryStats{Get: 49900}}}, 10)
+	suite.tc.UpdateStorePeerStats(2, []*pdpb.PeerStat{{RegionId: 1, QueryStats: &pdpb.QueryStats{Get: 9900}}}, 10)
+	suite.tc.UpdateLeaderWriteQueryRate(1, 10)
+	suite.tc.UpdateLeaderWriteQueryRate(2, 10)
+	suite.tc.UpdateLeaderWriteQueryRate(3, 10)
+	suite.tc.UpdateLeaderWriteQueryRate(4, 10)
+	re.Equal(float64(5000), suite.tc.GetStore(1).GetLeaderQueryRate())
+	// The read queries of the follower are not counted.
+	re.Equal(float64(10), suite.tc.GetStore(2).GetLeaderQueryRate())
+	// The read queries of the region come from the hot peer cache, and the ones of
+	// the region heartbeat are not counted again. The region is not hot enough to
+	// be skipped.
+	suite.tc.SetHotRegionCacheHitsThreshold(1000)
+	putRegionQPS := func(readQPS uint64) {
+		suite.tc.AddRegionLeaderWithReadInfo(1, 1, 0, 0, readQPS*utils.StoreHeartBeatReportInterval,
+			utils.StoreHeartBeatReportInterval, []uint64{2, 3, 4}, utils.DefaultReadMfSize)
+	}
+	putRegionQPS(200)
+	re.Empty(suite.schedule())
+
+	suite.tc.SetLeaderSchedulePolicy(constant.ByLoad.String())
+	ops := suite.schedule()
+	re.Len(ops, 1)
+	operatorutil.CheckTransferLeaderFrom(re, ops[0], operator.OpKind(0), 1)
+	influence := *operator.NewOpInfluence()
+	ops[0].UnfinishedInfluence(influence, suite.tc.GetRegion(1))
+	re.Equal(int64(-200), influence.GetStoreInfluence(1).LeaderLoad)
+
+	// The leader is too hot to be moved, it would make the target the hottest.
+	putRegionQPS(3000)
+	re.Empty(suite.schedule())
+}
+
 func (suite *balanceLeaderSchedulerTestSuite) TestBalanceLeaderTolerantRatio() {
 	re := suite.Require()
 	suite.tc.SetTolerantSizeRatio(2.5)
@@ -16,7 +16,12 @@ package schedulers
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"time"
@@ -25,6 +30,7 @@ import (
 	"github.com/unrolled/render"
 	"go.uber.org/zap"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/metapb"
 	"github.com/pingcap/log"
 
@@ -37,9 +43,17 @@ import (
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
+const (
+	// balanceRangeMaxRunningJobs is the max number of jobs which can run at the same time.
+	balanceRangeMaxRunningJobs = 4
+	balanceRangeDefaultTimeout = "1h"
+)
+
 type balanceRangeSchedulerHandler struct {
 	rd     *render.Render
 	config *balanceRangeSchedulerConfig
@@ -53,6 +67,9 @@ func newBalanceRangeHandler(conf *balanceRangeSchedulerConfig) http.Handler {
 	router := mux.NewRouter()
 	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
 	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/job", handler.addJob).Methods(http.MethodPost)
+	router.HandleFunc("/job/{job-id}", handler.updateJob).Methods(http.MethodPatch)
+	router.HandleFunc("/progress", handler.listProgress).Methods(http.MethodGet)
 	return router
 }
 
@@ -67,23 +84,268 @@ func (handler *balanceRangeSchedulerHandler) listConfig(w http.ResponseWriter, _
 	}
 }
 
+// addJob adds a new job, the input is the same as the one to create the scheduler,
+// besides the optional priority and mode.
+func (handler *balanceRangeSchedulerHandler) addJob(w http.ResponseWriter, r *http.Request) {
+	var input map[string]any
+	if err := apiutil.ReadJSONRespondError(handler.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	var args []string
+	collector := func(v string) {
+		args = append(args, v)
+	}
+	for _, key := range []string{"role", "engine", "timeout", "alias", "start-key", "end-key"} {
+		err := apiutil.CollectStringOption(key, input, collector)
+		if err != nil && key == "timeout" && errors.ErrorEqual(err, errs.ErrOptionNotExist) {
+			collector(balanceRangeDefaultTimeout)
+			continue
+		}
+		if err != nil {
+			handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	job, err := newBalanceRangeSchedulerJob(args)
+	if err != nil {
+		handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := updateBalanceRangeJob(job, input); err != nil {
+		handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := handler.config.addJob(job); err != nil {
+		handler.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	handler.rd.JSON(w, http.StatusOK, job.JobID)
+}
+
+// updateJob updates the priority or the mode of a job.
+func (handler *balanceRangeSchedulerHandler) updateJob(w http.ResponseWriter, r *http.Request) {
+	jobID, err := strconv.ParseUint(mux.Vars(r)["job-id"], 10, 64)
+	if err != nil {
+		handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var input map[string]any
+	if err := apiutil.ReadJSONRespondError(handler.rd, w, r.Body, &input); err != nil {
+		return
+	}
+	if len(input) == 0 {
+		handler.rd.JSON(w, http.StatusBadRequest, "priority or mode should be specified")
+		return
+	}
+	var updateErr error
+	err = handler.config.updateJob(jobID, func(job *balanceRangeSchedulerJob) bool {
+		updateErr = updateBalanceRangeJob(job, input)
+		return updateErr == nil
+	})
+	if updateErr != nil {
+		handler.rd.JSON(w, http.StatusBadRequest, updateErr.Error())
+		return
+	}
+	if err != nil {
+		if errors.ErrorEqual(err, errs.ErrSchedulerConfig) {
+			handler.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		handler.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	handler.rd.JSON(w, http.StatusOK, "The job is updated.")
+}
+
+// updateBalanceRangeJob updates the priority and the mode of the job by the input.
+func updateBalanceRangeJob(job *balanceRangeSchedulerJob, input map[string]any) error {
+	if priority, ok := input["priority"]; ok {
+		p, ok := priority.(float64)
+		if !ok {
+			return errs.ErrSchedulerConfig.FastGenByArgs("priority should be a number")
+		}
+		job.Priority = int(p)
+	}
+	if mode, ok := input["mode"]; ok {
+		m, ok := mode.(string)
+		if !ok {
+			return errs.ErrSchedulerConfig.FastGenByArgs("mode should be a string")
+		}
+		parsed, err := newBalanceRangeMode(m)
+		if err != nil {
+			return err
+		}
+		job.Mode = parsed
+	}
+	return nil
+}
+
+func (handler *balanceRangeSchedulerHandler) listProgress(w http.ResponseWriter, _ *http.Request) {
+	handler.rd.JSON(w, http.StatusOK, handler.config.progress())
+}
+
 type balanceRangeSchedulerConfig struct {
 	syncutil.RWMutex
 	schedulerConfig
 	jobs []*balanceRangeSchedulerJob
+	// movedChanged is true if the moved counts of the jobs are not persisted yet.
+	movedChanged bool
+}
+
+// MarshalJSON marshals the jobs, it is used to persist the config.
+func (conf *balanceRangeSchedulerConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(conf.jobs)
+}
+
+// UnmarshalJSON unmarshals the jobs.
+func (conf *balanceRangeSchedulerConfig) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &conf.jobs)
 }
 
 type balanceRangeSchedulerJob struct {
-	JobID   uint64          `json:"job-id"`
-	Role    core.Role       `json:"role"`
-	Engine  string          `json:"engine"`
-	Timeout time.Duration   `json:"timeout"`
-	Ranges  []core.KeyRange `json:"ranges"`
-	Alias   string          `json:"alias"`
-	Start   *time.Time      `json:"start,omitempty"`
-	Finish  *time.Time      `json:"finish,omitempty"`
-	Create  time.Time       `json:"create"`
-	Status  JobStatus       `json:"status"`
+	JobID    uint64          `json:"job-id"`
+	Role     core.Role       `json:"role"`
+	Engine   string          `json:"engine"`
+	Timeout  time.Duration   `json:"timeout"`
+	Ranges   []core.KeyRange `json:"ranges"`
+	Alias    string          `json:"alias"`
+	Priority int             `json:"priority"`
+	// Mode is empty for the jobs created before the mode is introduced, which is the same as count.
+	Mode   balanceRangeMode `json:"mode,omitempty"`
+	Start  *time.Time       `json:"start,omitempty"`
+	Finish *time.Time       `json:"finish,omitempty"`
+	Create time.Time        `json:"create"`
+	Status JobStatus        `json:"status"`
+	// Moved is the number of the finished operators of the job.
+	Moved uint64 `json:"moved,omitempty"`
+
+	// scoreGap is updated on every schedule, so it is not persisted.
+	scoreGap int64
+}
+
+// newBalanceRangeSchedulerJob creates a pending job with the args:
+// [role, engine, timeout, alias, range1, range2, ...]
+func newBalanceRangeSchedulerJob(args []string) (*balanceRangeSchedulerJob, error) {
+	if len(args) < 5 {
+		return nil, errs.ErrSchedulerConfig.FastGenByArgs("args length must be greater than 4")
+	}
+	roleString, err := url.QueryUnescape(args[0])
+	if err != nil {
+		return nil, errs.ErrQueryUnescape.Wrap(err)
+	}
+	role := core.NewRole(roleString)
+	if role == core.Unknown {
+		return nil, errs.ErrQueryUnescape.FastGenByArgs("role")
+	}
+	engine, err := url.QueryUnescape(args[1])
+	if err != nil {
+		return nil, errs.ErrQueryUnescape.Wrap(err)
+	}
+	if engine != core.EngineTiFlash && engine != core.EngineTiKV {
+		return nil, errs.ErrQueryUnescape.FastGenByArgs("engine must be tikv or tiflash ")
+	}
+	timeout, err := url.QueryUnescape(args[2])
+	if err != nil {
+		return nil, errs.ErrQueryUnescape.Wrap(err)
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, errs.ErrURLParse.Wrap(err)
+	}
+	alias, err := url.QueryUnescape(args[3])
+	if err != nil {
+		return nil, errs.ErrURLParse.Wrap(err)
+	}
+	ranges, err := getKeyRanges(args[4:])
+	if err != nil {
+		return nil, err
+	}
+	return &balanceRangeSchedulerJob{
+		Role:    role,
+		Engine:  engine,
+		Timeout: duration,
+		Alias:   alias,
+		Ranges:  ranges,
+		Mode:    balanceRangeCount,
+		Status:  pending,
+		Create:  time.Now(),
+	}, nil
+}
+
+func (job *balanceRangeSchedulerJob) clone() *balanceRangeSchedulerJob {
+	ranges := make([]core.KeyRange, len(job.Ranges))
+	copy(ranges, job.Ranges)
+	return &balanceRangeSchedulerJob{
+		Ranges:   ranges,
+		Role:     job.Role,
+		Engine:   job.Engine,
+		Timeout:  job.Timeout,
+		Alias:    job.Alias,
+		JobID:    job.JobID,
+		Priority: job.Priority,
+		Mode:     job.Mode,
+		Start:    job.Start,
+		Finish:   job.Finish,
+		Create:   job.Create,
+		Status:   job.Status,
+		Moved:    job.Moved,
+		scoreGap: job.scoreGap,
+	}
+}
+
+// overlaps returns true if any range of the job overlaps with the ranges.
+func (job *balanceRangeSchedulerJob) overlaps(ranges []core.KeyRange) bool {
+	for _, a := range job.Ranges {
+		for _, b := range ranges {
+			if (len(b.EndKey) == 0 || bytes.Compare(a.StartKey, b.EndKey) < 0) &&
+				(len(a.EndKey) == 0 || bytes.Compare(b.StartKey, a.EndKey) < 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextJobID returns the id of the next job, the caller should hold the lock.
+func (conf *balanceRangeSchedulerConfig) nextJobID() uint64 {
+	if len(conf.jobs) == 0 {
+		return 0
+	}
+	return conf.jobs[len(conf.jobs)-1].JobID + 1
+}
+
+func (conf *balanceRangeSchedulerConfig) addJob(job *balanceRangeSchedulerJob) error {
+	conf.Lock()
+	defer conf.Unlock()
+	job.JobID = conf.nextJobID()
+	conf.jobs = append(conf.jobs, job)
+	if err := conf.save(); err != nil {
+		conf.jobs = conf.jobs[:len(conf.jobs)-1]
+		return err
+	}
+	return nil
+}
+
+// updateJob updates the job by the function, the update is discarded if the function returns false.
+func (conf *balanceRangeSchedulerConfig) updateJob(jobID uint64, update func(job *balanceRangeSchedulerJob) bool) error {
+	conf.Lock()
+	defer conf.Unlock()
+	for i, job := range conf.jobs {
+		if job.JobID != jobID {
+			continue
+		}
+		updated := job.clone()
+		if !update(updated) {
+			return nil
+		}
+		conf.jobs[i] = updated
+		if err := conf.save(); err != nil {
+			conf.jobs[i] = job
+			return err
+		}
+		return nil
+	}
+	return errs.ErrSchedulerConfig.FastGenByArgs(fmt.Sprintf("job %d", jobID))
 }
 
 func (conf *balanceRangeSchedulerConfig) begin(index int) *balanceRangeSchedulerJob {
@@ -122,16 +384,117 @@ func (conf *balanceRangeSchedulerConfig) finish(index int) *balanceRangeSchedule
 	return job
 }
 
-func (conf *balanceRangeSchedulerConfig) peek() (int, *balanceRangeSchedulerJob) {
+// unfinished returns the indexes of the unfinished jobs, the jobs with higher
+// priority come first, and the earlier created ones come first for the same priority.
+func (conf *balanceRangeSchedulerConfig) unfinished() []int {
 	conf.RLock()
 	defer conf.RUnlock()
+	indexes := make([]int, 0, len(conf.jobs))
 	for index, job := range conf.jobs {
-		if job.Status == finished {
-			continue
+		if job.Status != finished {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return conf.jobs[indexes[i]].Priority > conf.jobs[indexes[j]].Priority
+	})
+	return indexes
+}
+
+func (conf *balanceRangeSchedulerConfig) getJob(index int) *balanceRangeSchedulerJob {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.jobs[index].clone()
+}
+
+// runningJobs returns the running jobs ordered by priority.
+func (conf *balanceRangeSchedulerConfig) runningJobs() []*balanceRangeSchedulerJob {
+	var jobs []*balanceRangeSchedulerJob
+	for _, index := range conf.unfinished() {
+		if job := conf.getJob(index); job.Status == running {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// recordMoved records a finished operator of the job. It is called by the operator
+// controller, so the moved count is persisted later by saveMoved.
+func (conf *balanceRangeSchedulerConfig) recordMoved(jobID uint64) {
+	conf.Lock()
+	defer conf.Unlock()
+	for _, job := range conf.jobs {
+		if job.JobID == jobID {
+			job.Moved++
+			conf.movedChanged = true
+			return
 		}
-		return index, job
 	}
-	return 0, nil
+}
+
+// saveMoved persists the moved counts of the jobs if they are changed.
+func (conf *balanceRangeSchedulerConfig) saveMoved() {
+	conf.Lock()
+	defer conf.Unlock()
+	if !conf.movedChanged {
+		return
+	}
+	if err := conf.save(); err != nil {
+		log.Warn("failed to persist config", zap.Error(err))
+		return
+	}
+	conf.movedChanged = false
+}
+
+func (conf *balanceRangeSchedulerConfig) updateScoreGap(jobID uint64, gap int64) {
+	conf.Lock()
+	defer conf.Unlock()
+	for _, job := range conf.jobs {
+		if job.JobID == jobID {
+			job.scoreGap = gap
+			return
+		}
+	}
+}
+
+// balanceRangeJobProgress is the progress of a job.
+type balanceRangeJobProgress struct {
+	JobID    uint64    `json:"job-id"`
+	Alias    string    `json:"alias"`
+	Priority int       `json:"priority"`
+	Status   JobStatus `json:"status"`
+	// RegionsMoved is the number of the finished operators of the job.
+	RegionsMoved uint64 `json:"regions-moved"`
+	// ScoreGap is the sum of the score exceeding the average score of all stores,
+	// which is the number of peers still need to be moved. It is updated on every
+	// schedule of the running job.
+	ScoreGap int64 `json:"score-gap"`
+	// ETA is estimated by the speed of the moved regions, it is empty if unknown.
+	ETA *time.Time `json:"eta,omitempty"`
+}
+
+func (conf *balanceRangeSchedulerConfig) progress() []*balanceRangeJobProgress {
+	conf.RLock()
+	defer conf.RUnlock()
+	now := time.Now()
+	progress := make([]*balanceRangeJobProgress, 0, len(conf.jobs))
+	for _, job := range conf.jobs {
+		p := &balanceRangeJobProgress{
+			JobID:        job.JobID,
+			Alias:        job.Alias,
+			Priority:     job.Priority,
+			Status:       job.Status,
+			RegionsMoved: job.Moved,
+			ScoreGap:     job.scoreGap,
+		}
+		if job.Status == running && job.Moved > 0 && job.Start != nil {
+			elapsed := now.Sub(*job.Start)
+			eta := now.Add(time.Duration(float64(elapsed) / float64(job.Moved) * float64(job.scoreGap)))
+			p.ETA = &eta
+		}
+		progress = append(progress, p)
+	}
+	return progress
 }
 
 func (conf *balanceRangeSchedulerConfig) clone() []*balanceRangeSchedulerJob {
@@ -139,17 +502,7 @@ func (conf *balanceRangeSchedulerConfig) clone() []*balanceRangeSchedulerJob {
 	defer conf.RUnlock()
 	jobs := make([]*balanceRangeSchedulerJob, 0, len(conf.jobs))
 	for _, job := range conf.jobs {
-		ranges := make([]core.KeyRange, len(job.Ranges))
-		copy(ranges, job.Ranges)
-		jobs = append(jobs, &balanceRangeSchedulerJob{
-			Ranges:  ranges,
-			Role:    job.Role,
-			Engine:  job.Engine,
-			Timeout: job.Timeout,
-			Alias:   job.Alias,
-			JobID:   job.JobID,
-			Start:   job.Start,
-		})
+		jobs = append(jobs, job.clone())
 	}
 
 	return jobs
@@ -167,10 +520,19 @@ func (s *balanceRangeScheduler) ReloadConfig() error {
 	s.conf.Lock()
 	defer s.conf.Unlock()
 
-	jobs := make([]*balanceRangeSchedulerJob, 0, len(s.conf.jobs))
-	if err := s.conf.load(jobs); err != nil {
+	var jobs []*balanceRangeSchedulerJob
+	if err := s.conf.load(&jobs); err != nil {
 		return err
 	}
+	// Keep the progress of the jobs which is not persisted yet.
+	for _, job := range jobs {
+		for _, old := range s.conf.jobs {
+			if old.JobID == job.JobID {
+				job.Moved, job.scoreGap = max(job.Moved, old.Moved), old.scoreGap
+				break
+			}
+		}
+	}
 	s.conf.jobs = jobs
 	return nil
 }
@@ -194,19 +556,44 @@ func (s *balanceRangeScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster)
 	if !allowed {
 		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRange)
 	}
-	index, job := s.conf.peek()
-	if job != nil {
-		if job.Status == pending {
-			job = s.conf.begin(index)
+	s.conf.saveMoved()
+	s.updateJobs()
+	return allowed
+}
+
+// updateJobs finishes the expired jobs and begins the pending jobs. The jobs with
+// higher priority begin first, and the running jobs never overlap with each other.
+func (s *balanceRangeScheduler) updateJobs() {
+	indexes := s.conf.unfinished()
+	var runningJobs []*balanceRangeSchedulerJob
+	for _, index := range indexes {
+		job := s.conf.getJob(index)
+		if job.Status != running {
+			continue
 		}
 		// todo: add other conditions such as the diff of the score between the source and target store.
 		if time.Since(*job.Start) > job.Timeout {
-			s.conf.finish(index)
-			balanceRangeExpiredCounter.Inc()
+			if s.conf.finish(index) != nil {
+				balanceRangeExpiredCounter.Inc()
+				continue
+			}
+		}
+		runningJobs = append(runningJobs, job)
+	}
+	for _, index := range indexes {
+		if len(runningJobs) >= balanceRangeMaxRunningJobs {
+			return
+		}
+		job := s.conf.getJob(index)
+		if job.Status != pending || slices.ContainsFunc(runningJobs, func(r *balanceRangeSchedulerJob) bool {
+			return r.overlaps(job.Ranges)
+		}) {
+			continue
+		}
+		if s.conf.begin(index) != nil {
+			runningJobs = append(runningJobs, job)
 		}
 	}
-
-	return allowed
 }
 
 // BalanceRangeCreateOption is used to create a scheduler with an option.
@@ -234,19 +621,42 @@ func newBalanceRangeScheduler(opController *operator.Controller, conf *balanceRa
 
 // Schedule schedules the balance key range operator.
 func (s *balanceRangeScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) ([]*operator.Operator, []plan.Plan) {
+	return s.schedule(cluster, false), nil
+}
+
+// DryRun implements the DryRunScheduler interface, the score gaps of the jobs are not updated.
+func (s *balanceRangeScheduler) DryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	return s.schedule(cluster, true), nil
+}
+
+func (s *balanceRangeScheduler) schedule(cluster sche.SchedulerCluster, dryRun bool) []*operator.Operator {
 	balanceRangeCounter.Inc()
-	_, job := s.conf.peek()
-	if job == nil {
+	jobs := s.conf.runningJobs()
+	if len(jobs) == 0 {
 		balanceRangeNoJobCounter.Inc()
-		return nil, nil
+		return nil
+	}
+	// Every running job schedules at most one operator, so that the jobs
+	// make progress at the same time.
+	var ops []*operator.Operator
+	for _, job := range jobs {
+		if op := s.scheduleJob(cluster, job, dryRun); op != nil {
+			ops = append(ops, op)
+		}
 	}
+	return ops
+}
 
+func (s *balanceRangeScheduler) scheduleJob(cluster sche.SchedulerCluster, job *balanceRangeSchedulerJob, dryRun bool) *operator.Operator {
 	opInfluence := s.OpController.GetOpInfluence(cluster.GetBasicCluster(), operator.WithRangeOption(job.Ranges))
 	// todo: don't prepare every times, the prepare information can be reused.
 	plan, err := s.prepare(cluster, opInfluence, job)
 	if err != nil {
-		log.Error("failed to prepare balance key range scheduler", errs.ZapError(err))
-		return nil, nil
+		log.Error("failed to prepare balance key range scheduler", zap.Uint64("job-id", job.JobID), errs.ZapError(err))
+		return nil
+	}
+	if !dryRun {
+		s.conf.updateScoreGap(job.JobID, plan.scoreGap())
 	}
 
 	downFilter := filter.NewRegionDownFilter()
@@ -261,21 +671,26 @@ func (s *balanceRangeScheduler) Schedule(cluster sche.SchedulerCluster, _ bool)
 		if plan.sourceScore < plan.averageScore {
 			break
 		}
-		switch job.Role {
-		case core.Leader:
-			plan.region = filter.SelectOneRegion(cluster.RandLeaderRegions(plan.sourceStoreID(), job.Ranges), nil, baseRegionFilters...)
-		case core.Learner:
-			plan.region = filter.SelectOneRegion(cluster.RandLearnerRegions(plan.sourceStoreID(), job.Ranges), nil, baseRegionFilters...)
-		case core.Follower:
-			plan.region = filter.SelectOneRegion(cluster.RandFollowerRegions(plan.sourceStoreID(), job.Ranges), nil, baseRegionFilters...)
+		if job.Mode.isFlow() {
+			plan.region = plan.selectHotRegion(baseRegionFilters)
+		} else {
+			switch job.Role {
+			case core.Leader:
+				plan.region = filter.SelectOneRegion(cluster.RandLeaderRegions(plan.sourceStoreID(), job.Ranges), nil, baseRegionFilters...)
+			case core.Learner:
+				plan.region = filter.SelectOneRegion(cluster.RandLearnerRegions(plan.sourceStoreID(), job.Ranges), nil, baseRegionFilters...)
+			case core.Follower:
+				plan.region = filter.SelectOneRegion(cluster.RandFollowerRegions(plan.sourceStoreID(), job.Ranges), nil, baseRegionFilters...)
+			}
+			plan.regionScore = plan.unitScore
 		}
 		if plan.region == nil {
 			balanceRangeNoRegionCounter.Inc()
 			continue
 		}
 		log.Debug("select region", zap.String("scheduler", s.GetName()), zap.Uint64("region-id", plan.region.GetID()))
-		// Skip hot regions.
-		if cluster.IsRegionHot(plan.region) {
+		// Skip hot regions unless balancing the flow, in which the hot regions are the ones to be moved.
+		if !job.Mode.isFlow() && cluster.IsRegionHot(plan.region) {
 			log.Debug("region is hot", zap.String("scheduler", s.GetName()), zap.Uint64("region-id", plan.region.GetID()))
 			balanceRangeHotCounter.Inc()
 			continue
@@ -289,10 +704,14 @@ func (s *balanceRangeScheduler) Schedule(cluster sche.SchedulerCluster, _ bool)
 		plan.fit = replicaFilter.(*filter.RegionReplicatedFilter).GetFit()
 		if op := s.transferPeer(plan, plan.stores[sourceIndex+1:]); op != nil {
 			op.Counters = append(op.Counters, balanceRangeNewOperatorCounter)
-			return []*operator.Operator{op}, nil
+			op.FinishedCounters = append(op.FinishedCounters, balanceRangeFinishedOperatorCounter)
+			jobID := job.JobID
+			op.FinishedHooks = append(op.FinishedHooks, func() { s.conf.recordMoved(jobID) })
+			op.SetAdditionalInfo("jobID", strconv.FormatUint(job.JobID, 10))
+			return op
 		}
 	}
-	return nil, nil
+	return nil
 }
 
 // transferPeer selects the best store to create a new peer to replace the old peer.
@@ -351,9 +770,9 @@ func (s *balanceRangeScheduler) transferPeer(plan *balanceRangeSchedulerPlan, ds
 		op.FinishedCounters = append(op.FinishedCounters,
 			balanceDirectionCounter.WithLabelValues(s.GetName(), sourceLabel, targetLabel),
 		)
-		op.SetAdditionalInfo("sourceScore", strconv.FormatInt(plan.sourceScore, 10))
-		op.SetAdditionalInfo("targetScore", strconv.FormatInt(plan.targetScore, 10))
-		op.SetAdditionalInfo("tolerate", strconv.FormatInt(plan.tolerate, 10))
+		op.SetAdditionalInfo("sourceScore", formatBalanceRangeScore(plan.sourceScore))
+		op.SetAdditionalInfo("targetScore", formatBalanceRangeScore(plan.targetScore))
+		op.SetAdditionalInfo("tolerate", formatBalanceRangeScore(plan.tolerate))
 		return op
 	}
 	balanceRangeNoReplacementCounter.Inc()
@@ -366,17 +785,29 @@ type balanceRangeSchedulerPlan struct {
 	// stores is sorted by score desc
 	stores []*core.StoreInfo
 	// scoreMap records the storeID -> score
-	scoreMap     map[uint64]int64
+	scoreMap map[uint64]float64
+	// hotPeers records the storeID -> hot peers sorted by score desc, it is
+	// only used to balance the flow.
+	hotPeers     map[uint64][]*balanceRangeHotPeer
 	source       *core.StoreInfo
-	sourceScore  int64
+	sourceScore  float64
 	target       *core.StoreInfo
-	targetScore  int64
+	targetScore  float64
 	region       *core.RegionInfo
+	regionScore  float64
 	fit          *placement.RegionFit
-	averageScore int64
-	job          *balanceRangeSchedulerJob
-	opInfluence  operator.OpInfluence
-	tolerate     int64
+	averageScore float64
+	// unitScore is the average score of a peer, it is 1 if balancing the count.
+	unitScore   float64
+	job         *balanceRangeSchedulerJob
+	opInfluence operator.OpInfluence
+	tolerate    float64
+}
+
+// balanceRangeHotPeer is a hot peer within the range and its score.
+type balanceRangeHotPeer struct {
+	region *core.RegionInfo
+	score  float64
 }
 
 func fetchAllRegions(cluster sche.SchedulerCluster, ranges *core.KeyRanges) []*core.RegionInfo {
@@ -425,31 +856,50 @@ func (s *balanceRangeScheduler) prepare(cluster sche.SchedulerCluster, opInfluen
 	}
 
 	// storeID <--> score mapping
-	scoreMap := make(map[uint64]int64, len(sources))
+	scoreMap := make(map[uint64]float64, len(sources))
 	for _, source := range sources {
 		scoreMap[source.GetID()] = 0
 	}
-	totalScore := int64(0)
+	var hotPeers map[uint64][]*balanceRangeHotPeer
+	if job.Mode.isFlow() {
+		hotPeers = collectHotPeers(cluster, job, scanRegions, len(sources))
+	}
+	totalScore, peerCount := float64(0), 0
 	for _, region := range scanRegions {
 		for _, peer := range region.GetPeersByRole(job.Role) {
-			scoreMap[peer.GetStoreId()] += 1
-			totalScore += 1
+			peerCount++
+			if !job.Mode.isFlow() {
+				scoreMap[peer.GetStoreId()] += 1
+				totalScore += 1
+			}
+		}
+	}
+	for storeID, peers := range hotPeers {
+		for _, peer := range peers {
+			scoreMap[storeID] += peer.score
+			totalScore += peer.score
+		}
+	}
+	unitScore := float64(1)
+	if job.Mode.isFlow() {
+		unitScore = 0
+		if peerCount > 0 {
+			unitScore = totalScore / float64(peerCount)
 		}
 	}
 
 	sort.Slice(sources, func(i, j int) bool {
 		role := job.Role
-		iop := opInfluence.GetStoreInfluence(sources[i].GetID()).GetStoreInfluenceByRole(role)
-		jop := opInfluence.GetStoreInfluence(sources[j].GetID()).GetStoreInfluenceByRole(role)
+		iop := float64(opInfluence.GetStoreInfluence(sources[i].GetID()).GetStoreInfluenceByRole(role)) * unitScore
+		jop := float64(opInfluence.GetStoreInfluence(sources[j].GetID()).GetStoreInfluenceByRole(role)) * unitScore
 		iScore := scoreMap[sources[i].GetID()]
 		jScore := scoreMap[sources[j].GetID()]
 		return iScore+iop > jScore+jop
 	})
 
-	averageScore := int64(0)
-	averageScore = totalScore / int64(len(sources))
+	averageScore := totalScore / float64(len(sources))
 
-	tolerantSizeRatio := int64(float64(len(scanRegions)) * adjustRatio)
+	tolerantSizeRatio := float64(len(scanRegions)) * adjustRatio
 	if tolerantSizeRatio < 1 {
 		tolerantSizeRatio = 1
 	}
@@ -457,16 +907,99 @@ func (s *balanceRangeScheduler) prepare(cluster sche.SchedulerCluster, opInfluen
 		SchedulerCluster: cluster,
 		stores:           sources,
 		scoreMap:         scoreMap,
+		hotPeers:         hotPeers,
 		source:           nil,
 		target:           nil,
 		region:           nil,
 		averageScore:     averageScore,
+		unitScore:        unitScore,
 		job:              job,
 		opInfluence:      opInfluence,
-		tolerate:         tolerantSizeRatio,
+		tolerate:         tolerantSizeRatio * unitScore,
 	}, nil
 }
 
+// collectHotPeers collects the hot peers with the role of the job within the
+// range. The score of a hot peer is its share of the total byte and query flow,
+// which is scaled so that the average score of the stores is 1.
+func collectHotPeers(cluster sche.SchedulerCluster, job *balanceRangeSchedulerJob, regions []*core.RegionInfo, storeCount int) map[uint64][]*balanceRangeHotPeer {
+	rw := utils.Write
+	if job.Mode == balanceRangeReadFlow {
+		rw = utils.Read
+	}
+	inRange := make(map[uint64]*core.RegionInfo, len(regions))
+	for _, region := range regions {
+		inRange[region.GetID()] = region
+	}
+	dims := []int{utils.ByteDim, utils.QueryDim}
+	type hotPeer struct {
+		storeID uint64
+		region  *core.RegionInfo
+		loads   []float64
+	}
+	var peers []*hotPeer
+	totalLoads := make([]float64, len(dims))
+	for storeID, stats := range cluster.GetHotPeerStats(rw) {
+		for _, stat := range stats {
+			region, ok := inRange[stat.RegionID]
+			if !ok || !slices.ContainsFunc(region.GetPeersByRole(job.Role), func(peer *metapb.Peer) bool {
+				return peer.GetStoreId() == storeID
+			}) {
+				continue
+			}
+			loads := make([]float64, len(dims))
+			for i, dim := range dims {
+				loads[i] = stat.GetLoad(dim)
+				totalLoads[i] += loads[i]
+			}
+			peers = append(peers, &hotPeer{storeID: storeID, region: region, loads: loads})
+		}
+	}
+	activeDims := 0
+	for _, total := range totalLoads {
+		if total > 0 {
+			activeDims++
+		}
+	}
+	hotPeers := make(map[uint64][]*balanceRangeHotPeer)
+	if activeDims == 0 {
+		return hotPeers
+	}
+	for _, peer := range peers {
+		score := float64(0)
+		for i, total := range totalLoads {
+			if total > 0 {
+				score += peer.loads[i] / total
+			}
+		}
+		hotPeers[peer.storeID] = append(hotPeers[peer.storeID], &balanceRangeHotPeer{
+			region: peer.region,
+			score:  score * float64(storeCount) / float64(activeDims),
+		})
+	}
+	for _, peers := range hotPeers {
+		sort.Slice(peers, func(i, j int) bool {
+			return peers[i].score > peers[j].score
+		})
+	}
+	return hotPeers
+}
+
+// selectHotRegion selects the hottest region of the source store which doesn't
+// make the source store fall below the average score after moving out.
+func (p *balanceRangeSchedulerPlan) selectHotRegion(filters []filter.RegionFilter) *core.RegionInfo {
+	for _, peer := range p.hotPeers[p.sourceStoreID()] {
+		if peer.score > p.sourceScore-p.averageScore {
+			continue
+		}
+		if region := filter.SelectOneRegion([]*core.RegionInfo{peer.region}, nil, filters...); region != nil {
+			p.regionScore = peer.score
+			return region
+		}
+	}
+	return nil
+}
+
 func (p *balanceRangeSchedulerPlan) sourceStoreID() uint64 {
 	return p.source.GetID()
 }
@@ -475,48 +1008,96 @@ func (p *balanceRangeSchedulerPlan) targetStoreID() uint64 {
 	return p.target.GetID()
 }
 
-func (p *balanceRangeSchedulerPlan) score(storeID uint64) int64 {
+func (p *balanceRangeSchedulerPlan) score(storeID uint64) float64 {
 	return p.scoreMap[storeID]
 }
 
-func (p *balanceRangeSchedulerPlan) shouldBalance(scheduler string) bool {
-	sourceInfluence := p.opInfluence.GetStoreInfluence(p.sourceStoreID())
-	sourceInf := sourceInfluence.GetStoreInfluenceByRole(p.job.Role)
-	// Sometimes, there are many remove-peer operators in the source store, we don't want to pick this store as source.
-	if sourceInf < 0 {
-		sourceInf = -sourceInf
+// influence returns the influence of the operators on the store. The influence
+// of an operator is measured by the average score of a peer when balancing the flow.
+func (p *balanceRangeSchedulerPlan) influence(storeID uint64) float64 {
+	inf := p.opInfluence.GetStoreInfluence(storeID).GetStoreInfluenceByRole(p.job.Role)
+	// Sometimes, there are many operators of the store in the opposite direction, we don't want to pick this store.
+	if inf < 0 {
+		inf = -inf
 	}
-	// to avoid schedule too much, if A's core greater than B and C a little
-	// we want that A should be moved out one region not two
-	sourceScore := p.sourceScore - sourceInf - p.tolerate
+	return float64(inf) * p.unitScore
+}
 
-	targetInfluence := p.opInfluence.GetStoreInfluence(p.targetStoreID())
-	targetInf := targetInfluence.GetStoreInfluenceByRole(p.job.Role)
-	// Sometimes, there are many add-peer operators in the target store, we don't want to pick this store as target.
-	if targetInf < 0 {
-		targetInf = -targetInf
+// scoreGap returns the sum of the score exceeding the average score, which
+// is converted to the number of peers by the average score of a peer.
+func (p *balanceRangeSchedulerPlan) scoreGap() int64 {
+	if p.unitScore == 0 {
+		return 0
+	}
+	gap := float64(0)
+	for _, store := range p.stores {
+		if score := p.score(store.GetID()); score > p.averageScore {
+			gap += score - p.averageScore
+		}
 	}
-	targetScore := p.targetScore + targetInf + p.tolerate
+	return int64(math.Round(gap / p.unitScore))
+}
+
+func (p *balanceRangeSchedulerPlan) shouldBalance(scheduler string) bool {
+	// to avoid schedule too much, if A's core greater than B and C a little
+	// we want that A should be moved out one region not two
+	sourceScore := p.sourceScore - p.influence(p.sourceStoreID()) - p.tolerate
+	targetScore := p.targetScore + p.influence(p.targetStoreID()) + p.tolerate
 
 	// the source score must be greater than the target score
 	shouldBalance := sourceScore >= targetScore
+	// the flow of the region may be large, moving it should not make the target hotter than the source.
+	if shouldBalance && p.job.Mode.isFlow() {
+		shouldBalance = p.targetScore+p.regionScore < p.sourceScore
+	}
 	if !shouldBalance && log.GetLevel() <= zap.DebugLevel {
 		log.Debug("skip balance",
 			zap.String("scheduler", scheduler),
 			zap.Uint64("region-id", p.region.GetID()),
 			zap.Uint64("source-store", p.sourceStoreID()),
 			zap.Uint64("target-store", p.targetStoreID()),
-			zap.Int64("origin-source-score", p.sourceScore),
-			zap.Int64("origin-target-score", p.targetScore),
-			zap.Int64("influence-source-score", sourceScore),
-			zap.Int64("influence-target-score", targetScore),
-			zap.Int64("average-region-score", p.averageScore),
-			zap.Int64("tolerate", p.tolerate),
+			zap.Float64("origin-source-score", p.sourceScore),
+			zap.Float64("origin-target-score", p.targetScore),
+			zap.Float64("influence-source-score", sourceScore),
+			zap.Float64("influence-target-score", targetScore),
+			zap.Float64("average-region-score", p.averageScore),
+			zap.Float64("region-score", p.regionScore),
+			zap.Float64("tolerate", p.tolerate),
 		)
 	}
 	return shouldBalance
 }
 
+func formatBalanceRangeScore(score float64) string {
+	return strconv.FormatFloat(math.Round(score*100)/100, 'f', -1, 64)
+}
+
+// balanceRangeMode is the way to measure the score of the stores within the range.
+type balanceRangeMode string
+
+const (
+	// balanceRangeCount balances the count of the peers with the role, it is the default mode.
+	balanceRangeCount balanceRangeMode = "count"
+	// balanceRangeWriteFlow balances the write byte and query flow of the hot peers with the role.
+	balanceRangeWriteFlow balanceRangeMode = "write-flow"
+	// balanceRangeReadFlow balances the read byte and query flow of the hot peers with the role.
+	balanceRangeReadFlow balanceRangeMode = "read-flow"
+)
+
+func newBalanceRangeMode(mode string) (balanceRangeMode, error) {
+	switch m := balanceRangeMode(mode); m {
+	case balanceRangeCount, balanceRangeWriteFlow, balanceRangeReadFlow:
+		return m, nil
+	case "":
+		return balanceRangeCount, nil
+	}
+	return "", errs.ErrSchedulerConfig.FastGenByArgs("mode must be count, write-flow or read-flow")
+}
+
+func (m balanceRangeMode) isFlow() bool {
+	return m == balanceRangeWriteFlow || m == balanceRangeReadFlow
+}
+
 // JobStatus is the status of the job.
 type JobStatus int
 
@@ -542,3 +1123,22 @@ func (s JobStatus) String() string {
 func (s JobStatus) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + s.String() + `"`), nil
 }
+
+// UnmarshalJSON unmarshals from json.
+func (s *JobStatus) UnmarshalJSON(data []byte) error {
+	var status string
+	if err := json.Unmarshal(data, &status); err != nil {
+		return err
+	}
+	switch status {
+	case "pending":
+		*s = pending
+	case "running":
+		*s = running
+	case "finished":
+		*s = finished
+	default:
+		return errs.ErrSchedulerConfig.FastGenByArgs("status")
+	}
+	return nil
+}
@@ -15,9 +15,13 @@
 package schedulers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tikv/pd/pkg/core"
@@ -46,8 +50,8 @@ func TestBalanceRangePlan(t *testing.T) {
 	re.NotNil(plan)
 	re.Len(plan.stores, 3)
 	re.Len(plan.scoreMap, 3)
-	re.Equal(int64(1), plan.scoreMap[1])
-	re.Equal(int64(1), plan.tolerate)
+	re.Equal(float64(1), plan.scoreMap[1])
+	re.Equal(float64(1), plan.tolerate)
 }
 
 func TestTIKVEngine(t *testing.T) {
@@ -56,6 +60,7 @@ func TestTIKVEngine(t *testing.T) {
 	defer cancel()
 	scheduler, err := CreateScheduler(types.BalanceRangeScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRangeScheduler, []string{"leader", "tikv", "1h", "test", "100", "200"}))
 	re.NoError(err)
+	re.True(scheduler.IsScheduleAllowed(tc))
 	ops, _ := scheduler.Schedule(tc, true)
 	re.Empty(ops)
 	for i := 1; i <= 3; i++ {
@@ -120,6 +125,7 @@ func TestTIFLASHEngine(t *testing.T) {
 	// generate a balance range scheduler with tiflash engine
 	scheduler, err := CreateScheduler(types.BalanceRangeScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRangeScheduler, []string{"learner", "tiflash", "1h", "test", startKey, endKey}))
 	re.NoError(err)
+	re.True(scheduler.IsScheduleAllowed(tc))
 	// tiflash-4 only has 1 region, so it doesn't need to balance
 	ops, _ := scheduler.Schedule(tc, false)
 	re.Empty(ops)
@@ -160,3 +166,169 @@ func TestFetchAllRegions(t *testing.T) {
 	regions = fetchAllRegions(tc, ranges)
 	re.Len(regions, 100)
 }
+
+func TestBalanceRangeJobs(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	for i := 1; i <= 3; i++ {
+		tc.AddLeaderStore(uint64(i), 0)
+	}
+	tc.AddLeaderRegionWithRange(1, "100", "110", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(2, "110", "120", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(3, "120", "130", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(4, "200", "210", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(5, "210", "220", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(6, "220", "230", 1, 2, 3)
+
+	s := storage.NewStorageWithMemoryBackend()
+	scheduler, err := CreateScheduler(types.BalanceRangeScheduler, oc, s, ConfigSliceDecoder(types.BalanceRangeScheduler, []string{"leader", "tikv", "1h", "job-0", "100", "200"}))
+	re.NoError(err)
+	sc := scheduler.(*balanceRangeScheduler)
+	conf := sc.conf
+	newJob := func(alias, startKey, endKey string, priority int) *balanceRangeSchedulerJob {
+		job, err := newBalanceRangeSchedulerJob([]string{"leader", "tikv", "1h", alias, startKey, endKey})
+		re.NoError(err)
+		job.Priority = priority
+		re.NoError(conf.addJob(job))
+		return job
+	}
+	// job-1 overlaps with job-0, job-2 doesn't.
+	re.Equal(uint64(1), newJob("job-1", "150", "250", 10).JobID)
+	re.Equal(uint64(2), newJob("job-2", "200", "300", 0).JobID)
+
+	// job-1 has the highest priority, so it begins first and job-0 has to wait.
+	re.True(scheduler.IsScheduleAllowed(tc))
+	jobs := conf.clone()
+	re.Equal(pending, jobs[0].Status)
+	re.Equal(running, jobs[1].Status)
+	re.Equal(pending, jobs[2].Status)
+
+	// job-0 begins after job-1 is finished, and job-2 runs at the same time.
+	setPriority := func(job *balanceRangeSchedulerJob) bool {
+		job.Priority = 20
+		return true
+	}
+	re.NoError(conf.updateJob(2, setPriority))
+	re.Error(conf.updateJob(3, setPriority))
+	conf.finish(1)
+	re.True(scheduler.IsScheduleAllowed(tc))
+	jobs = conf.clone()
+	re.Equal(running, jobs[0].Status)
+	re.Equal(finished, jobs[1].Status)
+	re.Equal(running, jobs[2].Status)
+
+	// Each running job schedules an operator, the one with higher priority comes first.
+	ops, _ := scheduler.Schedule(tc, false)
+	re.Len(ops, 2)
+	re.Equal("2", ops[0].GetAdditionalInfo("jobID"))
+	re.Equal("0", ops[1].GetAdditionalInfo("jobID"))
+	// The running jobs are traced as well.
+	result, traced := NewScheduleController(context.Background(), tc, oc, scheduler).Trace(tc.GetRegion(ops[1].RegionID()))
+	re.Equal(Scheduling, result.Status)
+	re.Len(traced, 1)
+	re.Equal("0", traced[0].GetAdditionalInfo("jobID"))
+
+	// The progress is updated when the operators are finished.
+	progress := conf.progress()
+	re.Len(progress, 3)
+	re.Equal(int64(2), progress[0].ScoreGap)
+	re.Zero(progress[0].RegionsMoved)
+	re.Nil(progress[0].ETA)
+	for _, hook := range ops[1].FinishedHooks {
+		hook()
+	}
+	progress = conf.progress()
+	re.Equal(uint64(1), progress[0].RegionsMoved)
+	re.NotNil(progress[0].ETA)
+	re.Zero(progress[2].RegionsMoved)
+
+	// The jobs are persisted with the moved counts, and the progress is kept after reloading.
+	re.True(scheduler.IsScheduleAllowed(tc))
+	data, err := s.LoadSchedulerConfig(scheduler.GetName())
+	re.NoError(err)
+	var persisted []*balanceRangeSchedulerJob
+	re.NoError(json.Unmarshal([]byte(data), &persisted))
+	re.Len(persisted, 3)
+	re.Equal(uint64(1), persisted[0].Moved)
+	re.Zero(persisted[2].Moved)
+	re.Equal(20, persisted[2].Priority)
+	re.Equal(core.NewKeyRange("200", "300"), persisted[2].Ranges[0])
+	restored, err := CreateScheduler(types.BalanceRangeScheduler, oc, s, ConfigJSONDecoder([]byte(data)))
+	re.NoError(err)
+	re.Equal(jobs[2].Ranges, restored.(*balanceRangeScheduler).conf.clone()[2].Ranges)
+	re.NoError(scheduler.ReloadConfig())
+	jobs = conf.clone()
+	re.Len(jobs, 3)
+	re.Equal(running, jobs[0].Status)
+	re.Equal(uint64(1), conf.progress()[0].RegionsMoved)
+
+	// The expired jobs are finished.
+	start := time.Now().Add(-2 * time.Hour)
+	conf.jobs[0].Start = &start
+	re.True(scheduler.IsScheduleAllowed(tc))
+	re.Equal(finished, conf.clone()[0].Status)
+	re.Len(conf.runningJobs(), 1)
+}
+
+func TestBalanceRangeJobOverlaps(t *testing.T) {
+	re := require.New(t)
+	job := &balanceRangeSchedulerJob{Ranges: []core.KeyRange{core.NewKeyRange("b", "d")}}
+	re.True(job.overlaps([]core.KeyRange{core.NewKeyRange("a", "c")}))
+	re.True(job.overlaps([]core.KeyRange{core.NewKeyRange("c", "")}))
+	re.True(job.overlaps([]core.KeyRange{core.NewKeyRange("", "")}))
+	re.False(job.overlaps([]core.KeyRange{core.NewKeyRange("a", "b")}))
+	re.False(job.overlaps([]core.KeyRange{core.NewKeyRange("d", "")}))
+	re.True(job.overlaps([]core.KeyRange{core.NewKeyRange("a", "b"), core.NewKeyRange("c", "e")}))
+	job.Ranges = []core.KeyRange{core.NewKeyRange("b", "")}
+	re.True(job.overlaps([]core.KeyRange{core.NewKeyRange("x", "y")}))
+	re.False(job.overlaps([]core.KeyRange{core.NewKeyRange("a", "b")}))
+}
+
+func TestBalanceRangeReadFlow(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetHotRegionCacheHitsThreshold(0)
+	for i := 1; i <= 3; i++ {
+		tc.AddLeaderStore(uint64(i), 0)
+	}
+	// The leader count of the stores is the same, but the leaders of store 1 are much hotter.
+	addRegionLeaderReadInfo(tc, []testRegionInfo{
+		{1, []uint64{1, 2, 3}, 10 * units.MiB, 0, 10000},
+		{2, []uint64{1, 2, 3}, 10 * units.MiB, 0, 10000},
+		{3, []uint64{2, 1, 3}, 1 * units.MiB, 0, 1000},
+		{4, []uint64{2, 1, 3}, 1 * units.MiB, 0, 1000},
+		{5, []uint64{3, 1, 2}, 1 * units.MiB, 0, 1000},
+		{6, []uint64{3, 1, 2}, 1 * units.MiB, 0, 1000},
+	})
+
+	scheduler, err := CreateScheduler(types.BalanceRangeScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRangeScheduler, []string{"leader", "tikv", "1h", "test", "", ""}))
+	re.NoError(err)
+	re.True(scheduler.IsScheduleAllowed(tc))
+	ops, _ := scheduler.Schedule(tc, false)
+	re.Empty(ops)
+
+	conf := scheduler.(*balanceRangeScheduler).conf
+	re.NoError(conf.updateJob(0, func(job *balanceRangeSchedulerJob) bool {
+		re.NoError(updateBalanceRangeJob(job, map[string]any{"mode": "read-flow"}))
+		return true
+	}))
+	re.Error(updateBalanceRangeJob(&balanceRangeSchedulerJob{}, map[string]any{"mode": "unknown"}))
+	ops, _ = scheduler.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal("2.5", ops[0].GetAdditionalInfo("sourceScore"))
+	re.Equal("0.25", ops[0].GetAdditionalInfo("targetScore"))
+	re.Contains(ops[0].Brief(), "transfer leader: store 1 to")
+	re.Contains([]uint64{1, 2}, ops[0].RegionID())
+	// The remaining gap is measured by the average score of a peer.
+	re.Equal(int64(3), conf.progress()[0].ScoreGap)
+
+	// The write flow is not hot, so there is nothing to balance.
+	re.NoError(conf.updateJob(0, func(job *balanceRangeSchedulerJob) bool {
+		job.Mode = balanceRangeWriteFlow
+		return true
+	}))
+	ops, _ = scheduler.Schedule(tc, false)
+	re.Empty(ops)
+}
@@ -221,6 +221,11 @@ func (s *balanceRegionScheduler) transferPeer(solver *solver, collector *plan.Co
 	if len(candidates.Stores) != 0 {
 		solver.Step++
 	}
+	if matrix := solver.GetSharedConfig().GetZoneCostMatrix(); matrix.IsEnabled() {
+		// Prefer the target stores with lower network cost, which are picked from the tail.
+		cmp := filter.ZoneCostComparer(matrix, solver.Source)
+		candidates.StableSort(func(a, b *core.StoreInfo) int { return -cmp(a, b) })
+	}
 
 	// candidates are sorted by region score desc, so we pick the last store as target store.
 	for i := range candidates.Stores {
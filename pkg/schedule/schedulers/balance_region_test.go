@@ -25,6 +25,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
@@ -274,6 +275,29 @@ func checkBalanceRegionSchedule1(re *require.Assertions, enablePlacementRules bo
 	re.True(plans[0].GetStatus().IsOK())
 }
 
+func TestBalanceRegionZoneCost(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetEnablePlacementRules(false)
+	tc.SetMaxReplicasWithLabel(false, 1, "zone")
+	sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	tc.AddLabelsStore(1, 16, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(2, 6, map[string]string{"zone": "z2"})
+	tc.AddLabelsStore(3, 8, map[string]string{"zone": "z1"})
+	tc.AddLeaderRegion(1, 1)
+	// The store with the least regions is preferred without zone costs.
+	ops, _ := sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferPeerWithLeaderTransfer(re, ops[0], operator.OpKind(0), 1, 2)
+	// The store in the same zone is preferred with zone costs.
+	tc.SetZoneCosts(sc.ZoneCosts{{From: "z1", To: "z2", Cost: 10}})
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferPeerWithLeaderTransfer(re, ops[0], operator.OpKind(0), 1, 3)
+}
+
 func TestBalanceRegionReplicas3(t *testing.T) {
 	re := require.New(t)
 	checkReplica3(re, false /* disable placement rules */)
@@ -0,0 +1,538 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// coldRegionMergeBudgetPerHour is the default number of the merges allowed in an hour.
+	coldRegionMergeBudgetPerHour = 10000
+	// coldRegionMergeMinRunLength is the default minimum number of the adjacent cold regions
+	// which are merged by the scheduler, the shorter runs are left to the merge checker.
+	coldRegionMergeMinRunLength = 3
+	// coldRegionMergePlanTTL is the max lifetime of a plan, it will be recomputed after that.
+	coldRegionMergePlanTTL = time.Minute
+	// coldRegionMergeHotHistory is the duration in which a region is not cold if it has been hot.
+	coldRegionMergeHotHistory = time.Hour
+	// coldRegionMergeBudgetWindow is the window of the merge budget.
+	coldRegionMergeBudgetWindow = time.Hour
+)
+
+type coldRegionMergeSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// BudgetPerHour is the max number of the merges created by the scheduler in an hour.
+	BudgetPerHour int `json:"budget-per-hour"`
+	// MinRunLength is the minimum number of the adjacent cold regions to be merged.
+	MinRunLength int `json:"min-run-length"`
+
+	// The following fields are the runtime states, they are not persisted.
+	plan *coldRegionMergePlan
+	// budgetWindowStart is the start time of the current budget window.
+	budgetWindowStart time.Time
+	// budgetUsed is the number of the merges created in the current budget window.
+	budgetUsed int
+}
+
+func (conf *coldRegionMergeSchedulerConfig) update(data []byte) (int, any) {
+	conf.Lock()
+	defer conf.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if msg := conf.validateLocked(); len(msg) > 0 {
+			if err := json.Unmarshal(oldc, conf); err != nil {
+				return http.StatusInternalServerError, err.Error()
+			}
+			return http.StatusBadRequest, msg
+		}
+		if err := conf.save(); err != nil {
+			log.Warn("failed to persist config", zap.Error(err))
+		}
+		// The runs depend on the config, recompute them in the next scheduling.
+		conf.plan = nil
+		log.Info("cold-region-merge-scheduler config is updated", zap.ByteString("old", oldc), zap.ByteString("new", newc))
+		return http.StatusOK, "Config is updated."
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	ok := reflectutil.FindSameFieldByJSON(conf, m)
+	if ok {
+		return http.StatusOK, "Config is the same with origin, so do nothing."
+	}
+	return http.StatusBadRequest, "Config item is not found."
+}
+
+func (conf *coldRegionMergeSchedulerConfig) validateLocked() string {
+	if conf.BudgetPerHour < 1 {
+		return "invalid budget-per-hour which should be a positive integer"
+	}
+	if conf.MinRunLength < 2 {
+		return "invalid min-run-length which should not be less than 2"
+	}
+	return ""
+}
+
+func (conf *coldRegionMergeSchedulerConfig) clone() *coldRegionMergeSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return &coldRegionMergeSchedulerConfig{
+		BudgetPerHour: conf.BudgetPerHour,
+		MinRunLength:  conf.MinRunLength,
+	}
+}
+
+func (conf *coldRegionMergeSchedulerConfig) getMinRunLength() int {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.MinRunLength
+}
+
+func (conf *coldRegionMergeSchedulerConfig) getPlan() *coldRegionMergePlan {
+	conf.RLock()
+	defer conf.RUnlock()
+	p := conf.plan.clone()
+	if p != nil {
+		p.BudgetUsed = conf.budgetUsedLocked(time.Now())
+	}
+	return p
+}
+
+func (conf *coldRegionMergeSchedulerConfig) isPlanStale(now time.Time) bool {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.plan.isStale(now)
+}
+
+func (conf *coldRegionMergeSchedulerConfig) setPlan(p *coldRegionMergePlan) {
+	conf.Lock()
+	defer conf.Unlock()
+	conf.plan = p
+}
+
+// getRuns returns the runs of the plan ordered by the benefit.
+func (conf *coldRegionMergeSchedulerConfig) getRuns() []*coldRegionMergeRun {
+	conf.RLock()
+	defer conf.RUnlock()
+	if conf.plan == nil {
+		return nil
+	}
+	return slices.Clone(conf.plan.Runs)
+}
+
+// removeRun removes the run from the plan after it is scheduled.
+func (conf *coldRegionMergeSchedulerConfig) removeRun(run *coldRegionMergeRun) {
+	conf.Lock()
+	defer conf.Unlock()
+	if conf.plan == nil {
+		return
+	}
+	conf.plan.Runs = slices.DeleteFunc(conf.plan.Runs, func(r *coldRegionMergeRun) bool { return r == run })
+}
+
+// getRemainingBudget returns the number of the merges which can be created in the current window.
+func (conf *coldRegionMergeSchedulerConfig) getRemainingBudget(now time.Time) int {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.BudgetPerHour - conf.budgetUsedLocked(now)
+}
+
+func (conf *coldRegionMergeSchedulerConfig) budgetUsedLocked(now time.Time) int {
+	if now.Sub(conf.budgetWindowStart) >= coldRegionMergeBudgetWindow {
+		return 0
+	}
+	return conf.budgetUsed
+}
+
+// consumeBudget charges the merges accepted by the operator controller.
+func (conf *coldRegionMergeSchedulerConfig) consumeBudget(now time.Time, n int) {
+	conf.Lock()
+	defer conf.Unlock()
+	if now.Sub(conf.budgetWindowStart) >= coldRegionMergeBudgetWindow {
+		conf.budgetWindowStart = now
+		conf.budgetUsed = 0
+	}
+	conf.budgetUsed += n
+}
+
+// coldRegionMergeRun is a run of the adjacent cold regions which can be merged into one.
+type coldRegionMergeRun struct {
+	StartKey    string `json:"start-key"`
+	EndKey      string `json:"end-key"`
+	RegionCount int    `json:"region-count"`
+	// Size is the total approximate size in MB of the regions.
+	Size int64 `json:"size"`
+	// Benefit is the number of the regions reduced after the run is merged.
+	Benefit int `json:"benefit"`
+
+	regionIDs []uint64
+}
+
+// coldRegionMergePlan is the runs ordered by the benefit.
+type coldRegionMergePlan struct {
+	CreateTime time.Time             `json:"create-time"`
+	Runs       []*coldRegionMergeRun `json:"runs"`
+	// BudgetUsed is the number of the merges created in the current hour.
+	BudgetUsed int `json:"budget-used"`
+}
+
+func (p *coldRegionMergePlan) clone() *coldRegionMergePlan {
+	if p == nil {
+		return nil
+	}
+	cloned := &coldRegionMergePlan{
+		CreateTime: p.CreateTime,
+		Runs:       make([]*coldRegionMergeRun, 0, len(p.Runs)),
+	}
+	for _, r := range p.Runs {
+		run := *r
+		cloned.Runs = append(cloned.Runs, &run)
+	}
+	return cloned
+}
+
+func (p *coldRegionMergePlan) isStale(now time.Time) bool {
+	return p == nil || len(p.Runs) == 0 || now.Sub(p.CreateTime) > coldRegionMergePlanTTL
+}
+
+type coldRegionMergeHandler struct {
+	rd     *render.Render
+	config *coldRegionMergeSchedulerConfig
+}
+
+func newColdRegionMergeHandler(conf *coldRegionMergeSchedulerConfig) http.Handler {
+	handler := &coldRegionMergeHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/plan", handler.getPlan).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *coldRegionMergeHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *coldRegionMergeHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := handler.config.clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+func (handler *coldRegionMergeHandler) getPlan(w http.ResponseWriter, _ *http.Request) {
+	p := handler.config.getPlan()
+	if p == nil {
+		handler.rd.JSON(w, http.StatusOK, "The plan has not been computed yet.")
+		return
+	}
+	handler.rd.JSON(w, http.StatusOK, p)
+}
+
+type coldRegionMergeScheduler struct {
+	*BaseScheduler
+	conf      *coldRegionMergeSchedulerConfig
+	handler   http.Handler
+	startTime time.Time
+
+	mu syncutil.RWMutex
+	// lastHotTime records the last time when the region is found hot in the hot cache.
+	lastHotTime map[uint64]time.Time
+}
+
+// newColdRegionMergeScheduler creates a scheduler that finds the long runs of the adjacent
+// cold and small regions, and merges them with a budget.
+func newColdRegionMergeScheduler(opController *operator.Controller, conf *coldRegionMergeSchedulerConfig) Scheduler {
+	return &coldRegionMergeScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.ColdRegionMergeScheduler, conf),
+		conf:          conf,
+		handler:       newColdRegionMergeHandler(conf),
+		startTime:     time.Now(),
+		lastHotTime:   make(map[uint64]time.Time),
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *coldRegionMergeScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *coldRegionMergeScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.RLock()
+	defer s.conf.RUnlock()
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *coldRegionMergeScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+
+	newCfg := &coldRegionMergeSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.BudgetPerHour = newCfg.BudgetPerHour
+	s.conf.MinRunLength = newCfg.MinRunLength
+	s.conf.plan = nil
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *coldRegionMergeScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpMerge) < cluster.GetSchedulerConfig().GetMergeScheduleLimit()
+	if !allowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpMerge)
+		return false
+	}
+	if s.conf.getRemainingBudget(time.Now()) <= 0 {
+		coldRegionMergeNoBudgetCounter.Inc()
+		return false
+	}
+	return true
+}
+
+// Schedule implements the Scheduler interface. The plan, the hot history and the
+// budget are not changed in a dry run.
+func (s *coldRegionMergeScheduler) Schedule(cluster sche.SchedulerCluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	coldRegionMergeScheduleCounter.Inc()
+	now := time.Now()
+	// The regions split recently are unknown to the scheduler, so it waits for
+	// the split-merge-interval after started like the merge checker.
+	if now.Sub(s.startTime) < cluster.GetSchedulerConfig().GetSplitMergeInterval() {
+		coldRegionMergeRecentlyStartCounter.Inc()
+		return nil, nil
+	}
+	runs := s.conf.getRuns()
+	if s.conf.isPlanStale(now) {
+		p := s.makePlan(cluster, now, dryRun)
+		runs = p.Runs
+		if !dryRun {
+			s.conf.setPlan(p)
+			coldRegionMergeNewPlanCounter.Inc()
+			log.Info("cold region merge plan is computed", zap.String("scheduler", s.GetName()), zap.Int("runs", len(p.Runs)))
+		}
+	}
+	// Every merge is made of two operators.
+	room := max((int(cluster.GetSchedulerConfig().GetMergeScheduleLimit())-int(s.OpController.OperatorCount(operator.OpMerge)))/2, 1)
+	room = min(room, s.conf.getRemainingBudget(now))
+	var ops []*operator.Operator
+	for _, run := range runs {
+		if room <= 0 {
+			break
+		}
+		runOps := s.scheduleRun(cluster, run, room)
+		if !dryRun {
+			s.chargeRun(run, runOps)
+		}
+		room -= len(runOps) / 2
+		ops = append(ops, runOps...)
+	}
+	if len(ops) == 0 {
+		coldRegionMergeNoOperatorCounter.Inc()
+		return nil, nil
+	}
+	return ops, nil
+}
+
+// chargeRun takes the run from the plan and consumes the budget only after the
+// merges are accepted by the operator controller.
+func (s *coldRegionMergeScheduler) chargeRun(run *coldRegionMergeRun, runOps []*operator.Operator) {
+	if len(runOps) == 0 {
+		// None of the regions can be merged, so the run is dropped.
+		s.conf.removeRun(run)
+		return
+	}
+	// Every merge is made of two operators, the hook is added to the first one.
+	for i := 0; i < len(runOps); i += 2 {
+		runOps[i].AddedHooks = append(runOps[i].AddedHooks, func() {
+			s.conf.removeRun(run)
+			s.conf.consumeBudget(time.Now(), 1)
+		})
+	}
+}
+
+// makePlan finds the runs of the adjacent cold and small regions, and orders them by the benefit.
+// The hot history is not updated in a dry run.
+func (s *coldRegionMergeScheduler) makePlan(cluster sche.SchedulerCluster, now time.Time, dryRun bool) *coldRegionMergePlan {
+	if !dryRun {
+		s.updateHotHistory(cluster, now)
+	}
+	regions := cluster.GetRegionStatsByType(statistics.UndersizedRegion)
+	sort.Slice(regions, func(i, j int) bool {
+		return bytes.Compare(regions[i].GetStartKey(), regions[j].GetStartKey()) < 0
+	})
+	storeConfig := cluster.GetStoreConfig()
+	maxSize, maxKeys := int64(storeConfig.GetRegionMaxSize()), int64(storeConfig.GetRegionMaxKeys())
+	minRunLength := s.conf.getMinRunLength()
+	p := &coldRegionMergePlan{CreateTime: now, Runs: make([]*coldRegionMergeRun, 0)}
+	var (
+		run       []*core.RegionInfo
+		size      int64
+		keys      int64
+		appendRun = func() {
+			if len(run) >= minRunLength {
+				p.Runs = append(p.Runs, newColdRegionMergeRun(run, size))
+			}
+			run, size, keys = nil, 0, 0
+		}
+	)
+	for _, region := range regions {
+		if !s.isColdRegion(cluster, region) {
+			appendRun()
+			continue
+		}
+		// The merged region should not be split again.
+		if len(run) > 0 && (!isAdjacentMergeable(cluster, run[len(run)-1], region) ||
+			size+region.GetApproximateSize() >= maxSize || keys+region.GetApproximateKeys() >= maxKeys) {
+			appendRun()
+		}
+		run = append(run, region)
+		size += region.GetApproximateSize()
+		keys += region.GetApproximateKeys()
+	}
+	appendRun()
+	// The runs which reduce more regions are merged first, and the smaller ones are
+	// merged first with the same benefit because they are cheaper.
+	sort.SliceStable(p.Runs, func(i, j int) bool {
+		if p.Runs[i].Benefit != p.Runs[j].Benefit {
+			return p.Runs[i].Benefit > p.Runs[j].Benefit
+		}
+		return p.Runs[i].Size < p.Runs[j].Size
+	})
+	return p
+}
+
+func newColdRegionMergeRun(regions []*core.RegionInfo, size int64) *coldRegionMergeRun {
+	run := &coldRegionMergeRun{
+		StartKey:    core.HexRegionKeyStr(regions[0].GetStartKey()),
+		EndKey:      core.HexRegionKeyStr(regions[len(regions)-1].GetEndKey()),
+		RegionCount: len(regions),
+		Size:        size,
+		Benefit:     len(regions) - 1,
+		regionIDs:   make([]uint64, 0, len(regions)),
+	}
+	for _, region := range regions {
+		run.regionIDs = append(run.regionIDs, region.GetID())
+	}
+	return run
+}
+
+// updateHotHistory records the regions which are hot now, and forgets the ones
+// which have been cold for a long time.
+func (s *coldRegionMergeScheduler) updateHotHistory(cluster sche.SchedulerCluster, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rw := range []utils.RWType{utils.Read, utils.Write} {
+		for _, stats := range cluster.GetHotPeerStats(rw) {
+			for _, stat := range stats {
+				s.lastHotTime[stat.RegionID] = now
+			}
+		}
+	}
+	for id, t := range s.lastHotTime {
+		if now.Sub(t) > coldRegionMergeHotHistory {
+			delete(s.lastHotTime, id)
+		}
+	}
+}
+
+// isColdRegion returns true if the region is not hot now and has not been hot recently.
+func (s *coldRegionMergeScheduler) isColdRegion(cluster sche.SchedulerCluster, region *core.RegionInfo) bool {
+	s.mu.RLock()
+	_, ok := s.lastHotTime[region.GetID()]
+	s.mu.RUnlock()
+	if ok {
+		return false
+	}
+	return !cluster.IsRegionHot(region)
+}
+
+// isAdjacentMergeable returns true if the region can be merged with the next one.
+func isAdjacentMergeable(cluster sche.SchedulerCluster, region, next *core.RegionInfo) bool {
+	if !bytes.Equal(region.GetEndKey(), next.GetStartKey()) || len(region.GetEndKey()) == 0 {
+		return false
+	}
+	return allowMerge(cluster, region, next)
+}
+
+// scheduleRun merges the disjoint pairs of the adjacent regions in the run, so the
+// run is halved by every round. At most limit merges are created.
+func (s *coldRegionMergeScheduler) scheduleRun(cluster sche.SchedulerCluster, run *coldRegionMergeRun, limit int) []*operator.Operator {
+	var ops []*operator.Operator
+	for i := 0; i+1 < len(run.regionIDs) && len(ops)/2 < limit; i += 2 {
+		region, next := cluster.GetRegion(run.regionIDs[i]), cluster.GetRegion(run.regionIDs[i+1])
+		if region == nil || next == nil || !isAdjacentMergeable(cluster, region, next) {
+			coldRegionMergeNotAllowedCounter.Inc()
+			continue
+		}
+		// The smaller region is merged into the larger one to reduce the data moved.
+		source, target := region, next
+		if source.GetApproximateSize() > target.GetApproximateSize() && !cluster.GetSchedulerConfig().IsOneWayMergeEnabled() {
+			source, target = target, source
+		}
+		mergeOps, err := operator.CreateMergeRegionOperator(s.GetName(), cluster, source, target, operator.OpMerge)
+		if err != nil {
+			log.Debug("fail to create cold region merge operator", errs.ZapError(err))
+			coldRegionMergeCreateOpFailCounter.Inc()
+			continue
+		}
+		for _, op := range mergeOps {
+			op.SetPriorityLevel(constant.Low)
+		}
+		mergeOps[0].Counters = append(mergeOps[0].Counters, coldRegionMergeNewOpCounter)
+		ops = append(ops, mergeOps...)
+	}
+	return ops
+}
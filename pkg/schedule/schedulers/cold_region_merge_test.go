@@ -0,0 +1,111 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestColdRegionMergeScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(true)
+	defer cancel()
+	tc.SetSplitMergeInterval(0)
+	for id := uint64(1); id <= 3; id++ {
+		tc.AddRegionStore(id, 20)
+	}
+	// Regions 1-5 are small, regions 6 and 9 are large, so regions 7-8 are a short run.
+	// Region 10 has been hot recently, so regions 11-13 are another run.
+	for id := uint64(1); id <= 13; id++ {
+		tc.AddLeaderRegionWithRange(id, fmt.Sprintf("%02d", id), fmt.Sprintf("%02d", id+1), 1, 2, 3)
+		size := int64(1)
+		if id == 6 || id == 9 {
+			size = 200
+		}
+		tc.PutRegion(tc.GetRegion(id).Clone(core.SetApproximateSize(size), core.SetApproximateKeys(size)))
+	}
+
+	sb, err := CreateScheduler(types.ColdRegionMergeScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ColdRegionMergeScheduler, nil))
+	re.NoError(err)
+	s := sb.(*coldRegionMergeScheduler)
+	s.lastHotTime[10] = time.Now()
+	re.True(sb.IsScheduleAllowed(tc))
+
+	p := s.makePlan(tc, time.Now(), true)
+	re.Len(p.Runs, 2)
+	re.Equal([]uint64{1, 2, 3, 4, 5}, p.Runs[0].regionIDs)
+	re.Equal(4, p.Runs[0].Benefit)
+	re.Equal([]uint64{11, 12, 13}, p.Runs[1].regionIDs)
+	re.Equal(2, p.Runs[1].Benefit)
+
+	// Nothing is changed in a dry run.
+	ops, _ := sb.Schedule(tc, true)
+	re.Len(ops, 6)
+	re.Nil(s.conf.getPlan())
+	re.Equal(coldRegionMergeBudgetPerHour, s.conf.getRemainingBudget(time.Now()))
+
+	// The disjoint pairs of the runs are merged.
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 6)
+	// The plan and the budget are charged only after the operators are accepted.
+	re.Len(s.conf.getPlan().Runs, 2)
+	re.Equal(coldRegionMergeBudgetPerHour, s.conf.getRemainingBudget(time.Now()))
+	re.Equal(6, oc.AddWaitingOperator(ops...))
+	merged := make(map[uint64]struct{})
+	for _, op := range ops {
+		re.Equal(operator.OpMerge, op.Kind()&operator.OpMerge)
+		merged[op.RegionID()] = struct{}{}
+	}
+	re.Len(merged, 6)
+	for _, id := range []uint64{1, 2, 3, 4, 11, 12} {
+		re.Contains(merged, id)
+	}
+
+	// The plan is exposed by the handler.
+	req, _ := http.NewRequest(http.MethodGet, "/plan", http.NoBody)
+	resp := httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusOK, resp.Code)
+	respPlan := &coldRegionMergePlan{}
+	re.NoError(json.Unmarshal(resp.Body.Bytes(), respPlan))
+	re.Empty(respPlan.Runs)
+	re.Equal(3, respPlan.BudgetUsed)
+
+	// The budget limits the merges in an hour.
+	req, _ = http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"min-run-length": 1}`))
+	resp = httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusBadRequest, resp.Code)
+	req, _ = http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"budget-per-hour": 3}`))
+	resp = httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusOK, resp.Code)
+	re.Equal(3, s.conf.clone().BudgetPerHour)
+	re.False(sb.IsScheduleAllowed(tc))
+	re.Equal(3, s.conf.getRemainingBudget(time.Now().Add(coldRegionMergeBudgetWindow)))
+}
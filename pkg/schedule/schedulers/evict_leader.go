@@ -15,9 +15,13 @@
 package schedulers
 
 import (
+	"cmp"
+	"math"
 	"math/rand"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
@@ -36,6 +40,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
 const (
@@ -43,15 +48,117 @@ const (
 	// leaders by one scheduling
 	EvictLeaderBatchSize = 3
 	lastStoreDeleteInfo  = "The last store has been deleted"
+	// defaultEvictLeaderDrainInterval is the interval of the gradual drain if
+	// it is not specified.
+	defaultEvictLeaderDrainInterval = time.Minute
 )
 
+// evictLeaderDrainConfig is used to drain the leaders of the stores gradually.
+// The leaders are evicted as fast as the limits allow if it is not set.
+type evictLeaderDrainConfig struct {
+	// DrainRatio is the ratio of the leaders to be drained in each interval,
+	// 0 means the gradual drain is disabled.
+	DrainRatio    float64           `json:"drain-ratio"`
+	DrainInterval typeutil.Duration `json:"drain-interval"`
+	// HoldRatio is the ratio of the leaders to be kept in the store until the
+	// HoldDeadline.
+	HoldRatio    float64    `json:"hold-ratio"`
+	HoldDeadline *time.Time `json:"hold-deadline,omitempty"`
+}
+
+func (conf evictLeaderDrainConfig) isGradual() bool {
+	return conf.DrainRatio > 0
+}
+
+func (conf evictLeaderDrainConfig) getDrainInterval() time.Duration {
+	if conf.DrainInterval.Duration <= 0 {
+		return defaultEvictLeaderDrainInterval
+	}
+	return conf.DrainInterval.Duration
+}
+
+func (conf evictLeaderDrainConfig) isHolding(now time.Time) bool {
+	return conf.HoldRatio > 0 && conf.HoldDeadline != nil && now.Before(*conf.HoldDeadline)
+}
+
+func (conf evictLeaderDrainConfig) equal(other evictLeaderDrainConfig) bool {
+	if conf.HoldDeadline == nil || other.HoldDeadline == nil {
+		if conf.HoldDeadline != other.HoldDeadline {
+			return false
+		}
+	} else if !conf.HoldDeadline.Equal(*other.HoldDeadline) {
+		return false
+	}
+	return conf.DrainRatio == other.DrainRatio &&
+		conf.getDrainInterval() == other.getDrainInterval() &&
+		conf.HoldRatio == other.HoldRatio
+}
+
+// evictLeaderDrain records the drain of a store, which starts when the
+// scheduler first meets the store.
+type evictLeaderDrain struct {
+	StartTime          time.Time `json:"start-time"`
+	InitialLeaderCount int       `json:"initial-leader-count"`
+}
+
+func (d *evictLeaderDrain) heldLeaderCount(conf evictLeaderDrainConfig) int {
+	if conf.HoldRatio <= 0 || conf.HoldDeadline == nil {
+		return 0
+	}
+	return int(math.Ceil(conf.HoldRatio * float64(d.InitialLeaderCount)))
+}
+
+func (d *evictLeaderDrain) leadersPerInterval(conf evictLeaderDrainConfig) int {
+	return max(int(math.Ceil(conf.DrainRatio*float64(d.InitialLeaderCount))), 1)
+}
+
+// allowedDrainCount returns the number of leaders which are allowed to be
+// drained by now.
+func (d *evictLeaderDrain) allowedDrainCount(conf evictLeaderDrainConfig, now time.Time) int {
+	allowed := d.InitialLeaderCount
+	if conf.isGradual() {
+		intervals := int(now.Sub(d.StartTime)/conf.getDrainInterval()) + 1
+		allowed = min(allowed, d.leadersPerInterval(conf)*intervals)
+	}
+	if conf.isHolding(now) {
+		allowed = min(allowed, d.InitialLeaderCount-d.heldLeaderCount(conf))
+	}
+	return max(allowed, 0)
+}
+
+// estimateFinishTime returns the time when all the leaders are expected to
+// be drained. It returns nil if the drain is not limited.
+func (d *evictLeaderDrain) estimateFinishTime(conf evictLeaderDrainConfig, now time.Time) *time.Time {
+	if !conf.isGradual() && !conf.isHolding(now) {
+		return nil
+	}
+	finish := now
+	if conf.isGradual() {
+		perInterval := d.leadersPerInterval(conf)
+		intervals := (d.InitialLeaderCount + perInterval - 1) / perInterval
+		finish = d.StartTime.Add(time.Duration(max(intervals-1, 0)) * conf.getDrainInterval())
+	}
+	if d.heldLeaderCount(conf) > 0 && finish.Before(*conf.HoldDeadline) {
+		finish = *conf.HoldDeadline
+	}
+	// The drain falls behind the schedule, it is expected to be finished soon.
+	if finish.Before(now) {
+		finish = now
+	}
+	return &finish
+}
+
 type evictLeaderSchedulerConfig struct {
 	syncutil.RWMutex
 	schedulerConfig
 
 	StoreIDWithRanges map[uint64][]core.KeyRange `json:"store-id-ranges"`
 	// Batch is used to generate multiple operators by one scheduling
-	Batch             int `json:"batch"`
+	Batch int `json:"batch"`
+	evictLeaderDrainConfig
+	// Drains is the drain state of the stores, which is persisted so that the
+	// drains are not restarted after the scheduler is reloaded.
+	Drains            map[uint64]*evictLeaderDrain `json:"drains,omitempty"`
 	cluster           *core.BasicCluster
 	removeSchedulerCb func(string) error
 }
@@ -80,11 +187,109 @@ func (conf *evictLeaderSchedulerConfig) clone() *evictLeaderSchedulerConfig {
 		storeIDWithRanges[id] = append(storeIDWithRanges[id], ranges...)
 	}
 	return &evictLeaderSchedulerConfig{
-		StoreIDWithRanges: storeIDWithRanges,
-		Batch:             conf.Batch,
+		StoreIDWithRanges:      storeIDWithRanges,
+		Batch:                  conf.Batch,
+		evictLeaderDrainConfig: conf.evictLeaderDrainConfig,
 	}
 }
 
+func (conf *evictLeaderSchedulerConfig) getDrainConfig() evictLeaderDrainConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.evictLeaderDrainConfig
+}
+
+// getDrainQuotas returns the number of leaders which can be drained from each
+// store now, pending is the number of the leaders which are being drained. It
+// returns nil if the drain is not limited. The drains of the new stores are not
+// started in dry run.
+func (conf *evictLeaderSchedulerConfig) getDrainQuotas(pending map[uint64]int, now time.Time, dryRun bool) map[uint64]int {
+	conf.Lock()
+	defer conf.Unlock()
+	if conf.Drains == nil {
+		conf.Drains = make(map[uint64]*evictLeaderDrain)
+	}
+	limited := conf.isGradual() || conf.isHolding(now)
+	var quotas map[uint64]int
+	if limited {
+		quotas = make(map[uint64]int, len(conf.StoreIDWithRanges))
+	}
+	var started []uint64
+	for id := range conf.StoreIDWithRanges {
+		store := conf.cluster.GetStore(id)
+		if store == nil {
+			continue
+		}
+		d, ok := conf.Drains[id]
+		if !ok {
+			d = &evictLeaderDrain{StartTime: now, InitialLeaderCount: store.GetLeaderCount()}
+			if !dryRun {
+				conf.Drains[id] = d
+				started = append(started, id)
+			}
+		}
+		if limited {
+			drained := max(d.InitialLeaderCount-store.GetLeaderCount(), 0)
+			quotas[id] = d.allowedDrainCount(conf.evictLeaderDrainConfig, now) - drained - pending[id]
+		}
+	}
+	if len(started) > 0 {
+		if err := conf.save(); err != nil {
+			// The drains are started again in the next schedule.
+			log.Warn("failed to persist the drain state", zap.Uint64s("store-ids", started), errs.ZapError(err))
+			for _, id := range started {
+				delete(conf.Drains, id)
+			}
+		}
+	}
+	return quotas
+}
+
+// evictLeaderDrainProgress is the drain progress of a store.
+type evictLeaderDrainProgress struct {
+	StoreID            uint64     `json:"store-id"`
+	StartTime          time.Time  `json:"start-time"`
+	InitialLeaderCount int        `json:"initial-leader-count"`
+	LeaderCount        int        `json:"leader-count"`
+	HeldLeaderCount    int        `json:"held-leader-count"`
+	Progress           float64    `json:"progress"`
+	EstimatedFinish    *time.Time `json:"estimated-finish-time,omitempty"`
+}
+
+func (conf *evictLeaderSchedulerConfig) getDrainProgress(now time.Time) []*evictLeaderDrainProgress {
+	conf.RLock()
+	defer conf.RUnlock()
+	progress := make([]*evictLeaderDrainProgress, 0, len(conf.Drains))
+	for id, d := range conf.Drains {
+		store := conf.cluster.GetStore(id)
+		if store == nil {
+			continue
+		}
+		p := &evictLeaderDrainProgress{
+			StoreID:            id,
+			StartTime:          d.StartTime,
+			InitialLeaderCount: d.InitialLeaderCount,
+			LeaderCount:        store.GetLeaderCount(),
+			Progress:           1,
+		}
+		if conf.isHolding(now) {
+			p.HeldLeaderCount = d.heldLeaderCount(conf.evictLeaderDrainConfig)
+		}
+		if p.InitialLeaderCount > 0 {
+			drained := max(p.InitialLeaderCount-p.LeaderCount, 0)
+			p.Progress = float64(drained) / float64(p.InitialLeaderCount)
+		}
+		if p.LeaderCount > 0 {
+			p.EstimatedFinish = d.estimateFinishTime(conf.evictLeaderDrainConfig, now)
+		}
+		progress = append(progress, p)
+	}
+	slices.SortFunc(progress, func(a, b *evictLeaderDrainProgress) int {
+		return cmp.Compare(a.StoreID, b.StoreID)
+	})
+	return progress
+}
+
 func (conf *evictLeaderSchedulerConfig) getRanges(id uint64) []string {
 	conf.RLock()
 	defer conf.RUnlock()
@@ -100,6 +305,7 @@ func (conf *evictLeaderSchedulerConfig) removeStoreLocked(id uint64) (bool, erro
 	_, exists := conf.StoreIDWithRanges[id]
 	if exists {
 		delete(conf.StoreIDWithRanges, id)
+		delete(conf.Drains, id)
 		conf.cluster.ResumeLeaderTransfer(id, constant.In)
 		return len(conf.StoreIDWithRanges) == 0, nil
 	}
@@ -144,6 +350,8 @@ func (conf *evictLeaderSchedulerConfig) reloadConfig() error {
 	pauseAndResumeLeaderTransfer(conf.cluster, constant.In, conf.StoreIDWithRanges, newCfg.StoreIDWithRanges)
 	conf.StoreIDWithRanges = newCfg.StoreIDWithRanges
 	conf.Batch = newCfg.Batch
+	conf.evictLeaderDrainConfig = newCfg.evictLeaderDrainConfig
+	conf.Drains = newCfg.Drains
 	return nil
 }
 
@@ -184,18 +392,28 @@ func (conf *evictLeaderSchedulerConfig) resumeLeaderTransferIfExist(id uint64) {
 	conf.cluster.ResumeLeaderTransfer(id, constant.In)
 }
 
-func (conf *evictLeaderSchedulerConfig) update(id uint64, newRanges []core.KeyRange, batch int) error {
+func (conf *evictLeaderSchedulerConfig) update(id uint64, newRanges []core.KeyRange, batch int, drain evictLeaderDrainConfig) error {
 	conf.Lock()
 	defer conf.Unlock()
 	if id != 0 {
 		conf.StoreIDWithRanges[id] = newRanges
 	}
 	conf.Batch = batch
+	old, oldDrains := conf.evictLeaderDrainConfig, conf.Drains
+	conf.evictLeaderDrainConfig = drain
+	if !old.equal(drain) {
+		// restart the drain with the new config.
+		conf.Drains = nil
+	}
 	err := conf.save()
-	if err != nil && id != 0 {
-		_, _ = conf.removeStoreLocked(id)
+	if err != nil {
+		conf.evictLeaderDrainConfig, conf.Drains = old, oldDrains
+		if id != 0 {
+			_, _ = conf.removeStoreLocked(id)
+		}
+		return err
 	}
-	return err
+	return nil
 }
 
 func (conf *evictLeaderSchedulerConfig) delete(id uint64) (any, error) {
@@ -287,8 +505,70 @@ func (s *evictLeaderScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster)
 
 // Schedule implements the Scheduler interface.
 func (s *evictLeaderScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) ([]*operator.Operator, []plan.Plan) {
+	return s.schedule(cluster, false), nil
+}
+
+// DryRun implements the DryRunScheduler interface, the drains of the stores are not started.
+func (s *evictLeaderScheduler) DryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	return s.schedule(cluster, true), nil
+}
+
+func (s *evictLeaderScheduler) schedule(cluster sche.SchedulerCluster, dryRun bool) []*operator.Operator {
 	evictLeaderCounter.Inc()
-	return scheduleEvictLeaderBatch(s.R, s.GetName(), cluster, s.conf), nil
+	now := time.Now()
+	var pending map[uint64]int
+	if drainConf := s.conf.getDrainConfig(); drainConf.isGradual() || drainConf.isHolding(now) {
+		pending = s.pendingDrainCount(cluster)
+	}
+	quotas := s.conf.getDrainQuotas(pending, now, dryRun)
+	if quotas == nil {
+		return scheduleEvictLeaderBatch(s.R, s.GetName(), cluster, s.conf)
+	}
+	var ops []*operator.Operator
+	batch := s.conf.getBatch()
+	for _, id := range s.conf.getStores() {
+		if quotas[id] <= 0 {
+			evictLeaderDrainThrottledCounter.Inc()
+			continue
+		}
+		quota := min(quotas[id], batch-len(ops))
+		if quota <= 0 {
+			break
+		}
+		conf := &evictLeaderStoreConf{evictLeaderStoresConf: s.conf, storeID: id, batch: quota}
+		ops = append(ops, scheduleEvictLeaderBatch(s.R, s.GetName(), cluster, conf)...)
+	}
+	return ops
+}
+
+// pendingDrainCount returns the number of the leaders which are being
+// transferred out of each store by the scheduler.
+func (s *evictLeaderScheduler) pendingDrainCount(cluster sche.SchedulerCluster) map[uint64]int {
+	pending := make(map[uint64]int)
+	for _, op := range s.OpController.GetOperators() {
+		if op.Desc() != s.GetName() {
+			continue
+		}
+		if region := cluster.GetRegion(op.RegionID()); region != nil {
+			pending[region.GetLeader().GetStoreId()]++
+		}
+	}
+	return pending
+}
+
+// evictLeaderStoreConf limits the eviction to a single store with a batch.
+type evictLeaderStoreConf struct {
+	evictLeaderStoresConf
+	storeID uint64
+	batch   int
+}
+
+func (conf *evictLeaderStoreConf) getStores() []uint64 {
+	return []uint64{conf.storeID}
+}
+
+func (conf *evictLeaderStoreConf) getBatch() int {
+	return conf.batch
 }
 
 func uniqueAppendOperator(dst []*operator.Operator, src ...*operator.Operator) []*operator.Operator {
@@ -424,6 +704,13 @@ func (handler *evictLeaderHandler) updateConfig(w http.ResponseWriter, r *http.R
 		batch = (int)(batchFloat)
 	}
 
+	drain, err := parseEvictLeaderDrainConfig(input, handler.config.getDrainConfig())
+	if err != nil {
+		handler.config.resumeLeaderTransferIfExist(id)
+		handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	ranges, ok := (input["ranges"]).([]string)
 	if ok {
 		if !inputHasStoreID {
@@ -443,7 +730,7 @@ func (handler *evictLeaderHandler) updateConfig(w http.ResponseWriter, r *http.R
 	}
 
 	// StoreIDWithRanges is only changed in update function.
-	err = handler.config.update(id, newRanges, batch)
+	err = handler.config.update(id, newRanges, batch, drain)
 	if err != nil {
 		handler.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
@@ -451,11 +738,60 @@ func (handler *evictLeaderHandler) updateConfig(w http.ResponseWriter, r *http.R
 	handler.rd.JSON(w, http.StatusOK, "The scheduler has been applied to the store.")
 }
 
+func parseEvictLeaderDrainConfig(input map[string]any, drain evictLeaderDrainConfig) (evictLeaderDrainConfig, error) {
+	if v, ok := input["drain-ratio"]; ok {
+		ratio, ok := v.(float64)
+		if !ok || ratio < 0 || ratio > 1 {
+			return drain, errs.ErrSchedulerConfig.FastGenByArgs("drain-ratio, it should be in [0, 1]")
+		}
+		drain.DrainRatio = ratio
+	}
+	if v, ok := input["drain-interval"]; ok {
+		str, ok := v.(string)
+		if !ok {
+			return drain, errs.ErrSchedulerConfig.FastGenByArgs("drain-interval")
+		}
+		interval, err := time.ParseDuration(str)
+		if err != nil || interval <= 0 {
+			return drain, errs.ErrSchedulerConfig.FastGenByArgs("drain-interval, it should be a positive duration")
+		}
+		drain.DrainInterval = typeutil.NewDuration(interval)
+	}
+	if v, ok := input["hold-ratio"]; ok {
+		ratio, ok := v.(float64)
+		if !ok || ratio < 0 || ratio > 1 {
+			return drain, errs.ErrSchedulerConfig.FastGenByArgs("hold-ratio, it should be in [0, 1]")
+		}
+		drain.HoldRatio = ratio
+	}
+	if v, ok := input["hold-deadline"]; ok {
+		str, ok := v.(string)
+		if !ok {
+			return drain, errs.ErrSchedulerConfig.FastGenByArgs("hold-deadline")
+		}
+		// an empty deadline means releasing the held leaders.
+		if len(str) == 0 {
+			drain.HoldDeadline = nil
+		} else {
+			deadline, err := time.Parse(time.RFC3339, str)
+			if err != nil {
+				return drain, errs.ErrSchedulerConfig.FastGenByArgs("hold-deadline, it should be in RFC3339 format")
+			}
+			drain.HoldDeadline = &deadline
+		}
+	}
+	return drain, nil
+}
+
 func (handler *evictLeaderHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
 	conf := handler.config.clone()
 	handler.rd.JSON(w, http.StatusOK, conf)
 }
 
+func (handler *evictLeaderHandler) getProgress(w http.ResponseWriter, _ *http.Request) {
+	handler.rd.JSON(w, http.StatusOK, handler.config.getDrainProgress(time.Now()))
+}
+
 func (handler *evictLeaderHandler) deleteConfig(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["store_id"]
 	id, err := strconv.ParseUint(idStr, 10, 64)
@@ -485,6 +821,7 @@ func newEvictLeaderHandler(config *evictLeaderSchedulerConfig) http.Handler {
 	router := mux.NewRouter()
 	router.HandleFunc("/config", h.updateConfig).Methods(http.MethodPost)
 	router.HandleFunc("/list", h.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/progress", h.getProgress).Methods(http.MethodGet)
 	router.HandleFunc("/delete/{store_id}", h.deleteConfig).Methods(http.MethodDelete)
 	return router
 }
@@ -16,7 +16,13 @@ package schedulers
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -137,3 +143,116 @@ func TestBatchEvict(t *testing.T) {
 		return len(ops) == 5
 	})
 }
+
+func TestEvictLeaderGradualDrain(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(true)
+	defer cancel()
+
+	tc.AddLeaderStore(1, 10)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderStore(3, 0)
+	for i := uint64(1); i <= 10; i++ {
+		tc.AddLeaderRegion(i, 1, 2, 3)
+	}
+	s := storage.NewStorageWithMemoryBackend()
+	sl, err := CreateScheduler(types.EvictLeaderScheduler, oc, s, ConfigSliceDecoder(types.EvictLeaderScheduler, []string{"1"}), func(string) error { return nil })
+	re.NoError(err)
+	conf := sl.(*evictLeaderScheduler).conf
+	post := func(body string) int {
+		req, _ := http.NewRequest(http.MethodPost, "/config", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		sl.ServeHTTP(resp, req)
+		return resp.Code
+	}
+	getProgress := func() []*evictLeaderDrainProgress {
+		req, _ := http.NewRequest(http.MethodGet, "/progress", http.NoBody)
+		resp := httptest.NewRecorder()
+		sl.ServeHTTP(resp, req)
+		re.Equal(http.StatusOK, resp.Code)
+		var progress []*evictLeaderDrainProgress
+		re.NoError(json.Unmarshal(resp.Body.Bytes(), &progress))
+		return progress
+	}
+
+	re.Equal(http.StatusBadRequest, post(`{"drain-ratio": 1.5}`))
+	re.Equal(http.StatusBadRequest, post(`{"drain-interval": "-1m"}`))
+	re.Equal(http.StatusBadRequest, post(`{"hold-deadline": "tomorrow"}`))
+	re.Equal(http.StatusOK, post(`{"drain-ratio": 0.2, "drain-interval": "1h"}`))
+	re.Equal(0.2, conf.getDrainConfig().DrainRatio)
+	re.Equal(time.Hour, conf.getDrainConfig().getDrainInterval())
+
+	// The drain is not started by the dry run.
+	ops, _ := sl.(DryRunScheduler).DryRun(tc)
+	re.NotEmpty(ops)
+	re.Empty(getProgress())
+
+	// 2 leaders are drained in the first interval, the random picking might
+	// get the same region, so we retry to make sure the batch is full.
+	scheduleN := func(n int) {
+		testutil.Eventually(re, func() bool {
+			ops, _ = sl.Schedule(tc, false)
+			return len(ops) == n
+		})
+	}
+	scheduleN(2)
+	re.Equal(2, oc.AddWaitingOperator(ops...))
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+	// The leaders are drained if the operators are finished.
+	for _, op := range oc.GetOperators() {
+		oc.RemoveOperator(op)
+	}
+	tc.UpdateLeaderCount(1, 8)
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+
+	progress := getProgress()
+	re.Len(progress, 1)
+	re.Equal(uint64(1), progress[0].StoreID)
+	re.Equal(10, progress[0].InitialLeaderCount)
+	re.Equal(8, progress[0].LeaderCount)
+	re.Equal(0.2, progress[0].Progress)
+	re.NotNil(progress[0].EstimatedFinish)
+	re.WithinDuration(progress[0].StartTime.Add(4*time.Hour), *progress[0].EstimatedFinish, time.Second)
+
+	// The drain state is persisted, so the drain is not restarted after reloading.
+	data, err := s.LoadSchedulerConfig(sl.GetName())
+	re.NoError(err)
+	persisted := &evictLeaderSchedulerConfig{}
+	re.NoError(DecodeConfig([]byte(data), persisted))
+	re.Len(persisted.Drains, 1)
+	re.True(progress[0].StartTime.Equal(persisted.Drains[1].StartTime))
+	re.Equal(10, persisted.Drains[1].InitialLeaderCount)
+	re.NoError(sl.ReloadConfig())
+	progress = getProgress()
+	re.Len(progress, 1)
+	re.True(persisted.Drains[1].StartTime.Equal(progress[0].StartTime))
+	re.Equal(10, progress[0].InitialLeaderCount)
+
+	// 2 more leaders are drained in the next interval.
+	conf.Lock()
+	conf.Drains[1].StartTime = conf.Drains[1].StartTime.Add(-time.Hour)
+	conf.Unlock()
+	scheduleN(2)
+
+	// The held leaders are kept until the deadline.
+	deadline := time.Now().Add(10 * time.Hour).Truncate(time.Second)
+	re.Equal(http.StatusOK, post(fmt.Sprintf(`{"drain-ratio": 0, "hold-ratio": 0.5, "hold-deadline": "%s"}`, deadline.Format(time.RFC3339))))
+	scheduleN(3)
+	tc.UpdateLeaderCount(1, 4)
+	ops, _ = sl.Schedule(tc, false)
+	re.Empty(ops)
+	progress = getProgress()
+	re.Len(progress, 1)
+	re.Equal(8, progress[0].InitialLeaderCount)
+	re.Equal(4, progress[0].HeldLeaderCount)
+	re.Equal(0.5, progress[0].Progress)
+	re.True(deadline.Equal(*progress[0].EstimatedFinish))
+
+	// The held leaders are released if the deadline is cleared.
+	re.Equal(http.StatusOK, post(`{"hold-deadline": ""}`))
+	scheduleN(3)
+	progress = getProgress()
+	re.Nil(progress[0].EstimatedFinish)
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
@@ -298,7 +299,7 @@ func (s *evictSlowStoreScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 			// slow node next time.
 			log.Info("slow store has been removed",
 				zap.Uint64("store-id", store.GetID()))
-		} else if store.GetSlowScore() <= slowStoreRecoverThreshold && s.conf.readyForRecovery() {
+		} else if isSlowStoreRecovered(cluster, store) && s.conf.readyForRecovery() {
 			log.Info("slow store has been recovered",
 				zap.Uint64("store-id", store.GetID()))
 		} else {
@@ -310,12 +311,17 @@ func (s *evictSlowStoreScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 
 	var slowStore *core.StoreInfo
 
+	byLatency := detectSlowStoreByLatency(cluster)
 	for _, store := range cluster.GetStores() {
 		if store.IsRemoved() {
 			continue
 		}
 
-		if (store.IsPreparing() || store.IsServing()) && store.IsSlow() {
+		isSlow := store.IsSlow()
+		if byLatency {
+			isSlow = cluster.IsSlowStore(store.GetID())
+		}
+		if (store.IsPreparing() || store.IsServing()) && isSlow {
 			// Do nothing if there is more than one slow store.
 			if slowStore != nil {
 				return nil, nil
@@ -324,7 +330,8 @@ func (s *evictSlowStoreScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 		}
 	}
 
-	if slowStore == nil || slowStore.GetSlowScore() < slowStoreEvictThreshold {
+	// The slow score is meaningless if the slow store is detected by latency.
+	if slowStore == nil || (!byLatency && slowStore.GetSlowScore() < slowStoreEvictThreshold) {
 		return nil, nil
 	}
 
@@ -339,6 +346,20 @@ func (s *evictSlowStoreScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 	return s.schedulerEvictLeader(cluster), nil
 }
 
+// detectSlowStoreByLatency returns whether the slow stores are detected by the
+// operation latencies instead of the slow score or the slow trend.
+func detectSlowStoreByLatency(cluster sche.SchedulerCluster) bool {
+	return cluster.GetSchedulerConfig().GetSlowStoreDetector() == sc.LatencyDetector
+}
+
+// isSlowStoreRecovered returns whether the evicted slow store has been recovered.
+func isSlowStoreRecovered(cluster sche.SchedulerCluster, store *core.StoreInfo) bool {
+	if detectSlowStoreByLatency(cluster) {
+		return !cluster.IsSlowStore(store.GetID())
+	}
+	return store.GetSlowScore() <= slowStoreRecoverThreshold
+}
+
 // newEvictSlowStoreScheduler creates a scheduler that detects and evicts slow stores.
 func newEvictSlowStoreScheduler(opController *operator.Controller, conf *evictSlowStoreSchedulerConfig) Scheduler {
 	handler := newEvictSlowStoreHandler(conf)
@@ -22,9 +22,11 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/storage"
@@ -207,3 +209,53 @@ func TestEvictSlowStoreBatch(t *testing.T) {
 	re.Equal(5, persistValue.Batch)
 	re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/schedule/schedulers/transientRecoveryGap"))
 }
+
+func TestEvictSlowStoreByLatency(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetSlowStoreDetector(sc.LatencyDetector)
+	re.NoError(failpoint.Enable("github.com/tikv/pd/pkg/statistics/refreshLatenciesOnHeartbeat", "return(true)"))
+	defer func() {
+		re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/statistics/refreshLatenciesOnHeartbeat"))
+	}()
+
+	tc.AddLeaderStore(1, 1)
+	tc.AddLeaderStore(2, 1)
+	tc.AddLeaderStore(3, 1)
+	tc.AddLeaderRegion(1, 1, 2, 3)
+	tc.AddLeaderRegion(2, 2, 1, 3)
+	tc.AddLeaderRegion(3, 3, 1, 2)
+	es, err := CreateScheduler(types.EvictSlowStoreScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.EvictSlowStoreScheduler, []string{}), nil)
+	re.NoError(err)
+
+	heartbeat := func(report uint64, latencies map[uint64]uint64) {
+		for id, latency := range latencies {
+			store := tc.GetStore(id)
+			tc.PutStore(store.Clone(core.SetStoreStats(&pdpb.StoreStats{
+				StoreId:     id,
+				Interval:    &pdpb.TimeInterval{StartTimestamp: report - 10, EndTimestamp: report},
+				OpLatencies: []*pdpb.RecordPair{{Key: "commit", Value: latency}},
+			})))
+			tc.ObserveSlowStore(id)
+		}
+	}
+	// The slow score doesn't matter.
+	for i := range uint64(10) {
+		heartbeat(10*(i+1), map[uint64]uint64{1: 10000, 2: 11000, 3: 12000})
+	}
+	tc.GetStore(1).GetStoreStats().SlowScore = 100
+	ops, _ := es.Schedule(tc, false)
+	re.Empty(ops)
+	re.Zero(es.(*evictSlowStoreScheduler).conf.evictStore())
+
+	// The commit latency of store 1 is much higher than the others.
+	for i := range uint64(10) {
+		heartbeat(200+10*i, map[uint64]uint64{1: 80000, 2: 11000, 3: 12000})
+	}
+	re.True(tc.IsSlowStore(1))
+	ops, _ = es.Schedule(tc, false)
+	re.NotEmpty(ops)
+	operatorutil.CheckMultiTargetTransferLeader(re, ops[0], operator.OpLeader, 1, []uint64{2, 3})
+	re.Equal(uint64(1), es.(*evictSlowStoreScheduler).conf.evictStore())
+}
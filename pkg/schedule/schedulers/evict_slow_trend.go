@@ -461,6 +461,9 @@ func chooseEvictCandidate(cluster sche.SchedulerCluster, lastEvictCandidate *slo
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none_too_few").Inc()
 		return
 	}
+	if detectSlowStoreByLatency(cluster) {
+		return chooseEvictCandidateByLatency(cluster, stores)
+	}
 
 	var candidates []*core.StoreInfo
 	var affectedStoreCount int
@@ -537,6 +540,28 @@ func chooseEvictCandidate(cluster sche.SchedulerCluster, lastEvictCandidate *slo
 	return store
 }
 
+// chooseEvictCandidateByLatency chooses the only store detected as slow by the
+// operation latencies, which has been compared with the other stores.
+func chooseEvictCandidateByLatency(cluster sche.SchedulerCluster, stores []*core.StoreInfo) (slowStore *core.StoreInfo) {
+	for _, store := range stores {
+		if store.IsRemoved() || !(store.IsPreparing() || store.IsServing()) || !cluster.IsSlowStore(store.GetID()) {
+			continue
+		}
+		if slowStore != nil {
+			storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none_too_many").Inc()
+			return nil
+		}
+		slowStore = store
+	}
+	if slowStore == nil {
+		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none_no_fit").Inc()
+		return nil
+	}
+	storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "add").Inc()
+	log.Info("evict-slow-trend-scheduler captured candidate by latency", zap.Uint64("store-id", slowStore.GetID()))
+	return slowStore
+}
+
 func checkStoresAreUpdated(cluster sche.SchedulerCluster, slowStoreID uint64, slowStoreRecordTS time.Time) bool {
 	stores := cluster.GetStores()
 	if len(stores) <= 1 {
@@ -621,6 +646,9 @@ func checkStoreCanRecover(cluster sche.SchedulerCluster, target *core.StoreInfo)
 }
 
 func checkStoreFasterThanOthers(cluster sche.SchedulerCluster, target *core.StoreInfo) bool {
+	if detectSlowStoreByLatency(cluster) {
+		return !cluster.IsSlowStore(target.GetID())
+	}
 	stores := cluster.GetStores()
 	expected := (len(stores) + 1) / 2
 	targetSlowTrend := target.GetSlowTrend()
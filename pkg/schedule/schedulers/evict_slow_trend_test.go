@@ -26,6 +26,7 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/storage"
@@ -247,6 +248,58 @@ func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendV2() {
 	re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/schedule/schedulers/transientRecoveryGap"))
 }
 
+func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendByLatency() {
+	re := suite.Require()
+	es2, ok := suite.es.(*evictSlowTrendScheduler)
+	re.True(ok)
+	re.NoError(failpoint.Enable("github.com/tikv/pd/pkg/schedule/schedulers/transientRecoveryGap", "return(true)"))
+	re.NoError(failpoint.Enable("github.com/tikv/pd/pkg/statistics/refreshLatenciesOnHeartbeat", "return(true)"))
+	suite.tc.SetSlowStoreDetector(sc.LatencyDetector)
+
+	heartbeat := func(report uint64, latencies map[uint64]uint64) {
+		for id, latency := range latencies {
+			storeInfo := suite.tc.GetStore(id)
+			suite.tc.PutStore(storeInfo.Clone(func(store *core.StoreInfo) {
+				store.GetStoreStats().Interval = &pdpb.TimeInterval{StartTimestamp: report - 10, EndTimestamp: report}
+				store.GetStoreStats().OpLatencies = []*pdpb.RecordPair{{Key: "commit", Value: latency}}
+			}, core.SetLastHeartbeatTS(time.Now())))
+			suite.tc.ObserveSlowStore(id)
+		}
+		// The heartbeat is recorded once, observe again to compare with the
+		// latest heartbeats of the other stores.
+		for id := range latencies {
+			suite.tc.ObserveSlowStore(id)
+		}
+	}
+	// The slow trend doesn't matter.
+	for i := range uint64(10) {
+		heartbeat(10*(i+1), map[uint64]uint64{1: 80000, 2: 11000, 3: 12000})
+	}
+	re.True(suite.tc.IsSlowStore(1))
+	ops, _ := suite.es.Schedule(suite.tc, false)
+	re.Empty(ops)
+	re.Equal(uint64(1), es2.conf.candidate())
+
+	// Evict leaders after the other stores are updated.
+	heartbeat(200, map[uint64]uint64{1: 80000, 2: 11000, 3: 12000})
+	ops, _ = suite.es.Schedule(suite.tc, false)
+	re.NotEmpty(ops)
+	operatorutil.CheckMultiTargetTransferLeader(re, ops[0], operator.OpLeader, 1, []uint64{2, 3})
+	re.Equal(uint64(1), es2.conf.evictedStore())
+
+	// Stop evicting after store 1 is recovered.
+	for i := range uint64(30) {
+		heartbeat(300+10*i, map[uint64]uint64{1: 11000, 2: 11000, 3: 12000})
+	}
+	re.False(suite.tc.IsSlowStore(1))
+	ops, _ = suite.es.Schedule(suite.tc, false)
+	re.Empty(ops)
+	re.Zero(es2.conf.evictedStore())
+
+	re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/statistics/refreshLatenciesOnHeartbeat"))
+	re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/schedule/schedulers/transientRecoveryGap"))
+}
+
 func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendPrepare() {
 	re := suite.Require()
 	es2, ok := suite.es.(*evictSlowTrendScheduler)
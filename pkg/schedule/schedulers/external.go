@@ -0,0 +1,745 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/external"
+	"github.com/tikv/pd/pkg/schedule/external/externalpb"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/utils/grpcutil"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	// externalDefaultTimeout is the default max time to send a request to the external scheduler.
+	externalDefaultTimeout = 3 * time.Second
+	// externalMaxTimeout is the max time to send a request to the external scheduler.
+	externalMaxTimeout = 10 * time.Second
+	// externalProposalTTL is the max lifetime of the cached proposals.
+	externalProposalTTL = 30 * time.Second
+	// externalRegionBatch is the max number of the regions sent in a request.
+	externalRegionBatch = 1024
+	// externalMaxUpdatedRegions is the max number of the updated regions waiting to be
+	// sent. The external scheduler falls too far behind if there are more, and it is
+	// synced with a snapshot again.
+	externalMaxUpdatedRegions = 256 * 1024
+	// externalDefaultBatch is the default max number of the operators accepted in a round.
+	externalDefaultBatch = 4
+	externalMaxBatch     = 64
+)
+
+type externalSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// Address is the URL of the gRPC service of the external scheduler, such as
+	// "http://127.0.0.1:20180".
+	Address string            `json:"address"`
+	Timeout typeutil.Duration `json:"timeout"`
+	Batch   int               `json:"batch"`
+}
+
+func (conf *externalSchedulerConfig) update(data []byte) (int, any) {
+	conf.Lock()
+	defer conf.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if msg := conf.validateLocked(); len(msg) > 0 {
+			if err := json.Unmarshal(oldc, conf); err != nil {
+				return http.StatusInternalServerError, err.Error()
+			}
+			return http.StatusBadRequest, msg
+		}
+		if err := conf.save(); err != nil {
+			log.Warn("failed to persist config", zap.Error(err))
+		}
+		log.Info("external-scheduler config is updated", zap.ByteString("old", oldc), zap.ByteString("new", newc))
+		return http.StatusOK, "Config is updated."
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	ok := reflectutil.FindSameFieldByJSON(conf, m)
+	if ok {
+		return http.StatusOK, "Config is the same with origin, so do nothing."
+	}
+	return http.StatusBadRequest, "Config item is not found."
+}
+
+func (conf *externalSchedulerConfig) validateLocked() string {
+	if !isValidExternalAddress(conf.Address) {
+		return "invalid address which should be a URL such as http://127.0.0.1:20180"
+	}
+	if conf.Timeout.Duration <= 0 || conf.Timeout.Duration > externalMaxTimeout {
+		return "invalid timeout which should be in (0, 10s]"
+	}
+	if conf.Batch < 1 || conf.Batch > externalMaxBatch {
+		return "invalid batch which should be in [1, 64]"
+	}
+	return ""
+}
+
+func isValidExternalAddress(address string) bool {
+	u, err := url.Parse(address)
+	return err == nil && len(u.Host) > 0
+}
+
+func (conf *externalSchedulerConfig) clone() *externalSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return &externalSchedulerConfig{
+		Address: conf.Address,
+		Timeout: conf.Timeout,
+		Batch:   conf.Batch,
+	}
+}
+
+type externalHandler struct {
+	rd     *render.Render
+	config *externalSchedulerConfig
+}
+
+func newExternalHandler(conf *externalSchedulerConfig) http.Handler {
+	handler := &externalHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *externalHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *externalHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := handler.config.clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+type externalScheduler struct {
+	*BaseScheduler
+	conf    *externalSchedulerConfig
+	handler http.Handler
+	ctx     context.Context
+	cancel  context.CancelFunc
+	// regionBatch is the max number of the regions sent in a request.
+	regionBatch int
+
+	// mu protects the following fields. It is never held while the stream sends or
+	// receives, which is done in the background.
+	mu      syncutil.Mutex
+	address string
+	conn    *grpc.ClientConn
+	// stream is the stream to the external scheduler. A new stream is opened with
+	// a snapshot of the cluster in the next scheduling if it is nil.
+	stream *externalStream
+	// proposals are replied by the external scheduler lately, they are taken by the scheduling.
+	proposals    []*externalpb.Proposal
+	proposalTime time.Time
+}
+
+// externalStream sends a snapshot of the cluster and then the deltas to the external
+// scheduler, and receives the proposals from it.
+type externalStream struct {
+	cancel context.CancelFunc
+	client externalpb.Scheduler_ScheduleClient
+	// sentStores are the stores sent lately. The store is sent again after it is
+	// updated, as the store info is never changed in place.
+	sentStores map[uint64]*core.StoreInfo
+	// deltas passes the updated regions to the sending goroutine, and a delta is only
+	// passed after the previous one is taken.
+	deltas chan *externalDelta
+
+	// The following fields are protected by the mutex of the scheduler.
+	// synced is set after the snapshot is sent.
+	synced bool
+	// updated are the IDs of the regions reported by the heartbeats since the
+	// snapshot or the last delta.
+	updated map[uint64]struct{}
+}
+
+type externalDelta struct {
+	regions map[uint64]struct{}
+	limit   uint32
+}
+
+// newExternalScheduler creates a scheduler that streams the cluster to an external
+// scheduler service over gRPC, and validates the operators proposed by it.
+func newExternalScheduler(opController *operator.Controller, conf *externalSchedulerConfig) Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &externalScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.ExternalScheduler, conf),
+		conf:          conf,
+		handler:       newExternalHandler(conf),
+		ctx:           ctx,
+		cancel:        cancel,
+		regionBatch:   externalRegionBatch,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *externalScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *externalScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.RLock()
+	defer s.conf.RUnlock()
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *externalScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+
+	newCfg := &externalSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.Address = newCfg.Address
+	s.conf.Timeout = newCfg.Timeout
+	s.conf.Batch = newCfg.Batch
+	return nil
+}
+
+// CleanConfig implements the Scheduler interface.
+func (s *externalScheduler) CleanConfig(sche.SchedulerCluster) {
+	s.cancel()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConnLocked()
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *externalScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	leaderAllowed, regionAllowed := s.getAllowedKinds(cluster)
+	if !leaderAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpLeader)
+	}
+	if !regionAllowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	return leaderAllowed || regionAllowed
+}
+
+func (s *externalScheduler) getAllowedKinds(cluster sche.SchedulerCluster) (leaderAllowed, regionAllowed bool) {
+	conf := cluster.GetSchedulerConfig()
+	leaderAllowed = s.OpController.OperatorCount(operator.OpLeader) < conf.GetLeaderScheduleLimit()
+	regionAllowed = s.OpController.OperatorCount(operator.OpRegion) < conf.GetRegionScheduleLimit()
+	return
+}
+
+// Schedule implements the Scheduler interface. It creates the operators from the
+// proposals cached from the stream, and passes the updates of the cluster to the
+// stream, so the scheduling is never blocked by the external scheduler.
+func (s *externalScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) ([]*operator.Operator, []plan.Plan) {
+	externalScheduleCounter.Inc()
+	conf := s.conf.clone()
+	proposals := s.takeProposals(time.Now())
+	s.sync(cluster, conf)
+	ops := s.createOperators(cluster, proposals, conf.Batch)
+	if len(ops) == 0 {
+		externalNoOperatorCounter.Inc()
+		return nil, nil
+	}
+	return ops, nil
+}
+
+// DryRun implements the DryRunScheduler interface, the cached proposals are not
+// taken and nothing is sent.
+func (s *externalScheduler) DryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	conf := s.conf.clone()
+	s.mu.Lock()
+	proposals := s.proposals
+	if time.Since(s.proposalTime) > externalProposalTTL {
+		proposals = nil
+	}
+	s.mu.Unlock()
+	return s.createOperators(cluster, proposals, conf.Batch), nil
+}
+
+// ObserveRegion implements the RegionObserver interface. The regions reported by the
+// heartbeats are sent to the external scheduler in the next delta.
+func (s *externalScheduler) ObserveRegion(region *core.RegionInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stream == nil {
+		return
+	}
+	if len(s.stream.updated) >= externalMaxUpdatedRegions {
+		externalTooManyUpdatesCounter.Inc()
+		log.Warn("the external scheduler falls behind, sync it with a snapshot again", zap.String("address", s.address))
+		s.closeStreamLocked()
+		return
+	}
+	s.stream.updated[region.GetID()] = struct{}{}
+}
+
+// takeProposals takes the cached proposals, the expired ones are dropped.
+func (s *externalScheduler) takeProposals(now time.Time) []*externalpb.Proposal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	proposals := s.proposals
+	s.proposals = nil
+	if len(proposals) > 0 && now.Sub(s.proposalTime) > externalProposalTTL {
+		externalExpiredProposalCounter.Add(float64(len(proposals)))
+		return nil
+	}
+	return proposals
+}
+
+// sync opens a stream which sends the snapshot if there is no stream, or passes
+// the regions updated since the last delta to the stream after the snapshot is sent.
+func (s *externalScheduler) sync(cluster sche.SchedulerCluster, conf *externalSchedulerConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return
+	}
+	if s.address != conf.Address {
+		s.closeConnLocked()
+	}
+	if s.conn == nil {
+		// The connection is established in the background.
+		conn, err := grpcutil.GetClientConn(s.ctx, conf.Address, nil)
+		if err != nil {
+			externalConnectFailCounter.Inc()
+			log.Warn("failed to connect the external scheduler", zap.String("address", conf.Address), errs.ZapError(err))
+			return
+		}
+		s.conn, s.address = conn, conf.Address
+	}
+	if s.stream == nil {
+		ctx, cancel := context.WithCancel(s.ctx)
+		s.stream = &externalStream{
+			cancel:     cancel,
+			sentStores: make(map[uint64]*core.StoreInfo),
+			deltas:     make(chan *externalDelta, 1),
+			updated:    make(map[uint64]struct{}),
+		}
+		externalSnapshotCounter.Inc()
+		go s.runStream(ctx, cluster, s.stream, externalpb.NewSchedulerClient(s.conn), uint32(conf.Batch))
+		return
+	}
+	if !s.stream.synced {
+		return
+	}
+	select {
+	case s.stream.deltas <- &externalDelta{regions: s.stream.updated, limit: uint32(conf.Batch)}:
+		s.stream.updated = make(map[uint64]struct{})
+	default:
+	}
+}
+
+// runStream opens the stream, sends the snapshot and then the deltas until the
+// stream breaks. The regions updated during the snapshot are sent in the first delta.
+func (s *externalScheduler) runStream(ctx context.Context, cluster sche.SchedulerCluster, es *externalStream, client externalpb.SchedulerClient, limit uint32) {
+	defer logutil.LogPanic()
+	stream, err := client.Schedule(ctx)
+	if err != nil {
+		s.breakStream(es, err)
+		return
+	}
+	es.client = stream
+	go s.receive(es)
+	if err := s.sendSnapshot(cluster, es, limit); err != nil {
+		s.breakStream(es, err)
+		return
+	}
+	s.mu.Lock()
+	es.synced = true
+	s.mu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delta := <-es.deltas:
+			if err := s.sendDelta(cluster, es, delta); err != nil {
+				s.breakStream(es, err)
+				return
+			}
+		}
+	}
+}
+
+// receive caches the proposals replied by the external scheduler until the stream breaks.
+func (s *externalScheduler) receive(es *externalStream) {
+	defer logutil.LogPanic()
+	for {
+		resp, err := es.client.Recv()
+		if err != nil {
+			s.breakStream(es, err)
+			return
+		}
+		s.mu.Lock()
+		if s.stream == es {
+			s.proposals, s.proposalTime = resp.GetProposals(), time.Now()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// sendSnapshot sends all the stores and the regions in batches.
+func (s *externalScheduler) sendSnapshot(cluster sche.SchedulerCluster, es *externalStream, limit uint32) error {
+	req := &externalpb.ScheduleRequest{Type: externalpb.RequestType_SNAPSHOT, Limit: limit}
+	req.Stores, _ = es.updatedStores(cluster)
+	regions := cluster.GetBasicCluster().ScanRegions(nil, nil, s.regionBatch)
+	for {
+		var next []*core.RegionInfo
+		if len(regions) == s.regionBatch {
+			if endKey := regions[len(regions)-1].GetEndKey(); len(endKey) > 0 {
+				next = cluster.GetBasicCluster().ScanRegions(endKey, nil, s.regionBatch)
+			}
+		}
+		for _, region := range regions {
+			req.Regions = append(req.Regions, external.NewRegion(region))
+		}
+		if len(next) == 0 {
+			req.Type = externalpb.RequestType_SNAPSHOT_END
+		}
+		if err := s.send(es, req); err != nil || len(next) == 0 {
+			return err
+		}
+		regions = next
+		req = &externalpb.ScheduleRequest{Type: externalpb.RequestType_SNAPSHOT, Limit: limit}
+	}
+}
+
+// sendDelta sends the updated stores and regions in batches.
+func (s *externalScheduler) sendDelta(cluster sche.SchedulerCluster, es *externalStream, delta *externalDelta) error {
+	req := &externalpb.ScheduleRequest{Type: externalpb.RequestType_DELTA, Limit: delta.limit}
+	req.Stores, req.RemovedStoreIds = es.updatedStores(cluster)
+	for id := range delta.regions {
+		if len(req.Regions) >= s.regionBatch {
+			if err := s.send(es, req); err != nil {
+				return err
+			}
+			req = &externalpb.ScheduleRequest{Type: externalpb.RequestType_DELTA, Limit: delta.limit}
+		}
+		if region := cluster.GetRegion(id); region != nil {
+			req.Regions = append(req.Regions, external.NewRegion(region))
+		} else {
+			req.RemovedRegionIds = append(req.RemovedRegionIds, id)
+		}
+	}
+	return s.send(es, req)
+}
+
+// send sends the request within the timeout, or the stream is canceled.
+func (s *externalScheduler) send(es *externalStream, req *externalpb.ScheduleRequest) error {
+	timer := time.AfterFunc(s.conf.clone().Timeout.Duration, func() {
+		externalTimeoutCounter.Inc()
+		es.cancel()
+	})
+	defer timer.Stop()
+	return es.client.Send(req)
+}
+
+// updatedStores returns the stores updated since they were sent, and the IDs of the
+// removed stores.
+func (es *externalStream) updatedStores(cluster sche.SchedulerCluster) (stores []*externalpb.Store, removed []uint64) {
+	alive := make(map[uint64]struct{}, len(es.sentStores))
+	for _, store := range cluster.GetStores() {
+		if store.IsRemoved() {
+			continue
+		}
+		alive[store.GetID()] = struct{}{}
+		if es.sentStores[store.GetID()] != store {
+			es.sentStores[store.GetID()] = store
+			stores = append(stores, external.NewStore(store))
+		}
+	}
+	for id := range es.sentStores {
+		if _, ok := alive[id]; !ok {
+			delete(es.sentStores, id)
+			removed = append(removed, id)
+		}
+	}
+	return stores, removed
+}
+
+// breakStream closes the stream, and a new one is opened with the snapshot in the
+// next scheduling.
+func (s *externalScheduler) breakStream(es *externalStream, err error) {
+	es.cancel()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stream != es {
+		return
+	}
+	s.closeStreamLocked()
+	if s.ctx.Err() == nil {
+		externalRPCFailCounter.Inc()
+		log.Warn("the stream to the external scheduler is broken", zap.String("address", s.address), errs.ZapError(err))
+	}
+}
+
+func (s *externalScheduler) closeStreamLocked() {
+	if s.stream != nil {
+		s.stream.cancel()
+	}
+	s.stream = nil
+}
+
+func (s *externalScheduler) closeConnLocked() {
+	s.closeStreamLocked()
+	if s.conn != nil {
+		if err := s.conn.Close(); err != nil {
+			log.Debug("failed to close the connection of the external scheduler", errs.ZapError(err))
+		}
+	}
+	s.conn, s.address, s.proposals = nil, "", nil
+}
+
+// createOperators creates the operators from the proposals. The proposals are
+// checked by the filters and the operator controller, the invalid ones are dropped.
+func (s *externalScheduler) createOperators(cluster sche.SchedulerCluster, proposals []*externalpb.Proposal, limit int) []*operator.Operator {
+	leaderAllowed, regionAllowed := s.getAllowedKinds(cluster)
+	ops := make([]*operator.Operator, 0, len(proposals))
+	regions := make(map[uint64]struct{}, len(proposals))
+	for _, p := range proposals {
+		if len(ops) >= limit {
+			break
+		}
+		if _, ok := regions[p.GetRegionId()]; ok {
+			externalInvalidProposalCounter.Inc()
+			continue
+		}
+		isLeader := p.GetType() == externalpb.ProposalType_TRANSFER_LEADER
+		if isLeader && !leaderAllowed || !isLeader && !regionAllowed {
+			externalFilteredProposalCounter.Inc()
+			continue
+		}
+		op := s.createOperator(cluster, p)
+		if op == nil {
+			continue
+		}
+		if ok, reason := s.OpController.CheckAddOperator(op); !ok {
+			externalRejectedCounter.Inc()
+			log.Debug("the proposal of the external scheduler is rejected",
+				zap.Uint64("region-id", p.GetRegionId()), zap.Stringer("type", p.GetType()), zap.String("reason", string(reason)))
+			continue
+		}
+		op.SetAdditionalInfo("proposal", p.GetType().String())
+		op.Counters = append(op.Counters, externalNewOpCounter)
+		regions[p.GetRegionId()] = struct{}{}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+func (s *externalScheduler) createOperator(cluster sche.SchedulerCluster, p *externalpb.Proposal) *operator.Operator {
+	region := cluster.GetRegion(p.GetRegionId())
+	if region == nil {
+		externalInvalidProposalCounter.Inc()
+		return nil
+	}
+	epoch := region.GetRegionEpoch()
+	if epoch.GetVersion() != p.GetRegionVersion() || epoch.GetConfVer() != p.GetConfVer() {
+		externalStaleProposalCounter.Inc()
+		return nil
+	}
+	// Only the healthy regions with a leader are scheduled, and the fault
+	// tolerance of the region must not be reduced.
+	if region.GetLeader() == nil || filter.SelectOneRegion([]*core.RegionInfo{region}, nil,
+		filter.NewRegionDownFilter(), filter.NewRegionPendingFilter()) == nil {
+		externalFilteredProposalCounter.Inc()
+		return nil
+	}
+	if !s.isProposalAllowed(cluster, region, p) {
+		externalFilteredProposalCounter.Inc()
+		return nil
+	}
+	var (
+		op  *operator.Operator
+		err error
+	)
+	switch p.GetType() {
+	case externalpb.ProposalType_TRANSFER_LEADER:
+		op, err = operator.CreateTransferLeaderOperator(s.GetName(), cluster, region, p.GetTargetStoreId(), []uint64{}, operator.OpLeader)
+	case externalpb.ProposalType_MOVE_PEER:
+		source := region.GetStorePeer(p.GetSourceStoreId())
+		peer := &metapb.Peer{StoreId: p.GetTargetStoreId(), Role: source.GetRole(), IsWitness: source.GetIsWitness()}
+		op, err = operator.CreateMovePeerOperator(s.GetName(), cluster, region, operator.OpRegion, p.GetSourceStoreId(), peer)
+	case externalpb.ProposalType_MOVE_LEADER:
+		source := region.GetStorePeer(p.GetSourceStoreId())
+		peer := &metapb.Peer{StoreId: p.GetTargetStoreId(), Role: source.GetRole(), IsWitness: source.GetIsWitness()}
+		op, err = operator.CreateMoveLeaderOperator(s.GetName(), cluster, region, operator.OpRegion|operator.OpLeader, p.GetSourceStoreId(), peer)
+	case externalpb.ProposalType_ADD_PEER:
+		op, err = operator.CreateAddPeerOperator(s.GetName(), cluster, region, &metapb.Peer{StoreId: p.GetTargetStoreId()}, operator.OpRegion)
+	case externalpb.ProposalType_REMOVE_PEER:
+		op, err = operator.CreateRemovePeerOperator(s.GetName(), cluster, operator.OpRegion, region, p.GetSourceStoreId())
+	}
+	if err != nil {
+		log.Debug("fail to create the operator proposed by the external scheduler", errs.ZapError(err))
+		externalCreateOpFailCounter.Inc()
+		return nil
+	}
+	return op
+}
+
+// isProposalAllowed checks the source and the target of the proposal, and that
+// neither the placement nor the fault tolerance of the region becomes worse.
+func (s *externalScheduler) isProposalAllowed(cluster sche.SchedulerCluster, region *core.RegionInfo, p *externalpb.Proposal) bool {
+	leaderStoreID := region.GetLeader().GetStoreId()
+	source, target := region.GetStorePeer(p.GetSourceStoreId()), region.GetStorePeer(p.GetTargetStoreId())
+	var changed *core.RegionInfo
+	switch p.GetType() {
+	case externalpb.ProposalType_TRANSFER_LEADER:
+		if target == nil || p.GetTargetStoreId() == leaderStoreID {
+			return false
+		}
+		return s.isTargetAllowed(cluster, region, leaderStoreID, p.GetTargetStoreId(), true)
+	case externalpb.ProposalType_MOVE_PEER, externalpb.ProposalType_MOVE_LEADER:
+		isLeader := p.GetSourceStoreId() == leaderStoreID
+		if source == nil || target != nil || (p.GetType() == externalpb.ProposalType_MOVE_LEADER) != isLeader ||
+			!s.isTargetAllowed(cluster, region, p.GetSourceStoreId(), p.GetTargetStoreId(), false) {
+			return false
+		}
+		peer := &metapb.Peer{StoreId: p.GetTargetStoreId(), Role: source.GetRole(), IsWitness: source.GetIsWitness()}
+		changed = region.Clone(core.WithRemoveStorePeer(p.GetSourceStoreId()), core.WithAddPeer(peer))
+	case externalpb.ProposalType_ADD_PEER:
+		// A peer is only added to the region which lacks replicas.
+		if target != nil || filter.IsRegionReplicated(cluster, region) ||
+			!s.isTargetAllowed(cluster, region, 0, p.GetTargetStoreId(), false) {
+			return false
+		}
+		changed = region.Clone(core.WithAddPeer(&metapb.Peer{StoreId: p.GetTargetStoreId()}))
+		if !isAddedPeerPlaced(cluster, changed, p.GetTargetStoreId()) {
+			return false
+		}
+	case externalpb.ProposalType_REMOVE_PEER:
+		// Only the extra peer is removed, so the region is still replicated after that.
+		if source == nil || p.GetSourceStoreId() == leaderStoreID {
+			return false
+		}
+		changed = region.Clone(core.WithRemoveStorePeer(p.GetSourceStoreId()))
+		if !filter.IsRegionReplicated(cluster, changed) {
+			return false
+		}
+	default:
+		externalInvalidProposalCounter.Inc()
+		return false
+	}
+	return !reducesFaultTolerance(region, changed)
+}
+
+// isTargetAllowed checks whether the target store is able to receive the leader or
+// the peer, and the placement of the region does not become worse. The source store
+// is 0 if a peer is added, and the placement is checked by isAddedPeerPlaced then.
+func (s *externalScheduler) isTargetAllowed(cluster sche.SchedulerCluster, region *core.RegionInfo, sourceStoreID, targetStoreID uint64, isLeader bool) bool {
+	target := cluster.GetStore(targetStoreID)
+	if target == nil {
+		return false
+	}
+	conf := cluster.GetSchedulerConfig()
+	source := cluster.GetStore(sourceStoreID)
+	if source == nil && sourceStoreID != 0 {
+		return false
+	}
+	var filters []filter.Filter
+	if isLeader {
+		filters = append(filters, &filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true, OperatorLevel: constant.Medium})
+		if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, false /*allowMoveLeader*/); leaderFilter != nil {
+			filters = append(filters, leaderFilter)
+		}
+	} else {
+		filters = append(filters,
+			&filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.Medium},
+			filter.NewStorageThresholdFilter(s.GetName()),
+			filter.NewSpecialUseFilter(s.GetName()),
+		)
+		if source != nil {
+			filters = append(filters, filter.NewPlacementSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, nil))
+		}
+	}
+	return filter.Target(conf, target, filters)
+}
+
+// isAddedPeerPlaced checks the peer added on the target store is placed by the
+// placement rules. If the rules are disabled, the new peer must not share the
+// location with the other peers.
+func isAddedPeerPlaced(cluster sche.SchedulerCluster, changed *core.RegionInfo, targetStoreID uint64) bool {
+	conf := cluster.GetSchedulerConfig()
+	if conf.IsPlacementRulesEnabled() {
+		fit := cluster.GetRuleManager().FitRegion(cluster, changed)
+		return !slices.ContainsFunc(fit.OrphanPeers, func(peer *metapb.Peer) bool {
+			return peer.GetStoreId() == targetStoreID
+		})
+	}
+	labels := conf.GetLocationLabels()
+	if len(labels) == 0 {
+		return true
+	}
+	var others []*core.StoreInfo
+	for _, store := range cluster.GetRegionStores(changed) {
+		if store.GetID() != targetStoreID {
+			others = append(others, store)
+		}
+	}
+	isolation := filter.NewIsolationFilter(types.ExternalScheduler.String(), labels[len(labels)-1], labels, others)
+	return isolation.Target(conf, cluster.GetStore(targetStoreID)).IsOK()
+}
+
+// reducesFaultTolerance returns whether the region tolerates fewer failed voters
+// after it is changed.
+func reducesFaultTolerance(region, changed *core.RegionInfo) bool {
+	tolerance := func(r *core.RegionInfo) int {
+		return (len(r.GetVoters()) - 1) / 2
+	}
+	return tolerance(changed) < tolerance(region)
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/external/externalpb"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/operatorutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+type mockExternalScheduler struct {
+	syncutil.Mutex
+	// streams are the requests received by every stream.
+	streams   [][]*externalpb.ScheduleRequest
+	proposals [][]*externalpb.Proposal
+	// broken breaks the stream after the next request is received.
+	broken bool
+}
+
+// Schedule implements the externalpb.SchedulerServer interface. The proposals are
+// replied after the snapshot or a delta is received.
+func (m *mockExternalScheduler) Schedule(stream externalpb.Scheduler_ScheduleServer) error {
+	m.Lock()
+	idx := len(m.streams)
+	m.streams = append(m.streams, nil)
+	m.Unlock()
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		m.Lock()
+		m.streams[idx] = append(m.streams[idx], req)
+		var resp *externalpb.ScheduleResponse
+		if req.GetType() != externalpb.RequestType_SNAPSHOT && len(m.proposals) > 0 {
+			resp = &externalpb.ScheduleResponse{Proposals: m.proposals[0]}
+			m.proposals = m.proposals[1:]
+		}
+		broken := m.broken
+		m.broken = false
+		m.Unlock()
+		if broken {
+			return errors.New("broken stream")
+		}
+		if resp != nil {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (m *mockExternalScheduler) propose(proposals ...*externalpb.Proposal) {
+	m.Lock()
+	defer m.Unlock()
+	m.proposals = append(m.proposals, proposals)
+}
+
+func (m *mockExternalScheduler) breakStream() {
+	m.Lock()
+	defer m.Unlock()
+	m.broken = true
+}
+
+func (m *mockExternalScheduler) requests(stream int) []*externalpb.ScheduleRequest {
+	m.Lock()
+	defer m.Unlock()
+	if stream >= len(m.streams) {
+		return nil
+	}
+	return slices.Clone(m.streams[stream])
+}
+
+func regionIDs(req *externalpb.ScheduleRequest) []uint64 {
+	ids := make([]uint64, 0, len(req.GetRegions()))
+	for _, region := range req.GetRegions() {
+		ids = append(ids, region.GetId())
+	}
+	return ids
+}
+
+func TestExternalScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(false)
+	defer cancel()
+
+	mock := &mockExternalScheduler{}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	re.NoError(err)
+	srv := grpc.NewServer()
+	externalpb.RegisterSchedulerServer(srv, mock)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	_, err = CreateScheduler(types.ExternalScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ExternalScheduler, []string{"127.0.0.1"}))
+	re.Error(err)
+	sb, err := CreateScheduler(types.ExternalScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ExternalScheduler, []string{"http://" + lis.Addr().String()}))
+	re.NoError(err)
+	defer sb.CleanConfig(tc)
+	s := sb.(*externalScheduler)
+	s.regionBatch = 2
+	updateConfig := func(body string) int {
+		req, _ := http.NewRequest(http.MethodPost, "/config", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		sb.ServeHTTP(resp, req)
+		return resp.Code
+	}
+	re.Equal(http.StatusBadRequest, updateConfig(`{"batch": 0}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"timeout": "20s"}`))
+	re.Equal(http.StatusOK, updateConfig(`{"batch": 2}`))
+
+	for id := uint64(1); id <= 4; id++ {
+		tc.AddRegionStore(id, 10)
+	}
+	tc.AddLeaderRegionWithRange(1, "", "a", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(2, "a", "b", 1, 2, 3)
+	tc.AddLeaderRegionWithRange(3, "b", "", 2, 1)
+	region1, region2, region3 := tc.GetRegion(1), tc.GetRegion(2), tc.GetRegion(3)
+	proposal := func(typ externalpb.ProposalType, region *core.RegionInfo, source, target uint64) *externalpb.Proposal {
+		return &externalpb.Proposal{
+			Type:          typ,
+			RegionId:      region.GetID(),
+			RegionVersion: region.GetRegionEpoch().GetVersion(),
+			ConfVer:       region.GetRegionEpoch().GetConfVer(),
+			SourceStoreId: source,
+			TargetStoreId: target,
+		}
+	}
+	// waitRequests waits for the stream to receive n requests.
+	waitRequests := func(stream, n int) []*externalpb.ScheduleRequest {
+		re.Eventually(func() bool {
+			return len(mock.requests(stream)) == n
+		}, 5*time.Second, 10*time.Millisecond)
+		return mock.requests(stream)
+	}
+	waitProposals := func() {
+		re.Eventually(func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return len(s.proposals) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	// exchange sends a delta to the stream and waits for the proposals.
+	exchange := func(n int, proposals ...*externalpb.Proposal) *externalpb.ScheduleRequest {
+		mock.propose(proposals...)
+		ops, _ := sb.Schedule(tc, false)
+		re.Empty(ops)
+		reqs := waitRequests(0, n)
+		waitProposals()
+		return reqs[n-1]
+	}
+
+	// The snapshot is sent in batches after the stream is opened.
+	mock.propose(proposal(externalpb.ProposalType_TRANSFER_LEADER, region1, 0, 2))
+	ops, _ := sb.Schedule(tc, false)
+	re.Empty(ops)
+	reqs := waitRequests(0, 2)
+	re.Equal(externalpb.RequestType_SNAPSHOT, reqs[0].Type)
+	re.Len(reqs[0].Stores, 4)
+	re.Equal([]uint64{1, 2}, regionIDs(reqs[0]))
+	re.Equal(uint32(2), reqs[0].Limit)
+	re.Equal(externalpb.RequestType_SNAPSHOT_END, reqs[1].Type)
+	re.Empty(reqs[1].Stores)
+	re.Equal([]uint64{3}, regionIDs(reqs[1]))
+	waitProposals()
+	// The cached proposals are not taken by the dry run.
+	ops, _ = s.DryRun(tc)
+	re.Len(ops, 1)
+	re.Empty(oc.GetOperators())
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferLeader(re, ops[0], operator.OpLeader, 1, 2)
+	// Nothing is updated since the snapshot.
+	reqs = waitRequests(0, 3)
+	re.Equal(externalpb.RequestType_DELTA, reqs[2].Type)
+	re.Empty(reqs[2].Stores)
+	re.Empty(reqs[2].Regions)
+
+	// The stores and the regions updated by the heartbeats are sent in the delta.
+	// The stale, invalid, duplicated and unhealthy proposals are dropped, and so
+	// are the proposals reducing the fault tolerance or breaking the placement.
+	tc.PutRegion(region2.Clone(core.WithDownPeers([]*pdpb.PeerStats{{Peer: region2.GetStorePeer(3), DownSeconds: 3600}})))
+	s.ObserveRegion(tc.GetRegion(2))
+	tc.UpdateStoreStatus(4)
+	stale := proposal(externalpb.ProposalType_TRANSFER_LEADER, region1, 0, 3)
+	stale.RegionVersion++
+	req := exchange(4,
+		stale,
+		proposal(externalpb.ProposalType_TRANSFER_LEADER, region2, 0, 2),
+		proposal(externalpb.ProposalType_REMOVE_PEER, region1, 3, 0),
+		proposal(externalpb.ProposalType(100), region1, 0, 0),
+		proposal(externalpb.ProposalType_ADD_PEER, region1, 0, 4),
+		proposal(externalpb.ProposalType_MOVE_LEADER, region1, 2, 4),
+		proposal(externalpb.ProposalType_MOVE_PEER, region1, 3, 4),
+		proposal(externalpb.ProposalType_MOVE_PEER, region1, 2, 4),
+		proposal(externalpb.ProposalType_ADD_PEER, region3, 0, 4),
+	)
+	re.Len(req.Stores, 1)
+	re.Equal(uint64(4), req.Stores[0].Id)
+	re.Equal([]uint64{2}, regionIDs(req))
+	re.True(req.Regions[0].Peers[2].IsDown)
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 2)
+	operatorutil.CheckTransferPeer(re, ops[0], operator.OpRegion, 3, 4)
+	operatorutil.CheckAddPeer(re, ops[1], operator.OpRegion, 4)
+	waitRequests(0, 5)
+
+	// The operators rejected by the controller are dropped.
+	re.True(oc.AddOperator(ops...))
+	exchange(6, proposal(externalpb.ProposalType_MOVE_PEER, region1, 2, 4))
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+	waitRequests(0, 7)
+
+	// The expired proposals are dropped.
+	exchange(8, proposal(externalpb.ProposalType_TRANSFER_LEADER, region1, 0, 2))
+	s.mu.Lock()
+	s.proposalTime = s.proposalTime.Add(-externalProposalTTL - time.Second)
+	s.mu.Unlock()
+	ops, _ = s.DryRun(tc)
+	re.Empty(ops)
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+	waitRequests(0, 9)
+
+	// The removed regions are sent in the delta.
+	s.ObserveRegion(region1)
+	tc.RemoveRegion(tc.GetRegion(1))
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+	req = waitRequests(0, 10)[9]
+	re.Empty(req.Regions)
+	re.Equal([]uint64{1}, req.RemovedRegionIds)
+
+	// A new stream is opened with the snapshot after the stream breaks.
+	mock.breakStream()
+	sb.Schedule(tc, false)
+	waitRequests(0, 11)
+	re.Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.stream == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	sb.Schedule(tc, false)
+	reqs = waitRequests(1, 1)
+	re.Equal(externalpb.RequestType_SNAPSHOT_END, reqs[0].Type)
+	re.Len(reqs[0].Stores, 4)
+	re.Equal([]uint64{2, 3}, regionIDs(reqs[0]))
+}
@@ -0,0 +1,609 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// globalBalanceBatchSize is the default number of operators to be created by one scheduling.
+	globalBalanceBatchSize = 4
+	// maxGlobalBalanceBatchSize is the maximum of the batch size.
+	maxGlobalBalanceBatchSize = 10
+	// globalBalanceCrossZoneCost is the default cost of moving a unit of data between two zones,
+	// the cost of moving it within a zone is 1.
+	globalBalanceCrossZoneCost = 10
+	// globalBalanceToleranceRatio is the default ratio of the target size which is tolerated,
+	// no plan is made if the sizes of all stores are within the tolerance.
+	globalBalanceToleranceRatio = 0.05
+	// globalBalancePlanTTL is the max lifetime of a plan, it will be recomputed after that.
+	globalBalancePlanTTL = 10 * time.Minute
+	// globalBalanceRetryLimit is the max number of regions picked for a move by one scheduling.
+	globalBalanceRetryLimit = 10
+)
+
+type globalBalanceSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// Batch is used to generate multiple operators by one scheduling.
+	Batch int `json:"batch"`
+	// CrossZoneCost is the cost of moving a unit of data between two zones. It is not
+	// used if the zone costs are configured in the replication config.
+	CrossZoneCost float64 `json:"cross-zone-cost"`
+	// ToleranceRatio is the ratio of the target size that the size of a store can deviate from.
+	ToleranceRatio float64 `json:"tolerance-ratio"`
+
+	// plan is the runtime plan, it is not persisted.
+	plan *globalBalancePlan
+}
+
+func (conf *globalBalanceSchedulerConfig) update(data []byte) (int, any) {
+	conf.Lock()
+	defer conf.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if msg := conf.validateLocked(); len(msg) > 0 {
+			if err := json.Unmarshal(oldc, conf); err != nil {
+				return http.StatusInternalServerError, err.Error()
+			}
+			return http.StatusBadRequest, msg
+		}
+		if err := conf.save(); err != nil {
+			log.Warn("failed to persist config", zap.Error(err))
+		}
+		// The parameters of the plan are changed, recompute it in the next scheduling.
+		conf.plan = nil
+		log.Info("global-balance-scheduler config is updated", zap.ByteString("old", oldc), zap.ByteString("new", newc))
+		return http.StatusOK, "Config is updated."
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	ok := reflectutil.FindSameFieldByJSON(conf, m)
+	if ok {
+		return http.StatusOK, "Config is the same with origin, so do nothing."
+	}
+	return http.StatusBadRequest, "Config item is not found."
+}
+
+func (conf *globalBalanceSchedulerConfig) validateLocked() string {
+	if conf.Batch < 1 || conf.Batch > maxGlobalBalanceBatchSize {
+		return "invalid batch size which should be an integer between 1 and 10"
+	}
+	if conf.CrossZoneCost < 1 {
+		return "invalid cross-zone-cost which should not be less than 1"
+	}
+	if conf.ToleranceRatio < 0 || conf.ToleranceRatio >= 1 {
+		return "invalid tolerance-ratio which should be in [0, 1)"
+	}
+	return ""
+}
+
+func (conf *globalBalanceSchedulerConfig) clone() *globalBalanceSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return &globalBalanceSchedulerConfig{
+		Batch:          conf.Batch,
+		CrossZoneCost:  conf.CrossZoneCost,
+		ToleranceRatio: conf.ToleranceRatio,
+	}
+}
+
+func (conf *globalBalanceSchedulerConfig) getBatch() int {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.Batch
+}
+
+func (conf *globalBalanceSchedulerConfig) getCrossZoneCost() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.CrossZoneCost
+}
+
+func (conf *globalBalanceSchedulerConfig) getToleranceRatio() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.ToleranceRatio
+}
+
+func (conf *globalBalanceSchedulerConfig) getPlan() *globalBalancePlan {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.plan.clone()
+}
+
+// currentPlan returns the plan without copying, it is only used by the scheduling
+// goroutine which is the only one to update the moves of the plan.
+func (conf *globalBalanceSchedulerConfig) currentPlan() *globalBalancePlan {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.plan
+}
+
+func (conf *globalBalanceSchedulerConfig) setPlan(p *globalBalancePlan) {
+	conf.Lock()
+	defer conf.Unlock()
+	conf.plan = p
+}
+
+// recordScheduled records the size of the region scheduled by the move, it is called
+// when the operator is accepted by the operator controller.
+func (conf *globalBalanceSchedulerConfig) recordScheduled(move *globalBalanceMove, size int64) {
+	conf.Lock()
+	defer conf.Unlock()
+	move.Scheduled += size
+}
+
+// globalBalanceMove is a planned data movement from a store to another one.
+type globalBalanceMove struct {
+	SourceStoreID uint64 `json:"source-store-id"`
+	TargetStoreID uint64 `json:"target-store-id"`
+	// Size is the planned size in MB to move.
+	Size int64 `json:"size"`
+	// Scheduled is the size in MB of the regions which have been scheduled.
+	Scheduled int64   `json:"scheduled"`
+	Cost      float64 `json:"cost"`
+	CrossZone bool    `json:"cross-zone"`
+}
+
+func (m *globalBalanceMove) remaining() int64 {
+	return m.Size - m.Scheduled
+}
+
+// globalBalancePlan is a batch of moves toward the target distribution.
+type globalBalancePlan struct {
+	CreateTime time.Time `json:"create-time"`
+	// Targets is the target size in MB of each store.
+	Targets       map[uint64]int64     `json:"targets"`
+	Moves         []*globalBalanceMove `json:"moves"`
+	TotalCost     float64              `json:"total-cost"`
+	CrossZoneSize int64                `json:"cross-zone-size"`
+}
+
+func (p *globalBalancePlan) clone() *globalBalancePlan {
+	if p == nil {
+		return nil
+	}
+	cloned := &globalBalancePlan{
+		CreateTime:    p.CreateTime,
+		Targets:       make(map[uint64]int64, len(p.Targets)),
+		Moves:         make([]*globalBalanceMove, 0, len(p.Moves)),
+		TotalCost:     p.TotalCost,
+		CrossZoneSize: p.CrossZoneSize,
+	}
+	for id, size := range p.Targets {
+		cloned.Targets[id] = size
+	}
+	for _, m := range p.Moves {
+		move := *m
+		cloned.Moves = append(cloned.Moves, &move)
+	}
+	return cloned
+}
+
+// isStale returns true if the plan should be recomputed.
+func (p *globalBalancePlan) isStale(stores []*core.StoreInfo, now time.Time) bool {
+	if p == nil || now.Sub(p.CreateTime) > globalBalancePlanTTL || len(p.Targets) != len(stores) {
+		return true
+	}
+	for _, store := range stores {
+		if _, ok := p.Targets[store.GetID()]; !ok {
+			return true
+		}
+	}
+	for _, m := range p.Moves {
+		if m.remaining() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// globalBalanceStore is the input of the planner.
+type globalBalanceStore struct {
+	id       uint64
+	zone     string
+	size     int64
+	capacity uint64
+}
+
+// computeGlobalBalancePlan computes the moves from the stores above the target size to
+// the stores below it. The target size of each store is proportional to its capacity.
+// The moves are decided greedily by the cost, so the data is moved within a zone as
+// much as possible. The moves smaller than minMoveSize are dropped.
+func computeGlobalBalancePlan(stores []*globalBalanceStore, zoneCost func(from, to string) float64, toleranceRatio float64, minMoveSize int64) *globalBalancePlan {
+	p := &globalBalancePlan{
+		Targets: make(map[uint64]int64, len(stores)),
+		Moves:   make([]*globalBalanceMove, 0),
+	}
+	var totalSize int64
+	var totalCapacity uint64
+	for _, store := range stores {
+		totalSize += store.size
+		totalCapacity += store.capacity
+	}
+	deltas := make(map[uint64]int64, len(stores))
+	unbalanced := false
+	for _, store := range stores {
+		var target int64
+		if totalCapacity > 0 {
+			target = int64(float64(totalSize) * float64(store.capacity) / float64(totalCapacity))
+		} else {
+			target = totalSize / int64(len(stores))
+		}
+		p.Targets[store.id] = target
+		deltas[store.id] = store.size - target
+		tolerance := int64(float64(target) * toleranceRatio)
+		if tolerance < minMoveSize {
+			tolerance = minMoveSize
+		}
+		if deltas[store.id] > tolerance || -deltas[store.id] > tolerance {
+			unbalanced = true
+		}
+	}
+	if !unbalanced {
+		return p
+	}
+
+	type route struct {
+		source, target *globalBalanceStore
+		cost           float64
+	}
+	routes := make([]route, 0)
+	for _, source := range stores {
+		if deltas[source.id] <= 0 {
+			continue
+		}
+		for _, target := range stores {
+			if deltas[target.id] >= 0 {
+				continue
+			}
+			cost := 1.0
+			if source.zone != target.zone {
+				cost = zoneCost(source.zone, target.zone)
+			}
+			routes = append(routes, route{source: source, target: target, cost: cost})
+		}
+	}
+	// The cheaper routes are used first, and the larger amounts are moved first at the same cost.
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].cost != routes[j].cost {
+			return routes[i].cost < routes[j].cost
+		}
+		return min(deltas[routes[i].source.id], -deltas[routes[i].target.id]) >
+			min(deltas[routes[j].source.id], -deltas[routes[j].target.id])
+	})
+	for _, r := range routes {
+		size := min(deltas[r.source.id], -deltas[r.target.id])
+		if size <= 0 || size < minMoveSize {
+			continue
+		}
+		deltas[r.source.id] -= size
+		deltas[r.target.id] += size
+		move := &globalBalanceMove{
+			SourceStoreID: r.source.id,
+			TargetStoreID: r.target.id,
+			Size:          size,
+			Cost:          float64(size) * r.cost,
+			CrossZone:     r.source.zone != r.target.zone,
+		}
+		p.Moves = append(p.Moves, move)
+		p.TotalCost += move.Cost
+		if move.CrossZone {
+			p.CrossZoneSize += size
+		}
+	}
+	return p
+}
+
+type globalBalanceHandler struct {
+	rd     *render.Render
+	config *globalBalanceSchedulerConfig
+}
+
+func newGlobalBalanceHandler(conf *globalBalanceSchedulerConfig) http.Handler {
+	handler := &globalBalanceHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/plan", handler.getPlan).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *globalBalanceHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *globalBalanceHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := handler.config.clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+func (handler *globalBalanceHandler) getPlan(w http.ResponseWriter, _ *http.Request) {
+	p := handler.config.getPlan()
+	if p == nil {
+		handler.rd.JSON(w, http.StatusOK, "The plan has not been computed yet.")
+		return
+	}
+	handler.rd.JSON(w, http.StatusOK, p)
+}
+
+type globalBalanceScheduler struct {
+	*BaseScheduler
+	conf          *globalBalanceSchedulerConfig
+	handler       http.Handler
+	filters       []filter.Filter
+	filterCounter *filter.Counter
+}
+
+// newGlobalBalanceScheduler creates a scheduler that computes a plan toward the target
+// distribution of the region size for all stores, and then creates operators from it.
+func newGlobalBalanceScheduler(opController *operator.Controller, conf *globalBalanceSchedulerConfig) Scheduler {
+	s := &globalBalanceScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.GlobalBalanceScheduler, conf),
+		conf:          conf,
+		handler:       newGlobalBalanceHandler(conf),
+		filterCounter: filter.NewCounter(types.GlobalBalanceScheduler.String()),
+	}
+	s.filters = []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.Medium},
+		filter.NewSpecialUseFilter(s.GetName()),
+		filter.NewEngineFilter(s.GetName(), filter.NotSpecialEngines),
+	}
+	return s
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *globalBalanceScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *globalBalanceScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.RLock()
+	defer s.conf.RUnlock()
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *globalBalanceScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+
+	newCfg := &globalBalanceSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.Batch = newCfg.Batch
+	s.conf.CrossZoneCost = newCfg.CrossZoneCost
+	s.conf.ToleranceRatio = newCfg.ToleranceRatio
+	s.conf.plan = nil
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *globalBalanceScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpRegion) < cluster.GetSchedulerConfig().GetRegionScheduleLimit()
+	if !allowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	return allowed
+}
+
+// Schedule implements the Scheduler interface.
+func (s *globalBalanceScheduler) Schedule(cluster sche.SchedulerCluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	return s.schedule(cluster, dryRun, false)
+}
+
+// DryRun implements the DryRunScheduler interface, the plan and its progress are not updated.
+func (s *globalBalanceScheduler) DryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	return s.schedule(cluster, true, true)
+}
+
+func (s *globalBalanceScheduler) schedule(cluster sche.SchedulerCluster, collectPlans, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	basePlan := plan.NewBalanceSchedulerPlan()
+	defer s.filterCounter.Flush()
+	var collector *plan.Collector
+	if collectPlans {
+		collector = plan.NewCollector(basePlan)
+	}
+	globalBalanceScheduleCounter.Inc()
+	conf := cluster.GetSchedulerConfig()
+	stores := filter.SelectSourceStores(cluster.GetStores(), s.filters, conf, collector, s.filterCounter)
+	if len(stores) < 2 {
+		return nil, collector.GetPlans()
+	}
+	p := s.conf.currentPlan()
+	if p.isStale(stores, time.Now()) {
+		p = s.makePlan(cluster, stores)
+		if !dryRun {
+			s.conf.setPlan(p)
+			globalBalanceNewPlanCounter.Inc()
+			log.Info("global balance plan is computed", zap.String("scheduler", s.GetName()),
+				zap.Int("moves", len(p.Moves)), zap.Float64("total-cost", p.TotalCost), zap.Int64("cross-zone-size", p.CrossZoneSize))
+		}
+	}
+	batch := s.conf.getBatch()
+	usedRegions := make(map[uint64]struct{})
+	// pending is the size scheduled by the moves in this round, which is recorded
+	// by the moves only after the operators are accepted.
+	pending := make(map[*globalBalanceMove]int64)
+	ops := make([]*operator.Operator, 0, batch)
+	for _, move := range p.Moves {
+		for len(ops) < batch {
+			remaining := move.remaining() - pending[move]
+			if remaining <= 0 {
+				break
+			}
+			op := s.scheduleMove(cluster, move, remaining, usedRegions, collector)
+			if op == nil {
+				break
+			}
+			size := op.ApproximateSize
+			pending[move] += size
+			if !dryRun {
+				op.AddedHooks = append(op.AddedHooks, func() { s.conf.recordScheduled(move, size) })
+			}
+			usedRegions[op.RegionID()] = struct{}{}
+			ops = append(ops, op)
+		}
+		if len(ops) >= batch {
+			break
+		}
+	}
+	if len(ops) == 0 {
+		globalBalanceNoOperatorCounter.Inc()
+	}
+	return ops, collector.GetPlans()
+}
+
+// makePlan computes a new plan with the size of the stores including the influence
+// of the running operators.
+func (s *globalBalanceScheduler) makePlan(cluster sche.SchedulerCluster, stores []*core.StoreInfo) *globalBalancePlan {
+	opInfluence := s.OpController.GetOpInfluence(cluster.GetBasicCluster())
+	matrix := cluster.GetSharedConfig().GetZoneCostMatrix()
+	inputs := make([]*globalBalanceStore, 0, len(stores))
+	for _, store := range stores {
+		inputs = append(inputs, &globalBalanceStore{
+			id:       store.GetID(),
+			zone:     matrix.GetZone(store),
+			size:     store.GetRegionSize() + opInfluence.GetStoreInfluence(store.GetID()).RegionSize,
+			capacity: store.GetCapacity(),
+		})
+	}
+	// The cost within a zone is 1, so the cost between zones is added to it
+	// if the zone costs are configured.
+	crossZoneCost := s.conf.getCrossZoneCost()
+	zoneCost := func(string, string) float64 { return crossZoneCost }
+	if matrix.IsEnabled() {
+		zoneCost = func(from, to string) float64 { return 1 + matrix.GetZoneCost(from, to) }
+	}
+	minMoveSize := int64(cluster.GetStoreConfig().GetRegionSplitSize())
+	p := computeGlobalBalancePlan(inputs, zoneCost, s.conf.getToleranceRatio(), minMoveSize)
+	p.CreateTime = time.Now()
+	return p
+}
+
+// scheduleMove picks a region from the source store of the move, and creates an operator
+// to move its peer to the target store.
+func (s *globalBalanceScheduler) scheduleMove(cluster sche.SchedulerCluster, move *globalBalanceMove, remaining int64,
+	usedRegions map[uint64]struct{}, collector *plan.Collector) *operator.Operator {
+	source, target := cluster.GetStore(move.SourceStoreID), cluster.GetStore(move.TargetStoreID)
+	if source == nil || target == nil {
+		return nil
+	}
+	conf := cluster.GetSchedulerConfig()
+	stores := cluster.GetStores()
+	replicaFilter := filter.NewRegionReplicatedFilter(cluster)
+	regionFilters := []filter.RegionFilter{
+		filter.NewRegionDownFilter(),
+		filter.NewRegionPendingFilter(),
+		replicaFilter,
+		filter.NewSnapshotSendFilter(stores, constant.Medium),
+		filter.NewRegionEmptyFilter(cluster),
+		filter.NewRegionWitnessFilter(source.GetID()),
+	}
+	ranges := []core.KeyRange{core.NewKeyRange("", "")}
+	for range globalBalanceRetryLimit {
+		region := filter.SelectOneRegion(cluster.RandFollowerRegions(source.GetID(), ranges), collector, regionFilters...)
+		if region == nil {
+			region = filter.SelectOneRegion(cluster.RandLeaderRegions(source.GetID(), ranges), collector, regionFilters...)
+		}
+		if region == nil {
+			globalBalanceNoRegionCounter.Inc()
+			return nil
+		}
+		if _, ok := usedRegions[region.GetID()]; ok {
+			continue
+		}
+		if cluster.IsRegionHot(region) {
+			globalBalanceHotCounter.Inc()
+			continue
+		}
+		if region.GetApproximateSize() > remaining {
+			globalBalanceTooLargeCounter.Inc()
+			continue
+		}
+		// The placement rules are respected by the placement safeguard.
+		filters := []filter.Filter{
+			filter.NewExcludedFilter(s.GetName(), nil, region.GetStoreIDs()),
+			filter.NewPlacementSafeguard(s.GetName(), conf, cluster.GetBasicCluster(), cluster.GetRuleManager(),
+				region, source, replicaFilter.(*filter.RegionReplicatedFilter).GetFit()),
+		}
+		candidates := filter.NewCandidates(s.R, []*core.StoreInfo{target}).FilterTarget(conf, collector, s.filterCounter, append(s.filters, filters...)...)
+		if len(candidates.Stores) == 0 {
+			globalBalanceNoReplacementCounter.Inc()
+			continue
+		}
+		oldPeer := region.GetStorePeer(source.GetID())
+		newPeer := &metapb.Peer{StoreId: target.GetID(), Role: oldPeer.Role}
+		op, err := operator.CreateMovePeerOperator(s.GetName(), cluster, region, operator.OpRegion, oldPeer.GetStoreId(), newPeer)
+		if err != nil {
+			log.Debug("fail to create global balance operator", errs.ZapError(err))
+			globalBalanceCreateOpFailCounter.Inc()
+			return nil
+		}
+		sourceLabel := strconv.FormatUint(source.GetID(), 10)
+		targetLabel := strconv.FormatUint(target.GetID(), 10)
+		op.Counters = append(op.Counters, globalBalanceNewOpCounter)
+		op.FinishedCounters = append(op.FinishedCounters,
+			balanceDirectionCounter.WithLabelValues(s.GetName(), sourceLabel, targetLabel),
+		)
+		op.SetAdditionalInfo("plannedSize", strconv.FormatInt(move.Size, 10))
+		op.SetAdditionalInfo("crossZone", strconv.FormatBool(move.CrossZone))
+		return op
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestGlobalBalancePlan(t *testing.T) {
+	re := require.New(t)
+	crossZoneCost := func(string, string) float64 { return 10 }
+	// The new stores are filled by the stores in the same zone.
+	stores := []*globalBalanceStore{
+		{id: 1, zone: "z1", size: 1000, capacity: 100},
+		{id: 2, zone: "z1", size: 0, capacity: 100},
+		{id: 3, zone: "z2", size: 1000, capacity: 100},
+		{id: 4, zone: "z2", size: 0, capacity: 100},
+	}
+	p := computeGlobalBalancePlan(stores, crossZoneCost, 0.05, 96)
+	re.Len(p.Moves, 2)
+	for _, m := range p.Moves {
+		re.False(m.CrossZone)
+		re.Equal(int64(500), m.Size)
+		re.Equal(m.SourceStoreID+1, m.TargetStoreID)
+	}
+	re.Equal(float64(1000), p.TotalCost)
+	re.Zero(p.CrossZoneSize)
+	for _, store := range stores {
+		re.Equal(int64(500), p.Targets[store.id])
+	}
+
+	// Only the necessary data is moved across zones.
+	stores = []*globalBalanceStore{
+		{id: 1, zone: "z1", size: 1200, capacity: 100},
+		{id: 2, zone: "z1", size: 0, capacity: 100},
+		{id: 3, zone: "z2", size: 600, capacity: 100},
+		{id: 4, zone: "z2", size: 600, capacity: 100},
+	}
+	p = computeGlobalBalancePlan(stores, crossZoneCost, 0.05, 96)
+	re.Len(p.Moves, 1)
+	re.Equal(uint64(1), p.Moves[0].SourceStoreID)
+	re.Equal(uint64(2), p.Moves[0].TargetStoreID)
+	re.Zero(p.CrossZoneSize)
+	stores[1].zone = "z2"
+	p = computeGlobalBalancePlan(stores, crossZoneCost, 0.05, 96)
+	re.Len(p.Moves, 1)
+	re.True(p.Moves[0].CrossZone)
+	re.Equal(int64(600), p.CrossZoneSize)
+	re.Equal(float64(6000), p.TotalCost)
+
+	// The cheaper routes between zones are preferred.
+	stores = []*globalBalanceStore{
+		{id: 1, zone: "z1", size: 600, capacity: 100},
+		{id: 2, zone: "z2", size: 600, capacity: 100},
+		{id: 3, zone: "z3", size: 0, capacity: 100},
+		{id: 4, zone: "z4", size: 0, capacity: 100},
+	}
+	matrix := sc.NewZoneCostMatrix(&sc.ReplicationConfig{
+		LocationLabels: []string{"zone"},
+		ZoneCosts: sc.ZoneCosts{
+			{From: "z1", To: "z4", Cost: 5},
+			{From: "z2", To: "z3", Cost: 5},
+		},
+	})
+	p = computeGlobalBalancePlan(stores, func(from, to string) float64 { return 1 + matrix.GetZoneCost(from, to) }, 0.05, 96)
+	re.Len(p.Moves, 2)
+	for _, m := range p.Moves {
+		re.Equal(m.SourceStoreID+2, m.TargetStoreID)
+	}
+	re.Equal(float64(1200), p.TotalCost)
+	re.Equal(int64(600), p.CrossZoneSize)
+
+	// The target size is proportional to the capacity.
+	stores = []*globalBalanceStore{
+		{id: 1, size: 300, capacity: 200},
+		{id: 2, size: 600, capacity: 100},
+	}
+	p = computeGlobalBalancePlan(stores, crossZoneCost, 0.05, 96)
+	re.Equal(int64(600), p.Targets[1])
+	re.Equal(int64(300), p.Targets[2])
+	re.Len(p.Moves, 1)
+	re.Equal(uint64(2), p.Moves[0].SourceStoreID)
+	re.Equal(int64(300), p.Moves[0].Size)
+
+	// No move is planned if all stores are within the tolerance.
+	stores = []*globalBalanceStore{
+		{id: 1, size: 1000, capacity: 100},
+		{id: 2, size: 950, capacity: 100},
+	}
+	p = computeGlobalBalancePlan(stores, crossZoneCost, 0.05, 10)
+	re.Empty(p.Moves)
+	// The moves smaller than a region are dropped.
+	p = computeGlobalBalancePlan(stores, crossZoneCost, 0, 96)
+	re.Empty(p.Moves)
+}
+
+func TestGlobalBalanceScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(true)
+	defer cancel()
+	tc.SetLocationLabels([]string{"zone"})
+
+	// Stores 1, 2, 3 and 7 are in z1, stores 4, 5, 6 and 8 are in z2.
+	// Stores 7 and 8 are new stores without any region.
+	for _, id := range []uint64{1, 2, 3, 7} {
+		regionCount := 10
+		if id == 7 {
+			regionCount = 0
+		}
+		tc.AddLabelsStore(id, regionCount, map[string]string{"zone": "z1"})
+	}
+	for _, id := range []uint64{4, 5, 6, 8} {
+		regionCount := 10
+		if id == 8 {
+			regionCount = 0
+		}
+		tc.AddLabelsStore(id, regionCount, map[string]string{"zone": "z2"})
+	}
+	for i := range uint64(10) {
+		tc.AddLeaderRegion(i+1, 1, 2, 3)
+		tc.AddLeaderRegion(i+11, 4, 5, 6)
+	}
+
+	sb, err := CreateScheduler(types.GlobalBalanceScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.GlobalBalanceScheduler, nil))
+	re.NoError(err)
+	re.True(sb.IsScheduleAllowed(tc))
+	// The plan is not saved in a dry run.
+	ops, _ := sb.(DryRunScheduler).DryRun(tc)
+	re.Len(ops, globalBalanceBatchSize)
+	re.Nil(sb.(*globalBalanceScheduler).conf.getPlan())
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, globalBalanceBatchSize)
+	zones := map[uint64]string{1: "z1", 2: "z1", 3: "z1", 7: "z1", 4: "z2", 5: "z2", 6: "z2", 8: "z2"}
+	for _, op := range ops {
+		re.Equal(operator.OpRegion, op.Kind()&operator.OpRegion)
+		var source, target uint64
+		for i := range op.Len() {
+			switch step := op.Step(i).(type) {
+			case operator.AddLearner:
+				target = step.ToStore
+			case operator.RemovePeer:
+				source = step.FromStore
+			}
+		}
+		re.Equal(zones[source], zones[target])
+		re.Contains([]uint64{7, 8}, target)
+		re.Equal("false", op.GetAdditionalInfo("crossZone"))
+	}
+
+	// The progress of the plan is only recorded for the accepted operators.
+	p := sb.(*globalBalanceScheduler).conf.getPlan()
+	re.NotNil(p)
+	re.Len(p.Moves, 6)
+	re.Zero(p.CrossZoneSize)
+	for _, m := range p.Moves {
+		re.Zero(m.Scheduled)
+	}
+	// Some operators may be rejected by the store limit.
+	var accepted int64
+	for _, op := range ops {
+		if oc.AddOperator(op) {
+			accepted++
+		}
+	}
+	re.Positive(accepted)
+
+	// The plan is exposed by the handler.
+	req, _ := http.NewRequest(http.MethodGet, "/plan", http.NoBody)
+	resp := httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusOK, resp.Code)
+	respPlan := &globalBalancePlan{}
+	re.NoError(json.Unmarshal(resp.Body.Bytes(), respPlan))
+	re.Len(respPlan.Moves, 6)
+	var scheduled int64
+	for _, m := range respPlan.Moves {
+		scheduled += m.Scheduled
+	}
+	re.Equal(accepted*96, scheduled)
+
+	// Update the config.
+	req, _ = http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"batch": 11}`))
+	resp = httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusBadRequest, resp.Code)
+	req, _ = http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"cross-zone-cost": 20}`))
+	resp = httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	re.Equal(http.StatusOK, resp.Code)
+	conf := sb.(*globalBalanceScheduler).conf.clone()
+	re.Equal(globalBalanceBatchSize, conf.Batch)
+	re.Equal(float64(20), conf.CrossZoneCost)
+	// The plan is recomputed after the config is changed.
+	re.Nil(sb.(*globalBalanceScheduler).conf.getPlan())
+}
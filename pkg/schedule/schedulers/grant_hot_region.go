@@ -15,7 +15,12 @@
 package schedulers
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -32,7 +37,9 @@ import (
 	"github.com/tikv/pd/pkg/errs"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/slice"
@@ -42,6 +49,93 @@ import (
 	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
+// grantHotRegionPolicy pins the hot regions in the key ranges or with the region
+// label to the stores matching the label constraints.
+type grantHotRegionPolicy struct {
+	Name string `json:"name"`
+	// Ranges and RegionLabel select the regions of the policy, all regions are
+	// selected if both of them are empty.
+	Ranges      []core.KeyRange      `json:"ranges,omitempty"`
+	RegionLabel *labeler.RegionLabel `json:"region-label,omitempty"`
+	// LeaderConstraints selects the stores for the leaders of the hot regions.
+	LeaderConstraints []placement.LabelConstraint `json:"leader-constraints"`
+	// PeerConstraints selects the stores for the peers of the hot regions, the
+	// peers are not moved if it is empty.
+	PeerConstraints []placement.LabelConstraint `json:"peer-constraints,omitempty"`
+}
+
+func (p *grantHotRegionPolicy) validate() error {
+	if len(p.Name) == 0 {
+		return errs.ErrSchedulerConfig.FastGenByArgs("policy name")
+	}
+	for _, r := range p.Ranges {
+		if len(r.EndKey) > 0 && bytes.Compare(r.StartKey, r.EndKey) >= 0 {
+			return errs.ErrSchedulerConfig.FastGenByArgs(fmt.Sprintf("ranges of policy %s", p.Name))
+		}
+	}
+	if p.RegionLabel != nil && len(p.RegionLabel.Key) == 0 {
+		return errs.ErrSchedulerConfig.FastGenByArgs(fmt.Sprintf("region-label of policy %s", p.Name))
+	}
+	if len(p.LeaderConstraints) == 0 {
+		return errs.ErrSchedulerConfig.FastGenByArgs(fmt.Sprintf("leader-constraints of policy %s", p.Name))
+	}
+	for _, constraints := range [][]placement.LabelConstraint{p.LeaderConstraints, p.PeerConstraints} {
+		for _, c := range constraints {
+			if !slice.Contains([]placement.LabelConstraintOp{placement.In, placement.NotIn, placement.Exists, placement.NotExists}, c.Op) {
+				return errs.ErrSchedulerConfig.FastGenByArgs(fmt.Sprintf("label constraint op %s of policy %s", c.Op, p.Name))
+			}
+		}
+	}
+	return nil
+}
+
+func (p *grantHotRegionPolicy) matchRegion(regionLabeler *labeler.RegionLabeler, region *core.RegionInfo) bool {
+	if len(p.Ranges) > 0 && !slice.AnyOf(p.Ranges, func(i int) bool {
+		r := p.Ranges[i]
+		if bytes.Compare(region.GetStartKey(), r.StartKey) < 0 {
+			return false
+		}
+		return len(r.EndKey) == 0 || (len(region.GetEndKey()) > 0 && bytes.Compare(region.GetEndKey(), r.EndKey) <= 0)
+	}) {
+		return false
+	}
+	if p.RegionLabel != nil {
+		return regionLabeler != nil && regionLabeler.GetRegionLabel(region, p.RegionLabel.Key) == p.RegionLabel.Value
+	}
+	return true
+}
+
+// resolvedGrantHotRegionPolicy is a policy with the stores matching its constraints.
+type resolvedGrantHotRegionPolicy struct {
+	*grantHotRegionPolicy
+	leaderStoreIDs []uint64
+	peerStoreIDs   []uint64
+}
+
+// resolveGrantHotRegionPolicies resolves the stores of the policies with the current store labels.
+func resolveGrantHotRegionPolicies(cluster sche.SchedulerCluster, policies []*grantHotRegionPolicy) []*resolvedGrantHotRegionPolicy {
+	stores := cluster.GetStores()
+	resolved := make([]*resolvedGrantHotRegionPolicy, 0, len(policies))
+	for _, p := range policies {
+		rp := &resolvedGrantHotRegionPolicy{grantHotRegionPolicy: p}
+		for _, store := range stores {
+			if store.IsRemoving() || store.IsRemoved() {
+				continue
+			}
+			if placement.MatchLabelConstraints(store, p.LeaderConstraints) {
+				rp.leaderStoreIDs = append(rp.leaderStoreIDs, store.GetID())
+			}
+			// The leader stores are always the peer stores, just like the fixed stores.
+			if len(p.PeerConstraints) > 0 && (placement.MatchLabelConstraints(store, p.PeerConstraints) ||
+				slice.Contains(rp.leaderStoreIDs, store.GetID())) {
+				rp.peerStoreIDs = append(rp.peerStoreIDs, store.GetID())
+			}
+		}
+		resolved = append(resolved, rp)
+	}
+	return resolved
+}
+
 type grantHotRegionSchedulerConfig struct {
 	syncutil.RWMutex
 	schedulerConfig
@@ -49,6 +143,8 @@ type grantHotRegionSchedulerConfig struct {
 	cluster       *core.BasicCluster
 	StoreIDs      []uint64 `json:"store-id"`
 	StoreLeaderID uint64   `json:"store-leader-id"`
+	// Policies takes the place of the fixed stores if it is not empty.
+	Policies []*grantHotRegionPolicy `json:"policies,omitempty"`
 }
 
 func (conf *grantHotRegionSchedulerConfig) setStore(leaderID uint64, peers []uint64) {
@@ -81,7 +177,26 @@ func (conf *grantHotRegionSchedulerConfig) clone() *grantHotRegionSchedulerConfi
 	return &grantHotRegionSchedulerConfig{
 		StoreIDs:      newStoreIDs,
 		StoreLeaderID: conf.StoreLeaderID,
+		Policies:      conf.Policies,
+	}
+}
+
+func (conf *grantHotRegionSchedulerConfig) getPolicies() []*grantHotRegionPolicy {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.Policies
+}
+
+func (conf *grantHotRegionSchedulerConfig) setPolicies(policies []*grantHotRegionPolicy) error {
+	conf.Lock()
+	defer conf.Unlock()
+	old := conf.Policies
+	conf.Policies = policies
+	if err := conf.save(); err != nil {
+		conf.Policies = old
+		return err
 	}
+	return nil
 }
 
 func (conf *grantHotRegionSchedulerConfig) persist() error {
@@ -142,6 +257,7 @@ func (s *grantHotRegionScheduler) ReloadConfig() error {
 	}
 	s.conf.StoreIDs = newCfg.StoreIDs
 	s.conf.StoreLeaderID = newCfg.StoreLeaderID
+	s.conf.Policies = newCfg.Policies
 	return nil
 }
 
@@ -174,6 +290,10 @@ func (handler *grantHotRegionHandler) updateConfig(w http.ResponseWriter, r *htt
 	if err := apiutil.ReadJSONRespondError(handler.rd, w, r.Body, &input); err != nil {
 		return
 	}
+	if rawPolicies, ok := input["policies"]; ok {
+		handler.updatePolicies(w, rawPolicies)
+		return
+	}
 	ids, ok := input["store-id"].(string)
 	if !ok {
 		handler.rd.JSON(w, http.StatusBadRequest, errs.ErrSchedulerConfig)
@@ -203,6 +323,42 @@ func (handler *grantHotRegionHandler) updateConfig(w http.ResponseWriter, r *htt
 	handler.rd.JSON(w, http.StatusOK, nil)
 }
 
+// updatePolicies replaces all policies, the fixed stores are used again if the policies are empty.
+func (handler *grantHotRegionHandler) updatePolicies(w http.ResponseWriter, rawPolicies any) {
+	data, err := json.Marshal(rawPolicies)
+	if err != nil {
+		handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var policies []*grantHotRegionPolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	names := make(map[string]struct{}, len(policies))
+	for _, p := range policies {
+		if p == nil {
+			handler.rd.JSON(w, http.StatusBadRequest, errs.ErrSchedulerConfig.FastGenByArgs("policies").Error())
+			return
+		}
+		if err := p.validate(); err != nil {
+			handler.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, ok := names[p.Name]; ok {
+			handler.rd.JSON(w, http.StatusBadRequest, errs.ErrSchedulerConfig.FastGenByArgs(fmt.Sprintf("duplicated policy %s", p.Name)).Error())
+			return
+		}
+		names[p.Name] = struct{}{}
+	}
+	if err := handler.config.setPolicies(policies); err != nil {
+		handler.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	log.Info("grant-hot-region-scheduler policies are updated", zap.Int("policy-count", len(policies)))
+	handler.rd.JSON(w, http.StatusOK, "The policies are updated.")
+}
+
 func (handler *grantHotRegionHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
 	conf := handler.config.clone()
 	handler.rd.JSON(w, http.StatusOK, conf)
@@ -243,6 +399,9 @@ func (s *grantHotRegionScheduler) dispatch(typ resourceType, cluster sche.Schedu
 
 func (s *grantHotRegionScheduler) randomSchedule(cluster sche.SchedulerCluster, srcStores []*statistics.StoreLoadDetail) (ops []*operator.Operator) {
 	isLeader := s.r.Int()%2 == 1
+	if policies := s.conf.getPolicies(); len(policies) > 0 {
+		return s.scheduleByPolicies(cluster, srcStores, resolveGrantHotRegionPolicies(cluster, policies), isLeader)
+	}
 	for _, srcStore := range srcStores {
 		srcStoreID := srcStore.GetID()
 		if isLeader {
@@ -272,8 +431,93 @@ func (s *grantHotRegionScheduler) randomSchedule(cluster sche.SchedulerCluster,
 	return nil
 }
 
+// scheduleByPolicies moves the hot peers to the stores of the first policy matching the region.
+func (s *grantHotRegionScheduler) scheduleByPolicies(cluster sche.SchedulerCluster, srcStores []*statistics.StoreLoadDetail,
+	policies []*resolvedGrantHotRegionPolicy, isLeader bool) []*operator.Operator {
+	regionLabeler := cluster.GetRegionLabeler()
+	for _, srcStore := range srcStores {
+		srcStoreID := srcStore.GetID()
+		for _, peer := range srcStore.HotPeers {
+			if s.OpController.GetOperator(peer.RegionID) != nil {
+				continue
+			}
+			region := cluster.GetRegion(peer.RegionID)
+			if region == nil {
+				continue
+			}
+			var policy *resolvedGrantHotRegionPolicy
+			for _, p := range policies {
+				if p.matchRegion(regionLabeler, region) {
+					policy = p
+					break
+				}
+			}
+			if policy == nil {
+				continue
+			}
+			var candidates []uint64
+			if isLeader {
+				if region.GetLeader().GetStoreId() != srcStoreID || slice.Contains(policy.leaderStoreIDs, srcStoreID) {
+					continue
+				}
+				candidates = policy.leaderStoreIDs
+			} else {
+				if len(policy.peerStoreIDs) == 0 || slice.Contains(policy.peerStoreIDs, srcStoreID) {
+					continue
+				}
+				candidates = policy.peerStoreIDs
+			}
+			op, err := s.transferTo(cluster, region, srcStoreID, candidates, isLeader, true)
+			if err != nil {
+				log.Debug("fail to create grant hot region operator by policy", zap.String("policy", policy.Name),
+					zap.Uint64("region-id", peer.RegionID), zap.Uint64("src-store-id", srcStoreID), errs.ZapError(err))
+				continue
+			}
+			grantHotRegionPolicyCounter.Inc()
+			return []*operator.Operator{op}
+		}
+	}
+	grantHotRegionSkipCounter.Inc()
+	return nil
+}
+
+// selectGrantHotRegionTarget selects the target store of a policy deterministically. The leader prefers
+// the stores which already have a peer of the region to avoid moving data, then the store with the least
+// leaders. The peer prefers the store with the least regions. The store ID breaks the tie.
+func selectGrantHotRegionTarget(cluster sche.SchedulerCluster, region *core.RegionInfo, storeIDs []uint64, isLeader bool) uint64 {
+	return slices.MinFunc(storeIDs, func(a, b uint64) int {
+		storeA, storeB := cluster.GetStore(a), cluster.GetStore(b)
+		if isLeader {
+			hasA, hasB := region.GetStorePeer(a) != nil, region.GetStorePeer(b) != nil
+			if hasA != hasB {
+				if hasA {
+					return -1
+				}
+				return 1
+			}
+			if c := cmp.Compare(storeA.GetLeaderCount(), storeB.GetLeaderCount()); c != 0 {
+				return c
+			}
+		} else if c := cmp.Compare(storeA.GetRegionCount(), storeB.GetRegionCount()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+}
+
 func (s *grantHotRegionScheduler) transfer(cluster sche.SchedulerCluster, regionID uint64, srcStoreID uint64, isLeader bool) (op *operator.Operator, err error) {
 	srcRegion := cluster.GetRegion(regionID)
+	candidates := s.conf.getStoreIDs()
+	if isLeader {
+		candidates = []uint64{s.conf.getStoreLeaderID()}
+	}
+	return s.transferTo(cluster, srcRegion, srcStoreID, candidates, isLeader, false)
+}
+
+// transferTo moves the leader or the peer of the region on the source store to one of the candidate stores.
+// If allowMoveLeader is true, the leader is moved to the candidate store without a peer of the region.
+func (s *grantHotRegionScheduler) transferTo(cluster sche.SchedulerCluster, srcRegion *core.RegionInfo, srcStoreID uint64,
+	candidate []uint64, isLeader, allowMoveLeader bool) (op *operator.Operator, err error) {
 	if srcRegion == nil || len(srcRegion.GetDownPeers()) != 0 || len(srcRegion.GetPendingPeers()) != 0 {
 		return nil, errs.ErrRegionRuleNotFound
 	}
@@ -286,22 +530,23 @@ func (s *grantHotRegionScheduler) transfer(cluster sche.SchedulerCluster, region
 		filter.NewPlacementSafeguard(s.GetName(), cluster.GetSchedulerConfig(), cluster.GetBasicCluster(), cluster.GetRuleManager(), srcRegion, srcStore, nil),
 	}
 
-	storeIDs := s.conf.getStoreIDs()
-	destStoreIDs := make([]uint64, 0, len(storeIDs))
-	var candidate []uint64
+	destStoreIDs := make([]uint64, 0, len(candidate))
 	if isLeader {
 		filters = append(filters, &filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true, OperatorLevel: constant.High})
-		candidate = []uint64{s.conf.getStoreLeaderID()}
 	} else {
 		filters = append(filters, &filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.High},
 			filter.NewExcludedFilter(s.GetName(), srcRegion.GetStoreIDs(), srcRegion.GetStoreIDs()))
-		candidate = storeIDs
 	}
+	moveLeaderFilter := &filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.High}
 	for _, storeID := range candidate {
 		store := cluster.GetStore(storeID)
 		if !filter.Target(cluster.GetSchedulerConfig(), store, filters) {
 			continue
 		}
+		if isLeader && srcRegion.GetStorePeer(storeID) == nil &&
+			(!allowMoveLeader || !filter.Target(cluster.GetSchedulerConfig(), store, []filter.Filter{moveLeaderFilter})) {
+			continue
+		}
 		destStoreIDs = append(destStoreIDs, storeID)
 	}
 	if len(destStoreIDs) == 0 {
@@ -312,10 +557,17 @@ func (s *grantHotRegionScheduler) transfer(cluster sche.SchedulerCluster, region
 	if srcPeer == nil {
 		return nil, errs.ErrStoreNotFound
 	}
-	i := s.r.Int() % len(destStoreIDs)
-	dstStore := &metapb.Peer{StoreId: destStoreIDs[i]}
+	var dstStore *metapb.Peer
+	if allowMoveLeader {
+		dstStore = &metapb.Peer{StoreId: selectGrantHotRegionTarget(cluster, srcRegion, destStoreIDs, isLeader)}
+	} else {
+		i := s.r.Int() % len(destStoreIDs)
+		dstStore = &metapb.Peer{StoreId: destStoreIDs[i]}
+	}
 
-	if isLeader {
+	if isLeader && srcRegion.GetStorePeer(dstStore.StoreId) == nil {
+		op, err = operator.CreateMoveLeaderOperator(s.GetName()+"-move-leader", cluster, srcRegion, operator.OpRegion|operator.OpLeader, srcStore.GetID(), dstStore)
+	} else if isLeader {
 		op, err = operator.CreateTransferLeaderOperator(s.GetName()+"-leader", cluster, srcRegion, dstStore.StoreId, []uint64{}, operator.OpLeader)
 	} else {
 		op, err = operator.CreateMovePeerOperator(s.GetName()+"-move", cluster, srcRegion, operator.OpRegion|operator.OpLeader, srcStore.GetID(), dstStore)
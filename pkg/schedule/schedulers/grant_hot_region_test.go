@@ -0,0 +1,141 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestGrantHotRegionPolicies(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetHotRegionCacheHitsThreshold(0)
+	tc.AddLabelsStore(1, 2, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(2, 2, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(3, 2, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(4, 2, map[string]string{"zone": "z2", "role": "hot"})
+	tc.AddLabelsStore(5, 2, map[string]string{"zone": "z2"})
+	for id := uint64(1); id <= 5; id++ {
+		tc.UpdateStorageWrittenBytes(id, units.MiB*utils.StoreHeartBeatReportInterval)
+	}
+	addRegionInfo(tc, utils.Write, []testRegionInfo{
+		{1, []uint64{1, 2, 3}, 512 * units.KiB, 0, 0},
+		{2, []uint64{5, 1, 2}, 512 * units.KiB, 0, 0},
+	})
+	region1, region2 := tc.GetRegion(1), tc.GetRegion(2)
+	re.NoError(tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "app",
+		Labels:   []labeler.RegionLabel{{Key: "app", Value: "y"}},
+		RuleType: labeler.KeyRange,
+		Data: []any{map[string]any{
+			"start_key": hex.EncodeToString(region2.GetStartKey()),
+			"end_key":   hex.EncodeToString(region2.GetEndKey()),
+		}},
+	}))
+
+	sb, err := CreateScheduler(types.GrantHotRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.GrantHotRegionScheduler, []string{"1", "1,2,3"}))
+	re.NoError(err)
+	s := sb.(*grantHotRegionScheduler)
+	updateConfig := func(body string) int {
+		req, _ := http.NewRequest(http.MethodPost, "/config", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		sb.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	// Invalid policies are rejected.
+	re.Equal(http.StatusBadRequest, updateConfig(`{"policies": [{"name": "p", "leader-constraints": [{"key": "zone", "op": "eq", "values": ["z1"]}]}]}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"policies": [{"name": "p"}]}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"policies": [{"name": "p", "leader-constraints": [{"key": "zone", "op": "in", "values": ["z1"]}]},
+		{"name": "p", "leader-constraints": [{"key": "zone", "op": "in", "values": ["z1"]}]}]}`))
+	re.Empty(s.conf.getPolicies())
+
+	policies := fmt.Sprintf(`{"policies": [
+		{"name": "range", "ranges": [{"start-key": "%s", "end-key": "%s"}],
+			"leader-constraints": [{"key": "role", "op": "in", "values": ["hot"]}],
+			"peer-constraints": [{"key": "zone", "op": "in", "values": ["z2"]}]},
+		{"name": "label", "region-label": {"key": "app", "value": "y"},
+			"leader-constraints": [{"key": "zone", "op": "in", "values": ["z1"]}]}]}`,
+		hex.EncodeToString(region1.GetStartKey()), hex.EncodeToString(region1.GetEndKey()))
+	re.Equal(http.StatusOK, updateConfig(policies))
+	conf := s.conf.clone()
+	re.Len(conf.Policies, 2)
+	re.Equal("range", conf.Policies[0].Name)
+	re.Equal(region1.GetStartKey(), conf.Policies[0].Ranges[0].StartKey)
+
+	// The stores of the policies are resolved with the store labels.
+	resolved := resolveGrantHotRegionPolicies(tc, s.conf.getPolicies())
+	re.Equal([]uint64{4}, resolved[0].leaderStoreIDs)
+	re.ElementsMatch([]uint64{4, 5}, resolved[0].peerStoreIDs)
+	re.ElementsMatch([]uint64{1, 2, 3}, resolved[1].leaderStoreIDs)
+	re.Empty(resolved[1].peerStoreIDs)
+
+	schedule := func(typ resourceType, storeID uint64, isLeader bool) []*operator.Operator {
+		s.prepareForBalance(typ, tc)
+		detail := s.stLoadInfos[typ][storeID]
+		re.NotNil(detail)
+		return s.scheduleByPolicies(tc, []*statistics.StoreLoadDetail{detail}, resolved, isLeader)
+	}
+	// The leader of region 1 is moved to store 4 which has no peer of it.
+	ops := schedule(writeLeader, 1, true)
+	re.Len(ops, 1)
+	re.Equal(uint64(1), ops[0].RegionID())
+	re.Equal(operator.OpRegion, ops[0].Kind()&operator.OpRegion)
+	re.Equal(uint64(4), ops[0].Step(0).(operator.AddLearner).ToStore)
+	// The leader of region 2 is transferred to the store in z1 with a peer of it and the least leaders,
+	// store 3 has no peer of region 2.
+	tc.UpdateLeaderCount(1, 10)
+	ops = schedule(writeLeader, 5, true)
+	re.Len(ops, 1)
+	re.Equal(uint64(2), ops[0].RegionID())
+	step, ok := ops[0].Step(0).(operator.TransferLeader)
+	re.True(ok)
+	re.Equal(uint64(2), step.ToStore)
+	// The peer of region 1 is moved to the store in z2 with the least regions, region 2 has no peer constraints.
+	tc.UpdateRegionCount(4, 10)
+	ops = schedule(writePeer, 2, false)
+	re.Len(ops, 1)
+	re.Equal(uint64(1), ops[0].RegionID())
+	re.Equal(uint64(5), ops[0].Step(0).(operator.AddLearner).ToStore)
+	re.Empty(schedule(writePeer, 5, false))
+
+	// The fixed stores are used again after the policies are cleared.
+	re.Equal(http.StatusOK, updateConfig(`{"policies": []}`))
+	re.Empty(s.conf.getPolicies())
+	req, _ := http.NewRequest(http.MethodGet, "/list", http.NoBody)
+	resp := httptest.NewRecorder()
+	sb.ServeHTTP(resp, req)
+	listed := make(map[string]any)
+	re.NoError(json.Unmarshal(resp.Body.Bytes(), &listed))
+	re.NotContains(listed, "policies")
+	re.Equal(float64(1), listed["store-leader-id"])
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/errs"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/operator"
@@ -494,6 +495,9 @@ type balanceSolver struct {
 	maxPeerNum    int
 	minHotDegree  int
 
+	// zoneCost is used to prefer the leader transfers within a zone.
+	zoneCost *sc.ZoneCostMatrix
+
 	rank
 }
 
@@ -504,6 +508,7 @@ func (bs *balanceSolver) init() {
 	bs.minHotDegree = bs.GetSchedulerConfig().GetHotRegionCacheHitsThreshold()
 	bs.firstPriority, bs.secondPriority = prioritiesToDim(bs.getPriorities())
 	bs.greatDecRatio, bs.minorDecRatio = bs.sche.conf.getGreatDecRatio(), bs.sche.conf.getMinorDecRatio()
+	bs.zoneCost = bs.GetSharedConfig().GetZoneCostMatrix()
 	switch bs.sche.conf.getRankFormulaVersion() {
 	case "v1":
 		bs.rank = initRankV1(bs)
@@ -1243,6 +1248,27 @@ func (bs *balanceSolver) compareDstStore(detail1, detail2 *statistics.StoreLoadD
 	return 0
 }
 
+// compareZoneCost compares the network cost of the leader transfer of solution1 and solution2, the result is:
+// 1. if solution1 costs less than solution2, return -1
+// 2. if solution1 costs more than solution2, return 1
+// 3. otherwise, return 0
+// The leader transfer within a zone avoids the cross-zone traffic between the clients and the leader.
+func (bs *balanceSolver) compareZoneCost(solution1, solution2 *solution) int {
+	if bs.opTy != transferLeader || !bs.zoneCost.IsEnabled() {
+		return 0
+	}
+	cost1 := bs.zoneCost.GetCost(solution1.srcStore.StoreInfo, solution1.dstStore.StoreInfo)
+	cost2 := bs.zoneCost.GetCost(solution2.srcStore.StoreInfo, solution2.dstStore.StoreInfo)
+	switch {
+	case cost1 < cost2:
+		return -1
+	case cost1 > cost2:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // stepRank returns a function can calculate the discretized data,
 // where `rate` will be discretized by `step`.
 // `rate` is the speed of the dim, `step` is the step size of the discretized data.
@@ -145,6 +145,12 @@ func (r *rankV1) betterThan(old *solution) bool {
 		return false
 	}
 
+	if r := r.compareZoneCost(r.cur, old); r < 0 {
+		return true
+	} else if r > 0 {
+		return false
+	}
+
 	if r := r.compareDstStore(r.cur.dstStore, old.dstStore); r < 0 {
 		return true
 	} else if r > 0 {
@@ -240,6 +240,28 @@ func (r *rankV2) calcProgressiveRank() {
 }
 
 func (r *rankV2) getScoreByPriorities(dim int, rs *rankRatios) int {
+	srcRate, dstRate := r.cur.getExtremeLoad(dim)
+	srcPendingRate, dstPendingRate := r.cur.getPendingLoad(dim)
+	srcTopnRate, dstTopnRate := math.MaxFloat64, math.MaxFloat64
+	if topnHotPeer := r.nthHotPeer[r.cur.srcStore.GetID()][dim]; topnHotPeer != nil {
+		srcTopnRate = topnHotPeer.GetLoad(dim)
+	}
+	if topnHotPeer := r.nthHotPeer[r.cur.dstStore.GetID()][dim]; topnHotPeer != nil {
+		dstTopnRate = topnHotPeer.GetLoad(dim)
+	}
+	return rs.getScore(srcRate, dstRate, r.cur.getPeersRateFromCache(dim), srcTopnRate, dstTopnRate, r.getMinRate(dim),
+		func(reverse, pendingRateLimit bool) bool {
+			// avoid with pending influence when approaching the balanced state
+			return r.isTolerance(dim, reverse) && (!pendingRateLimit || math.Abs(srcPendingRate)+math.Abs(dstPendingRate) < 1 /*byte*/)
+		})
+}
+
+// getScore returns the score of moving the load peersRate from the store with srcRate to the store with dstRate.
+// srcTopnRate and dstTopnRate are the loads of the top n peers of the two stores, which limit the min better rate.
+// minRate is used as the 0 value, and allowBetter gives the extra restrictions of a positive score.
+// It is shared by the schedulers which balance some kind of load between two stores, such as hot regions and CPU.
+func (rs *rankRatios) getScore(srcRate, dstRate, peersRate, srcTopnRate, dstTopnRate, minRate float64,
+	allowBetter func(reverse, pendingRateLimit bool) bool) int {
 	// For unbalanced state,
 	// roughly speaking, as long as the diff is reduced, it is either better or not worse.
 	// To distinguish the small regions, the one where the diff is reduced too little is defined as not worse,
@@ -267,21 +289,12 @@ func (r *rankV2) getScoreByPriorities(dim int, rs *rankRatios) int {
 	// * f: maxBetterRate < peersRate <= maxNotWorsenedRate  ====> score == -1
 	// * g: peersRate > maxNotWorsenedRate                   ====> score == -2
 
-	srcRate, dstRate := r.cur.getExtremeLoad(dim)
-	srcPendingRate, dstPendingRate := r.cur.getPendingLoad(dim)
-	peersRate := r.cur.getPeersRateFromCache(dim)
-	highRate, lowRate := srcRate, dstRate
-	topnHotPeer := r.nthHotPeer[r.cur.srcStore.GetID()][dim]
+	highRate, lowRate, topnRate := srcRate, dstRate, srcTopnRate
 	reverse := false
 	if srcRate < dstRate {
-		highRate, lowRate = dstRate, srcRate
+		highRate, lowRate, topnRate = dstRate, srcRate, dstTopnRate
 		peersRate = -peersRate
 		reverse = true
-		topnHotPeer = r.nthHotPeer[r.cur.dstStore.GetID()][dim]
-	}
-	topnRate := math.MaxFloat64
-	if topnHotPeer != nil {
-		topnRate = topnHotPeer.GetLoad(dim)
 	}
 
 	if highRate*rs.currentChecker.balancedRatio <= lowRate {
@@ -308,8 +321,8 @@ func (r *rankV2) getScoreByPriorities(dim int, rs *rankRatios) int {
 		// (highRate-maxNotWorsenedRate) / (lowRate+maxNotWorsenedRate) = futureChecker.balancedRatio
 		maxNotWorsenedRate := (highRate - lowRate*rs.futureChecker.balancedRatio) / (1.0 + rs.futureChecker.balancedRatio)
 
-		if minNotWorsenedRate > -r.getMinRate(dim) { // use min rate as 0 value
-			minNotWorsenedRate = -r.getMinRate(dim)
+		if minNotWorsenedRate > -minRate { // use min rate as 0 value
+			minNotWorsenedRate = -minRate
 		}
 
 		if peersRate >= minNotWorsenedRate && peersRate <= maxNotWorsenedRate {
@@ -364,8 +377,8 @@ func (r *rankV2) getScoreByPriorities(dim int, rs *rankRatios) int {
 		minNotWorsenedRate = (highRate*rs.futureChecker.preBalancedRatio - lowRate) / (1.0 + rs.futureChecker.preBalancedRatio)
 		// (highRate-maxNotWorsenedRate) / (lowRate+maxNotWorsenedRate) = futureChecker.preBalancedRatio
 		maxNotWorsenedRate = (highRate - lowRate*rs.futureChecker.preBalancedRatio) / (1.0 + rs.futureChecker.preBalancedRatio)
-		if minNotWorsenedRate > -r.getMinRate(dim) { // use min rate as 0 value
-			minNotWorsenedRate = -r.getMinRate(dim)
+		if minNotWorsenedRate > -minRate { // use min rate as 0 value
+			minNotWorsenedRate = -minRate
 		}
 		// When approaching the balanced state, wait for pending influence to zero before scheduling to reduce jitter.
 		// From pre-balanced state to balanced state, we don't need other more schedule.
@@ -408,14 +421,13 @@ func (r *rankV2) getScoreByPriorities(dim int, rs *rankRatios) int {
 		maxBetterRate = maxBalancedRate + rs.perceivedRatio*(highRate-lowRate-maxBalancedRate-minBetterRate)
 
 		maxNotWorsenedRate = maxBalancedRate + rs.perceivedRatio*(highRate-lowRate-maxBalancedRate-minNotWorsenedRate)
-		minNotWorsenedRate = -r.getMinRate(dim) // use min rate as 0 value
+		minNotWorsenedRate = -minRate // use min rate as 0 value
 	}
 
 	switch {
 	case minBetterRate <= peersRate && peersRate <= maxBetterRate:
 		// Positive score requires some restrictions.
-		if peersRate >= r.getMinRate(dim) && r.isTolerance(dim, reverse) &&
-			(!pendingRateLimit || math.Abs(srcPendingRate)+math.Abs(dstPendingRate) < 1 /*byte*/) { // avoid with pending influence when approaching the balanced state
+		if peersRate >= minRate && allowBetter(reverse, pendingRateLimit) {
 			switch {
 			case peersRate < minBalancedRate:
 				return 2
@@ -455,6 +467,12 @@ func (r *rankV2) betterThan(old *solution) bool {
 		return false
 	}
 
+	if r := r.compareZoneCost(r.cur, old); r < 0 {
+		return true
+	} else if r > 0 {
+		return false
+	}
+
 	if r := r.compareDstStore(r.cur.dstStore, old.dstStore); r < 0 {
 		return true
 	} else if r > 0 {
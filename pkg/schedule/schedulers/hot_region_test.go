@@ -29,6 +29,7 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/types"
@@ -1369,6 +1370,44 @@ func TestHotReadRegionScheduleByteRateOnly(t *testing.T) {
 	clearPendingInfluence(hb)
 }
 
+func TestHotReadLeaderScheduleWithZoneCost(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	tc.SetLocationLabels([]string{"zone"})
+	tc.SetZoneCosts(sc.ZoneCosts{{From: "z1", To: "z2", Cost: 10}})
+	scheduler, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb := scheduler.(*hotScheduler)
+	hb.conf.ReadPriorities = []string{utils.BytePriority, utils.KeyPriority}
+	hb.conf.setHistorySampleDuration(0)
+
+	// Stores 1 and 3 are in z1, store 2 is in z2, and stores 2 and 3 have the same load.
+	tc.AddLabelsStore(1, 3, map[string]string{"zone": "z1"})
+	tc.AddLabelsStore(2, 3, map[string]string{"zone": "z2"})
+	tc.AddLabelsStore(3, 3, map[string]string{"zone": "z1"})
+	tc.UpdateStorageReadBytes(1, 7.5*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(2, 1*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(3, 1*units.MiB*utils.StoreHeartBeatReportInterval)
+	addRegionInfo(tc, utils.Read, []testRegionInfo{
+		{1, []uint64{1, 2, 3}, 512 * units.KiB, 0, 0},
+		{2, []uint64{1, 3, 2}, 512 * units.KiB, 0, 0},
+		{3, []uint64{1, 2, 3}, 512 * units.KiB, 0, 0},
+	})
+	testutil.Eventually(re, func() bool {
+		return tc.IsRegionHot(tc.GetRegion(1))
+	})
+
+	// The leader is always transferred within the zone.
+	for range 10 {
+		ops, _ := hb.Schedule(tc, false)
+		re.Len(ops, 1)
+		operatorutil.CheckTransferLeader(re, ops[0], operator.OpHotRegion, 1, 3)
+		clearPendingInfluence(hb)
+	}
+}
+
 func TestHotReadRegionScheduleWithQuery(t *testing.T) {
 	re := require.New(t)
 
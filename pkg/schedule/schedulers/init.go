@@ -15,17 +15,16 @@
 package schedulers
 
 import (
-	"net/url"
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
 var registerOnce sync.Once
@@ -496,6 +495,42 @@ func schedulersRegister() {
 		return sche, nil
 	})
 
+	// shuffle chaos
+	RegisterSliceDecoderBuilder(types.ShuffleChaosScheduler, func(args []string) ConfigDecoder {
+		return func(v any) error {
+			conf, ok := v.(*shuffleChaosSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			preset := shuffleChaosPresetLight
+			if len(args) == 1 {
+				preset = args[0]
+			}
+			if _, ok := shuffleChaosPresets[preset]; !ok {
+				return errs.ErrSchedulerConfig.FastGenByArgs("preset")
+			}
+			conf.applyPresetLocked(preset)
+			conf.Duration = typeutil.NewDuration(shuffleChaosDefaultDuration)
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.ShuffleChaosScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := &shuffleChaosSchedulerConfig{
+			schedulerConfig: &baseSchedulerConfig{},
+		}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		if len(conf.Kinds) == 0 {
+			conf.applyPresetLocked(shuffleChaosPresetLight)
+		}
+		sche := newShuffleChaosScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
+
 	// split bucket
 	RegisterSliceDecoderBuilder(types.SplitBucketScheduler, func([]string) ConfigDecoder {
 		return func(any) error {
@@ -556,55 +591,11 @@ func schedulersRegister() {
 			if !ok {
 				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
 			}
-			if len(args) < 5 {
-				return errs.ErrSchedulerConfig.FastGenByArgs("args length must be greater than 4")
-			}
-			roleString, err := url.QueryUnescape(args[0])
-			if err != nil {
-				return errs.ErrQueryUnescape.Wrap(err)
-			}
-			role := core.NewRole(roleString)
-			if role == core.Unknown {
-				return errs.ErrQueryUnescape.FastGenByArgs("role")
-			}
-			engine, err := url.QueryUnescape(args[1])
-			if err != nil {
-				return errs.ErrQueryUnescape.Wrap(err)
-			}
-			if engine != core.EngineTiFlash && engine != core.EngineTiKV {
-				return errs.ErrQueryUnescape.FastGenByArgs("engine must be tikv or tiflash ")
-			}
-			timeout, err := url.QueryUnescape(args[2])
-			if err != nil {
-				return errs.ErrQueryUnescape.Wrap(err)
-			}
-			duration, err := time.ParseDuration(timeout)
-			if err != nil {
-				return errs.ErrURLParse.Wrap(err)
-			}
-			alias, err := url.QueryUnescape(args[3])
-			if err != nil {
-				return errs.ErrURLParse.Wrap(err)
-			}
-			ranges, err := getKeyRanges(args[4:])
+			job, err := newBalanceRangeSchedulerJob(args)
 			if err != nil {
 				return err
 			}
-			id := uint64(0)
-			if len(conf.jobs) > 0 {
-				id = conf.jobs[len(conf.jobs)-1].JobID + 1
-			}
-
-			job := &balanceRangeSchedulerJob{
-				Role:    role,
-				Engine:  engine,
-				Timeout: duration,
-				Alias:   alias,
-				Ranges:  ranges,
-				Status:  pending,
-				JobID:   id,
-				Create:  time.Now(),
-			}
+			job.JobID = conf.nextJobID()
 			conf.jobs = append(conf.jobs, job)
 			return nil
 		}
@@ -622,4 +613,140 @@ func schedulersRegister() {
 		conf.init(sche.GetName(), storage, conf)
 		return sche, nil
 	})
+
+	// global balance
+	RegisterSliceDecoderBuilder(types.GlobalBalanceScheduler, func([]string) ConfigDecoder {
+		return func(v any) error {
+			conf, ok := v.(*globalBalanceSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			conf.Batch = globalBalanceBatchSize
+			conf.CrossZoneCost = globalBalanceCrossZoneCost
+			conf.ToleranceRatio = globalBalanceToleranceRatio
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.GlobalBalanceScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := &globalBalanceSchedulerConfig{
+			schedulerConfig: &baseSchedulerConfig{},
+		}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		if conf.Batch == 0 {
+			conf.Batch = globalBalanceBatchSize
+		}
+		if conf.CrossZoneCost == 0 {
+			conf.CrossZoneCost = globalBalanceCrossZoneCost
+		}
+		sche := newGlobalBalanceScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
+
+	// balance cpu
+	RegisterSliceDecoderBuilder(types.BalanceCPUScheduler, func([]string) ConfigDecoder {
+		return func(v any) error {
+			conf, ok := v.(*balanceCPUSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			conf.Batch = balanceCPUBatchSize
+			conf.BalancedRatio = balanceCPUBalancedRatio
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.BalanceCPUScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := &balanceCPUSchedulerConfig{
+			schedulerConfig: &baseSchedulerConfig{},
+		}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		if conf.Batch == 0 {
+			conf.Batch = balanceCPUBatchSize
+		}
+		if conf.BalancedRatio == 0 {
+			conf.BalancedRatio = balanceCPUBalancedRatio
+		}
+		sche := newBalanceCPUScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
+
+	// cold region merge
+	RegisterSliceDecoderBuilder(types.ColdRegionMergeScheduler, func([]string) ConfigDecoder {
+		return func(v any) error {
+			conf, ok := v.(*coldRegionMergeSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			conf.BudgetPerHour = coldRegionMergeBudgetPerHour
+			conf.MinRunLength = coldRegionMergeMinRunLength
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.ColdRegionMergeScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := &coldRegionMergeSchedulerConfig{
+			schedulerConfig: &baseSchedulerConfig{},
+		}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		if conf.BudgetPerHour == 0 {
+			conf.BudgetPerHour = coldRegionMergeBudgetPerHour
+		}
+		if conf.MinRunLength == 0 {
+			conf.MinRunLength = coldRegionMergeMinRunLength
+		}
+		sche := newColdRegionMergeScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
+
+	// external
+	RegisterSliceDecoderBuilder(types.ExternalScheduler, func(args []string) ConfigDecoder {
+		return func(v any) error {
+			if len(args) != 1 {
+				return errs.ErrSchedulerConfig.FastGenByArgs("address")
+			}
+			if !isValidExternalAddress(args[0]) {
+				return errs.ErrSchedulerConfig.FastGenByArgs("address")
+			}
+			conf, ok := v.(*externalSchedulerConfig)
+			if !ok {
+				return errs.ErrScheduleConfigNotExist.FastGenByArgs()
+			}
+			conf.Address = args[0]
+			conf.Timeout = typeutil.NewDuration(externalDefaultTimeout)
+			conf.Batch = externalDefaultBatch
+			return nil
+		}
+	})
+
+	RegisterScheduler(types.ExternalScheduler, func(opController *operator.Controller,
+		storage endpoint.ConfigStorage, decoder ConfigDecoder, _ ...func(string) error) (Scheduler, error) {
+		conf := &externalSchedulerConfig{
+			schedulerConfig: &baseSchedulerConfig{},
+		}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		if conf.Timeout.Duration == 0 {
+			conf.Timeout = typeutil.NewDuration(externalDefaultTimeout)
+		}
+		if conf.Batch == 0 {
+			conf.Batch = externalDefaultBatch
+		}
+		sche := newExternalScheduler(opController, conf)
+		conf.init(sche.GetName(), storage, conf)
+		return sche, nil
+	})
 }
@@ -226,6 +226,26 @@ func balanceRangeCounterWithEvent(event string) prometheus.Counter {
 	return schedulerCounter.WithLabelValues(types.BalanceRangeScheduler.String(), event)
 }
 
+func globalBalanceCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.GlobalBalanceScheduler.String(), event)
+}
+
+func balanceCPUCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.BalanceCPUScheduler.String(), event)
+}
+
+func coldRegionMergeCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.ColdRegionMergeScheduler.String(), event)
+}
+
+func externalCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.ExternalScheduler.String(), event)
+}
+
+func shuffleChaosCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.ShuffleChaosScheduler.String(), event)
+}
+
 // WithLabelValues is a heavy operation, define variable to avoid call it every time.
 var (
 	balanceLeaderScheduleCounter         = balanceLeaderCounterWithEvent("schedule")
@@ -246,16 +266,18 @@ var (
 	balanceRegionCreateOpFailCounter  = balanceRegionCounterWithEvent("create-operator-fail")
 	balanceRegionNoReplacementCounter = balanceRegionCounterWithEvent("no-replacement")
 
-	evictLeaderCounter              = evictLeaderCounterWithEvent("schedule")
-	evictLeaderNoLeaderCounter      = evictLeaderCounterWithEvent("no-leader")
-	evictLeaderPickUnhealthyCounter = evictLeaderCounterWithEvent("pick-unhealthy-region")
-	evictLeaderNoTargetStoreCounter = evictLeaderCounterWithEvent("no-target-store")
-	evictLeaderNewOperatorCounter   = evictLeaderCounterWithEvent("new-operator")
+	evictLeaderCounter               = evictLeaderCounterWithEvent("schedule")
+	evictLeaderNoLeaderCounter       = evictLeaderCounterWithEvent("no-leader")
+	evictLeaderPickUnhealthyCounter  = evictLeaderCounterWithEvent("pick-unhealthy-region")
+	evictLeaderNoTargetStoreCounter  = evictLeaderCounterWithEvent("no-target-store")
+	evictLeaderNewOperatorCounter    = evictLeaderCounterWithEvent("new-operator")
+	evictLeaderDrainThrottledCounter = evictLeaderCounterWithEvent("drain-throttled")
 
 	evictSlowStoreCounter = schedulerCounter.WithLabelValues(types.EvictSlowStoreScheduler.String(), "schedule")
 
-	grantHotRegionCounter     = grantHotRegionCounterWithEvent("schedule")
-	grantHotRegionSkipCounter = grantHotRegionCounterWithEvent("skip")
+	grantHotRegionCounter       = grantHotRegionCounterWithEvent("schedule")
+	grantHotRegionSkipCounter   = grantHotRegionCounterWithEvent("skip")
+	grantHotRegionPolicyCounter = grantHotRegionCounterWithEvent("policy")
 
 	grantLeaderCounter            = grantLeaderCounterWithEvent("schedule")
 	grantLeaderNoFollowerCounter  = grantLeaderCounterWithEvent("no-follower")
@@ -332,6 +354,13 @@ var (
 	shuffleRegionCreateOperatorFailCounter = shuffleRegionCounterWithEvent("create-operator-fail")
 	shuffleRegionNoSourceStoreCounter      = shuffleRegionCounterWithEvent("no-source-store")
 
+	shuffleChaosCounter             = shuffleChaosCounterWithEvent("schedule")
+	shuffleChaosNewOperatorCounter  = shuffleChaosCounterWithEvent("new-operator")
+	shuffleChaosNoOperatorCounter   = shuffleChaosCounterWithEvent("no-operator")
+	shuffleChaosCreateOpFailCounter = shuffleChaosCounterWithEvent("create-operator-fail")
+	shuffleChaosStoppedCounter      = shuffleChaosCounterWithEvent("stopped")
+	shuffleChaosRateLimitedCounter  = shuffleChaosCounterWithEvent("rate-limited")
+
 	splitBucketDisableCounter            = splitBucketCounterWithEvent("bucket-disable")
 	splitBucketSplitLimitCounter         = splitBucketCounterWithEvent("split-limit")
 	splitBucketScheduleCounter           = splitBucketCounterWithEvent("schedule")
@@ -342,18 +371,61 @@ var (
 	splitBucketNoSplitKeysCounter        = splitBucketCounterWithEvent("no-split-keys")
 	splitBucketCreateOperatorFailCounter = splitBucketCounterWithEvent("create-operator-fail")
 	splitBucketNewOperatorCounter        = splitBucketCounterWithEvent("new-operator")
+	splitBucketMergeCooldownCounter      = splitBucketCounterWithEvent("merge-cooldown")
+	splitBucketReadHotCounter            = splitBucketCounterWithEvent("read-hot")
 
 	transferWitnessLeaderCounter              = transferWitnessLeaderCounterWithEvent("schedule")
 	transferWitnessLeaderNewOperatorCounter   = transferWitnessLeaderCounterWithEvent("new-operator")
 	transferWitnessLeaderNoTargetStoreCounter = transferWitnessLeaderCounterWithEvent("no-target-store")
 
-	balanceRangeCounter              = balanceRangeCounterWithEvent("schedule")
-	balanceRangeNewOperatorCounter   = balanceRangeCounterWithEvent("new-operator")
-	balanceRangeExpiredCounter       = balanceRangeCounterWithEvent("expired")
-	balanceRangeNoRegionCounter      = balanceRangeCounterWithEvent("no-region")
-	balanceRangeHotCounter           = balanceRangeCounterWithEvent("region-hot")
-	balanceRangeNoLeaderCounter      = balanceRangeCounterWithEvent("no-leader")
-	balanceRangeCreateOpFailCounter  = balanceRangeCounterWithEvent("create-operator-fail")
-	balanceRangeNoReplacementCounter = balanceRangeCounterWithEvent("no-replacement")
-	balanceRangeNoJobCounter         = balanceRangeCounterWithEvent("no-job")
+	balanceRangeCounter                 = balanceRangeCounterWithEvent("schedule")
+	balanceRangeNewOperatorCounter      = balanceRangeCounterWithEvent("new-operator")
+	balanceRangeExpiredCounter          = balanceRangeCounterWithEvent("expired")
+	balanceRangeNoRegionCounter         = balanceRangeCounterWithEvent("no-region")
+	balanceRangeHotCounter              = balanceRangeCounterWithEvent("region-hot")
+	balanceRangeNoLeaderCounter         = balanceRangeCounterWithEvent("no-leader")
+	balanceRangeCreateOpFailCounter     = balanceRangeCounterWithEvent("create-operator-fail")
+	balanceRangeNoReplacementCounter    = balanceRangeCounterWithEvent("no-replacement")
+	balanceRangeNoJobCounter            = balanceRangeCounterWithEvent("no-job")
+	balanceRangeFinishedOperatorCounter = balanceRangeCounterWithEvent("finished-operator")
+
+	globalBalanceScheduleCounter      = globalBalanceCounterWithEvent("schedule")
+	globalBalanceNewPlanCounter       = globalBalanceCounterWithEvent("new-plan")
+	globalBalanceNoOperatorCounter    = globalBalanceCounterWithEvent("no-operator")
+	globalBalanceNoRegionCounter      = globalBalanceCounterWithEvent("no-region")
+	globalBalanceHotCounter           = globalBalanceCounterWithEvent("region-hot")
+	globalBalanceTooLargeCounter      = globalBalanceCounterWithEvent("region-too-large")
+	globalBalanceNoReplacementCounter = globalBalanceCounterWithEvent("no-replacement")
+	globalBalanceCreateOpFailCounter  = globalBalanceCounterWithEvent("create-operator-fail")
+	globalBalanceNewOpCounter         = globalBalanceCounterWithEvent("new-operator")
+
+	balanceCPUScheduleCounter     = balanceCPUCounterWithEvent("schedule")
+	balanceCPUNoOperatorCounter   = balanceCPUCounterWithEvent("no-operator")
+	balanceCPUUnhealthyCounter    = balanceCPUCounterWithEvent("unhealthy-replica")
+	balanceCPUNoTargetCounter     = balanceCPUCounterWithEvent("no-target-store")
+	balanceCPUCreateOpFailCounter = balanceCPUCounterWithEvent("create-operator-fail")
+
+	coldRegionMergeScheduleCounter      = coldRegionMergeCounterWithEvent("schedule")
+	coldRegionMergeRecentlyStartCounter = coldRegionMergeCounterWithEvent("recently-start")
+	coldRegionMergeNewPlanCounter       = coldRegionMergeCounterWithEvent("new-plan")
+	coldRegionMergeNoBudgetCounter      = coldRegionMergeCounterWithEvent("no-budget")
+	coldRegionMergeNoOperatorCounter    = coldRegionMergeCounterWithEvent("no-operator")
+	coldRegionMergeNotAllowedCounter    = coldRegionMergeCounterWithEvent("not-allowed")
+	coldRegionMergeCreateOpFailCounter  = coldRegionMergeCounterWithEvent("create-operator-fail")
+	coldRegionMergeNewOpCounter         = coldRegionMergeCounterWithEvent("new-operator")
+
+	externalScheduleCounter         = externalCounterWithEvent("schedule")
+	externalConnectFailCounter      = externalCounterWithEvent("connect-fail")
+	externalRPCFailCounter          = externalCounterWithEvent("rpc-fail")
+	externalTimeoutCounter          = externalCounterWithEvent("timeout")
+	externalExpiredProposalCounter  = externalCounterWithEvent("expired-proposal")
+	externalNoOperatorCounter       = externalCounterWithEvent("no-operator")
+	externalInvalidProposalCounter  = externalCounterWithEvent("invalid-proposal")
+	externalStaleProposalCounter    = externalCounterWithEvent("stale-proposal")
+	externalFilteredProposalCounter = externalCounterWithEvent("filtered-proposal")
+	externalCreateOpFailCounter     = externalCounterWithEvent("create-operator-fail")
+	externalRejectedCounter         = externalCounterWithEvent("rejected-by-controller")
+	externalNewOpCounter            = externalCounterWithEvent("new-operator")
+	externalSnapshotCounter         = externalCounterWithEvent("snapshot")
+	externalTooManyUpdatesCounter   = externalCounterWithEvent("too-many-updates")
 )
@@ -52,6 +52,7 @@ func (r *rangeCluster) updateStoreInfo(s *core.StoreInfo) *core.StoreInfo {
 	amplification := float64(s.GetRegionSize()) / used
 	leaderCount := r.subCluster.GetStoreLeaderCount(id)
 	leaderSize := r.subCluster.GetStoreLeaderRegionSize(id)
+	leaderWriteQueryRate := r.subCluster.GetStoreLeaderWriteQueryRate(id)
 	regionCount := r.subCluster.GetStoreRegionCount(id)
 	regionSize := r.subCluster.GetStoreRegionSize(id)
 	pendingPeerCount := r.subCluster.GetStorePendingPeerCount(id)
@@ -65,6 +66,7 @@ func (r *rangeCluster) updateStoreInfo(s *core.StoreInfo) *core.StoreInfo {
 		core.SetPendingPeerCount(pendingPeerCount),
 		core.SetLeaderSize(leaderSize),
 		core.SetRegionSize(regionSize),
+		core.SetLeaderWriteQueryRate(leaderWriteQueryRate),
 	)
 	return newStore
 }
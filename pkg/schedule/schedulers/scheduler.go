@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule/config"
 	sche "github.com/tikv/pd/pkg/schedule/core"
@@ -61,6 +62,28 @@ type Scheduler interface {
 	IsDefault() bool
 }
 
+// DryRunScheduler is implemented by the schedulers whose Schedule changes their own
+// states, such as the progress of their jobs. DryRun returns the operators and plans
+// as Schedule does with the plans collected, but never changes any state, so that the
+// scheduling can be previewed.
+type DryRunScheduler interface {
+	DryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan)
+}
+
+// RegionObserver is implemented by the schedulers which keep the states updated by
+// the region heartbeats, such as the leaders with the highest CPU usage.
+type RegionObserver interface {
+	ObserveRegion(region *core.RegionInfo)
+}
+
+// dryRunSchedule runs the scheduler once without changing its states.
+func dryRunSchedule(s Scheduler, cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	if d, ok := s.(DryRunScheduler); ok {
+		return d.DryRun(cluster)
+	}
+	return s.Schedule(cluster, true)
+}
+
 // EncodeConfig encode the custom config for each scheduler.
 func EncodeConfig(v any) ([]byte, error) {
 	marshaled, err := json.Marshal(v)
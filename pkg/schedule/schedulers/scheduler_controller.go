@@ -15,9 +15,11 @@
 package schedulers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,7 +30,9 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
@@ -260,6 +264,7 @@ func (c *Controller) RemoveScheduler(name string) error {
 
 	s.Stop()
 	schedulerStatusGauge.DeleteLabelValues(name, "allow")
+	c.opController.RemoveConsumer(name)
 	delete(c.schedulers, name)
 	return nil
 }
@@ -371,10 +376,14 @@ func (c *Controller) runScheduler(s *ScheduleController) {
 		select {
 		case <-ticker.C:
 			diagnosable := s.IsDiagnosticAllowed()
+			s.scheduleMu.Lock()
 			if !s.AllowSchedule(diagnosable) {
+				s.scheduleMu.Unlock()
 				continue
 			}
-			if op := s.Schedule(diagnosable); len(op) > 0 {
+			op := s.Schedule(diagnosable)
+			s.scheduleMu.Unlock()
+			if len(op) > 0 {
 				added := c.opController.AddWaitingOperator(op...)
 				log.Debug("add operator", zap.Int("added", added), zap.Int("total", len(op)), zap.String("scheduler", s.Scheduler.GetName()))
 			}
@@ -417,6 +426,75 @@ func (c *Controller) GetPausedSchedulerDelayUntil(name string) (int64, error) {
 	return s.GetDelayUntil(), nil
 }
 
+// DryRun runs the specified scheduler, or all schedulers if the name is "all", on the
+// current cluster and returns the operators and plans they would produce. The operators
+// are checked by the operator controller but never added, so nothing is sent to TiKV.
+func (c *Controller) DryRun(name string) ([]*DryRunResult, error) {
+	c.RLock()
+	if c.cluster == nil {
+		c.RUnlock()
+		return nil, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	var scs []*ScheduleController
+	if name != "all" {
+		sc, ok := c.schedulers[name]
+		if !ok {
+			c.RUnlock()
+			return nil, errs.ErrSchedulerNotFound.FastGenByArgs()
+		}
+		scs = append(scs, sc)
+	} else {
+		for _, sc := range c.schedulers {
+			scs = append(scs, sc)
+		}
+	}
+	c.RUnlock()
+	sort.Slice(scs, func(i, j int) bool {
+		return scs[i].Scheduler.GetName() < scs[j].Scheduler.GetName()
+	})
+
+	results := make([]*DryRunResult, 0, len(scs))
+	allOps := make([]*operator.Operator, 0)
+	opCounts := make([]int, 0, len(scs))
+	for _, s := range scs {
+		result, ops := s.DryRun()
+		results = append(results, result)
+		allOps = append(allOps, ops...)
+		opCounts = append(opCounts, len(ops))
+	}
+	// Check all operators together, so that the operators of different schedulers
+	// compete with each other as they do in the real scheduling.
+	opResults := c.opController.DryRunOperators(allOps...)
+	for i, result := range results {
+		result.Operators, opResults = opResults[:opCounts[i]], opResults[opCounts[i]:]
+	}
+	return results, nil
+}
+
+// Trace runs the scheduler once on the current cluster and returns why the region
+// is scheduled or not by it, including the results of all its filters on the stores
+// the region may be moved from and to.
+func (c *Controller) Trace(name string, regionID uint64) (*TraceResult, error) {
+	c.RLock()
+	if c.cluster == nil {
+		c.RUnlock()
+		return nil, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	sc, ok := c.schedulers[name]
+	cluster := c.cluster
+	c.RUnlock()
+	if !ok {
+		return nil, errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	region := cluster.GetRegion(regionID)
+	if region == nil {
+		return nil, errs.ErrRegionNotFound.FastGenByArgs(regionID)
+	}
+	result, ops := sc.Trace(region)
+	result.Operators = c.opController.DryRunOperators(ops...)
+	return result, nil
+}
+
 // CheckTransferWitnessLeader determines if transfer leader is required, then sends to the scheduler if needed
 func (c *Controller) CheckTransferWitnessLeader(region *core.RegionInfo) {
 	if core.NeedTransferWitnessLeader(region) {
@@ -433,6 +511,18 @@ func (c *Controller) CheckTransferWitnessLeader(region *core.RegionInfo) {
 	}
 }
 
+// ObserveRegion passes the region reported by the heartbeat to the schedulers which
+// keep the states updated by the heartbeats.
+func (c *Controller) ObserveRegion(region *core.RegionInfo) {
+	c.RLock()
+	defer c.RUnlock()
+	for _, s := range c.schedulers {
+		if o, ok := s.Scheduler.(RegionObserver); ok {
+			o.ObserveRegion(region)
+		}
+	}
+}
+
 // GetAllSchedulerConfigs returns all scheduler configs.
 func (c *Controller) GetAllSchedulerConfigs() (sches, configs []string, err error) {
 	return c.storage.LoadAllSchedulerConfigs()
@@ -449,6 +539,13 @@ type ScheduleController struct {
 	delayAt            int64
 	delayUntil         int64
 	diagnosticRecorder *DiagnosticRecorder
+	// scheduleMu makes the dry runs and the traces of the scheduler run in turn
+	// with its scheduling, because the scheduler is not safe to run concurrently.
+	scheduleMu syncutil.Mutex
+	// pending records if the scheduler is not allowed to schedule in the last
+	// check, so the dry runs can report it without checking it again, which may
+	// change the states of the scheduler.
+	pending atomic.Bool
 }
 
 // NewScheduleController creates a new ScheduleController.
@@ -520,16 +617,151 @@ retry:
 		if len(ops) == 0 {
 			continue
 		}
+		s.setConsumer(ops)
 		return ops
 	}
 	s.nextInterval = s.Scheduler.GetNextInterval(s.nextInterval)
 	return nil
 }
 
+// setConsumer marks the operators as created by the scheduler, so they are
+// counted in its budget of the operator slots.
+func (s *ScheduleController) setConsumer(ops []*operator.Operator) {
+	for _, op := range ops {
+		op.SetConsumer(s.Scheduler.GetName())
+	}
+}
+
 // DiagnoseDryRun returns the operators and plans of a scheduler.
 func (s *ScheduleController) DiagnoseDryRun() ([]*operator.Operator, []plan.Plan) {
-	cacheCluster := newCacheCluster(s.cluster)
-	return s.Scheduler.Schedule(cacheCluster, true)
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	return s.diagnoseDryRun(newCacheCluster(s.cluster))
+}
+
+func (s *ScheduleController) diagnoseDryRun(cluster sche.SchedulerCluster) ([]*operator.Operator, []plan.Plan) {
+	ops, plans := dryRunSchedule(s.Scheduler, cluster)
+	s.setConsumer(ops)
+	return ops, plans
+}
+
+// DryRun runs the scheduler once without any limitation of its status, and returns
+// the result with the status it has in the real scheduling. The operators are
+// returned separately and should be checked by the operator controller.
+func (s *ScheduleController) DryRun() (*DryRunResult, []*operator.Operator) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	return s.dryRun(newCacheCluster(s.cluster))
+}
+
+// dryRun runs the scheduler on the given cluster.
+func (s *ScheduleController) dryRun(cluster sche.SchedulerCluster) (*DryRunResult, []*operator.Operator) {
+	result := &DryRunResult{
+		Name:   s.Scheduler.GetName(),
+		Status: Normal,
+	}
+	switch {
+	case s.IsPaused():
+		result.Status = Paused
+	case s.cluster.IsSchedulingHalted():
+		result.Status = Halted
+	case s.pending.Load():
+		result.Status = Pending
+	}
+	ops, plans := s.diagnoseDryRun(cluster)
+	if len(ops) > 0 && result.Status == Normal {
+		result.Status = Scheduling
+	}
+	result.Plans = make([]*DryRunPlan, 0, len(plans))
+	for _, p := range plans {
+		result.Plans = append(result.Plans, newDryRunPlan(p))
+	}
+	return result, ops
+}
+
+// DryRunResult is the result of a scheduler in dry-run mode.
+type DryRunResult struct {
+	Name string `json:"name"`
+	// Status is the status the scheduler has in the real scheduling, such as paused.
+	Status    string                   `json:"status"`
+	Operators []*operator.DryRunResult `json:"operators"`
+	Plans     []*DryRunPlan            `json:"plans,omitempty"`
+}
+
+// DryRunPlan is the output form of a plan in dry-run mode.
+type DryRunPlan struct {
+	Step   int    `json:"step"`
+	Status string `json:"status"`
+	// Resources are the IDs of the resources picked at each step, such as
+	// the source store, region and target store of a balance plan.
+	Resources []uint64 `json:"resources"`
+}
+
+// Trace runs the scheduler once in dry-run mode with the region picked first if
+// the scheduler picks the regions randomly, and returns why the region is scheduled
+// or not. The operators of the region are returned separately and should be checked
+// by the operator controller.
+//
+// The trace runs on the running instance of the scheduler, so its states such as
+// the running jobs and the pending proposals are traced as well.
+func (s *ScheduleController) Trace(region *core.RegionInfo) (*TraceResult, []*operator.Operator) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	trace := filter.NewTrace(region)
+	dryRun, ops := s.dryRun(newTraceCluster(newCacheCluster(s.cluster), region, trace))
+	result := &TraceResult{
+		Name:     dryRun.Name,
+		RegionID: region.GetID(),
+		Status:   dryRun.Status,
+		Filters:  trace.GetRecords(),
+	}
+	stores := region.GetStoreIDs()
+	for _, p := range dryRun.Plans {
+		// The plans of the source step are about the stores of the region, and
+		// the plans of the following steps are about the region itself.
+		if p.Step == 0 {
+			if _, ok := stores[p.Resources[0]]; !ok {
+				continue
+			}
+		} else if p.Resources[1] != region.GetID() {
+			continue
+		}
+		result.Plans = append(result.Plans, p)
+	}
+	regionOps := make([]*operator.Operator, 0, len(ops))
+	for _, op := range ops {
+		if op.RegionID() == region.GetID() {
+			regionOps = append(regionOps, op)
+		}
+	}
+	return result, regionOps
+}
+
+// TraceResult is the decision of a scheduler on a region in dry-run mode.
+type TraceResult struct {
+	Name     string `json:"name"`
+	RegionID uint64 `json:"region-id"`
+	// Status is the status the scheduler has in the real scheduling, such as paused.
+	Status string `json:"status"`
+	// Operators are the operators of the region the scheduler would create.
+	Operators []*operator.DryRunResult `json:"operators"`
+	// Plans are the plans which pick the region, or the stores of the region as the source.
+	Plans []*DryRunPlan `json:"plans,omitempty"`
+	// Filters are the results of every filter on the stores the region may be moved from and to.
+	Filters []*filter.TraceRecord `json:"filters,omitempty"`
+}
+
+func newDryRunPlan(p plan.Plan) *DryRunPlan {
+	step := p.GetStep()
+	resources := make([]uint64, 0, step+1)
+	for i := 0; i <= step; i++ {
+		resources = append(resources, p.GetResource(i))
+	}
+	return &DryRunPlan{
+		Step:      step,
+		Status:    p.GetStatus().String(),
+		Resources: resources,
+	}
 }
 
 // GetInterval returns the interval of scheduling for a scheduler.
@@ -544,7 +776,11 @@ func (s *ScheduleController) SetInterval(interval time.Duration) {
 
 // AllowSchedule returns if a scheduler is allowed to
 func (s *ScheduleController) AllowSchedule(diagnosable bool) bool {
-	if !s.Scheduler.IsScheduleAllowed(s.cluster) {
+	allowed := s.Scheduler.IsScheduleAllowed(s.cluster)
+	s.pending.Store(!allowed)
+	if !allowed {
+		// The scheduler may be blocked by the schedule limits, so it asks for its share.
+		s.opController.RecordConsumerDemand(s.Scheduler.GetName(), 0)
 		if diagnosable {
 			s.diagnosticRecorder.SetResultFromStatus(Pending)
 		}
@@ -565,25 +801,52 @@ func (s *ScheduleController) AllowSchedule(diagnosable bool) bool {
 	return true
 }
 
-// IsPaused returns if a scheduler is paused.
+// IsPaused returns if a scheduler is paused, either by the user or by being
+// out of its time windows.
 func (s *ScheduleController) IsPaused() bool {
+	return s.isPausedByUser() || s.IsOutOfTimeWindows()
+}
+
+func (s *ScheduleController) isPausedByUser() bool {
 	delayUntil := atomic.LoadInt64(&s.delayUntil)
 	return time.Now().Unix() < delayUntil
 }
 
-// GetDelayAt returns paused timestamp of a paused scheduler
+// IsOutOfTimeWindows returns if a scheduler is out of its time windows.
+// It is always false if the scheduler has no time window.
+func (s *ScheduleController) IsOutOfTimeWindows() bool {
+	return !s.getTimeWindows().IsActive(time.Now())
+}
+
+func (s *ScheduleController) getTimeWindows() sc.TimeWindows {
+	return s.cluster.GetSchedulerConfig().GetScheduleConfig().SchedulerTimeWindows[s.Scheduler.GetName()]
+}
+
+// GetDelayAt returns paused timestamp of a paused scheduler. If it is paused
+// by its time windows, the end of the last time window is returned.
 func (s *ScheduleController) GetDelayAt() int64 {
-	if s.IsPaused() {
+	if s.isPausedByUser() {
 		return atomic.LoadInt64(&s.delayAt)
 	}
+	if s.IsOutOfTimeWindows() {
+		if end := s.getTimeWindows().LastEnd(time.Now()); !end.IsZero() {
+			return end.Unix()
+		}
+	}
 	return 0
 }
 
-// GetDelayUntil returns resume timestamp of a paused scheduler
+// GetDelayUntil returns resume timestamp of a paused scheduler. If it is paused
+// by its time windows, the start of the next time window is returned.
 func (s *ScheduleController) GetDelayUntil() int64 {
-	if s.IsPaused() {
+	if s.isPausedByUser() {
 		return atomic.LoadInt64(&s.delayUntil)
 	}
+	if s.IsOutOfTimeWindows() {
+		if start := s.getTimeWindows().NextStart(time.Now()); !start.IsZero() {
+			return start.Unix()
+		}
+	}
 	return 0
 }
 
@@ -621,3 +884,84 @@ func newCacheCluster(c sche.SchedulerCluster) *cacheCluster {
 		stores:           c.GetStores(),
 	}
 }
+
+// traceCluster puts the traced region at the head of the random regions of its
+// stores, so the schedulers picking the regions randomly check it first. Its
+// config carries the trace, so the filters called with it are traced.
+type traceCluster struct {
+	sche.SchedulerCluster
+	region *core.RegionInfo
+	conf   sc.SchedulerConfigProvider
+}
+
+func newTraceCluster(c sche.SchedulerCluster, region *core.RegionInfo, trace *filter.Trace) *traceCluster {
+	return &traceCluster{
+		SchedulerCluster: c,
+		region:           region,
+		conf:             trace.WrapConfig(c.GetSchedulerConfig()),
+	}
+}
+
+// GetSchedulerConfig implements the SchedulerCluster interface.
+func (c *traceCluster) GetSchedulerConfig() sc.SchedulerConfigProvider {
+	return c.conf
+}
+
+// GetSharedConfig implements the SharedCluster interface.
+func (c *traceCluster) GetSharedConfig() sc.SharedConfigProvider {
+	return c.conf
+}
+
+// RandLeaderRegions implements the RegionSetInformer interface.
+func (c *traceCluster) RandLeaderRegions(storeID uint64, ranges []core.KeyRange) []*core.RegionInfo {
+	return c.prepend(c.region.GetLeader().GetStoreId() == storeID, ranges, c.SchedulerCluster.RandLeaderRegions(storeID, ranges))
+}
+
+// RandFollowerRegions implements the RegionSetInformer interface.
+func (c *traceCluster) RandFollowerRegions(storeID uint64, ranges []core.KeyRange) []*core.RegionInfo {
+	_, ok := c.region.GetFollowers()[storeID]
+	return c.prepend(ok, ranges, c.SchedulerCluster.RandFollowerRegions(storeID, ranges))
+}
+
+// RandLearnerRegions implements the RegionSetInformer interface.
+func (c *traceCluster) RandLearnerRegions(storeID uint64, ranges []core.KeyRange) []*core.RegionInfo {
+	return c.prepend(c.region.GetStoreLearner(storeID) != nil, ranges, c.SchedulerCluster.RandLearnerRegions(storeID, ranges))
+}
+
+// RandWitnessRegions implements the RegionSetInformer interface.
+func (c *traceCluster) RandWitnessRegions(storeID uint64, ranges []core.KeyRange) []*core.RegionInfo {
+	return c.prepend(c.region.GetStoreWitness(storeID) != nil, ranges, c.SchedulerCluster.RandWitnessRegions(storeID, ranges))
+}
+
+// RandPendingRegions implements the RegionSetInformer interface.
+func (c *traceCluster) RandPendingRegions(storeID uint64, ranges []core.KeyRange) []*core.RegionInfo {
+	peer := c.region.GetStorePeer(storeID)
+	ok := peer != nil && c.region.GetPendingPeer(peer.GetId()) != nil
+	return c.prepend(ok, ranges, c.SchedulerCluster.RandPendingRegions(storeID, ranges))
+}
+
+func (c *traceCluster) prepend(onStore bool, ranges []core.KeyRange, regions []*core.RegionInfo) []*core.RegionInfo {
+	if !onStore || !isRegionInRanges(c.region, ranges) {
+		return regions
+	}
+	traced := []*core.RegionInfo{c.region}
+	for _, region := range regions {
+		if region.GetID() != c.region.GetID() {
+			traced = append(traced, region)
+		}
+	}
+	return traced
+}
+
+func isRegionInRanges(region *core.RegionInfo, ranges []core.KeyRange) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if bytes.Compare(region.GetStartKey(), r.StartKey) >= 0 &&
+			(len(r.EndKey) == 0 || (len(region.GetEndKey()) > 0 && bytes.Compare(region.GetEndKey(), r.EndKey) <= 0)) {
+			return true
+		}
+	}
+	return false
+}
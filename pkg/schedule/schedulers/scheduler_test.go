@@ -16,8 +16,11 @@ package schedulers
 
 import (
 	"context"
+	"fmt"
 	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/docker/go-units"
 	"github.com/stretchr/testify/require"
@@ -25,6 +28,7 @@ import (
 	"github.com/pingcap/kvproto/pkg/metapb"
 
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
 	"github.com/tikv/pd/pkg/schedule/config"
@@ -36,6 +40,7 @@ import (
 	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/utils/operatorutil"
 	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 )
 
@@ -557,3 +562,193 @@ func TestDisabled(t *testing.T) {
 		re.True(scheduling.IsDisable())
 	}
 }
+
+func TestSchedulerDryRun(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	tc.SetTolerantSizeRatio(2.5)
+	// store 1 has 16 leaders and store 2 has none
+	tc.AddLeaderStore(1, 16)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderRegion(1, 1, 2)
+	sl, err := CreateScheduler(types.BalanceLeaderScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceLeaderScheduler, []string{"", ""}))
+	re.NoError(err)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	controller := NewController(ctx, tc, storage.NewStorageWithMemoryBackend(), oc)
+	sc := NewScheduleController(ctx, tc, oc, sl)
+	controller.schedulers[sl.GetName()] = sc
+
+	results, err := controller.DryRun("all")
+	re.NoError(err)
+	re.Len(results, 1)
+	re.Equal(sl.GetName(), results[0].Name)
+	re.Equal(Scheduling, results[0].Status)
+	re.NotEmpty(results[0].Operators)
+	for _, op := range results[0].Operators {
+		re.True(op.Accepted)
+		re.Equal(sl.GetName(), op.Desc)
+	}
+	// nothing is added to the operator controller
+	re.Empty(oc.GetOperators())
+	re.Empty(oc.GetWaitingOperators())
+
+	// a paused scheduler still runs, but reports its status
+	sc.SetDelay(time.Now().Unix(), time.Now().Unix()+100)
+	results, err = controller.DryRun(sl.GetName())
+	re.NoError(err)
+	re.Equal(Paused, results[0].Status)
+	re.NotEmpty(results[0].Operators)
+
+	// a pending scheduler reports the status of its last check
+	sc.SetDelay(0, 0)
+	tc.SetLeaderScheduleLimit(0)
+	results, err = controller.DryRun(sl.GetName())
+	re.NoError(err)
+	re.Equal(Scheduling, results[0].Status)
+	re.False(sc.AllowSchedule(false))
+	results, err = controller.DryRun(sl.GetName())
+	re.NoError(err)
+	re.Equal(Pending, results[0].Status)
+
+	// the jobs of the balance range scheduler are not begun by the dry-run
+	sr, err := CreateScheduler(types.BalanceRangeScheduler, oc, storage.NewStorageWithMemoryBackend(),
+		ConfigSliceDecoder(types.BalanceRangeScheduler, []string{"leader", "tikv", "1h", "job-0", "100", "200"}))
+	re.NoError(err)
+	controller.schedulers[sr.GetName()] = NewScheduleController(ctx, tc, oc, sr)
+	results, err = controller.DryRun(sr.GetName())
+	re.NoError(err)
+	re.Equal(Normal, results[0].Status)
+	re.Equal(pending, sr.(*balanceRangeScheduler).conf.clone()[0].Status)
+
+	_, err = controller.DryRun("unknown-scheduler")
+	re.ErrorIs(err, errs.ErrSchedulerNotFound)
+}
+
+func TestSchedulerTrace(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	tc.SetTolerantSizeRatio(1)
+	tc.AddRegionStore(1, 100)
+	tc.AddRegionStore(2, 90)
+	tc.AddRegionStore(3, 80)
+	tc.AddRegionStore(4, 0)
+	tc.AddRegionStore(5, 0)
+	tc.SetStoreDown(5)
+	for i := uint64(1); i <= 10; i++ {
+		tc.AddLeaderRegion(i, 1, 2, 3)
+	}
+	sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	controller := NewController(ctx, tc, storage.NewStorageWithMemoryBackend(), oc)
+	sc := NewScheduleController(ctx, tc, oc, sb)
+	controller.schedulers[sb.GetName()] = sc
+
+	// The traced region is picked first, so it is always scheduled.
+	for i := 0; i < 5; i++ {
+		result, err := controller.Trace(sb.GetName(), 7)
+		re.NoError(err)
+		re.Equal(sb.GetName(), result.Name)
+		re.Equal(uint64(7), result.RegionID)
+		re.Len(result.Operators, 1)
+		re.Equal(uint64(7), result.Operators[0].RegionID)
+		re.True(result.Operators[0].Accepted)
+		for _, p := range result.Plans {
+			if p.Step > 0 {
+				re.Equal(uint64(7), p.Resources[1])
+			}
+		}
+		records := make(map[string]string)
+		for _, r := range result.Filters {
+			// Only the stores of the region are traced as the source.
+			if r.Action == "filter-source" {
+				re.Contains([]uint64{1, 2, 3}, r.StoreID)
+			}
+			records[fmt.Sprintf("%s-%d-%s", r.Action, r.StoreID, r.Filter)] = r.Status
+		}
+		// All the filters of the chain are traced, not only the first failed one.
+		re.Equal("OK", records["filter-target-4-rule-fit-filter"])
+		re.Equal("OK", records["filter-target-4-exclude-filter"])
+		re.Equal("StoreAlreadyHasPeer", records["filter-target-2-exclude-filter"])
+		// The down store is filtered as an unavailable target before the region is picked.
+		re.Equal("StoreDown", records["filter-target-5-store-state-down-filter"])
+		re.Equal("OK", records["filter-target-5-rule-fit-filter"])
+	}
+	re.Empty(oc.GetOperators())
+
+	// The concurrent traces and the real scheduling do not affect each other.
+	var wg sync.WaitGroup
+	results := make([]*TraceResult, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = controller.Trace(sb.GetName(), uint64(i+1))
+		}(i)
+	}
+	sc.scheduleMu.Lock()
+	ops := sc.Schedule(false)
+	sc.scheduleMu.Unlock()
+	re.Len(ops, 1)
+	wg.Wait()
+	for i, result := range results {
+		re.Len(result.Operators, 1)
+		re.Equal(uint64(i+1), result.Operators[0].RegionID)
+		for _, r := range result.Filters {
+			if r.Action == "filter-source" {
+				re.Contains([]uint64{1, 2, 3}, r.StoreID)
+			}
+		}
+	}
+
+	_, err = controller.Trace(sb.GetName(), 100)
+	re.ErrorIs(err, errs.ErrRegionNotFound)
+	_, err = controller.Trace("unknown-scheduler", 7)
+	re.ErrorIs(err, errs.ErrSchedulerNotFound)
+}
+
+func TestSchedulerTimeWindows(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+
+	sl, err := CreateScheduler(types.BalanceLeaderScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceLeaderScheduler, []string{"", ""}))
+	re.NoError(err)
+	s := NewScheduleController(context.Background(), tc, oc, sl)
+	re.False(s.IsPaused())
+	re.True(s.AllowSchedule(false))
+
+	// The scheduler is always in the time window.
+	tc.SetSchedulerTimeWindows(sl.GetName(), config.TimeWindows{
+		{Cron: "* * * * *", Duration: typeutil.NewDuration(time.Hour)},
+	})
+	re.False(s.IsOutOfTimeWindows())
+	re.True(s.AllowSchedule(false))
+
+	// The time window starts 12 hours later.
+	now := time.Now().UTC()
+	tc.SetSchedulerTimeWindows(sl.GetName(), config.TimeWindows{
+		{Cron: fmt.Sprintf("0 %d * * *", (now.Hour()+12)%24), Duration: typeutil.NewDuration(time.Hour), TimeZone: "UTC"},
+	})
+	re.True(s.IsOutOfTimeWindows())
+	re.True(s.IsPaused())
+	re.False(s.AllowSchedule(false))
+	re.Greater(s.GetDelayUntil(), now.Unix())
+	re.LessOrEqual(s.GetDelayUntil(), now.Add(12*time.Hour).Unix())
+	re.Less(s.GetDelayAt(), now.Unix())
+
+	// The pause by the user takes precedence.
+	s.SetDelay(now.Unix(), now.Unix()+100)
+	re.Equal(now.Unix()+100, s.GetDelayUntil())
+
+	// Other schedulers are not affected.
+	other, err := CreateScheduler(types.BalanceRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	re.False(NewScheduleController(context.Background(), tc, oc, other).IsPaused())
+}
@@ -0,0 +1,605 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
+)
+
+const (
+	shuffleChaosLeader    = "leader"
+	shuffleChaosRegion    = "region"
+	shuffleChaosHotRegion = "hot-region"
+
+	shuffleChaosPresetLight  = "light"
+	shuffleChaosPresetMedium = "medium"
+	shuffleChaosPresetHeavy  = "heavy"
+	// shuffleChaosPresetCustom is the preset of the config whose kinds or rate are
+	// changed after a preset is applied.
+	shuffleChaosPresetCustom = "custom"
+
+	// shuffleChaosDefaultDuration is the default duration of a run, so a forgotten
+	// scheduler does not shuffle forever.
+	shuffleChaosDefaultDuration = time.Hour
+	// shuffleChaosMaxRate is the max number of the operators created in a minute.
+	shuffleChaosMaxRate = 600
+	// shuffleChaosMaxRecords is the max number of the records kept in the report,
+	// the oldest ones are dropped first.
+	shuffleChaosMaxRecords = 1000
+)
+
+var shuffleChaosKinds = []string{shuffleChaosLeader, shuffleChaosRegion, shuffleChaosHotRegion}
+
+// shuffleChaosPreset is a combination of the shuffles and the rate.
+type shuffleChaosPreset struct {
+	kinds []string
+	rate  uint64
+}
+
+var shuffleChaosPresets = map[string]shuffleChaosPreset{
+	shuffleChaosPresetLight:  {kinds: []string{shuffleChaosLeader}, rate: 1},
+	shuffleChaosPresetMedium: {kinds: []string{shuffleChaosLeader, shuffleChaosRegion}, rate: 6},
+	shuffleChaosPresetHeavy:  {kinds: shuffleChaosKinds, rate: 30},
+}
+
+type shuffleChaosSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+
+	// Preset is the name of the preset which the kinds and the rate come from.
+	Preset string `json:"preset"`
+	// Kinds are the shuffles to be made, they are picked randomly in every scheduling.
+	Kinds []string `json:"kinds"`
+	// Rate is the max number of the operators created in a minute.
+	Rate uint64 `json:"rate"`
+	// StoreLabels selects the stores to be shuffled, both the source and the target
+	// stores should have all the labels. All the stores are selected if it is empty.
+	StoreLabels map[string]string `json:"store-labels"`
+	// Duration is the time after which the run is stopped, 0 means no limit.
+	Duration typeutil.Duration `json:"duration"`
+	// MaxOperators is the number of the operators after which the run is stopped,
+	// 0 means no limit.
+	MaxOperators uint64 `json:"max-operators"`
+
+	// The following fields are the runtime states, they are not persisted. A run
+	// is started when the scheduler is created or the config is updated.
+	startTime     time.Time
+	lastOpTime    time.Time
+	operatorCount uint64
+	stopReason    string
+	records       []*shuffleChaosRecord
+}
+
+func (conf *shuffleChaosSchedulerConfig) applyPresetLocked(name string) {
+	preset, ok := shuffleChaosPresets[name]
+	if !ok {
+		return
+	}
+	conf.Preset = name
+	conf.Kinds = slices.Clone(preset.kinds)
+	conf.Rate = preset.rate
+}
+
+// matchPresetLocked changes the preset to custom if the kinds or the rate are
+// different from the ones of the preset.
+func (conf *shuffleChaosSchedulerConfig) matchPresetLocked() {
+	preset, ok := shuffleChaosPresets[conf.Preset]
+	if !ok {
+		return
+	}
+	if conf.Rate != preset.rate || !slices.Equal(conf.Kinds, preset.kinds) {
+		conf.Preset = shuffleChaosPresetCustom
+	}
+}
+
+func (conf *shuffleChaosSchedulerConfig) update(data []byte) (int, any) {
+	conf.Lock()
+	defer conf.Unlock()
+
+	oldc, _ := json.Marshal(conf)
+
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	// The preset is applied first, so the items set along with it take effect.
+	if preset, ok := m["preset"].(string); ok {
+		conf.applyPresetLocked(preset)
+	}
+	// The labels are replaced rather than merged.
+	if _, ok := m["store-labels"]; ok {
+		conf.StoreLabels = nil
+	}
+	if err := json.Unmarshal(data, conf); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	conf.matchPresetLocked()
+	newc, _ := json.Marshal(conf)
+	if !bytes.Equal(oldc, newc) {
+		if msg := conf.validateLocked(); len(msg) > 0 {
+			conf.StoreLabels = nil
+			if err := json.Unmarshal(oldc, conf); err != nil {
+				return http.StatusInternalServerError, err.Error()
+			}
+			return http.StatusBadRequest, msg
+		}
+		if err := conf.save(); err != nil {
+			log.Warn("failed to persist config", zap.Error(err))
+		}
+		conf.restartLocked(time.Now())
+		log.Info("shuffle-chaos-scheduler config is updated", zap.ByteString("old", oldc), zap.ByteString("new", newc))
+		return http.StatusOK, "Config is updated."
+	}
+	ok := reflectutil.FindSameFieldByJSON(conf, m)
+	if ok {
+		return http.StatusOK, "Config is the same with origin, so do nothing."
+	}
+	return http.StatusBadRequest, "Config item is not found."
+}
+
+func (conf *shuffleChaosSchedulerConfig) validateLocked() string {
+	if _, ok := shuffleChaosPresets[conf.Preset]; !ok && conf.Preset != shuffleChaosPresetCustom {
+		return "invalid preset which should be one of light, medium, heavy and custom"
+	}
+	if len(conf.Kinds) == 0 {
+		return "invalid kinds which should not be empty"
+	}
+	for _, kind := range conf.Kinds {
+		if !slices.Contains(shuffleChaosKinds, kind) {
+			return "invalid kind " + kind + " which should be one of leader, region and hot-region"
+		}
+	}
+	if conf.Rate < 1 || conf.Rate > shuffleChaosMaxRate {
+		return "invalid rate which should be in [1, 600]"
+	}
+	if conf.Duration.Duration < 0 {
+		return "invalid duration which should not be negative"
+	}
+	return ""
+}
+
+func (conf *shuffleChaosSchedulerConfig) clone() *shuffleChaosSchedulerConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	labels := make(map[string]string, len(conf.StoreLabels))
+	for k, v := range conf.StoreLabels {
+		labels[k] = v
+	}
+	return &shuffleChaosSchedulerConfig{
+		Preset:       conf.Preset,
+		Kinds:        slices.Clone(conf.Kinds),
+		Rate:         conf.Rate,
+		StoreLabels:  labels,
+		Duration:     conf.Duration,
+		MaxOperators: conf.MaxOperators,
+	}
+}
+
+// restartLocked starts a new run, the records of the previous runs are kept
+// in the report.
+func (conf *shuffleChaosSchedulerConfig) restartLocked(now time.Time) {
+	conf.startTime = now
+	conf.lastOpTime = time.Time{}
+	conf.operatorCount = 0
+	conf.stopReason = ""
+}
+
+func (conf *shuffleChaosSchedulerConfig) getKinds() []string {
+	conf.RLock()
+	defer conf.RUnlock()
+	return slices.Clone(conf.Kinds)
+}
+
+func (conf *shuffleChaosSchedulerConfig) getLabelConstraints() []placement.LabelConstraint {
+	conf.RLock()
+	defer conf.RUnlock()
+	constraints := make([]placement.LabelConstraint, 0, len(conf.StoreLabels))
+	for k, v := range conf.StoreLabels {
+		constraints = append(constraints, placement.LabelConstraint{Key: k, Op: placement.In, Values: []string{v}})
+	}
+	sort.Slice(constraints, func(i, j int) bool { return constraints[i].Key < constraints[j].Key })
+	return constraints
+}
+
+// checkRun returns whether the run can create an operator at the time. It stops
+// the run if the duration or the number of the operators is reached.
+func (conf *shuffleChaosSchedulerConfig) checkRun(now time.Time) (stopped bool, limited bool) {
+	conf.Lock()
+	defer conf.Unlock()
+	if len(conf.stopReason) == 0 {
+		switch {
+		case conf.Duration.Duration > 0 && now.Sub(conf.startTime) >= conf.Duration.Duration:
+			conf.stopReason = "the duration " + conf.Duration.String() + " is reached"
+		case conf.MaxOperators > 0 && conf.operatorCount >= conf.MaxOperators:
+			conf.stopReason = "the max operators is reached"
+		}
+		if len(conf.stopReason) > 0 {
+			log.Info("shuffle chaos run is stopped", zap.Time("start-time", conf.startTime),
+				zap.Uint64("operator-count", conf.operatorCount), zap.String("reason", conf.stopReason))
+		}
+	}
+	if len(conf.stopReason) > 0 {
+		return true, false
+	}
+	return false, now.Sub(conf.lastOpTime) < time.Minute/time.Duration(conf.Rate)
+}
+
+func (conf *shuffleChaosSchedulerConfig) record(r *shuffleChaosRecord) {
+	conf.Lock()
+	defer conf.Unlock()
+	conf.lastOpTime = r.Time
+	conf.operatorCount++
+	if len(conf.records) >= shuffleChaosMaxRecords {
+		conf.records = conf.records[1:]
+	}
+	conf.records = append(conf.records, r)
+}
+
+func (conf *shuffleChaosSchedulerConfig) getReport() *shuffleChaosReport {
+	conf.RLock()
+	defer conf.RUnlock()
+	records := make([]*shuffleChaosRecord, 0, len(conf.records))
+	for _, r := range conf.records {
+		record := *r
+		records = append(records, &record)
+	}
+	return &shuffleChaosReport{
+		StartTime:     conf.startTime,
+		Stopped:       len(conf.stopReason) > 0,
+		StopReason:    conf.stopReason,
+		OperatorCount: conf.operatorCount,
+		Records:       records,
+	}
+}
+
+// shuffleChaosRecord is an operator created by the scheduler, it is used to
+// correlate the shuffles with the errors of the applications.
+type shuffleChaosRecord struct {
+	Time          time.Time `json:"time"`
+	Kind          string    `json:"kind"`
+	RegionID      uint64    `json:"region-id"`
+	SourceStoreID uint64    `json:"source-store-id"`
+	TargetStoreID uint64    `json:"target-store-id"`
+	Operator      string    `json:"operator"`
+}
+
+// shuffleChaosReport is the state of the current run and the records of the
+// operators created recently.
+type shuffleChaosReport struct {
+	StartTime     time.Time             `json:"start-time"`
+	Stopped       bool                  `json:"stopped"`
+	StopReason    string                `json:"stop-reason,omitempty"`
+	OperatorCount uint64                `json:"operator-count"`
+	Records       []*shuffleChaosRecord `json:"records"`
+}
+
+type shuffleChaosHandler struct {
+	rd     *render.Render
+	config *shuffleChaosSchedulerConfig
+}
+
+func newShuffleChaosHandler(conf *shuffleChaosSchedulerConfig) http.Handler {
+	handler := &shuffleChaosHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	router.HandleFunc("/report", handler.getReport).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *shuffleChaosHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *shuffleChaosHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	conf := handler.config.clone()
+	handler.rd.JSON(w, http.StatusOK, conf)
+}
+
+func (handler *shuffleChaosHandler) getReport(w http.ResponseWriter, _ *http.Request) {
+	handler.rd.JSON(w, http.StatusOK, handler.config.getReport())
+}
+
+// shuffleChaosScheduler combines the shuffles of the leaders, the regions and the
+// hot regions with a rate, it is used to inject chaos into the test clusters.
+type shuffleChaosScheduler struct {
+	*BaseScheduler
+	conf    *shuffleChaosSchedulerConfig
+	handler http.Handler
+}
+
+// newShuffleChaosScheduler creates an admin scheduler that shuffles the leaders,
+// the regions and the hot regions among the selected stores until it is stopped.
+func newShuffleChaosScheduler(opController *operator.Controller, conf *shuffleChaosSchedulerConfig) Scheduler {
+	conf.restartLocked(time.Now())
+	return &shuffleChaosScheduler{
+		BaseScheduler: NewBaseScheduler(opController, types.ShuffleChaosScheduler, conf),
+		conf:          conf,
+		handler:       newShuffleChaosHandler(conf),
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *shuffleChaosScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *shuffleChaosScheduler) EncodeConfig() ([]byte, error) {
+	s.conf.RLock()
+	defer s.conf.RUnlock()
+	return EncodeConfig(s.conf)
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *shuffleChaosScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+
+	newCfg := &shuffleChaosSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.Preset = newCfg.Preset
+	s.conf.Kinds = newCfg.Kinds
+	s.conf.Rate = newCfg.Rate
+	s.conf.StoreLabels = newCfg.StoreLabels
+	s.conf.Duration = newCfg.Duration
+	s.conf.MaxOperators = newCfg.MaxOperators
+	s.conf.restartLocked(time.Now())
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *shuffleChaosScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	stopped, limited := s.conf.checkRun(time.Now())
+	if stopped {
+		shuffleChaosStoppedCounter.Inc()
+		return false
+	}
+	if limited {
+		shuffleChaosRateLimitedCounter.Inc()
+		return false
+	}
+	for _, kind := range s.conf.getKinds() {
+		if s.isKindAllowed(cluster, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *shuffleChaosScheduler) isKindAllowed(cluster sche.SchedulerCluster, kind string) bool {
+	conf := cluster.GetSchedulerConfig()
+	leaderAllowed := s.OpController.OperatorCount(operator.OpLeader) < conf.GetLeaderScheduleLimit()
+	regionAllowed := s.OpController.OperatorCount(operator.OpRegion) < conf.GetRegionScheduleLimit()
+	if !leaderAllowed && kind != shuffleChaosRegion {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpLeader)
+	}
+	if !regionAllowed && kind != shuffleChaosLeader {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	switch kind {
+	case shuffleChaosLeader:
+		return leaderAllowed
+	case shuffleChaosRegion:
+		return regionAllowed
+	default:
+		return leaderAllowed && regionAllowed
+	}
+}
+
+// Schedule implements the Scheduler interface.
+func (s *shuffleChaosScheduler) Schedule(cluster sche.SchedulerCluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	shuffleChaosCounter.Inc()
+	labelFilter := filter.NewLabelConstraintFilter(s.GetName(), s.conf.getLabelConstraints())
+	kinds := s.conf.getKinds()
+	// The kinds are tried in a random order, so a kind without any candidate
+	// does not block the others.
+	for _, i := range s.R.Perm(len(kinds)) {
+		kind := kinds[i]
+		if !s.isKindAllowed(cluster, kind) {
+			continue
+		}
+		var (
+			op     *operator.Operator
+			source uint64
+			target uint64
+		)
+		switch kind {
+		case shuffleChaosLeader:
+			op, source, target = s.shuffleLeader(cluster, labelFilter)
+		case shuffleChaosRegion:
+			op, source, target = s.shuffleRegion(cluster, labelFilter)
+		case shuffleChaosHotRegion:
+			op, source, target = s.shuffleHotRegion(cluster, labelFilter)
+		}
+		if op == nil {
+			continue
+		}
+		op.SetPriorityLevel(constant.Low)
+		op.Counters = append(op.Counters, shuffleChaosNewOperatorCounter)
+		if !dryRun {
+			// The operator is recorded only if it is accepted by the operator controller.
+			desc := op.String()
+			op.AddedHooks = append(op.AddedHooks, func() {
+				s.conf.record(&shuffleChaosRecord{
+					Time:          time.Now(),
+					Kind:          kind,
+					RegionID:      op.RegionID(),
+					SourceStoreID: source,
+					TargetStoreID: target,
+					Operator:      desc,
+				})
+			})
+		}
+		return []*operator.Operator{op}, nil
+	}
+	shuffleChaosNoOperatorCounter.Inc()
+	return nil, nil
+}
+
+// shuffleLeader transfers the leader of a random region to a random follower,
+// the stores of both the leader and the follower should be selected.
+func (s *shuffleChaosScheduler) shuffleLeader(cluster sche.SchedulerCluster, labelFilter filter.Filter) (*operator.Operator, uint64, uint64) {
+	filters := []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: s.GetName(), TransferLeader: true, OperatorLevel: constant.Low},
+		filter.NewSpecialUseFilter(s.GetName()),
+		labelFilter,
+	}
+	candidates := filter.NewCandidates(s.R, cluster.GetStores()).
+		FilterTarget(cluster.GetSchedulerConfig(), nil, nil, filters...).
+		Shuffle()
+	pendingFilter := filter.NewRegionPendingFilter()
+	downFilter := filter.NewRegionDownFilter()
+	for _, target := range candidates.Stores {
+		regions := filter.SelectRegions(cluster.RandFollowerRegions(target.GetID(), nil), pendingFilter, downFilter)
+		for _, region := range regions {
+			source := cluster.GetStore(region.GetLeader().GetStoreId())
+			if source == nil || !labelFilter.Source(cluster.GetSchedulerConfig(), source).IsOK() {
+				continue
+			}
+			op, err := operator.CreateTransferLeaderOperator(s.GetName(), cluster, region, target.GetID(), []uint64{}, operator.OpAdmin)
+			if err != nil {
+				log.Debug("fail to create shuffle leader operator", errs.ZapError(err))
+				shuffleChaosCreateOpFailCounter.Inc()
+				return nil, 0, 0
+			}
+			return op, source.GetID(), target.GetID()
+		}
+	}
+	return nil, 0, 0
+}
+
+// shuffleRegion moves a random peer of a random region to another random store.
+func (s *shuffleChaosScheduler) shuffleRegion(cluster sche.SchedulerCluster, labelFilter filter.Filter) (*operator.Operator, uint64, uint64) {
+	filters := []filter.Filter{
+		&filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.Low},
+		filter.NewSpecialUseFilter(s.GetName()),
+		labelFilter,
+	}
+	candidates := filter.NewCandidates(s.R, cluster.GetStores()).
+		FilterSource(cluster.GetSchedulerConfig(), nil, nil, filters...).
+		Shuffle()
+	pendingFilter := filter.NewRegionPendingFilter()
+	downFilter := filter.NewRegionDownFilter()
+	replicaFilter := filter.NewRegionReplicatedFilter(cluster)
+	for _, source := range candidates.Stores {
+		region := filter.SelectOneRegion(cluster.RandFollowerRegions(source.GetID(), nil), nil,
+			pendingFilter, downFilter, replicaFilter)
+		if region == nil {
+			region = filter.SelectOneRegion(cluster.RandLeaderRegions(source.GetID(), nil), nil,
+				pendingFilter, downFilter, replicaFilter)
+		}
+		if region == nil {
+			continue
+		}
+		oldPeer := region.GetStorePeer(source.GetID())
+		targetFilters := append(slices.Clone(filters),
+			filter.NewPlacementSafeguard(s.GetName(), cluster.GetSchedulerConfig(), cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, nil),
+			filter.NewExcludedFilter(s.GetName(), nil, region.GetStoreIDs()))
+		target := filter.NewCandidates(s.R, cluster.GetStores()).
+			FilterTarget(cluster.GetSchedulerConfig(), nil, nil, targetFilters...).
+			RandomPick()
+		if target == nil {
+			continue
+		}
+		newPeer := &metapb.Peer{StoreId: target.GetID(), Role: oldPeer.GetRole()}
+		op, err := operator.CreateMovePeerOperator(s.GetName(), cluster, region, operator.OpRegion, source.GetID(), newPeer)
+		if err != nil {
+			log.Debug("fail to create shuffle region operator", errs.ZapError(err))
+			shuffleChaosCreateOpFailCounter.Inc()
+			return nil, 0, 0
+		}
+		return op, source.GetID(), target.GetID()
+	}
+	return nil, 0, 0
+}
+
+// shuffleHotRegion moves the leader of a random hot region to another random store.
+func (s *shuffleChaosScheduler) shuffleHotRegion(cluster sche.SchedulerCluster, labelFilter filter.Filter) (*operator.Operator, uint64, uint64) {
+	var regionIDs []uint64
+	for _, rw := range []utils.RWType{utils.Read, utils.Write} {
+		for _, stats := range cluster.GetHotPeerStats(rw) {
+			for _, stat := range stats {
+				regionIDs = append(regionIDs, stat.RegionID)
+			}
+		}
+	}
+	s.R.Shuffle(len(regionIDs), func(i, j int) { regionIDs[i], regionIDs[j] = regionIDs[j], regionIDs[i] })
+	for _, regionID := range regionIDs {
+		region := cluster.GetRegion(regionID)
+		if region == nil || len(region.GetDownPeers()) != 0 || len(region.GetPendingPeers()) != 0 {
+			continue
+		}
+		source := cluster.GetStore(region.GetLeader().GetStoreId())
+		if source == nil || !labelFilter.Source(cluster.GetSchedulerConfig(), source).IsOK() {
+			continue
+		}
+		filters := []filter.Filter{
+			&filter.StoreStateFilter{ActionScope: s.GetName(), MoveRegion: true, OperatorLevel: constant.Low},
+			filter.NewSpecialUseFilter(s.GetName()),
+			labelFilter,
+			filter.NewExcludedFilter(s.GetName(), region.GetStoreIDs(), region.GetStoreIDs()),
+			filter.NewPlacementSafeguard(s.GetName(), cluster.GetSchedulerConfig(), cluster.GetBasicCluster(), cluster.GetRuleManager(), region, source, nil),
+		}
+		target := filter.NewCandidates(s.R, cluster.GetStores()).
+			FilterTarget(cluster.GetSchedulerConfig(), nil, nil, filters...).
+			RandomPick()
+		if target == nil {
+			continue
+		}
+		op, err := operator.CreateMoveLeaderOperator(s.GetName(), cluster, region, operator.OpRegion|operator.OpLeader, source.GetID(), &metapb.Peer{StoreId: target.GetID()})
+		if err != nil {
+			log.Debug("fail to create shuffle hot region operator", errs.ZapError(err))
+			shuffleChaosCreateOpFailCounter.Inc()
+			return nil, 0, 0
+		}
+		return op, source.GetID(), target.GetID()
+	}
+	return nil, 0, 0
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestShuffleChaosScheduler(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(true)
+	defer cancel()
+	for id := uint64(1); id <= 4; id++ {
+		zone := "z1"
+		if id == 4 {
+			zone = "z2"
+		}
+		tc.AddLabelsStore(id, 10, map[string]string{"zone": zone})
+		// The added operators are not limited by the store limit.
+		tc.SetStoreLimit(id, storelimit.AddPeer, 6000)
+		tc.SetStoreLimit(id, storelimit.RemovePeer, 6000)
+	}
+	for id := uint64(1); id <= 10; id++ {
+		tc.AddLeaderRegion(id, 1, 2, 3)
+	}
+
+	sb, err := CreateScheduler(types.ShuffleChaosScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ShuffleChaosScheduler, []string{"heavy"}))
+	re.NoError(err)
+	s := sb.(*shuffleChaosScheduler)
+	conf := s.conf.clone()
+	re.Equal(shuffleChaosPresetHeavy, conf.Preset)
+	re.Equal(shuffleChaosKinds, conf.Kinds)
+	re.Equal(uint64(30), conf.Rate)
+	re.Equal(shuffleChaosDefaultDuration, conf.Duration.Duration)
+	_, err = CreateScheduler(types.ShuffleChaosScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.ShuffleChaosScheduler, []string{"unknown"}))
+	re.Error(err)
+
+	updateConfig := func(body string) int {
+		req, _ := http.NewRequest(http.MethodPost, "/config", strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		sb.ServeHTTP(resp, req)
+		return resp.Code
+	}
+	getReport := func() *shuffleChaosReport {
+		req, _ := http.NewRequest(http.MethodGet, "/report", http.NoBody)
+		resp := httptest.NewRecorder()
+		sb.ServeHTTP(resp, req)
+		re.Equal(http.StatusOK, resp.Code)
+		report := &shuffleChaosReport{}
+		re.NoError(json.Unmarshal(resp.Body.Bytes(), report))
+		return report
+	}
+	schedule := func() *operator.Operator {
+		// Skip the rate limit of the last operator.
+		s.conf.Lock()
+		s.conf.lastOpTime = time.Time{}
+		s.conf.Unlock()
+		if !sb.IsScheduleAllowed(tc) {
+			return nil
+		}
+		ops, _ := sb.Schedule(tc, false)
+		if len(ops) == 0 {
+			return nil
+		}
+		// Remove the operator after it is added to make room for the next one.
+		re.Equal(1, oc.AddWaitingOperator(ops...))
+		re.True(oc.RemoveOperator(ops[0]))
+		return ops[0]
+	}
+
+	// Only the stores in zone z1 are shuffled, so the regions can not be moved.
+	re.Equal(http.StatusOK, updateConfig(`{"store-labels": {"zone": "z1"}, "rate": 600}`))
+	conf = s.conf.clone()
+	re.Equal(shuffleChaosPresetCustom, conf.Preset)
+	re.Equal(map[string]string{"zone": "z1"}, conf.StoreLabels)
+	for range 10 {
+		op := schedule()
+		re.NotNil(op)
+		re.Equal(operator.OpLeader, op.Kind()&operator.OpLeader)
+		re.Zero(op.Kind() & operator.OpRegion)
+	}
+	// The operators are recorded and the rate is limited.
+	re.False(sb.IsScheduleAllowed(tc))
+	report := getReport()
+	re.False(report.Stopped)
+	re.Equal(uint64(10), report.OperatorCount)
+	re.Len(report.Records, 10)
+	for _, r := range report.Records {
+		re.Equal(shuffleChaosLeader, r.Kind)
+		re.Equal(uint64(1), r.SourceStoreID)
+		re.Contains([]uint64{2, 3}, r.TargetStoreID)
+	}
+	// The dry run is not recorded.
+	s.conf.Lock()
+	s.conf.lastOpTime = time.Time{}
+	s.conf.Unlock()
+	ops, _ := sb.Schedule(tc, true)
+	re.Len(ops, 1)
+	re.Len(getReport().Records, 10)
+	// The operator rejected by the operator controller is not recorded either.
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	region := tc.GetRegion(ops[0].RegionID())
+	tc.PutRegion(region.Clone(core.WithIncVersion()))
+	re.Zero(oc.AddWaitingOperator(ops...))
+	tc.PutRegion(region)
+	report = getReport()
+	re.Equal(uint64(10), report.OperatorCount)
+	re.Len(report.Records, 10)
+
+	// The run is stopped after the max operators.
+	re.Equal(http.StatusOK, updateConfig(`{"store-labels": {}, "kinds": ["region"], "max-operators": 2}`))
+	for range 2 {
+		op := schedule()
+		re.NotNil(op)
+		re.Equal(operator.OpRegion, op.Kind()&operator.OpRegion)
+	}
+	re.Nil(schedule())
+	report = getReport()
+	re.True(report.Stopped)
+	re.Equal(uint64(2), report.OperatorCount)
+	re.Len(report.Records, 12)
+	for _, r := range report.Records[10:] {
+		re.Equal(shuffleChaosRegion, r.Kind)
+		re.Equal(uint64(4), r.TargetStoreID)
+	}
+
+	// The run is stopped after the duration.
+	re.Equal(http.StatusOK, updateConfig(`{"max-operators": 0, "duration": "1m"}`))
+	re.NotNil(schedule())
+	s.conf.Lock()
+	s.conf.startTime = time.Now().Add(-time.Minute)
+	s.conf.Unlock()
+	re.Nil(schedule())
+	re.True(getReport().Stopped)
+
+	// The preset is applied before the other items.
+	re.Equal(http.StatusOK, updateConfig(`{"preset": "medium", "rate": 10}`))
+	conf = s.conf.clone()
+	re.Equal(shuffleChaosPresetCustom, conf.Preset)
+	re.Equal([]string{shuffleChaosLeader, shuffleChaosRegion}, conf.Kinds)
+	re.Equal(uint64(10), conf.Rate)
+	re.Equal(http.StatusOK, updateConfig(`{"preset": "light"}`))
+	re.Equal(shuffleChaosPresetLight, s.conf.clone().Preset)
+	re.Equal(http.StatusBadRequest, updateConfig(`{"kinds": ["leader", "unknown"]}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"preset": "unknown"}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"rate": 0}`))
+	conf = s.conf.clone()
+	re.Equal(shuffleChaosPresetLight, conf.Preset)
+	re.Equal([]string{shuffleChaosLeader}, conf.Kinds)
+	re.Equal(uint64(1), conf.Rate)
+	re.False(getReport().Stopped)
+}
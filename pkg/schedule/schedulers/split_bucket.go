@@ -20,7 +20,9 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
 
@@ -33,21 +35,38 @@ import (
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/statistics/buckets"
+	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/pkg/utils/reflectutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
 const (
 	// defaultHotDegree is the default hot region threshold.
 	defaultHotDegree  = 3
 	defaultSplitLimit = 10
+	// the default read thresholds are the same as the load base split of TiKV.
+	defaultSplitBucketReadFlowThreshold  = 30 * units.MiB
+	defaultSplitBucketReadQueryThreshold = 3000
+	defaultSplitBucketMergeCooldown      = 5 * time.Minute
+	// maxSplitBucketMergeCooldown is limited by the time the finished operators are kept.
+	maxSplitBucketMergeCooldown = 10 * time.Minute
+)
+
+const (
+	splitBucketHotDegreeDim = "hot-degree"
+	splitBucketReadFlowDim  = "read-flow"
+	splitBucketReadQueryDim = "read-query"
 )
 
 func initSplitBucketConfig() *splitBucketSchedulerConfig {
 	return &splitBucketSchedulerConfig{
-		schedulerConfig: &baseSchedulerConfig{},
-		Degree:          defaultHotDegree,
-		SplitLimit:      defaultSplitLimit,
+		schedulerConfig:    &baseSchedulerConfig{},
+		Degree:             defaultHotDegree,
+		SplitLimit:         defaultSplitLimit,
+		ReadFlowThreshold:  defaultSplitBucketReadFlowThreshold,
+		ReadQueryThreshold: defaultSplitBucketReadQueryThreshold,
+		MergeCooldown:      typeutil.NewDuration(defaultSplitBucketMergeCooldown),
 	}
 }
 
@@ -56,14 +75,44 @@ type splitBucketSchedulerConfig struct {
 	schedulerConfig
 	Degree     int    `json:"degree"`
 	SplitLimit uint64 `json:"split-limit"`
+	// ReadFlowThreshold and ReadQueryThreshold are the read bytes and queries per second
+	// of a hot bucket to split, even if the region is small. Zero disables the dimension.
+	ReadFlowThreshold  uint64 `json:"read-flow-threshold"`
+	ReadQueryThreshold uint64 `json:"read-query-threshold"`
+	// MergeCooldown is the time to wait before splitting a region which was just merged.
+	MergeCooldown typeutil.Duration `json:"merge-cooldown"`
 }
 
 func (conf *splitBucketSchedulerConfig) clone() *splitBucketSchedulerConfig {
 	conf.RLock()
 	defer conf.RUnlock()
 	return &splitBucketSchedulerConfig{
-		Degree: conf.Degree,
+		Degree:             conf.Degree,
+		ReadFlowThreshold:  conf.ReadFlowThreshold,
+		ReadQueryThreshold: conf.ReadQueryThreshold,
+		MergeCooldown:      conf.MergeCooldown,
+	}
+}
+
+func (conf *splitBucketSchedulerConfig) validateLocked() error {
+	if conf.MergeCooldown.Duration < 0 || conf.MergeCooldown.Duration > maxSplitBucketMergeCooldown {
+		return errs.ErrSchedulerConfig.FastGenByArgs("merge-cooldown")
+	}
+	return nil
+}
+
+// getReadHotDim returns the read dimension in which the bucket is hot, or an empty string if there is none.
+func (conf *splitBucketSchedulerConfig) getReadHotDim(bucket *buckets.BucketStat) string {
+	if len(bucket.Loads) <= int(utils.RegionReadQueryNum) {
+		return ""
+	}
+	if conf.ReadFlowThreshold > 0 && bucket.Loads[utils.RegionReadBytes] >= conf.ReadFlowThreshold {
+		return splitBucketReadFlowDim
+	}
+	if conf.ReadQueryThreshold > 0 && bucket.Loads[utils.RegionReadQueryNum] >= conf.ReadQueryThreshold {
+		return splitBucketReadQueryDim
 	}
+	return ""
 }
 
 func (conf *splitBucketSchedulerConfig) getDegree() int {
@@ -110,6 +159,11 @@ func (h *splitBucketHandler) updateConfig(w http.ResponseWriter, r *http.Request
 		rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if err := h.conf.validateLocked(); err != nil {
+		_ = json.Unmarshal(oldc, h.conf)
+		rd.Text(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	newc, _ := json.Marshal(h.conf)
 	if !bytes.Equal(oldc, newc) {
 		if err := h.conf.save(); err != nil {
@@ -165,6 +219,9 @@ func (s *splitBucketScheduler) ReloadConfig() error {
 	}
 	s.conf.SplitLimit = newCfg.SplitLimit
 	s.conf.Degree = newCfg.Degree
+	s.conf.ReadFlowThreshold = newCfg.ReadFlowThreshold
+	s.conf.ReadQueryThreshold = newCfg.ReadQueryThreshold
+	s.conf.MergeCooldown = newCfg.MergeCooldown
 	return nil
 }
 
@@ -207,8 +264,22 @@ func (s *splitBucketScheduler) Schedule(cluster sche.SchedulerCluster, _ bool) (
 	return s.splitBucket(plan), nil
 }
 
+// isMergedRecently returns true if the region was just merged, splitting it again may cause the
+// region to be merged and split back and forth.
+func (s *splitBucketScheduler) isMergedRecently(regionID uint64, cooldown time.Duration) bool {
+	if cooldown <= 0 {
+		return false
+	}
+	op := s.OpController.GetOperatorStatus(regionID)
+	return op != nil && op.Kind()&operator.OpMerge != 0 && op.Status == pdpb.OperatorStatus_SUCCESS &&
+		time.Since(op.FinishTime) < cooldown
+}
+
 func (s *splitBucketScheduler) splitBucket(plan *splitBucketPlan) []*operator.Operator {
-	var splitBucket *buckets.BucketStat
+	var (
+		splitBucket *buckets.BucketStat
+		splitDim    string
+	)
 	for regionID, buckets := range plan.hotBuckets {
 		region := plan.cluster.GetRegion(regionID)
 		// skip if the region doesn't exist
@@ -216,16 +287,24 @@ func (s *splitBucketScheduler) splitBucket(plan *splitBucketPlan) []*operator.Op
 			splitBucketNoRegionCounter.Inc()
 			continue
 		}
-		// region size is less than split region size
-		if region.GetApproximateSize() <= plan.hotRegionSplitSize {
-			splitBucketRegionTooSmallCounter.Inc()
-			continue
-		}
 		if op := s.OpController.GetOperator(regionID); op != nil {
 			splitBucketOperatorExistCounter.Inc()
 			continue
 		}
+		if s.isMergedRecently(regionID, plan.conf.MergeCooldown.Duration) {
+			splitBucketMergeCooldownCounter.Inc()
+			continue
+		}
+		// region size is less than split region size, only the read hot buckets can be split.
+		regionTooSmall := region.GetApproximateSize() <= plan.hotRegionSplitSize
 		for _, bucket := range buckets {
+			dim := splitBucketHotDegreeDim
+			if regionTooSmall {
+				if dim = plan.conf.getReadHotDim(bucket); dim == "" {
+					splitBucketRegionTooSmallCounter.Inc()
+					continue
+				}
+			}
 			// the key range of the bucket must less than the region.
 			// like bucket: [001 100] and region: [001 100] will not pass.
 			// like bucket: [003 100] and region: [002 100] will pass.
@@ -240,6 +319,7 @@ func (s *splitBucketScheduler) splitBucket(plan *splitBucketPlan) []*operator.Op
 
 			if splitBucket == nil || bucket.HotDegree > splitBucket.HotDegree {
 				splitBucket = bucket
+				splitDim = dim
 			}
 		}
 	}
@@ -262,7 +342,11 @@ func (s *splitBucketScheduler) splitBucket(plan *splitBucketPlan) []*operator.Op
 			return nil
 		}
 		splitBucketNewOperatorCounter.Inc()
+		if splitDim != splitBucketHotDegreeDim {
+			splitBucketReadHotCounter.Inc()
+		}
 		op.SetAdditionalInfo("hot-degree", strconv.FormatInt(int64(splitBucket.HotDegree), 10))
+		op.SetAdditionalInfo("hot-dim", splitDim)
 		return []*operator.Operator{op}
 	}
 	return nil
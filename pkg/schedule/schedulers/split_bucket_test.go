@@ -16,15 +16,25 @@ package schedulers
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/require"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/statistics/buckets"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
 func TestSplitBucket(t *testing.T) {
@@ -105,3 +115,82 @@ func TestSplitBucket(t *testing.T) {
 	step = ops[0].Step(0).(operator.SplitRegion)
 	re.Len(step.SplitKeys, 2)
 }
+
+func TestSplitBucketReadHot(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.AddRegionStore(1, 10)
+	tc.AddRegionStore(2, 10)
+	tc.AddRegionStore(3, 10)
+	// the regions are small, only the read hot buckets can be split.
+	for i := uint64(1); i <= 2; i++ {
+		tc.AddLeaderRegionWithRange(i, fmt.Sprintf("%20d", i*10), fmt.Sprintf("%20d", (i+1)*10), 1, 2, 3)
+		tc.PutRegion(tc.GetRegion(i).Clone(core.SetApproximateSize(10)))
+	}
+	sb, err := CreateScheduler(types.SplitBucketScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.SplitBucketScheduler, nil))
+	re.NoError(err)
+	scheduler := sb.(*splitBucketScheduler)
+	conf := scheduler.conf
+	newBucket := func(regionID uint64, loads []uint64) *buckets.BucketStat {
+		return &buckets.BucketStat{
+			RegionID:  regionID,
+			HotDegree: 3,
+			StartKey:  []byte(fmt.Sprintf("%20d", regionID*10+1)),
+			EndKey:    []byte(fmt.Sprintf("%20d", regionID*10+2)),
+			Loads:     loads,
+		}
+	}
+	hotBuckets := map[uint64][]*buckets.BucketStat{
+		1: {newBucket(1, []uint64{units.MiB, 100, 100, 0, 0, 0})},
+	}
+	plan := &splitBucketPlan{
+		cluster:            tc,
+		hotBuckets:         hotBuckets,
+		hotRegionSplitSize: 512,
+		conf:               conf.clone(),
+	}
+	re.Empty(scheduler.splitBucket(plan))
+
+	// case 1: the point get workload is hot in the read query dimension.
+	hotBuckets[1][0].Loads[utils.RegionReadQueryNum] = defaultSplitBucketReadQueryThreshold
+	ops := scheduler.splitBucket(plan)
+	re.Len(ops, 1)
+	re.Equal(uint64(1), ops[0].RegionID())
+	re.Equal(splitBucketReadQueryDim, ops[0].GetAdditionalInfo("hot-dim"))
+	re.Len(ops[0].Step(0).(operator.SplitRegion).SplitKeys, 2)
+
+	// case 2: the read flow dimension is disabled.
+	hotBuckets[1][0].Loads = []uint64{defaultSplitBucketReadFlowThreshold, 0, 0, 0, 0, 0}
+	ops = scheduler.splitBucket(plan)
+	re.Len(ops, 1)
+	re.Equal(splitBucketReadFlowDim, ops[0].GetAdditionalInfo("hot-dim"))
+	plan.conf.ReadFlowThreshold = 0
+	re.Empty(scheduler.splitBucket(plan))
+
+	// case 3: the region which was just merged is not split.
+	hotBuckets[2] = []*buckets.BucketStat{newBucket(2, []uint64{0, 0, defaultSplitBucketReadQueryThreshold, 0, 0, 0})}
+	op := operator.NewTestOperator(2, tc.GetRegion(2).GetRegionEpoch(), operator.OpMerge)
+	re.True(op.Start())
+	oc.SetOperator(op)
+	oc.Dispatch(tc.GetRegion(2), operator.DispatchFromHeartBeat, nil)
+	re.Equal(pdpb.OperatorStatus_SUCCESS, oc.GetOperatorStatus(2).Status)
+	re.Empty(scheduler.splitBucket(plan))
+	plan.conf.MergeCooldown = typeutil.NewDuration(0)
+	ops = scheduler.splitBucket(plan)
+	re.Len(ops, 1)
+	re.Equal(uint64(2), ops[0].RegionID())
+
+	// the merge cooldown can't be longer than the time the operator records are kept.
+	req, _ := http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"merge-cooldown": "1h"}`))
+	resp := httptest.NewRecorder()
+	scheduler.ServeHTTP(resp, req)
+	re.Equal(http.StatusBadRequest, resp.Code)
+	re.Equal(defaultSplitBucketMergeCooldown, conf.clone().MergeCooldown.Duration)
+	req, _ = http.NewRequest(http.MethodPost, "/config", strings.NewReader(`{"merge-cooldown": "1m", "read-query-threshold": 1000}`))
+	resp = httptest.NewRecorder()
+	scheduler.ServeHTTP(resp, req)
+	re.Equal(http.StatusOK, resp.Code)
+	re.Equal(time.Minute, conf.clone().MergeCooldown.Duration)
+	re.Equal(uint64(1000), conf.clone().ReadQueryThreshold)
+}
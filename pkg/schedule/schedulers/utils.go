@@ -15,6 +15,7 @@
 package schedulers
 
 import (
+	"math"
 	"net/url"
 	"strconv"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/utils"
 )
 
 const (
@@ -169,19 +171,51 @@ func (p *solver) shouldBalance(scheduleName string) bool {
 }
 
 func (p *solver) getTolerantResource() int64 {
-	if p.tolerantSource > 0 {
-		return p.tolerantSource
+	if p.tolerantSource == 0 {
+		switch {
+		case (p.kind.Resource == constant.LeaderKind || p.kind.Resource == constant.WitnessKind) && p.kind.Policy == constant.ByCount:
+			p.tolerantSource = int64(p.tolerantSizeRatio)
+		case p.kind.Resource == constant.LeaderKind && p.kind.Policy == constant.ByLoad:
+			p.tolerantSource = int64(math.Ceil(p.getAverageLeaderQueryRate() * p.tolerantSizeRatio))
+		default:
+			regionSize := p.GetAverageRegionSize()
+			p.tolerantSource = int64(float64(regionSize) * p.tolerantSizeRatio)
+		}
 	}
-
-	if (p.kind.Resource == constant.LeaderKind || p.kind.Resource == constant.WitnessKind) && p.kind.Policy == constant.ByCount {
-		p.tolerantSource = int64(p.tolerantSizeRatio)
-	} else {
-		regionSize := p.GetAverageRegionSize()
-		p.tolerantSource = int64(float64(regionSize) * p.tolerantSizeRatio)
+	// The leaders are much more uneven in the query rate than in the size, so the
+	// tolerance is at least the load of the region to be moved, otherwise a hot
+	// leader may be transferred back and forth between two stores.
+	if p.kind.Policy == constant.ByLoad && p.Region != nil {
+		return max(p.tolerantSource, p.getRegionLeaderLoad())
 	}
 	return p.tolerantSource
 }
 
+// getRegionLeaderLoad returns the query rate of the region's leader rounded up to
+// an integer. The write queries come from the region heartbeats, and the read
+// queries come from the hot peer cache, which is fed by the store heartbeats.
+func (p *solver) getRegionLeaderLoad() int64 {
+	queryRate := p.Region.GetWriteQueryRate()
+	if stat := p.GetHotPeerStat(utils.Read, p.Region.GetID(), p.Region.GetLeader().GetStoreId()); stat != nil {
+		queryRate += stat.GetLoad(utils.QueryDim)
+	}
+	return int64(math.Ceil(queryRate))
+}
+
+// getAverageLeaderQueryRate returns the average query rate of the leaders.
+func (p *solver) getAverageLeaderQueryRate() float64 {
+	var queryRate float64
+	var leaderCount int
+	for _, store := range p.GetStores() {
+		queryRate += store.GetLeaderQueryRate()
+		leaderCount += store.GetLeaderCount()
+	}
+	if leaderCount == 0 {
+		return 0
+	}
+	return queryRate / float64(leaderCount)
+}
+
 func adjustTolerantRatio(cluster sche.SchedulerCluster, kind constant.ScheduleKind) float64 {
 	var tolerantSizeRatio float64
 	switch c := cluster.(type) {
@@ -64,6 +64,8 @@ const (
 	ShuffleLeaderScheduler CheckerSchedulerType = "shuffle-leader-scheduler"
 	// ShuffleRegionScheduler is shuffle region scheduler name.
 	ShuffleRegionScheduler CheckerSchedulerType = "shuffle-region-scheduler"
+	// ShuffleChaosScheduler is shuffle chaos scheduler name.
+	ShuffleChaosScheduler CheckerSchedulerType = "shuffle-chaos-scheduler"
 	// SplitBucketScheduler is the split bucket name.
 	SplitBucketScheduler CheckerSchedulerType = "split-bucket-scheduler"
 	// TransferWitnessLeaderScheduler is transfer witness leader scheduler name.
@@ -72,6 +74,14 @@ const (
 	LabelScheduler CheckerSchedulerType = "label-scheduler"
 	// BalanceRangeScheduler is balance key range scheduler name.
 	BalanceRangeScheduler CheckerSchedulerType = "balance-range-scheduler"
+	// GlobalBalanceScheduler is global balance scheduler name.
+	GlobalBalanceScheduler CheckerSchedulerType = "global-balance-scheduler"
+	// BalanceCPUScheduler is balance cpu scheduler name.
+	BalanceCPUScheduler CheckerSchedulerType = "balance-cpu-scheduler"
+	// ColdRegionMergeScheduler is cold region merge scheduler name.
+	ColdRegionMergeScheduler CheckerSchedulerType = "cold-region-merge-scheduler"
+	// ExternalScheduler is the name of the scheduler which proposes operators out of PD.
+	ExternalScheduler CheckerSchedulerType = "external-scheduler"
 )
 
 // TODO: SchedulerTypeCompatibleMap and ConvertOldStrToType should be removed after
@@ -96,10 +106,15 @@ var (
 		ShuffleHotRegionScheduler:      "shuffle-hot-region",
 		ShuffleLeaderScheduler:         "shuffle-leader",
 		ShuffleRegionScheduler:         "shuffle-region",
+		ShuffleChaosScheduler:          "shuffle-chaos",
 		SplitBucketScheduler:           "split-bucket",
 		TransferWitnessLeaderScheduler: "transfer-witness-leader",
 		LabelScheduler:                 "label",
 		BalanceRangeScheduler:          "balance-range",
+		GlobalBalanceScheduler:         "global-balance",
+		BalanceCPUScheduler:            "balance-cpu",
+		ColdRegionMergeScheduler:       "cold-region-merge",
+		ExternalScheduler:              "external",
 	}
 
 	// ConvertOldStrToType exists for compatibility.
@@ -120,10 +135,15 @@ var (
 		"shuffle-hot-region":      ShuffleHotRegionScheduler,
 		"shuffle-leader":          ShuffleLeaderScheduler,
 		"shuffle-region":          ShuffleRegionScheduler,
+		"shuffle-chaos":           ShuffleChaosScheduler,
 		"split-bucket":            SplitBucketScheduler,
 		"transfer-witness-leader": TransferWitnessLeaderScheduler,
 		"label":                   LabelScheduler,
 		"balance-range":           BalanceRangeScheduler,
+		"global-balance":          GlobalBalanceScheduler,
+		"balance-cpu":             BalanceCPUScheduler,
+		"cold-region-merge":       ColdRegionMergeScheduler,
+		"external":                ExternalScheduler,
 	}
 
 	// StringToSchedulerType is a map to convert the scheduler string to the CheckerSchedulerType.
@@ -144,10 +164,15 @@ var (
 		"shuffle-hot-region-scheduler":      ShuffleHotRegionScheduler,
 		"shuffle-leader-scheduler":          ShuffleLeaderScheduler,
 		"shuffle-region-scheduler":          ShuffleRegionScheduler,
+		"shuffle-chaos-scheduler":           ShuffleChaosScheduler,
 		"split-bucket-scheduler":            SplitBucketScheduler,
 		"transfer-witness-leader-scheduler": TransferWitnessLeaderScheduler,
 		"label-scheduler":                   LabelScheduler,
 		"balance-range-scheduler":           BalanceRangeScheduler,
+		"global-balance-scheduler":          GlobalBalanceScheduler,
+		"balance-cpu-scheduler":             BalanceCPUScheduler,
+		"cold-region-merge-scheduler":       ColdRegionMergeScheduler,
+		"external-scheduler":                ExternalScheduler,
 	}
 
 	// DefaultSchedulers is the default scheduler types.
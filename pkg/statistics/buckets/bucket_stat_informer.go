@@ -187,9 +187,10 @@ func (b *BucketTreeItem) inherit(origins []*BucketTreeItem) {
 
 func (b *BucketTreeItem) calculateHotDegree() {
 	for _, stat := range b.stats {
-		// TODO: qps should be considered, tikv will report this in next sprint
+		// TODO: write qps should be considered.
 		// the order: read [bytes keys qps] and write[bytes keys qps]
-		readLoads := stat.Loads[:2]
+		// read qps is considered for the point get workloads which read few bytes and keys.
+		readLoads := stat.Loads[:3]
 		// keep same with the hot region hot degree
 		// https://github.com/tikv/pd/blob/6f6f545a6716840f7e2c7f4d8ed9b49f613a5cd8/pkg/statistics/hot_peer_cache.go#L220-L222
 		readHot := slice.AnyOf(readLoads, func(i int) bool {
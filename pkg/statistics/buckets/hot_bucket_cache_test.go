@@ -243,6 +243,11 @@ func TestCalculateHotDegree(t *testing.T) {
 	origin.stats[0].Loads = []uint64{0, 0, 0, minHotThresholds[3] + 1, minHotThresholds[4] + 1, 0}
 	origin.calculateHotDegree()
 	re.Equal(1, origin.stats[0].HotDegree)
+
+	// case3: the read query will be hot
+	origin.stats[0].Loads = []uint64{0, 0, minHotThresholds[2] + 1, 0, 0, 0}
+	origin.calculateHotDegree()
+	re.Equal(2, origin.stats[0].HotDegree)
 }
 
 func newTestBuckets(regionID uint64, version uint64, keys [][]byte, flow uint64) *metapb.Buckets {
@@ -15,19 +15,59 @@
 package statistics
 
 import (
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
 // SlowStat contains cluster's slow nodes' statistics.
 type SlowStat struct {
 	*SlowStoresStats
+	opt config.SchedulerConfigProvider
+
+	mu           syncutil.Mutex
+	detectorName string
+	detector     SlowStoreDetector
 }
 
 // NewSlowStat creates the container to hold slow nodes' statistics.
-func NewSlowStat() *SlowStat {
+func NewSlowStat(opt config.SchedulerConfigProvider) *SlowStat {
 	return &SlowStat{
 		SlowStoresStats: NewSlowStoresStats(),
+		opt:             opt,
+	}
+}
+
+// ObserveSlowStore detects whether the store is slow with the configured
+// detector and updates its status.
+func (s *SlowStat) ObserveSlowStore(store *core.StoreInfo) {
+	s.ObserveSlowStoreStatus(store.GetID(), s.getDetector().Observe(store))
+}
+
+// RemoveSlowStoreStatus removes the status and the records of the store.
+func (s *SlowStat) RemoveSlowStoreStatus(storeID uint64) {
+	s.SlowStoresStats.RemoveSlowStoreStatus(storeID)
+	s.getDetector().Remove(storeID)
+}
+
+// getDetector returns the configured detector, the records of the previous
+// detector are dropped if the detector is changed.
+func (s *SlowStat) getDetector() SlowStoreDetector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := s.opt.GetSlowStoreDetector()
+	if s.detector == nil || s.detectorName != name {
+		s.detectorName = name
+		switch name {
+		case config.LatencyDetector:
+			s.detector = NewLatencyDetector(func(store *core.StoreInfo) string {
+				return s.opt.GetZoneCostMatrix().GetZone(store)
+			})
+		default:
+			s.detector = NewSlowScoreDetector()
+		}
 	}
+	return s.detector
 }
 
 // SlowStoresStats is a cached statistics for the slow store.
@@ -56,13 +96,21 @@ func (s *SlowStoresStats) ObserveSlowStoreStatus(storeID uint64, isSlow bool) {
 	defer s.Unlock()
 	// If the given store was slow, this store should be recorded. Otherwise,
 	// this store should be removed from the recording list.
-	if _, ok := s.slowStores[storeID]; !ok && isSlow {
+	if isSlow {
 		s.slowStores[storeID] = struct{}{}
 	} else {
 		delete(s.slowStores, storeID)
 	}
 }
 
+// IsSlowStore returns whether the store is slow.
+func (s *SlowStoresStats) IsSlowStore(storeID uint64) bool {
+	s.RLock()
+	defer s.RUnlock()
+	_, ok := s.slowStores[storeID]
+	return ok
+}
+
 // ExistsSlowStores returns whether there exists slow stores in this cluster.
 func (s *SlowStoresStats) ExistsSlowStores() bool {
 	s.RLock()
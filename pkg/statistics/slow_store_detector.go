@@ -0,0 +1,233 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// latencyWindowSize is the number of the latest heartbeats used to calculate
+	// the latency percentile of a store.
+	latencyWindowSize = 30
+	// latencyMinSamples is the minimum number of the heartbeats needed to
+	// calculate the latency percentile, which avoids evicting a store because of
+	// a few spikes.
+	latencyMinSamples = 10
+	latencyPercentile = 0.9
+	// latencySlowRatio is the ratio of the latency percentile of a slow store to
+	// the median one of the other stores in the same zone.
+	latencySlowRatio = 2.0
+	// minSlowLatency is the minimum latency in microseconds of a slow store, which
+	// avoids treating a store as slow in an idle cluster.
+	minSlowLatency = 10000
+	// latencyRefreshInterval is the interval to refresh the latency percentiles
+	// of the stores in each zone.
+	latencyRefreshInterval = 10 * time.Second
+)
+
+// SlowStoreDetector detects the slow stores by the store heartbeats.
+type SlowStoreDetector interface {
+	// Observe records the heartbeat of the store and returns whether it is slow.
+	Observe(store *core.StoreInfo) bool
+	// Remove removes the records of the store.
+	Remove(storeID uint64)
+}
+
+// slowScoreDetector detects the slow stores by the slow score reported by TiKV.
+type slowScoreDetector struct{}
+
+// NewSlowScoreDetector creates a detector based on the slow score.
+func NewSlowScoreDetector() SlowStoreDetector {
+	return slowScoreDetector{}
+}
+
+// Observe implements the SlowStoreDetector interface.
+func (slowScoreDetector) Observe(store *core.StoreInfo) bool {
+	return store.IsSlow()
+}
+
+// Remove implements the SlowStoreDetector interface.
+func (slowScoreDetector) Remove(uint64) {}
+
+// storeLatencies records the latest latencies of the operations of a store.
+type storeLatencies struct {
+	zone string
+	// lastReport is the end timestamp of the last recorded heartbeat, which
+	// avoids recording a heartbeat twice.
+	lastReport uint64
+	latencies  map[string][]uint64
+	// percentiles caches the latency percentile of each operation, which is
+	// updated when a heartbeat is recorded.
+	percentiles map[string]uint64
+}
+
+func (l *storeLatencies) record(stats *pdpb.StoreStats) {
+	report := stats.GetInterval().GetEndTimestamp()
+	if report != 0 && report <= l.lastReport {
+		return
+	}
+	l.lastReport = report
+	for _, pair := range stats.GetOpLatencies() {
+		latencies := append(l.latencies[pair.GetKey()], pair.GetValue())
+		if len(latencies) > latencyWindowSize {
+			latencies = latencies[len(latencies)-latencyWindowSize:]
+		}
+		l.latencies[pair.GetKey()] = latencies
+		if len(latencies) < latencyMinSamples {
+			continue
+		}
+		sorted := slices.Clone(latencies)
+		slices.Sort(sorted)
+		l.percentiles[pair.GetKey()] = sorted[int(float64(len(sorted)-1)*latencyPercentile)]
+	}
+}
+
+type zoneOp struct {
+	zone string
+	op   string
+}
+
+// latencySnapshot is the latency percentiles of a store used in the last refresh.
+type latencySnapshot struct {
+	zone        string
+	percentiles map[string]uint64
+}
+
+// latencyDetector detects the slow stores by the operation latencies, such as
+// apply and commit durations, reported in the store heartbeats. A store is slow
+// if the latency percentile of any operation is much higher than the median one
+// of the other stores in the same zone, which share the same kind of disks.
+//
+// The sorted percentiles of each zone are refreshed once per refreshInterval
+// rather than on every heartbeat, so a heartbeat only costs a binary search.
+type latencyDetector struct {
+	syncutil.RWMutex
+	getZone         func(*core.StoreInfo) string
+	stores          map[uint64]*storeLatencies
+	refreshInterval time.Duration
+	lastRefresh     time.Time
+	zoneLatencies   map[zoneOp][]uint64
+	snapshots       map[uint64]latencySnapshot
+}
+
+// NewLatencyDetector creates a detector based on the operation latencies.
+func NewLatencyDetector(getZone func(*core.StoreInfo) string) SlowStoreDetector {
+	return &latencyDetector{
+		getZone:         getZone,
+		stores:          make(map[uint64]*storeLatencies),
+		refreshInterval: latencyRefreshInterval,
+	}
+}
+
+// Observe implements the SlowStoreDetector interface.
+func (d *latencyDetector) Observe(store *core.StoreInfo) bool {
+	d.Lock()
+	defer d.Unlock()
+	l, ok := d.stores[store.GetID()]
+	if !ok {
+		l = &storeLatencies{
+			latencies:   make(map[string][]uint64),
+			percentiles: make(map[string]uint64),
+		}
+		d.stores[store.GetID()] = l
+	}
+	l.zone = d.getZone(store)
+	l.record(store.GetStoreStats())
+	d.refreshLocked(time.Now())
+	return d.isSlowLocked(store.GetID(), l)
+}
+
+// refreshLocked rebuilds the sorted percentiles of each zone if they are
+// older than the refresh interval.
+func (d *latencyDetector) refreshLocked(now time.Time) {
+	interval := d.refreshInterval
+	failpoint.Inject("refreshLatenciesOnHeartbeat", func() {
+		interval = 0
+	})
+	if now.Sub(d.lastRefresh) < interval {
+		return
+	}
+	d.lastRefresh = now
+	d.zoneLatencies = make(map[zoneOp][]uint64)
+	d.snapshots = make(map[uint64]latencySnapshot, len(d.stores))
+	for id, l := range d.stores {
+		for op, latency := range l.percentiles {
+			key := zoneOp{zone: l.zone, op: op}
+			d.zoneLatencies[key] = append(d.zoneLatencies[key], latency)
+		}
+		d.snapshots[id] = latencySnapshot{zone: l.zone, percentiles: maps.Clone(l.percentiles)}
+	}
+	for _, latencies := range d.zoneLatencies {
+		slices.Sort(latencies)
+	}
+}
+
+// othersMedianLocked returns the median latency percentile of the operation
+// of the other stores in the zone in the last refresh.
+func (d *latencyDetector) othersMedianLocked(storeID uint64, zone, op string) (uint64, bool) {
+	sorted := d.zoneLatencies[zoneOp{zone: zone, op: op}]
+	self := -1
+	if snapshot, ok := d.snapshots[storeID]; ok && snapshot.zone == zone {
+		if latency, ok := snapshot.percentiles[op]; ok {
+			self, _ = slices.BinarySearch(sorted, latency)
+		}
+	}
+	if self < 0 {
+		if len(sorted) == 0 {
+			return 0, false
+		}
+		return sorted[len(sorted)/2], true
+	}
+	if len(sorted) <= 1 {
+		return 0, false
+	}
+	// Skip the store itself in the sorted percentiles.
+	median := (len(sorted) - 1) / 2
+	if median >= self {
+		median++
+	}
+	return sorted[median], true
+}
+
+func (d *latencyDetector) isSlowLocked(storeID uint64, l *storeLatencies) bool {
+	for op, latency := range l.percentiles {
+		if latency < minSlowLatency {
+			continue
+		}
+		median, ok := d.othersMedianLocked(storeID, l.zone, op)
+		if ok && float64(latency) >= latencySlowRatio*float64(median) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove implements the SlowStoreDetector interface.
+func (d *latencyDetector) Remove(storeID uint64) {
+	d.Lock()
+	defer d.Unlock()
+	delete(d.stores, storeID)
+	// Drop the percentiles of the removed store in the next heartbeat.
+	d.lastRefresh = time.Time{}
+}
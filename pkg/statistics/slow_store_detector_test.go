@@ -0,0 +1,152 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+)
+
+func newLatencyStore(id uint64, zone string, report uint64, apply uint64) *core.StoreInfo {
+	store := core.NewStoreInfo(&metapb.Store{Id: id, Labels: []*metapb.StoreLabel{{Key: "zone", Value: zone}}})
+	return store.Clone(core.SetStoreStats(&pdpb.StoreStats{
+		StoreId:     id,
+		Interval:    &pdpb.TimeInterval{StartTimestamp: report - 10, EndTimestamp: report},
+		OpLatencies: []*pdpb.RecordPair{{Key: "apply", Value: apply}},
+	}))
+}
+
+func TestLatencyDetector(t *testing.T) {
+	re := require.New(t)
+	d := NewLatencyDetector(func(store *core.StoreInfo) string { return store.GetLabelValue("zone") })
+	// Refresh the percentiles of the zones on every heartbeat.
+	d.(*latencyDetector).refreshInterval = 0
+	observe := func(report uint64, latencies map[uint64]uint64, zones map[uint64]string) map[uint64]bool {
+		for id, latency := range latencies {
+			d.Observe(newLatencyStore(id, zones[id], report, latency))
+		}
+		// The heartbeat is recorded once, observe again to compare with the
+		// latest heartbeats of the other stores.
+		res := make(map[uint64]bool)
+		for id, latency := range latencies {
+			res[id] = d.Observe(newLatencyStore(id, zones[id], report, latency))
+		}
+		return res
+	}
+	zones := map[uint64]string{1: "z1", 2: "z1", 3: "z1", 4: "z2"}
+
+	// It is not slow before there are enough samples.
+	for i := range uint64(latencyMinSamples - 1) {
+		res := observe(10*(i+1), map[uint64]uint64{1: 50000, 2: 10000, 3: 12000, 4: 10000}, zones)
+		re.False(res[1])
+	}
+	// The same heartbeat is not recorded twice.
+	re.False(d.Observe(newLatencyStore(1, "z1", 10*(latencyMinSamples-1), 50000)))
+	res := observe(10*latencyMinSamples, map[uint64]uint64{1: 50000, 2: 10000, 3: 12000, 4: 10000}, zones)
+	re.True(res[1])
+	re.False(res[2])
+	re.False(res[3])
+	// Store 4 has no other store to compare with in z2.
+	re.False(res[4])
+
+	// A few spikes don't make a store slow.
+	for i := range uint64(latencyWindowSize) {
+		latency := uint64(10000)
+		if i%10 == 0 {
+			latency = 100000
+		}
+		res = observe(1000+10*i, map[uint64]uint64{1: 10000, 2: latency, 3: 12000}, zones)
+	}
+	re.False(res[1])
+	re.False(res[2])
+
+	// It is not slow if the latency is too small.
+	for i := range uint64(latencyWindowSize) {
+		res = observe(2000+10*i, map[uint64]uint64{1: 8000, 2: 1000, 3: 1000}, zones)
+	}
+	re.False(res[1])
+
+	// The records are removed with the store.
+	d.Remove(1)
+	re.False(d.Observe(newLatencyStore(1, "z1", 5000, 50000)))
+}
+
+func TestLatencyDetectorRefresh(t *testing.T) {
+	re := require.New(t)
+	d := NewLatencyDetector(func(store *core.StoreInfo) string { return store.GetLabelValue("zone") }).(*latencyDetector)
+	d.refreshInterval = time.Hour
+	latencies := map[uint64]uint64{1: 50000, 2: 10000, 3: 12000}
+	for i := range uint64(latencyMinSamples) {
+		for id, latency := range latencies {
+			re.False(d.Observe(newLatencyStore(id, "z1", 10*(i+1), latency)))
+		}
+	}
+	// The percentiles of the zone are not refreshed within the interval.
+	re.Empty(d.zoneLatencies)
+	re.False(d.Observe(newLatencyStore(1, "z1", 1000, 50000)))
+
+	d.lastRefresh = time.Now().Add(-time.Hour)
+	re.True(d.Observe(newLatencyStore(1, "z1", 1010, 50000)))
+	re.Len(d.zoneLatencies[zoneOp{zone: "z1", op: "apply"}], 3)
+	re.False(d.Observe(newLatencyStore(2, "z1", 1010, 10000)))
+
+	// The removed store is dropped in the next refresh.
+	d.Remove(2)
+	d.Remove(3)
+	re.False(d.Observe(newLatencyStore(1, "z1", 1020, 50000)))
+	re.Len(d.zoneLatencies[zoneOp{zone: "z1", op: "apply"}], 1)
+}
+
+func TestSlowStatDetector(t *testing.T) {
+	re := require.New(t)
+	opt := mockconfig.NewTestOptions()
+	s := NewSlowStat(opt)
+
+	// The slow score detector is used by default.
+	store := newLatencyStore(1, "z1", 10, 50000)
+	store.GetStoreStats().SlowScore = 100
+	s.ObserveSlowStore(store)
+	re.True(s.IsSlowStore(1))
+	re.True(s.ExistsSlowStores())
+	s.ObserveSlowStore(store)
+	re.True(s.IsSlowStore(1))
+	store.GetStoreStats().SlowScore = 1
+	s.ObserveSlowStore(store)
+	re.False(s.IsSlowStore(1))
+
+	// Switch to the latency detector.
+	cfg := opt.GetScheduleConfig().Clone()
+	cfg.SlowStoreDetector = sc.LatencyDetector
+	opt.SetScheduleConfig(cfg)
+	opt.SetLocationLabels([]string{"zone"})
+	s.getDetector().(*latencyDetector).refreshInterval = 0
+	for i := range uint64(latencyMinSamples) {
+		s.ObserveSlowStore(newLatencyStore(2, "z1", 10*(i+1), 10000))
+		s.ObserveSlowStore(newLatencyStore(1, "z1", 10*(i+1), 50000))
+	}
+	re.True(s.IsSlowStore(1))
+	re.False(s.IsSlowStore(2))
+	s.RemoveSlowStoreStatus(1)
+	re.False(s.IsSlowStore(1))
+}
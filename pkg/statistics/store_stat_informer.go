@@ -17,4 +17,5 @@ package statistics
 // StoreStatInformer provides access to a shared informer of statistics.
 type StoreStatInformer interface {
 	GetStoresLoads() map[uint64][]float64
+	IsSlowStore(storeID uint64) bool
 }
@@ -21,6 +21,7 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
 	"github.com/tikv/pd/pkg/utils/keypath"
 )
 
@@ -34,6 +35,10 @@ type ConfigStorage interface {
 	LoadSchedulerConfig(schedulerName string) (string, error)
 	SaveSchedulerConfig(schedulerName string, data []byte) error
 	RemoveSchedulerConfig(schedulerName string) error
+	// The config and the scheduler configs can be changed together in a transaction.
+	SaveConfigInTxn(txn kv.Txn, cfg any) error
+	SaveSchedulerConfigInTxn(txn kv.Txn, schedulerName string, data []byte) error
+	RemoveSchedulerConfigInTxn(txn kv.Txn, schedulerName string) error
 }
 
 var _ ConfigStorage = (*StorageEndpoint)(nil)
@@ -80,3 +85,18 @@ func (se *StorageEndpoint) SaveSchedulerConfig(schedulerName string, data []byte
 func (se *StorageEndpoint) RemoveSchedulerConfig(schedulerName string) error {
 	return se.Remove(keypath.SchedulerConfigPath(schedulerName))
 }
+
+// SaveConfigInTxn stores marshallable cfg to the keypath.Config in the given transaction.
+func (*StorageEndpoint) SaveConfigInTxn(txn kv.Txn, cfg any) error {
+	return saveJSONInTxn(txn, keypath.ConfigPath(), cfg)
+}
+
+// SaveSchedulerConfigInTxn saves the config of the given scheduler in the given transaction.
+func (*StorageEndpoint) SaveSchedulerConfigInTxn(txn kv.Txn, schedulerName string, data []byte) error {
+	return txn.Save(keypath.SchedulerConfigPath(schedulerName), string(data))
+}
+
+// RemoveSchedulerConfigInTxn removes the config of the given scheduler in the given transaction.
+func (*StorageEndpoint) RemoveSchedulerConfigInTxn(txn kv.Txn, schedulerName string) error {
+	return txn.Remove(keypath.SchedulerConfigPath(schedulerName))
+}
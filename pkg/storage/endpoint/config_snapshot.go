@@ -0,0 +1,75 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/keypath"
+)
+
+// ConfigSnapshotStorage defines the storage operations on the versioned config snapshots.
+type ConfigSnapshotStorage interface {
+	LoadConfigSnapshot(version uint64) (string, error)
+	LoadConfigSnapshots(f func(k, v string)) error
+	LoadConfigSnapshotVersion() (uint64, error)
+	SaveConfigSnapshot(version uint64, snapshot any) error
+	DeleteConfigSnapshot(version uint64) error
+}
+
+var _ ConfigSnapshotStorage = (*StorageEndpoint)(nil)
+
+// LoadConfigSnapshot loads the config snapshot with the given version.
+func (se *StorageEndpoint) LoadConfigSnapshot(version uint64) (string, error) {
+	return se.Load(keypath.ConfigSnapshotPath(version))
+}
+
+// LoadConfigSnapshots loads all config snapshots in the order of version.
+func (se *StorageEndpoint) LoadConfigSnapshots(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.ConfigSnapshotPathPrefix(), f)
+}
+
+// LoadConfigSnapshotVersion loads the latest allocated version of the config snapshots,
+// which is kept even if the snapshot with this version is deleted.
+func (se *StorageEndpoint) LoadConfigSnapshotVersion() (uint64, error) {
+	value, err := se.Load(keypath.ConfigSnapshotVersionPath())
+	if err != nil || len(value) == 0 {
+		return 0, err
+	}
+	version, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errs.ErrStrconvParseUint.Wrap(err).GenWithStackByArgs()
+	}
+	return version, nil
+}
+
+// SaveConfigSnapshot stores the config snapshot with the given version, and records
+// the version as the latest allocated one in the same transaction.
+func (se *StorageEndpoint) SaveConfigSnapshot(version uint64, snapshot any) error {
+	return se.RunInTxn(context.Background(), func(txn kv.Txn) error {
+		if err := saveJSONInTxn(txn, keypath.ConfigSnapshotPath(version), snapshot); err != nil {
+			return err
+		}
+		return txn.Save(keypath.ConfigSnapshotVersionPath(), strconv.FormatUint(version, 10))
+	})
+}
+
+// DeleteConfigSnapshot removes the config snapshot with the given version.
+func (se *StorageEndpoint) DeleteConfigSnapshot(version uint64) error {
+	return se.Remove(keypath.ConfigSnapshotPath(version))
+}
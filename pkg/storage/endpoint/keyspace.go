@@ -45,6 +45,10 @@ type KeyspaceStorage interface {
 	LoadKeyspaceID(txn kv.Txn, name string) (bool, uint32, error)
 	// LoadRangeKeyspace loads no more than limit keyspaces starting at startID.
 	LoadRangeKeyspace(txn kv.Txn, startID uint32, limit int) ([]*keyspacepb.KeyspaceMeta, error)
+	SaveResourcePool(txn kv.Txn, name string, pool any) error
+	DeleteResourcePool(txn kv.Txn, name string) error
+	// LoadResourcePools loads all the resource pools, f is called with the JSON value of each pool.
+	LoadResourcePools(txn kv.Txn, f func(v string) error) error
 	RunInTxn(ctx context.Context, f func(txn kv.Txn) error) error
 }
 
@@ -122,3 +126,29 @@ func (*StorageEndpoint) LoadRangeKeyspace(txn kv.Txn, startID uint32, limit int)
 	}
 	return keyspaces, nil
 }
+
+// SaveResourcePool saves the resource pool to the path specified by its name.
+func (*StorageEndpoint) SaveResourcePool(txn kv.Txn, name string, pool any) error {
+	return saveJSONInTxn(txn, keypath.ResourcePoolPath(name), pool)
+}
+
+// DeleteResourcePool removes the resource pool specified by its name.
+func (*StorageEndpoint) DeleteResourcePool(txn kv.Txn, name string) error {
+	return txn.Remove(keypath.ResourcePoolPath(name))
+}
+
+// LoadResourcePools loads all the resource pools.
+func (*StorageEndpoint) LoadResourcePools(txn kv.Txn, f func(v string) error) error {
+	startKey := keypath.ResourcePoolPrefix()
+	endKey := clientv3.GetPrefixRangeEnd(startKey)
+	_, values, err := txn.LoadRange(startKey, endKey, 0)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if err := f(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
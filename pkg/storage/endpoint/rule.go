@@ -29,6 +29,9 @@ type RuleStorage interface {
 	LoadRules(f func(k, v string)) error
 	LoadRuleGroups(f func(k, v string)) error
 	LoadRegionRules(f func(k, v string)) error
+	LoadRegionRuleHistory(f func(k, v string)) error
+	LoadRuleTemplates(f func(k, v string)) error
+	LoadRuleTemplateInstances(f func(k, v string)) error
 
 	// We need to use txn to avoid concurrent modification.
 	// And it is helpful for the scheduling server to watch the rule.
@@ -38,6 +41,12 @@ type RuleStorage interface {
 	DeleteRuleGroup(txn kv.Txn, groupID string) error
 	SaveRegionRule(txn kv.Txn, ruleKey string, rule any) error
 	DeleteRegionRule(txn kv.Txn, ruleKey string) error
+	SaveRegionRuleHistory(txn kv.Txn, seq uint64, change any) error
+	DeleteRegionRuleHistory(txn kv.Txn, seq uint64) error
+	SaveRuleTemplate(txn kv.Txn, templateID string, template any) error
+	DeleteRuleTemplate(txn kv.Txn, templateID string) error
+	SaveRuleTemplateInstance(txn kv.Txn, groupID string, instance any) error
+	DeleteRuleTemplateInstance(txn kv.Txn, groupID string) error
 
 	RunInTxn(ctx context.Context, f func(txn kv.Txn) error) error
 }
@@ -84,6 +93,21 @@ func (*StorageEndpoint) DeleteRegionRule(txn kv.Txn, ruleKey string) error {
 	return txn.Remove(keypath.RegionLabelKeyPath(ruleKey))
 }
 
+// LoadRegionRuleHistory loads the change history of region rules from storage.
+func (se *StorageEndpoint) LoadRegionRuleHistory(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.RegionLabelHistoryPathPrefix(), f)
+}
+
+// SaveRegionRuleHistory saves a change of region rules to the storage.
+func (*StorageEndpoint) SaveRegionRuleHistory(txn kv.Txn, seq uint64, change any) error {
+	return saveJSONInTxn(txn, keypath.RegionLabelHistoryPath(seq), change)
+}
+
+// DeleteRegionRuleHistory removes a change of region rules from storage.
+func (*StorageEndpoint) DeleteRegionRuleHistory(txn kv.Txn, seq uint64) error {
+	return txn.Remove(keypath.RegionLabelHistoryPath(seq))
+}
+
 // LoadRule load a placement rule from storage.
 func (se *StorageEndpoint) LoadRule(ruleKey string) (string, error) {
 	return se.Load(keypath.RuleKeyPath(ruleKey))
@@ -93,3 +117,33 @@ func (se *StorageEndpoint) LoadRule(ruleKey string) (string, error) {
 func (se *StorageEndpoint) LoadRules(f func(k, v string)) error {
 	return se.loadRangeByPrefix(keypath.RulesPathPrefix(), f)
 }
+
+// LoadRuleTemplates loads placement rule templates from storage.
+func (se *StorageEndpoint) LoadRuleTemplates(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.RuleTemplatePathPrefix(), f)
+}
+
+// SaveRuleTemplate stores a placement rule template to storage.
+func (*StorageEndpoint) SaveRuleTemplate(txn kv.Txn, templateID string, template any) error {
+	return saveJSONInTxn(txn, keypath.RuleTemplatePath(templateID), template)
+}
+
+// DeleteRuleTemplate removes a placement rule template from storage.
+func (*StorageEndpoint) DeleteRuleTemplate(txn kv.Txn, templateID string) error {
+	return txn.Remove(keypath.RuleTemplatePath(templateID))
+}
+
+// LoadRuleTemplateInstances loads placement rule template instances from storage.
+func (se *StorageEndpoint) LoadRuleTemplateInstances(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.RuleTemplateInstancePathPrefix(), f)
+}
+
+// SaveRuleTemplateInstance stores a placement rule template instance to storage.
+func (*StorageEndpoint) SaveRuleTemplateInstance(txn kv.Txn, groupID string, instance any) error {
+	return saveJSONInTxn(txn, keypath.RuleTemplateInstancePath(groupID), instance)
+}
+
+// DeleteRuleTemplateInstance removes a placement rule template instance from storage.
+func (*StorageEndpoint) DeleteRuleTemplateInstance(txn kv.Txn, groupID string) error {
+	return txn.Remove(keypath.RuleTemplateInstancePath(groupID))
+}
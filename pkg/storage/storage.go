@@ -36,6 +36,7 @@ type Storage interface {
 	kv.Base
 	endpoint.ServiceMiddlewareStorage
 	endpoint.ConfigStorage
+	endpoint.ConfigSnapshotStorage
 	endpoint.MetaStorage
 	endpoint.RuleStorage
 	endpoint.ReplicationStatusStorage
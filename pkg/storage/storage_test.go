@@ -129,6 +129,43 @@ func TestStoreWeight(t *testing.T) {
 	}
 }
 
+func TestConfigSnapshot(t *testing.T) {
+	re := require.New(t)
+	storage := NewStorageWithMemoryBackend()
+
+	// The versions are loaded in the numeric order rather than the lexical order.
+	versions := []uint64{10, 2, 1}
+	for _, version := range versions {
+		re.NoError(storage.SaveConfigSnapshot(version, map[string]uint64{"version": version}))
+	}
+	value, err := storage.LoadConfigSnapshot(2)
+	re.NoError(err)
+	re.JSONEq(`{"version":2}`, value)
+	value, err = storage.LoadConfigSnapshot(3)
+	re.NoError(err)
+	re.Empty(value)
+
+	var loaded []string
+	re.NoError(storage.LoadConfigSnapshots(func(_, v string) {
+		loaded = append(loaded, v)
+	}))
+	re.Equal([]string{`{"version":1}`, `{"version":2}`, `{"version":10}`}, loaded)
+
+	re.NoError(storage.DeleteConfigSnapshot(2))
+	loaded = loaded[:0]
+	re.NoError(storage.LoadConfigSnapshots(func(_, v string) {
+		loaded = append(loaded, v)
+	}))
+	re.Equal([]string{`{"version":1}`, `{"version":10}`}, loaded)
+
+	// The latest saved version is kept after the snapshot is deleted.
+	re.NoError(storage.SaveConfigSnapshot(11, map[string]uint64{"version": 11}))
+	re.NoError(storage.DeleteConfigSnapshot(11))
+	version, err := storage.LoadConfigSnapshotVersion()
+	re.NoError(err)
+	re.Equal(uint64(11), version)
+}
+
 func TestLoadGCSafePoint(t *testing.T) {
 	re := require.New(t)
 	storage := NewStorageWithMemoryBackend()
@@ -41,6 +41,8 @@ const (
 	schedulerConfigPathFormat   = "/pd/%d/scheduler_config/%s"                // "/pd/{cluster_id}/scheduler_config/{scheduler_name}"
 	storeLeaderWeightPathFormat = "/pd/%d/schedule/store_weight/%020d/leader" // "/pd/{cluster_id}/schedule/store_weight/{store_id}/leader"
 	storeRegionWeightPathFormat = "/pd/%d/schedule/store_weight/%020d/region" // "/pd/{cluster_id}/schedule/store_weight/{store_id}/region"
+	configSnapshotPathFormat    = "/pd/%d/schedule/config_snapshot/%s"        // "/pd/{cluster_id}/schedule/config_snapshot/{version}"
+	configSnapshotVersionFormat = "/pd/%d/schedule/config_snapshot_version"   // "/pd/{cluster_id}/schedule/config_snapshot_version"
 
 	serviceMiddlewarePathFormat = "/pd/%d/service_middleware"                  // "/pd/{cluster_id}/service_middleware"
 	replicationModePathFormat   = "/pd/%d/replication_mode/%s"                 // "/pd/{cluster_id}/replication_mode/{mode}"
@@ -57,6 +59,12 @@ const (
 	ruleGroupPathFormat     = "/pd/%d/rule_group/%s"   // "/pd/{cluster_id}/rule_group/{group_id}"
 	regionLablePathFormat   = "/pd/%d/region_label/%s" // "/pd/{cluster_id}/region_label/{label_id}"
 	regionLabelPrefixFormat = "/pd/%d/region_label/"   // "/pd/{cluster_id}/region_label/"
+	// "%020d" adds extra padding to make the sequence of the history ordered.
+	regionLabelHistoryPathFormat   = "/pd/%d/region_label_history/%020d" // "/pd/{cluster_id}/region_label_history/{seq}"
+	regionLabelHistoryPrefixFormat = "/pd/%d/region_label_history/"      // "/pd/{cluster_id}/region_label_history/"
+	// The rule templates are not under ruleCommonPrefixFormat because only the rendered rules are watched.
+	ruleTemplatePathFormat         = "/pd/%d/placement_template/%s"          // "/pd/{cluster_id}/placement_template/{template_id}"
+	ruleTemplateInstancePathFormat = "/pd/%d/placement_template_instance/%s" // "/pd/{cluster_id}/placement_template_instance/{group_id}"
 
 	// "%08d" adds extra padding to make encoded ID ordered.
 	// Encoded ID can be decoded directly with strconv.ParseUint. Width of the
@@ -87,6 +95,8 @@ const (
 	keyspaceMetaPrefixFormat    = "/pd/%d/keyspaces/meta/"                     // "/pd/{cluster_id}/keyspaces/meta/"
 	keyspaceMetaPathFormat      = "/pd/%d/keyspaces/meta/%08d"                 // "/pd/{cluster_id}/keyspaces/meta/{keyspace_id}"
 	keyspaceIDPathFormat        = "/pd/%d/keyspaces/id/%s"                     // "/pd/{cluster_id}/keyspaces/id/{keyspace_name}"
+	resourcePoolPrefixFormat    = "/pd/%d/keyspaces/resource_pool/"            // "/pd/{cluster_id}/keyspaces/resource_pool/"
+	resourcePoolPathFormat      = "/pd/%d/keyspaces/resource_pool/%s"          // "/pd/{cluster_id}/keyspaces/resource_pool/{pool_name}"
 	keyspaceGroupIDPrefixFormat = "/pd/%d/tso/keyspace_groups/membership/"     // "/pd/{cluster_id}/tso/keyspace_groups/membership/"
 	keyspaceGroupIDPathFormat   = "/pd/%d/tso/keyspace_groups/membership/%05d" // "/pd/{cluster_id}/tso/keyspace_groups/membership/{group_id}"
 	keyspaceGroupIDPattern      = `tso/keyspace_groups/membership/(\d{5})$`
@@ -202,6 +212,16 @@ func KeyspaceIDPath(name string) string {
 	return fmt.Sprintf(keyspaceIDPathFormat, ClusterID(), name)
 }
 
+// ResourcePoolPrefix returns the prefix of the keyspace resource pools.
+func ResourcePoolPrefix() string {
+	return fmt.Sprintf(resourcePoolPrefixFormat, ClusterID())
+}
+
+// ResourcePoolPath returns the path to the keyspace resource pool with the given name.
+func ResourcePoolPath(name string) string {
+	return fmt.Sprintf(resourcePoolPathFormat, ClusterID(), name)
+}
+
 // KeyspaceGroupIDPrefix returns the prefix of keyspace group id.
 func KeyspaceGroupIDPrefix() string {
 	return fmt.Sprintf(keyspaceGroupIDPrefixFormat, ClusterID())
@@ -30,3 +30,18 @@ func SchedulerConfigPathPrefix() string {
 func SchedulerConfigPath(schedulerName string) string {
 	return fmt.Sprintf(schedulerConfigPathFormat, ClusterID(), schedulerName)
 }
+
+// ConfigSnapshotPathPrefix returns the path prefix to save the config snapshots.
+func ConfigSnapshotPathPrefix() string {
+	return fmt.Sprintf(configSnapshotPathFormat, ClusterID(), "")
+}
+
+// ConfigSnapshotPath returns the path to save the config snapshot with the given version.
+func ConfigSnapshotPath(version uint64) string {
+	return fmt.Sprintf(configSnapshotPathFormat, ClusterID(), fmt.Sprintf("%020d", version))
+}
+
+// ConfigSnapshotVersionPath returns the path to save the latest allocated version of the config snapshots.
+func ConfigSnapshotVersionPath() string {
+	return fmt.Sprintf(configSnapshotVersionFormat, ClusterID())
+}
@@ -52,3 +52,33 @@ func RuleGroupPathPrefix() string {
 func RegionLabelPathPrefix() string {
 	return RegionLabelKeyPath("")
 }
+
+// RegionLabelHistoryPath returns the path to save the change history of the region label rules with the given sequence.
+func RegionLabelHistoryPath(seq uint64) string {
+	return fmt.Sprintf(regionLabelHistoryPathFormat, ClusterID(), seq)
+}
+
+// RegionLabelHistoryPathPrefix returns the path prefix to save the change history of the region label rules.
+func RegionLabelHistoryPathPrefix() string {
+	return fmt.Sprintf(regionLabelHistoryPrefixFormat, ClusterID())
+}
+
+// RuleTemplatePath returns the path to save the placement rule template with the given template ID.
+func RuleTemplatePath(templateID string) string {
+	return fmt.Sprintf(ruleTemplatePathFormat, ClusterID(), templateID)
+}
+
+// RuleTemplatePathPrefix returns the path prefix to save the placement rule templates.
+func RuleTemplatePathPrefix() string {
+	return RuleTemplatePath("")
+}
+
+// RuleTemplateInstancePath returns the path to save the placement rule template instance with the given group ID.
+func RuleTemplateInstancePath(groupID string) string {
+	return fmt.Sprintf(ruleTemplateInstancePathFormat, ClusterID(), groupID)
+}
+
+// RuleTemplateInstancePathPrefix returns the path prefix to save the placement rule template instances.
+func RuleTemplateInstancePathPrefix() string {
+	return RuleTemplateInstancePath("")
+}
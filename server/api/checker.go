@@ -16,6 +16,7 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
@@ -74,6 +75,32 @@ func (c *checkerHandler) PauseOrResumeChecker(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// @Tags     checker
+// @Summary  Get the regions at risk in the order of repairs, the region with the highest data-loss risk is the first.
+// @Param    limit  query  integer  false  "Limit count"
+// @Produce  json
+// @Success  200  {array}   checker.RegionRisk
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /checker/repair-queue [get]
+func (c *checkerHandler) GetRepairQueue(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			c.r.JSON(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	queue, err := c.Handler.GetRepairQueue(limit)
+	if err != nil {
+		c.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.r.JSON(w, http.StatusOK, queue)
+}
+
 // FIXME: details of input json body params
 // @Tags     checker
 // @Summary  Get if checker is paused
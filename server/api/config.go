@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
 
 	"github.com/pingcap/errcode"
@@ -406,6 +407,61 @@ func (h *confHandler) SetScheduleConfig(w http.ResponseWriter, r *http.Request)
 	h.rd.JSON(w, http.StatusOK, "The config is updated.")
 }
 
+// @Tags     config
+// @Summary  Get the time windows of all schedulers.
+// @Produce  json
+// @Success  200  {object}  map[string]sc.TimeWindows
+// @Router   /config/scheduler-time-windows [get]
+func (h *confHandler) GetSchedulerTimeWindows(w http.ResponseWriter, _ *http.Request) {
+	h.rd.JSON(w, http.StatusOK, h.svr.GetHandler().GetSchedulerTimeWindows())
+}
+
+// @Tags     config
+// @Summary  Set the time windows of a scheduler, which is paused outside its time windows.
+// @Accept   json
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Param    body  body  sc.TimeWindows  true  "The time windows, such as [{"cron": "0 1 * * *", "duration": "4h"}]."
+// @Produce  json
+// @Success  200  {string}  string  "The time windows are updated."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scheduler is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/scheduler-time-windows/{name} [post]
+func (h *confHandler) SetSchedulerTimeWindows(w http.ResponseWriter, r *http.Request) {
+	var windows sc.TimeWindows
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &windows); err != nil {
+		return
+	}
+	if err := windows.Validate(); err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.setSchedulerTimeWindows(w, mux.Vars(r)["name"], windows)
+}
+
+// @Tags     config
+// @Summary  Remove the time windows of a scheduler.
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Produce  json
+// @Success  200  {string}  string  "The time windows are updated."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/scheduler-time-windows/{name} [delete]
+func (h *confHandler) DeleteSchedulerTimeWindows(w http.ResponseWriter, r *http.Request) {
+	h.setSchedulerTimeWindows(w, mux.Vars(r)["name"], nil)
+}
+
+func (h *confHandler) setSchedulerTimeWindows(w http.ResponseWriter, name string, windows sc.TimeWindows) {
+	if err := h.svr.GetHandler().SetSchedulerTimeWindows(name, windows); err != nil {
+		if errors.ErrorEqual(err, errs.ErrSchedulerNotFound.FastGenByArgs()) {
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The time windows are updated.")
+}
+
 // @Tags     config
 // @Summary  Get replication config.
 // @Produce  json
@@ -0,0 +1,194 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/errs"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/utils/apiutil"
+	"github.com/tikv/pd/server"
+)
+
+const currentConfigSnapshot = "current"
+
+type configSnapshotHandler struct {
+	*server.Handler
+	rd *render.Render
+}
+
+func newConfigSnapshotHandler(svr *server.Server, rd *render.Render) *configSnapshotHandler {
+	return &configSnapshotHandler{
+		Handler: svr.GetHandler(),
+		rd:      rd,
+	}
+}
+
+// @Tags     config
+// @Summary  List all saved config snapshots.
+// @Produce  json
+// @Success  200  {array}   sc.Snapshot
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/snapshots [get]
+func (h *configSnapshotHandler) GetConfigSnapshots(w http.ResponseWriter, _ *http.Request) {
+	snapshots, err := h.Handler.GetConfigSnapshots()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, snapshots)
+}
+
+// @Tags     config
+// @Summary  Save the current scheduling configurations as a new config snapshot.
+// @Accept   json
+// @Param    body  body  object  false  "json params, such as {"comment": "before upgrade"}"
+// @Produce  json
+// @Success  200  {object}  sc.Snapshot
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/snapshots [post]
+func (h *configSnapshotHandler) SaveConfigSnapshot(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Comment string `json:"comment"`
+	}
+	if r.ContentLength != 0 {
+		if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &input); err != nil {
+			return
+		}
+	}
+	snapshot, err := h.Handler.SaveConfigSnapshot(input.Comment)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, snapshot)
+}
+
+// @Tags     config
+// @Summary  Get a config snapshot.
+// @Param    version  path  string  true  "The version of the snapshot, or "current" for the current configurations."
+// @Produce  json
+// @Success  200  {object}  sc.Snapshot
+// @Failure  404  {string}  string  "The snapshot is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/snapshots/{version} [get]
+func (h *configSnapshotHandler) GetConfigSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.getSnapshot(mux.Vars(r)["version"])
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, snapshot)
+}
+
+// @Tags     config
+// @Summary  Delete a saved config snapshot.
+// @Param    version  path  integer  true  "The version of the snapshot."
+// @Produce  json
+// @Success  200  {string}  string  "The snapshot is deleted."
+// @Failure  404  {string}  string  "The snapshot is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/snapshots/{version} [delete]
+func (h *configSnapshotHandler) DeleteConfigSnapshot(w http.ResponseWriter, r *http.Request) {
+	version, err := server.ParseConfigSnapshotVersion(mux.Vars(r)["version"])
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	if err := h.Handler.DeleteConfigSnapshot(version); err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The snapshot is deleted.")
+}
+
+// @Tags     config
+// @Summary  Compare a config snapshot with the base one.
+// @Param    version  path   string  true   "The version of the snapshot, or "current" for the current configurations."
+// @Param    base     query  string  false  "The version of the base snapshot, the current configurations are used if it is empty."
+// @Produce  json
+// @Success  200  {array}   sc.SnapshotDiff
+// @Failure  404  {string}  string  "The snapshot is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/snapshots/{version}/diff [get]
+func (h *configSnapshotHandler) DiffConfigSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.getSnapshot(mux.Vars(r)["version"])
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	base := r.URL.Query().Get("base")
+	if len(base) == 0 {
+		base = currentConfigSnapshot
+	}
+	baseSnapshot, err := h.getSnapshot(base)
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	diffs, err := sc.DiffSnapshots(baseSnapshot, snapshot)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, diffs)
+}
+
+// @Tags     config
+// @Summary  Apply a saved config snapshot, which also can be used to roll back the configurations.
+// @Param    version  path  integer  true  "The version of the snapshot."
+// @Produce  json
+// @Success  200  {string}  string  "The snapshot is applied."
+// @Failure  404  {string}  string  "The snapshot is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request, and the configurations are restored."
+// @Router   /config/snapshots/{version}/apply [post]
+func (h *configSnapshotHandler) ApplyConfigSnapshot(w http.ResponseWriter, r *http.Request) {
+	version, err := server.ParseConfigSnapshotVersion(mux.Vars(r)["version"])
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	if err := h.Handler.ApplyConfigSnapshot(version); err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "The snapshot is applied.")
+}
+
+func (h *configSnapshotHandler) getSnapshot(version string) (*sc.Snapshot, error) {
+	if version == currentConfigSnapshot {
+		return h.Handler.GetCurrentConfigSnapshot()
+	}
+	v, err := server.ParseConfigSnapshotVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return h.Handler.GetConfigSnapshot(v)
+}
+
+func (h *configSnapshotHandler) handleErr(w http.ResponseWriter, err error) {
+	if errors.ErrorEqual(err, errs.ErrConfigSnapshotNotFound.FastGenByArgs()) {
+		h.rd.JSON(w, http.StatusNotFound, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+}
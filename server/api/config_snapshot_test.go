@@ -0,0 +1,156 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/pingcap/failpoint"
+
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/types"
+	tu "github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/config"
+)
+
+type configSnapshotTestSuite struct {
+	suite.Suite
+	svr       *server.Server
+	cleanup   tu.CleanupFunc
+	urlPrefix string
+}
+
+func TestConfigSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(configSnapshotTestSuite))
+}
+
+func (suite *configSnapshotTestSuite) SetupSuite() {
+	re := suite.Require()
+	suite.svr, suite.cleanup = mustNewServer(re)
+	server.MustWaitLeader(re, []*server.Server{suite.svr})
+
+	addr := suite.svr.GetAddr()
+	suite.urlPrefix = fmt.Sprintf("%s%s/api/v1/config/snapshots", addr, apiPrefix)
+
+	mustBootstrapCluster(re, suite.svr)
+}
+
+func (suite *configSnapshotTestSuite) TearDownSuite() {
+	suite.cleanup()
+}
+
+func (suite *configSnapshotTestSuite) TestApplyConfigSnapshot() {
+	re := suite.Require()
+	shuffleLeader := types.ShuffleLeaderScheduler.String()
+	defaultLimit := suite.svr.GetScheduleConfig().LeaderScheduleLimit
+	base := suite.saveSnapshot(re)
+
+	// Change the configurations and apply the saved snapshot.
+	re.NoError(suite.svr.GetHandler().AddScheduler(types.ShuffleLeaderScheduler))
+	suite.setLeaderScheduleLimit(re, defaultLimit+1)
+	changed := suite.saveSnapshot(re)
+	re.Equal(base.Version+1, changed.Version)
+	suite.applySnapshot(re, base.Version, tu.StatusOK(re))
+	suite.checkConfigs(re, defaultLimit, false)
+
+	// Apply the changed one again.
+	suite.applySnapshot(re, changed.Version, tu.StatusOK(re))
+	suite.checkConfigs(re, defaultLimit+1, true)
+
+	// The version of the deleted snapshot is not reused.
+	re.NoError(tu.CheckDelete(testDialClient, fmt.Sprintf("%s/%d", suite.urlPrefix, changed.Version), tu.StatusOK(re)))
+	latest := suite.saveSnapshot(re)
+	re.Equal(changed.Version+1, latest.Version)
+	re.Contains(latest.Schedulers, shuffleLeader)
+
+	// Nothing is changed if the snapshot is invalid.
+	invalid := *base
+	invalid.Version = latest.Version + 1
+	invalid.Schedulers = map[string]json.RawMessage{"unknown-scheduler": json.RawMessage("{}")}
+	re.NoError(suite.svr.GetStorage().SaveConfigSnapshot(invalid.Version, &invalid))
+	suite.applySnapshot(re, invalid.Version, tu.Status(re, http.StatusInternalServerError))
+	suite.checkConfigs(re, defaultLimit+1, true)
+
+	suite.applySnapshot(re, base.Version, tu.StatusOK(re))
+	suite.checkConfigs(re, defaultLimit, false)
+}
+
+func (suite *configSnapshotTestSuite) TestApplyConfigSnapshotRollback() {
+	re := suite.Require()
+	defaultLimit := suite.svr.GetScheduleConfig().LeaderScheduleLimit
+	base := suite.saveSnapshot(re)
+	re.NoError(suite.svr.GetHandler().AddScheduler(types.ShuffleLeaderScheduler))
+	suite.setLeaderScheduleLimit(re, defaultLimit+1)
+	suite.checkConfigs(re, defaultLimit+1, true)
+
+	// The configurations are restored if the schedulers fail to follow the snapshot.
+	re.NoError(failpoint.Enable("github.com/tikv/pd/server/syncConfigSnapshotFail", "1*return(true)"))
+	suite.applySnapshot(re, base.Version, tu.Status(re, http.StatusInternalServerError))
+	re.NoError(failpoint.Disable("github.com/tikv/pd/server/syncConfigSnapshotFail"))
+	suite.checkConfigs(re, defaultLimit+1, true)
+
+	// Nothing is changed if the configurations fail to be persisted.
+	re.NoError(failpoint.Enable("github.com/tikv/pd/server/config/persistFail", "return(true)"))
+	suite.applySnapshot(re, base.Version, tu.Status(re, http.StatusInternalServerError))
+	re.NoError(failpoint.Disable("github.com/tikv/pd/server/config/persistFail"))
+	suite.checkConfigs(re, defaultLimit+1, true)
+
+	suite.applySnapshot(re, base.Version, tu.StatusOK(re))
+	suite.checkConfigs(re, defaultLimit, false)
+}
+
+func (suite *configSnapshotTestSuite) saveSnapshot(re *require.Assertions) *sc.Snapshot {
+	snapshot := &sc.Snapshot{}
+	re.NoError(tu.CheckPostJSON(testDialClient, suite.urlPrefix, nil, tu.StatusOK(re), tu.ExtractJSON(re, snapshot)))
+	return snapshot
+}
+
+func (suite *configSnapshotTestSuite) applySnapshot(re *require.Assertions, version uint64, checkOpts ...func([]byte, int, http.Header)) {
+	re.NoError(tu.CheckPostJSON(testDialClient, fmt.Sprintf("%s/%d/apply", suite.urlPrefix, version), nil, checkOpts...))
+}
+
+func (suite *configSnapshotTestSuite) setLeaderScheduleLimit(re *require.Assertions, limit uint64) {
+	cfg := suite.svr.GetScheduleConfig()
+	cfg.LeaderScheduleLimit = limit
+	re.NoError(suite.svr.SetScheduleConfig(*cfg))
+}
+
+// checkConfigs checks both the running and the persisted configurations.
+func (suite *configSnapshotTestSuite) checkConfigs(re *require.Assertions, leaderScheduleLimit uint64, hasShuffleLeader bool) {
+	name := types.ShuffleLeaderScheduler.String()
+	controller := suite.svr.GetRaftCluster().GetCoordinator().GetSchedulersController()
+	re.Equal(hasShuffleLeader, controller.GetScheduler(name) != nil)
+	re.Equal(leaderScheduleLimit, suite.svr.GetScheduleConfig().LeaderScheduleLimit)
+
+	names, _, err := suite.svr.GetStorage().LoadAllSchedulerConfigs()
+	re.NoError(err)
+	re.Equal(hasShuffleLeader, slices.Contains(names, name))
+	cfg := &config.Config{}
+	ok, err := suite.svr.GetStorage().LoadConfig(cfg)
+	re.NoError(err)
+	re.True(ok)
+	re.Equal(leaderScheduleLimit, cfg.Schedule.LeaderScheduleLimit)
+	re.Equal(hasShuffleLeader, slices.ContainsFunc(cfg.Schedule.Schedulers, func(s sc.SchedulerConfig) bool {
+		return s.Type == types.SchedulerTypeCompatibleMap[types.ShuffleLeaderScheduler] && !s.Disable
+	}))
+}
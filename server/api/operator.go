@@ -197,3 +197,18 @@ func (h *operatorHandler) GetOperatorRecords(w http.ResponseWriter, r *http.Requ
 	}
 	h.r.JSON(w, http.StatusOK, records)
 }
+
+// @Tags     operator
+// @Summary  lists the usage of the operator slots by each scheduler and checker.
+// @Produce  json
+// @Success  200  {object}  []operator.ConsumerBudget
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/budget [get]
+func (h *operatorHandler) GetOperatorBudget(w http.ResponseWriter, _ *http.Request) {
+	budgets, err := h.GetConsumerBudgets()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, budgets)
+}
@@ -66,7 +66,7 @@ func (h *regionLabelHandler) PatchRegionLabelRules(w http.ResponseWriter, r *htt
 	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &patch); err != nil {
 		return
 	}
-	if err := cluster.GetRegionLabeler().Patch(patch); err != nil {
+	if err := cluster.GetRegionLabeler().PatchBy(patch, getLabelRuleOperator(r)); err != nil {
 		if errs.ErrRegionRuleContent.Equal(err) || errs.ErrHexDecodingString.Equal(err) {
 			h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		} else {
@@ -99,6 +99,28 @@ func (h *regionLabelHandler) GetRegionLabelRulesByIDs(w http.ResponseWriter, r *
 	h.rd.JSON(w, http.StatusOK, rules)
 }
 
+// @Tags     region_label
+// @Summary  Get the change history of label rules from the newest to the oldest.
+// @Param    id     query  string   false  "Rule Id"
+// @Param    limit  query  integer  false  "Limit count"
+// @Produce  json
+// @Success  200  {array}   labeler.LabelRuleChange
+// @Failure  400  {string}  string  "The input is invalid."
+// @Router   /config/region-label/rules/history [get]
+func (h *regionLabelHandler) GetRegionLabelRuleHistory(w http.ResponseWriter, r *http.Request) {
+	cluster := getCluster(r)
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			h.rd.JSON(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	h.rd.JSON(w, http.StatusOK, cluster.GetRegionLabeler().GetLabelRuleHistory(r.URL.Query().Get("id"), limit))
+}
+
 // @Tags     region_label
 // @Summary  Get label rule of cluster by id.
 // @Param    id  path  string  true  "Rule Id"
@@ -136,7 +158,7 @@ func (h *regionLabelHandler) DeleteRegionLabelRule(w http.ResponseWriter, r *htt
 		h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	err = cluster.GetRegionLabeler().DeleteLabelRule(id)
+	err = cluster.GetRegionLabeler().DeleteLabelRuleBy(id, getLabelRuleOperator(r))
 	if err != nil {
 		if errs.ErrRegionRuleNotFound.Equal(err) {
 			h.rd.JSON(w, http.StatusNotFound, err.Error())
@@ -163,7 +185,7 @@ func (h *regionLabelHandler) SetRegionLabelRule(w http.ResponseWriter, r *http.R
 	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &rule); err != nil {
 		return
 	}
-	if err := cluster.GetRegionLabeler().SetLabelRule(&rule); err != nil {
+	if err := cluster.GetRegionLabeler().SetLabelRuleBy(&rule, getLabelRuleOperator(r)); err != nil {
 		if errs.ErrRegionRuleContent.Equal(err) || errs.ErrHexDecodingString.Equal(err) {
 			h.rd.JSON(w, http.StatusBadRequest, err.Error())
 		} else {
@@ -223,3 +245,10 @@ func (h *regionLabelHandler) GetRegionLabels(w http.ResponseWriter, r *http.Requ
 	labels := cluster.GetRegionLabeler().GetRegionLabels(region)
 	h.rd.JSON(w, http.StatusOK, labels)
 }
+
+// getLabelRuleOperator returns the operator of the label rule change, which is
+// the caller ID and the IP of the request.
+func getLabelRuleOperator(r *http.Request) string {
+	ip, _ := apiutil.GetIPPortFromHTTPRequest(r)
+	return apiutil.GetCallerIDOnHTTP(r) + "@" + ip
+}
@@ -15,10 +15,13 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -26,6 +29,7 @@ import (
 	"github.com/pingcap/failpoint"
 
 	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/utils/apiutil"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server"
 )
@@ -110,6 +114,39 @@ func (suite *regionLabelTestSuite) TestGetSet() {
 	re.Equal([]*labeler.LabelRule{rules[1], rules[2]}, resp)
 }
 
+func (suite *regionLabelTestSuite) TestHistory() {
+	re := suite.Require()
+	rule := &labeler.LabelRule{ID: "history", Labels: []labeler.RegionLabel{{Key: "k", Value: "v"}}, RuleType: labeler.KeyPrefix, Data: []any{"7a"}}
+	data, err := json.Marshal(rule)
+	re.NoError(err)
+	req, err := http.NewRequest(http.MethodPost, suite.urlPrefix+"rule", bytes.NewBuffer(data))
+	re.NoError(err)
+	req.Header.Set(apiutil.XCallerIDHeader, "test-caller")
+	resp, err := testDialClient.Do(req)
+	re.NoError(err)
+	resp.Body.Close()
+	re.Equal(http.StatusOK, resp.StatusCode)
+	err = tu.CheckDelete(testDialClient, suite.urlPrefix+"rule/history", tu.StatusOK(re))
+	re.NoError(err)
+
+	var history []*labeler.LabelRuleChange
+	err = tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"rules/history?id=history", &history)
+	re.NoError(err)
+	re.Len(history, 2)
+	re.Equal(labeler.LabelRuleActionDelete, history[0].Action)
+	re.Equal([]any{"7a"}, history[0].Old.Data)
+	re.Nil(history[0].New)
+	re.Equal(labeler.LabelRuleActionSet, history[1].Action)
+	re.True(strings.HasPrefix(history[1].Operator, "test-caller@"))
+	re.Nil(history[1].Old)
+	err = tu.ReadGetJSON(re, testDialClient, suite.urlPrefix+"rules/history?id=history&limit=1", &history)
+	re.NoError(err)
+	re.Len(history, 1)
+	re.Equal(labeler.LabelRuleActionDelete, history[0].Action)
+	err = tu.CheckGetJSON(testDialClient, suite.urlPrefix+"rules/history?limit=x", nil, tu.Status(re, http.StatusBadRequest))
+	re.NoError(err)
+}
+
 func makeKeyRanges(keys ...string) []any {
 	var res []any
 	for i := 0; i < len(keys); i += 2 {
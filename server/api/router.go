@@ -137,16 +137,20 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/operators", operatorHandler.CreateOperator, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/operators", operatorHandler.DeleteOperators, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/operators/records", operatorHandler.GetOperatorRecords, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/operators/budget", operatorHandler.GetOperatorBudget, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/operators/{region_id}", operatorHandler.GetOperatorsByRegion, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/operators/{region_id}", operatorHandler.DeleteOperatorByRegion, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 
 	checkerHandler := newCheckerHandler(svr, rd)
+	registerFunc(apiRouter, "/checker/repair-queue", checkerHandler.GetRepairQueue, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/checker/{name}", checkerHandler.PauseOrResumeChecker, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/checker/{name}", checkerHandler.GetCheckerStatus, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
 	schedulerHandler := newSchedulerHandler(svr, rd)
 	registerFunc(apiRouter, "/schedulers", schedulerHandler.GetSchedulers, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/schedulers", schedulerHandler.CreateScheduler, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/schedulers/dry-run", schedulerHandler.DryRunSchedulers, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/schedulers/trace/{name}/{region_id}", schedulerHandler.TraceScheduler, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/schedulers/{name}", schedulerHandler.DeleteScheduler, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/schedulers/{name}", schedulerHandler.PauseOrResumeScheduler, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 
@@ -167,6 +171,9 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/config/default", confHandler.GetDefaultConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/config/schedule", confHandler.GetScheduleConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/config/schedule", confHandler.SetScheduleConfig, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/config/scheduler-time-windows", confHandler.GetSchedulerTimeWindows, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(apiRouter, "/config/scheduler-time-windows/{name}", confHandler.SetSchedulerTimeWindows, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(apiRouter, "/config/scheduler-time-windows/{name}", confHandler.DeleteSchedulerTimeWindows, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(apiRouter, "/config/pd-server", confHandler.GetPDServerConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/config/replicate", confHandler.GetReplicationConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/config/replicate", confHandler.SetReplicationConfig, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
@@ -177,12 +184,21 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(apiRouter, "/config/replication-mode", confHandler.GetReplicationModeConfig, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(apiRouter, "/config/replication-mode", confHandler.SetReplicationModeConfig, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 
+	configSnapshotHandler := newConfigSnapshotHandler(svr, rd)
+	registerFunc(clusterRouter, "/config/snapshots", configSnapshotHandler.GetConfigSnapshots, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/config/snapshots", configSnapshotHandler.SaveConfigSnapshot, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/config/snapshots/{version}", configSnapshotHandler.GetConfigSnapshot, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/config/snapshots/{version}", configSnapshotHandler.DeleteConfigSnapshot, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(clusterRouter, "/config/snapshots/{version}/diff", configSnapshotHandler.DiffConfigSnapshot, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/config/snapshots/{version}/apply", configSnapshotHandler.ApplyConfigSnapshot, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+
 	rulesHandler := newRulesHandler(svr, rd)
 	ruleRouter := clusterRouter.NewRoute().Subrouter()
 	ruleRouter.Use(newRuleMiddleware(svr, rd).middleware)
 	registerFunc(ruleRouter, "/config/rules", rulesHandler.GetAllRules, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules", rulesHandler.SetAllRules, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	registerFunc(ruleRouter, "/config/rules/batch", rulesHandler.BatchRules, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(ruleRouter, "/config/rules/simulate", rulesHandler.SimulateRules, setMethods(http.MethodPost), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules/group/{group}", rulesHandler.GetRuleByGroup, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules/region/{region}", rulesHandler.GetRulesByRegion, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/rules/region/{region}/detail", rulesHandler.CheckRegionPlacementRule, setMethods(http.MethodGet), setAuditBackend(prometheus))
@@ -196,6 +212,15 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	registerFunc(ruleRouter, "/config/rule_group/{id}", rulesHandler.DeleteGroupConfig, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
 	registerFunc(ruleRouter, "/config/rule_groups", rulesHandler.GetAllGroupConfigs, setMethods(http.MethodGet), setAuditBackend(prometheus))
 
+	registerFunc(ruleRouter, "/config/rule_templates", rulesHandler.GetRuleTemplates, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(ruleRouter, "/config/rule_template/{id}", rulesHandler.GetRuleTemplateByID, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(ruleRouter, "/config/rule_template", rulesHandler.SetRuleTemplate, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(ruleRouter, "/config/rule_template/{id}", rulesHandler.DeleteRuleTemplate, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+	registerFunc(ruleRouter, "/config/rule_template_instances", rulesHandler.GetRuleTemplateInstances, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(ruleRouter, "/config/rule_template_instance/{group}", rulesHandler.GetRuleTemplateInstance, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(ruleRouter, "/config/rule_template_instance", rulesHandler.SetRuleTemplateInstance, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
+	registerFunc(ruleRouter, "/config/rule_template_instance/{group}", rulesHandler.DeleteRuleTemplateInstance, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
+
 	registerFunc(ruleRouter, "/config/placement-rule", rulesHandler.GetPlacementRules, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(ruleRouter, "/config/placement-rule", rulesHandler.SetPlacementRules, setMethods(http.MethodPost), setAuditBackend(localLog, prometheus))
 	// {group} can be a regular expression, we should enable path encode to
@@ -207,6 +232,7 @@ func createRouter(prefix string, svr *server.Server) *mux.Router {
 	regionLabelHandler := newRegionLabelHandler(svr, rd)
 	registerFunc(clusterRouter, "/config/region-label/rules", regionLabelHandler.GetAllRegionLabelRules, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(clusterRouter, "/config/region-label/rules/ids", regionLabelHandler.GetRegionLabelRulesByIDs, setMethods(http.MethodGet), setAuditBackend(prometheus))
+	registerFunc(clusterRouter, "/config/region-label/rules/history", regionLabelHandler.GetRegionLabelRuleHistory, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	// {id} can be a string with special characters, we should enable path encode to support it.
 	registerFunc(escapeRouter, "/config/region-label/rule/{id}", regionLabelHandler.GetRegionLabelRuleByID, setMethods(http.MethodGet), setAuditBackend(prometheus))
 	registerFunc(escapeRouter, "/config/region-label/rule/{id}", regionLabelHandler.DeleteRegionLabelRule, setMethods(http.MethodDelete), setAuditBackend(localLog, prometheus))
@@ -336,6 +336,35 @@ func (h *ruleHandler) BatchRules(w http.ResponseWriter, r *http.Request) {
 	h.rd.JSON(w, http.StatusOK, "Batch operations successfully.")
 }
 
+// @Tags     rule
+// @Summary  Simulate a change of the rules without applying it. The regions in the affected ranges are fitted against the proposed rules to estimate the peers to add or remove, the data each store would gain or lose, and the rules which can not be satisfied by the current stores.
+// @Accept   json
+// @Param    change  body  placement.RuleChange  true  "The proposed change, one and only one of rules, batch and bundles should be set"
+// @Produce  json
+// @Success  200  {object}  placement.SimulationResult
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/simulate [post]
+func (h *ruleHandler) SimulateRules(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	var change placement.RuleChange
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &change); err != nil {
+		return
+	}
+	result, err := manager.SetKeyType(h.svr.GetConfig().PDServerCfg.KeyType).
+		Simulate(&change, getCluster(r))
+	if err != nil {
+		if errs.ErrRuleContent.Equal(err) || errs.ErrHexDecodingString.Equal(err) || errs.ErrBuildRuleList.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, result)
+}
+
 // @Tags     rule
 // @Summary  Get rule group config by group id.
 // @Param    id  path  string  true  "Group Id"
@@ -535,3 +564,181 @@ func (h *ruleHandler) SetPlacementRuleByGroup(w http.ResponseWriter, r *http.Req
 	}
 	h.rd.JSON(w, http.StatusOK, "Update group and rules successfully.")
 }
+
+// @Tags     rule
+// @Summary  List all rule templates.
+// @Produce  json
+// @Success  200  {array}   placement.RuleTemplate
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Router   /config/rule_templates [get]
+func (h *ruleHandler) GetRuleTemplates(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	h.rd.JSON(w, http.StatusOK, manager.GetRuleTemplates())
+}
+
+// @Tags     rule
+// @Summary  Get rule template by id.
+// @Param    id  path  string  true  "Template Id"
+// @Produce  json
+// @Success  200  {object}  placement.RuleTemplate
+// @Failure  404  {string}  string  "The template does not exist."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Router   /config/rule_template/{id} [get]
+func (h *ruleHandler) GetRuleTemplateByID(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	id := mux.Vars(r)["id"]
+	template := manager.GetRuleTemplate(id)
+	if template == nil {
+		h.rd.JSON(w, http.StatusNotFound, errs.ErrRuleTemplateNotFound.FastGenByArgs(id).Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, template)
+}
+
+// @Tags     rule
+// @Summary  Update rule template, all the instances of it are re-rendered at once.
+// @Accept   json
+// @Param    template  body  placement.RuleTemplate  true  "Parameters of rule template"
+// @Produce  json
+// @Success  200  {string}  string  "Update rule template successfully."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rule_template [post]
+func (h *ruleHandler) SetRuleTemplate(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	var template placement.RuleTemplate
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &template); err != nil {
+		return
+	}
+	if err := manager.SetKeyType(h.svr.GetConfig().PDServerCfg.KeyType).
+		SetRuleTemplate(&template); err != nil {
+		h.rd.JSON(w, ruleTemplateErrorStatus(err), err.Error())
+		return
+	}
+	cluster := getCluster(r)
+	for _, inst := range manager.GetRuleTemplateInstances() {
+		if inst.TemplateID != template.ID {
+			continue
+		}
+		for _, rule := range manager.GetRulesByGroup(inst.GroupID) {
+			cluster.AddSuspectKeyRange(rule.StartKey, rule.EndKey)
+		}
+	}
+	h.rd.JSON(w, http.StatusOK, "Update rule template successfully.")
+}
+
+// @Tags     rule
+// @Summary  Delete rule template which is not used by any instance.
+// @Param    id  path  string  true  "Template Id"
+// @Produce  json
+// @Success  200  {string}  string  "Delete rule template successfully."
+// @Failure  400  {string}  string  "The template is in use."
+// @Failure  404  {string}  string  "The template does not exist."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rule_template/{id} [delete]
+func (h *ruleHandler) DeleteRuleTemplate(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	if err := manager.DeleteRuleTemplate(mux.Vars(r)["id"]); err != nil {
+		h.rd.JSON(w, ruleTemplateErrorStatus(err), err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Delete rule template successfully.")
+}
+
+// @Tags     rule
+// @Summary  List all rule template instances.
+// @Produce  json
+// @Success  200  {array}   placement.RuleTemplateInstance
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Router   /config/rule_template_instances [get]
+func (h *ruleHandler) GetRuleTemplateInstances(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	h.rd.JSON(w, http.StatusOK, manager.GetRuleTemplateInstances())
+}
+
+// @Tags     rule
+// @Summary  Get rule template instance by group id.
+// @Param    group  path  string  true  "The name of group"
+// @Produce  json
+// @Success  200  {object}  placement.RuleTemplateInstance
+// @Failure  404  {string}  string  "The instance does not exist."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Router   /config/rule_template_instance/{group} [get]
+func (h *ruleHandler) GetRuleTemplateInstance(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	group := mux.Vars(r)["group"]
+	inst := manager.GetRuleTemplateInstance(group)
+	if inst == nil {
+		h.rd.JSON(w, http.StatusNotFound, errs.ErrRuleTemplateNotFound.FastGenByArgs("instance of group "+group).Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, inst)
+}
+
+// @Tags     rule
+// @Summary  Instantiate a rule template as a group, the old rules of the group are dropped.
+// @Accept   json
+// @Param    instance  body  placement.RuleTemplateInstance  true  "Parameters of rule template instance"
+// @Produce  json
+// @Success  200  {string}  string  "Update rule template instance successfully."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The template does not exist."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rule_template_instance [post]
+func (h *ruleHandler) SetRuleTemplateInstance(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	var inst placement.RuleTemplateInstance
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &inst); err != nil {
+		return
+	}
+	oldRules := manager.GetRulesByGroup(inst.GroupID)
+	if err := manager.SetKeyType(h.svr.GetConfig().PDServerCfg.KeyType).
+		SetRuleTemplateInstance(&inst); err != nil {
+		h.rd.JSON(w, ruleTemplateErrorStatus(err), err.Error())
+		return
+	}
+	cluster := getCluster(r)
+	for _, rule := range append(oldRules, manager.GetRulesByGroup(inst.GroupID)...) {
+		cluster.AddSuspectKeyRange(rule.StartKey, rule.EndKey)
+	}
+	h.rd.JSON(w, http.StatusOK, "Update rule template instance successfully.")
+}
+
+// @Tags     rule
+// @Summary  Delete rule template instance and the group of it.
+// @Param    group  path  string  true  "The name of group"
+// @Produce  json
+// @Success  200  {string}  string  "Delete rule template instance successfully."
+// @Failure  404  {string}  string  "The instance does not exist."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rule_template_instance/{group} [delete]
+func (h *ruleHandler) DeleteRuleTemplateInstance(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	group := mux.Vars(r)["group"]
+	oldRules := manager.GetRulesByGroup(group)
+	if err := manager.DeleteRuleTemplateInstance(group); err != nil {
+		h.rd.JSON(w, ruleTemplateErrorStatus(err), err.Error())
+		return
+	}
+	cluster := getCluster(r)
+	for _, rule := range oldRules {
+		cluster.AddSuspectKeyRange(rule.StartKey, rule.EndKey)
+	}
+	h.rd.JSON(w, http.StatusOK, "Delete rule template instance successfully.")
+}
+
+func ruleTemplateErrorStatus(err error) int {
+	switch {
+	case errs.ErrRuleTemplateNotFound.Equal(err):
+		return http.StatusNotFound
+	case errs.ErrRuleTemplateContent.Equal(err), errs.ErrRuleContent.Equal(err),
+		errs.ErrHexDecodingString.Equal(err), errs.ErrBuildRuleList.Equal(err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
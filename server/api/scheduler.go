@@ -190,6 +190,10 @@ func (h *schedulerHandler) CreateScheduler(w http.ResponseWriter, r *http.Reques
 			limit = uint64(l)
 		}
 		collector(strconv.FormatUint(limit, 10))
+	case types.ShuffleChaosScheduler:
+		if preset, ok := input["preset"].(string); ok {
+			collector(preset)
+		}
 	case types.GrantHotRegionScheduler:
 		isExist, err := h.isSchedulerExist(types.BalanceHotRegionScheduler)
 		if err != nil {
@@ -212,6 +216,11 @@ func (h *schedulerHandler) CreateScheduler(w http.ResponseWriter, r *http.Reques
 		}
 		collector(leaderID)
 		collector(peerIDs)
+	case types.ExternalScheduler:
+		if err := apiutil.CollectStringOption("address", input, collector); err != nil {
+			h.r.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 	case types.BalanceHotRegionScheduler:
 		isExist, err := h.isSchedulerExist(types.GrantHotRegionScheduler)
 		if err != nil {
@@ -283,6 +292,56 @@ func (h *schedulerHandler) redirectSchedulerDelete(w http.ResponseWriter, name,
 	h.r.JSON(w, resp.StatusCode, nil)
 }
 
+// @Tags     scheduler
+// @Summary  Run schedulers without dispatching the operators.
+// @Param    name  query  string  false  "The name of the scheduler, all schedulers are run if it is empty."
+// @Produce  json
+// @Success  200  {array}   schedulers.DryRunResult
+// @Failure  404  {string}  string  "The scheduler is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/dry-run [get]
+func (h *schedulerHandler) DryRunSchedulers(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if len(name) == 0 {
+		name = "all"
+	}
+	results, err := h.Handler.DryRunSchedulers(name)
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, results)
+}
+
+// @Tags     scheduler
+// @Summary  Run a scheduler without dispatching the operators, and trace why a region is scheduled or not by it.
+// @Param    name       path  string   true  "The name of the scheduler."
+// @Param    region_id  path  integer  true  "The ID of the region."
+// @Produce  json
+// @Success  200  {object}  schedulers.TraceResult
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The scheduler or the region is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/trace/{name}/{region_id} [get]
+func (h *schedulerHandler) TraceScheduler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	regionID, err := strconv.ParseUint(vars["region_id"], 10, 64)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := h.Handler.TraceScheduler(vars["name"], regionID)
+	if err != nil {
+		if errors.ErrorEqual(err, errs.ErrRegionNotFound.FastGenByArgs(regionID)) {
+			h.r.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, result)
+}
+
 // FIXME: details of input json body params
 // @Tags     scheduler
 // @Summary  Pause or resume a scheduler.
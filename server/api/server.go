@@ -47,6 +47,7 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 	//	"/operators", http.MethodGet
 	//	"/operators", http.MethodPost
 	//	"/operators/records",http.MethodGet
+	//	"/operators/budget", http.MethodGet
 	//	"/operators/{region_id}", http.MethodGet
 	//	"/operators/{region_id}", http.MethodDelete
 	//	"/checker/{name}", http.MethodPost
@@ -54,6 +55,8 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 	//	"/schedulers", http.MethodGet
 	//	"/schedulers/{name}", http.MethodPost, which is to be used to pause or resume the scheduler rather than create a new scheduler
 	//	"/schedulers/diagnostic/{name}", http.MethodGet
+	//	"/schedulers/dry-run", http.MethodGet
+	//	"/schedulers/trace/{name}/{region_id}", http.MethodGet
 	//	"/scheduler-config", http.MethodGet
 	//	"/hotspot/regions/read", http.MethodGet
 	//	"/hotspot/regions/write", http.MethodGet
@@ -117,7 +120,11 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 				prefix+"/config/region-label/rules",
 				scheapi.APIPathPrefix+"/config/region-label/rules",
 				constant.SchedulingServiceName,
-				[]string{http.MethodGet}),
+				[]string{http.MethodGet},
+				func(r *http.Request) bool {
+					// The change history is only recorded by the PD server which changes the rules.
+					return !strings.HasSuffix(r.URL.Path, "/history")
+				}),
 			serverapi.MicroserviceRedirectRule(
 				prefix+"/config/region-label/rule/", // Note: this is a typo in the original code
 				scheapi.APIPathPrefix+"/config/region-label/rules",
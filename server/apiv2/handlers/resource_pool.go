@@ -0,0 +1,150 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/keyspace"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/apiv2/middlewares"
+)
+
+// RegisterResourcePool registers keyspace resource pool related handlers to router paths.
+func RegisterResourcePool(r *gin.RouterGroup) {
+	router := r.Group("resource-pools")
+	router.Use(middlewares.BootstrapChecker())
+	router.POST("", SetResourcePool)
+	router.GET("", GetResourcePools)
+	router.GET("/:name", GetResourcePool)
+	router.DELETE("/:name", DeleteResourcePool)
+}
+
+// SetResourcePool creates or updates a resource pool.
+//
+// @Tags     resource-pools
+// @Summary  Create or update a resource pool which confines a range of keyspaces to the stores selected by labels.
+// @Param    body  body  keyspace.ResourcePool  true  "Resource pool"
+// @Produce  json
+// @Success  200  {string}  string  "The resource pool is set."
+// @Failure  400  {string}  string  "The input is invalid, or the stores of the pool are not enough."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /resource-pools [post]
+func SetResourcePool(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	pool := &keyspace.ResourcePool{}
+	if err := c.BindJSON(pool); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errs.ErrBindJSON.Wrap(err).GenWithStackByCause())
+		return
+	}
+	if err := manager.SetResourcePool(pool); err != nil {
+		if errs.ErrResourcePoolInvalid.Equal(err) || errs.ErrResourcePoolOverlapped.Equal(err) ||
+			errs.ErrResourcePoolCapacity.Equal(err) || errs.ErrRuleContent.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, "The resource pool is set.")
+}
+
+// GetResourcePools returns all the resource pools.
+//
+// @Tags     resource-pools
+// @Summary  Get all the resource pools with the matched stores and the region size.
+// @Produce  json
+// @Success  200  {array}   keyspace.ResourcePoolStatus
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /resource-pools [get]
+func GetResourcePools(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	pools, err := manager.GetResourcePools()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, pools)
+}
+
+// GetResourcePool returns the resource pool.
+//
+// @Tags     resource-pools
+// @Summary  Get the resource pool with the matched stores and the region size.
+// @Param    name  path  string  true  "Resource pool name"
+// @Produce  json
+// @Success  200  {object}  keyspace.ResourcePoolStatus
+// @Failure  404  {string}  string  "The resource pool does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /resource-pools/{name} [get]
+func GetResourcePool(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	pool, err := manager.GetResourcePool(c.Param("name"))
+	if err != nil {
+		if errs.ErrResourcePoolNotFound.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, pool)
+}
+
+// DeleteResourcePool deletes the resource pool.
+//
+// @Tags     resource-pools
+// @Summary  Delete the resource pool and its placement rules.
+// @Param    name  path  string  true  "Resource pool name"
+// @Produce  json
+// @Success  200  {string}  string  "The resource pool is deleted."
+// @Failure  404  {string}  string  "The resource pool does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /resource-pools/{name} [delete]
+func DeleteResourcePool(c *gin.Context) {
+	svr := c.MustGet(middlewares.ServerContextKey).(*server.Server)
+	manager := svr.GetKeyspaceManager()
+	if manager == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, managerUninitializedErr)
+		return
+	}
+	if err := manager.DeleteResourcePool(c.Param("name")); err != nil {
+		if errs.ErrResourcePoolNotFound.Equal(err) {
+			c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, "The resource pool is deleted.")
+}
@@ -56,6 +56,7 @@ func NewV2Handler(_ context.Context, svr *server.Server) (http.Handler, apiutil.
 	root.GET("ready", handlers.Ready)
 	handlers.RegisterKeyspace(root)
 	handlers.RegisterTSOKeyspaceGroup(root)
+	handlers.RegisterResourcePool(root)
 	handlers.RegisterMicroservice(root)
 	return router, group, nil
 }
@@ -378,8 +378,7 @@ func (c *RaftCluster) Start(s Server, bootstrap bool) (err error) {
 
 	if !c.IsServiceIndependent(constant.SchedulingServiceName) {
 		for _, store := range c.GetStores() {
-			storeID := store.GetID()
-			c.slowStat.ObserveSlowStoreStatus(storeID, store.IsSlow())
+			c.slowStat.ObserveSlowStore(store)
 		}
 	}
 	c.replicationMode, err = replication.NewReplicationModeManager(s.GetConfig().ReplicationMode, c.storage, cluster, s)
@@ -1023,20 +1022,21 @@ func (c *RaftCluster) HandleStoreHeartbeat(heartbeat *pdpb.StoreHeartbeatRequest
 	}
 
 	nowTime := time.Now()
+	leaderReadQueryRate := core.SetLeaderReadQueryRate(c.GetStoreLeaderReadQueryRate(storeID, stats))
 	var newStore *core.StoreInfo
 	// If this cluster has slow stores, we should awaken hibernated regions in other stores.
 	if !c.IsServiceIndependent(constant.SchedulingServiceName) {
 		if needAwaken, slowStoreIDs := c.NeedAwakenAllRegionsInStore(storeID); needAwaken {
 			log.Info("forcely awaken hibernated regions", zap.Uint64("store-id", storeID), zap.Uint64s("slow-stores", slowStoreIDs))
-			newStore = store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime), core.SetLastAwakenTime(nowTime), opt)
+			newStore = store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime), core.SetLastAwakenTime(nowTime), leaderReadQueryRate, opt)
 			resp.AwakenRegions = &pdpb.AwakenRegions{
 				AbnormalStores: slowStoreIDs,
 			}
 		} else {
-			newStore = store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime), opt)
+			newStore = store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime), leaderReadQueryRate, opt)
 		}
 	} else {
-		newStore = store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime), opt)
+		newStore = store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(nowTime), leaderReadQueryRate, opt)
 	}
 
 	if newStore.IsLowSpace(c.opt.GetLowSpaceRatio()) {
@@ -1064,7 +1064,7 @@ func (c *RaftCluster) HandleStoreHeartbeat(heartbeat *pdpb.StoreHeartbeatRequest
 	if !c.IsServiceIndependent(constant.SchedulingServiceName) {
 		c.hotStat.Observe(storeID, newStore.GetStoreStats())
 		c.hotStat.FilterUnhealthyStore(c)
-		c.slowStat.ObserveSlowStoreStatus(storeID, newStore.IsSlow())
+		c.slowStat.ObserveSlowStore(newStore)
 		reportInterval := stats.GetInterval()
 		interval = reportInterval.GetEndTimestamp() - reportInterval.GetStartTimestamp()
 
@@ -1728,7 +1728,7 @@ func (c *RaftCluster) setStore(store *core.StoreInfo) error {
 	}
 	c.PutStore(store)
 	if !c.IsServiceIndependent(constant.SchedulingServiceName) {
-		c.updateStoreStatistics(store.GetID(), store.IsSlow())
+		c.updateStoreStatistics(store)
 	}
 	return nil
 }
@@ -70,7 +70,7 @@ func newSchedulingController(parentCtx context.Context, basicCluster *core.Basic
 		opt:          opt,
 		labelStats:   statistics.NewLabelStatistics(),
 		hotStat:      statistics.NewHotStat(parentCtx, basicCluster),
-		slowStat:     statistics.NewSlowStat(),
+		slowStat:     statistics.NewSlowStat(opt),
 		regionStats:  statistics.NewRegionStatistics(basicCluster, opt, ruleManager),
 	}
 }
@@ -204,9 +204,9 @@ func (sc *schedulingController) removeStoreStatistics(storeID uint64) {
 	sc.slowStat.RemoveSlowStoreStatus(storeID)
 }
 
-func (sc *schedulingController) updateStoreStatistics(storeID uint64, isSlow bool) {
-	sc.hotStat.GetOrCreateRollingStoreStats(storeID)
-	sc.slowStat.ObserveSlowStoreStatus(storeID, isSlow)
+func (sc *schedulingController) updateStoreStatistics(store *core.StoreInfo) {
+	sc.hotStat.GetOrCreateRollingStoreStats(store.GetID())
+	sc.slowStat.ObserveSlowStore(store)
 }
 
 // GetHotStat gets hot stat.
@@ -261,6 +261,11 @@ func (sc *schedulingController) GetStoresLoads() map[uint64][]float64 {
 	return sc.hotStat.GetStoresLoads()
 }
 
+// IsSlowStore returns whether the store is detected as a slow store.
+func (sc *schedulingController) IsSlowStore(storeID uint64) bool {
+	return sc.slowStat.IsSlowStore(storeID)
+}
+
 // IsRegionHot checks if a region is in hot state.
 func (sc *schedulingController) IsRegionHot(region *core.RegionInfo) bool {
 	return sc.hotStat.IsRegionHot(region, sc.opt.GetHotRegionCacheHitsThreshold())
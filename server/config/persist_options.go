@@ -40,6 +40,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/storage/kv"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 )
@@ -185,6 +186,11 @@ func (o *PersistOptions) SetLocationLabels(labels []string) {
 	o.SetReplicationConfig(v)
 }
 
+// GetZoneCostMatrix returns the network cost matrix between zones.
+func (o *PersistOptions) GetZoneCostMatrix() *sc.ZoneCostMatrix {
+	return sc.NewZoneCostMatrix(o.GetReplicationConfig())
+}
+
 // GetIsolationLevel returns the isolation label for each region.
 func (o *PersistOptions) GetIsolationLevel() string {
 	return o.GetReplicationConfig().IsolationLevel
@@ -316,6 +322,11 @@ func (o *PersistOptions) SetEnableDiagnostic(enable bool) {
 	o.SetScheduleConfig(v)
 }
 
+// GetPromoteWitnessDownTime returns the minimum down time of a voter before promoting its witness.
+func (o *PersistOptions) GetPromoteWitnessDownTime() time.Duration {
+	return o.GetScheduleConfig().PromoteWitnessDownTime.Duration
+}
+
 // IsWitnessAllowed returns whether is enable to use witness.
 func (o *PersistOptions) IsWitnessAllowed() bool {
 	return o.GetScheduleConfig().EnableWitness
@@ -538,6 +549,11 @@ func (o *PersistOptions) GetSlowStoreEvictingAffectedStoreRatioThreshold() float
 	return o.GetScheduleConfig().SlowStoreEvictingAffectedStoreRatioThreshold
 }
 
+// GetSlowStoreDetector returns the strategy to detect the slow stores.
+func (o *PersistOptions) GetSlowStoreDetector() string {
+	return o.GetScheduleConfig().SlowStoreDetector
+}
+
 // GetHighSpaceRatio returns the high space ratio.
 func (o *PersistOptions) GetHighSpaceRatio() float64 {
 	return o.GetScheduleConfig().HighSpaceRatio
@@ -548,6 +564,19 @@ func (o *PersistOptions) GetRegionScoreFormulaVersion() string {
 	return o.GetScheduleConfig().RegionScoreFormulaVersion
 }
 
+// IsOperatorFairShareEnabled returns whether the operator slots are shared by weights.
+func (o *PersistOptions) IsOperatorFairShareEnabled() bool {
+	return o.GetScheduleConfig().EnableOperatorFairShare
+}
+
+// GetOperatorBudgetWeight returns the weight of the scheduler or checker when sharing the operator slots.
+func (o *PersistOptions) GetOperatorBudgetWeight(name string) float64 {
+	if weight, ok := o.GetScheduleConfig().OperatorBudgetWeights[name]; ok {
+		return weight
+	}
+	return 1
+}
+
 // GetSchedulerMaxWaitingOperator returns the number of the max waiting operators.
 func (o *PersistOptions) GetSchedulerMaxWaitingOperator() uint64 {
 	return o.getTTLNumberOr(sc.SchedulerMaxWaitingOperatorKey, o.GetScheduleConfig().SchedulerMaxWaitingOperator)
@@ -783,7 +812,23 @@ func (o *PersistOptions) SwitchRaftV2(storage endpoint.ConfigStorage) error {
 
 // Persist saves the configuration to the storage.
 func (o *PersistOptions) Persist(storage endpoint.ConfigStorage) error {
-	cfg := &persistedConfig{
+	failpoint.Inject("persistFail", func() {
+		failpoint.Return(errors.New("fail to persist"))
+	})
+	return storage.SaveConfig(o.persistedConfig())
+}
+
+// PersistInTxn saves the configuration to the storage in the given transaction,
+// so that it can be persisted atomically with other changes.
+func (o *PersistOptions) PersistInTxn(storage endpoint.ConfigStorage, txn kv.Txn) error {
+	failpoint.Inject("persistFail", func() {
+		failpoint.Return(errors.New("fail to persist"))
+	})
+	return storage.SaveConfigInTxn(txn, o.persistedConfig())
+}
+
+func (o *PersistOptions) persistedConfig() *persistedConfig {
+	return &persistedConfig{
 		Config: &Config{
 			Schedule:        *o.GetScheduleConfig(),
 			Replication:     *o.GetReplicationConfig(),
@@ -796,10 +841,6 @@ func (o *PersistOptions) Persist(storage endpoint.ConfigStorage) error {
 		},
 		StoreConfig: *o.GetStoreConfig(),
 	}
-	failpoint.Inject("persistFail", func() {
-		failpoint.Return(errors.New("fail to persist"))
-	})
-	return storage.SaveConfig(cfg)
 }
 
 // Reload reloads the configuration from the storage.
@@ -841,6 +882,7 @@ func adjustScheduleCfg(scheduleCfg *sc.ScheduleConfig) {
 		}
 	}
 	scheduleCfg.MigrateDeprecatedFlags()
+	scheduleCfg.CompileSchedulerTimeWindows()
 }
 
 // CheckLabelProperty checks the label property.
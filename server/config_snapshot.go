@@ -0,0 +1,380 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	"github.com/tikv/pd/pkg/schedule/checker"
+	sc "github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/schedulers"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/server/cluster"
+)
+
+// GetCurrentConfigSnapshot returns the snapshot of the current scheduling configurations
+// without saving it.
+func (h *Handler) GetCurrentConfigSnapshot() (*sc.Snapshot, error) {
+	h.configSnapshotLock.Lock()
+	defer h.configSnapshotLock.Unlock()
+	return h.getCurrentConfigSnapshot()
+}
+
+func (h *Handler) getCurrentConfigSnapshot() (*sc.Snapshot, error) {
+	c, err := h.GetRaftCluster()
+	if err != nil {
+		return nil, err
+	}
+	names, configs, err := h.s.storage.LoadAllSchedulerConfigs()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &sc.Snapshot{
+		CreatedAt:  time.Now(),
+		Schedule:   h.s.GetScheduleConfig(),
+		Schedulers: make(map[string]json.RawMessage, len(names)),
+	}
+	for i, name := range names {
+		data := configs[i]
+		if len(data) == 0 {
+			data = "{}"
+		}
+		snapshot.Schedulers[name] = json.RawMessage(data)
+	}
+	// The checkers run in the scheduling service if it is independent.
+	if c.IsServiceIndependent(constant.SchedulingServiceName) {
+		return snapshot, nil
+	}
+	for _, name := range checker.PausableCheckerNames {
+		delayUntil, err := c.GetCoordinator().GetCheckerDelayUntil(name)
+		if err != nil {
+			return nil, err
+		}
+		if delayUntil > 0 {
+			if snapshot.PausedCheckers == nil {
+				snapshot.PausedCheckers = make(map[string]int64)
+			}
+			snapshot.PausedCheckers[name] = delayUntil
+		}
+	}
+	return snapshot, nil
+}
+
+// SaveConfigSnapshot saves the current scheduling configurations as a new version.
+func (h *Handler) SaveConfigSnapshot(comment string) (*sc.Snapshot, error) {
+	h.configSnapshotLock.Lock()
+	defer h.configSnapshotLock.Unlock()
+	snapshot, err := h.getCurrentConfigSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := h.getConfigSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	// The version is allocated from the latest allocated one rather than the
+	// latest existing snapshot, so a deleted version is never reused.
+	version, err := h.s.storage.LoadConfigSnapshotVersion()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) > 0 {
+		version = max(version, snapshots[len(snapshots)-1].Version)
+	}
+	snapshot.Version = version + 1
+	snapshot.Comment = comment
+	if err := h.s.storage.SaveConfigSnapshot(snapshot.Version, snapshot); err != nil {
+		return nil, err
+	}
+	log.Info("config snapshot is saved", zap.Uint64("version", snapshot.Version), zap.String("comment", comment))
+	return snapshot, nil
+}
+
+// GetConfigSnapshots returns all saved config snapshots in the order of version.
+func (h *Handler) GetConfigSnapshots() ([]*sc.Snapshot, error) {
+	h.configSnapshotLock.Lock()
+	defer h.configSnapshotLock.Unlock()
+	return h.getConfigSnapshots()
+}
+
+func (h *Handler) getConfigSnapshots() ([]*sc.Snapshot, error) {
+	var (
+		snapshots []*sc.Snapshot
+		decodeErr error
+	)
+	err := h.s.storage.LoadConfigSnapshots(func(_, v string) {
+		snapshot := &sc.Snapshot{}
+		if err := json.Unmarshal([]byte(v), snapshot); err != nil {
+			if decodeErr == nil {
+				decodeErr = errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+			}
+			return
+		}
+		snapshots = append(snapshots, snapshot)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return snapshots, nil
+}
+
+// GetConfigSnapshot returns the saved config snapshot with the given version.
+func (h *Handler) GetConfigSnapshot(version uint64) (*sc.Snapshot, error) {
+	h.configSnapshotLock.Lock()
+	defer h.configSnapshotLock.Unlock()
+	return h.getConfigSnapshot(version)
+}
+
+func (h *Handler) getConfigSnapshot(version uint64) (*sc.Snapshot, error) {
+	value, err := h.s.storage.LoadConfigSnapshot(version)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, errs.ErrConfigSnapshotNotFound.FastGenByArgs(version)
+	}
+	snapshot := &sc.Snapshot{}
+	if err := json.Unmarshal([]byte(value), snapshot); err != nil {
+		return nil, errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+	}
+	return snapshot, nil
+}
+
+// DeleteConfigSnapshot deletes the saved config snapshot with the given version.
+func (h *Handler) DeleteConfigSnapshot(version uint64) error {
+	h.configSnapshotLock.Lock()
+	defer h.configSnapshotLock.Unlock()
+	if _, err := h.getConfigSnapshot(version); err != nil {
+		return err
+	}
+	return h.s.storage.DeleteConfigSnapshot(version)
+}
+
+// ApplyConfigSnapshot applies the saved config snapshot with the given version, which
+// can also be used to roll back to a previous version. The snapshot is validated before
+// any change is made, and the configurations are persisted in a single transaction. If
+// the running schedulers fail to follow the persisted configurations, the configurations
+// before applying are restored.
+func (h *Handler) ApplyConfigSnapshot(version uint64) error {
+	h.configSnapshotLock.Lock()
+	defer h.configSnapshotLock.Unlock()
+	c, err := h.GetRaftCluster()
+	if err != nil {
+		return err
+	}
+	target, err := h.getConfigSnapshot(version)
+	if err != nil {
+		return err
+	}
+	origin, err := h.getCurrentConfigSnapshot()
+	if err != nil {
+		return err
+	}
+	if err := h.validateConfigSnapshot(c, origin, target); err != nil {
+		return err
+	}
+	if err := h.persistConfigSnapshot(origin, target); err != nil {
+		return err
+	}
+	if err := h.syncConfigSnapshot(c, origin, target); err != nil {
+		log.Error("failed to apply config snapshot, restore the origin configs",
+			zap.Uint64("version", version), errs.ZapError(err))
+		if restoreErr := h.persistConfigSnapshot(target, origin); restoreErr != nil {
+			log.Error("failed to restore the origin configs", errs.ZapError(restoreErr))
+			return err
+		}
+		if restoreErr := h.syncConfigSnapshot(c, target, origin); restoreErr != nil {
+			log.Error("failed to restore the origin configs", errs.ZapError(restoreErr))
+		}
+		return err
+	}
+	log.Info("config snapshot is applied", zap.Uint64("version", version))
+	return nil
+}
+
+// validateConfigSnapshot checks whether the target snapshot can be applied on the
+// current one without changing anything.
+func (h *Handler) validateConfigSnapshot(c *cluster.RaftCluster, current, target *sc.Snapshot) error {
+	if err := target.Schedule.Validate(); err != nil {
+		return err
+	}
+	if err := target.Schedule.Deprecated(); err != nil {
+		return err
+	}
+	for name, data := range target.Schedulers {
+		tp := schedulers.FindSchedulerTypeByName(name)
+		if len(tp) == 0 {
+			return errs.ErrSchedulerNotFound.FastGenByArgs()
+		}
+		if old, ok := current.Schedulers[name]; ok && bytes.Equal(old, data) {
+			continue
+		}
+		// Decode the config with a scheduler which is never added, so the current
+		// configurations are not affected.
+		if _, err := schedulers.CreateScheduler(tp, c.GetOperatorController(),
+			storage.NewStorageWithMemoryBackend(), schedulers.ConfigJSONDecoder(data)); err != nil {
+			return err
+		}
+	}
+	for name := range target.PausedCheckers {
+		if !slices.Contains(checker.PausableCheckerNames, name) {
+			return errs.ErrCheckerNotFound.FastGenByArgs()
+		}
+	}
+	return nil
+}
+
+// persistConfigSnapshot persists the changes from the current snapshot to the target
+// one in a single transaction, including the schedule config and the scheduler configs.
+func (h *Handler) persistConfigSnapshot(current, target *sc.Snapshot) error {
+	old := h.opt.GetScheduleConfig()
+	h.opt.SetScheduleConfig(target.Schedule.Clone())
+	err := h.s.storage.RunInTxn(h.s.Context(), func(txn kv.Txn) error {
+		for name := range current.Schedulers {
+			if _, ok := target.Schedulers[name]; ok {
+				continue
+			}
+			if err := h.s.storage.RemoveSchedulerConfigInTxn(txn, name); err != nil {
+				return err
+			}
+		}
+		for name, data := range target.Schedulers {
+			if old, ok := current.Schedulers[name]; ok && bytes.Equal(old, data) {
+				continue
+			}
+			if err := h.s.storage.SaveSchedulerConfigInTxn(txn, name, data); err != nil {
+				return err
+			}
+		}
+		return h.opt.PersistInTxn(h.s.storage, txn)
+	})
+	if err != nil {
+		h.opt.SetScheduleConfig(old)
+		return err
+	}
+	h.opt.SetSchedulingAllowanceStatus(target.Schedule.HaltScheduling, "manually")
+	return nil
+}
+
+// syncConfigSnapshot makes the running schedulers and checkers follow the persisted
+// target snapshot. It only changes the persisted configurations when they are already
+// the same as the target ones, so it can be retried to restore the configurations.
+func (h *Handler) syncConfigSnapshot(c *cluster.RaftCluster, current, target *sc.Snapshot) error {
+	var err error
+	independent := c.IsServiceIndependent(constant.SchedulingServiceName)
+	controller := c.GetCoordinator().GetSchedulersController()
+	// Remove the schedulers which do not exist in the target snapshot.
+	for _, name := range controller.GetSchedulerNames() {
+		if _, ok := target.Schedulers[name]; ok {
+			continue
+		}
+		if independent {
+			err = c.RemoveSchedulerHandler(name)
+		} else {
+			err = c.RemoveScheduler(name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	var removeSchedulerCb func(string) error
+	if independent {
+		removeSchedulerCb = controller.RemoveSchedulerHandler
+	} else {
+		removeSchedulerCb = controller.RemoveScheduler
+	}
+	for name, data := range target.Schedulers {
+		if controller.GetScheduler(name) == nil {
+			// Add the schedulers which only exist in the target snapshot.
+			tp := schedulers.FindSchedulerTypeByName(name)
+			s, err := schedulers.CreateScheduler(tp, c.GetOperatorController(), h.s.storage,
+				schedulers.ConfigJSONDecoder(data), removeSchedulerCb)
+			if err != nil {
+				return err
+			}
+			args := schedulerArgs(target.Schedule, tp)
+			if independent {
+				err = c.AddSchedulerHandler(s, args...)
+			} else {
+				err = c.AddScheduler(s, args...)
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		// The scheduling service watches the scheduler configs by itself.
+		if independent || bytes.Equal(current.Schedulers[name], data) {
+			continue
+		}
+		// Reload the config of the existing schedulers.
+		if err := controller.ReloadSchedulerConfig(name); err != nil {
+			return err
+		}
+	}
+	failpoint.Inject("syncConfigSnapshotFail", func() {
+		failpoint.Return(errors.New("fail to sync config snapshot"))
+	})
+	if independent {
+		return nil
+	}
+	now := time.Now().Unix()
+	for _, name := range checker.PausableCheckerNames {
+		var delay int64
+		if delayUntil, ok := target.PausedCheckers[name]; ok && delayUntil > now {
+			delay = delayUntil - now
+		}
+		if err := c.GetCoordinator().PauseOrResumeChecker(name, delay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schedulerArgs returns the arguments of the scheduler in the schedule config.
+func schedulerArgs(cfg *sc.ScheduleConfig, tp types.CheckerSchedulerType) []string {
+	for _, s := range cfg.Schedulers {
+		if s.Type == types.SchedulerTypeCompatibleMap[tp] {
+			return s.Args
+		}
+	}
+	return nil
+}
+
+// ParseConfigSnapshotVersion parses the version of a config snapshot.
+func ParseConfigSnapshotVersion(s string) (uint64, error) {
+	version, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || version == 0 {
+		return 0, errs.ErrConfigSnapshotNotFound.FastGenByArgs(s)
+	}
+	return version, nil
+}
@@ -20,6 +20,7 @@ import (
 	"net/url"
 	"path"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -75,6 +76,8 @@ type Handler struct {
 	opt             *config.PersistOptions
 	pluginChMap     map[string]chan string
 	pluginChMapLock syncutil.RWMutex
+	// configSnapshotLock is used to serialize the operations on config snapshots.
+	configSnapshotLock syncutil.Mutex
 }
 
 func newHandler(s *Server) *Handler {
@@ -189,6 +192,10 @@ func (h *Handler) GetAllRequestHistoryHotRegion(request *HistoryHotRegionsReques
 
 // AddScheduler adds a scheduler.
 func (h *Handler) AddScheduler(tp types.CheckerSchedulerType, args ...string) error {
+	return h.addScheduler(tp, schedulers.ConfigSliceDecoder(tp, args), args...)
+}
+
+func (h *Handler) addScheduler(tp types.CheckerSchedulerType, decoder schedulers.ConfigDecoder, args ...string) error {
 	c, err := h.GetRaftCluster()
 	if err != nil {
 		return err
@@ -200,7 +207,7 @@ func (h *Handler) AddScheduler(tp types.CheckerSchedulerType, args ...string) er
 	} else {
 		removeSchedulerCb = c.GetCoordinator().GetSchedulersController().RemoveScheduler
 	}
-	s, err := schedulers.CreateScheduler(tp, c.GetOperatorController(), h.s.storage, schedulers.ConfigSliceDecoder(tp, args), removeSchedulerCb)
+	s, err := schedulers.CreateScheduler(tp, c.GetOperatorController(), h.s.storage, decoder, removeSchedulerCb)
 	if err != nil {
 		return err
 	}
@@ -245,7 +252,46 @@ func (h *Handler) RemoveScheduler(name string) error {
 			log.Info("remove scheduler successfully", zap.String("scheduler-name", name))
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if _, ok := h.s.GetScheduleConfig().SchedulerTimeWindows[name]; ok {
+		return h.SetSchedulerTimeWindows(name, nil)
+	}
+	return nil
+}
+
+// GetSchedulerTimeWindows returns the time windows of all schedulers.
+func (h *Handler) GetSchedulerTimeWindows() map[string]sc.TimeWindows {
+	return h.s.GetScheduleConfig().SchedulerTimeWindows
+}
+
+// SetSchedulerTimeWindows sets the time windows of a scheduler, outside which the
+// scheduler is paused. The time windows are removed if it is empty.
+func (h *Handler) SetSchedulerTimeWindows(name string, windows sc.TimeWindows) error {
+	if len(windows) > 0 {
+		names, _, err := h.s.storage.LoadAllSchedulerConfigs()
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(names, name) {
+			return errs.ErrSchedulerNotFound.FastGenByArgs()
+		}
+	}
+	cfg := h.s.GetScheduleConfig()
+	if len(windows) > 0 {
+		if cfg.SchedulerTimeWindows == nil {
+			cfg.SchedulerTimeWindows = make(map[string]sc.TimeWindows)
+		}
+		cfg.SchedulerTimeWindows[name] = windows
+	} else {
+		delete(cfg.SchedulerTimeWindows, name)
+	}
+	if err := h.s.SetScheduleConfig(*cfg); err != nil {
+		return err
+	}
+	log.Info("scheduler time windows are updated", zap.String("scheduler-name", name), zap.Reflect("time-windows", windows))
+	return nil
 }
 
 // SetAllStoresLimit is used to set limit of all stores.
@@ -1737,6 +1737,9 @@ func (s *Server) campaignLeader() {
 	})
 
 	CheckPDVersionWithClusterVersion(s.persistOptions)
+	if err := s.keyspaceManager.ReconcileResourcePools(); err != nil {
+		log.Warn("failed to reconcile the rules of the resource pools", errs.ZapError(err))
+	}
 	log.Info("PD leader is ready to serve", zap.String("leader-name", s.Name()))
 
 	leaderTicker := time.NewTicker(constant.LeaderTickInterval)
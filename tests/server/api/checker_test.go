@@ -17,12 +17,14 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/tikv/pd/pkg/schedule/checker"
 	tu "github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/tests"
 )
@@ -51,6 +53,7 @@ func (suite *checkerTestSuite) TestAPI() {
 func (suite *checkerTestSuite) checkAPI(cluster *tests.TestCluster) {
 	re := suite.Require()
 	testErrCases(re, cluster)
+	testRepairQueue(re, cluster)
 
 	testCases := []struct {
 		name string
@@ -98,6 +101,16 @@ func testErrCases(re *require.Assertions, cluster *tests.TestCluster) {
 	re.NoError(err)
 }
 
+func testRepairQueue(re *require.Assertions, cluster *tests.TestCluster) {
+	urlPrefix := fmt.Sprintf("%s/pd/api/v1/checker/repair-queue", cluster.GetLeaderServer().GetAddr())
+	var queue []*checker.RegionRisk
+	err := tu.ReadGetJSON(re, tests.TestDialClient, urlPrefix+"?limit=10", &queue)
+	re.NoError(err)
+	re.Empty(queue)
+	err = tu.CheckGetJSON(tests.TestDialClient, urlPrefix+"?limit=-1", nil, tu.Status(re, http.StatusBadRequest))
+	re.NoError(err)
+}
+
 func testGetStatus(re *require.Assertions, cluster *tests.TestCluster, name string) {
 	input := make(map[string]any)
 	urlPrefix := fmt.Sprintf("%s/pd/api/v1/checker", cluster.GetLeaderServer().GetAddr())
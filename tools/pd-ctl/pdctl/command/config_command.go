@@ -42,13 +42,19 @@ const (
 	clusterVersionPrefix          = "pd/api/v1/config/cluster-version"
 	rulesPrefix                   = "pd/api/v1/config/rules"
 	rulesBatchPrefix              = "pd/api/v1/config/rules/batch"
+	rulesSimulatePrefix           = "pd/api/v1/config/rules/simulate"
 	rulePrefix                    = "pd/api/v1/config/rule"
 	ruleGroupPrefix               = "pd/api/v1/config/rule_group"
 	ruleGroupsPrefix              = "pd/api/v1/config/rule_groups"
 	replicationModePrefix         = "pd/api/v1/config/replication-mode"
 	ruleBundlePrefix              = "pd/api/v1/config/placement-rule"
+	ruleTemplatePrefix            = "pd/api/v1/config/rule_template"
+	ruleTemplatesPrefix           = "pd/api/v1/config/rule_templates"
+	ruleTemplateInstancePrefix    = "pd/api/v1/config/rule_template_instance"
+	ruleTemplateInstancesPrefix   = "pd/api/v1/config/rule_template_instances"
 	pdServerPrefix                = "pd/api/v1/config/pd-server"
 	serviceMiddlewareConfigPrefix = "pd/api/v1/service-middleware/config"
+	configSnapshotsPrefix         = "pd/api/v1/config/snapshots"
 	// flagFromPD is useful for us to debug.
 	flagFromPD = "from_pd"
 )
@@ -63,6 +69,7 @@ func NewConfigCommand() *cobra.Command {
 	conf.AddCommand(NewSetConfigCommand())
 	conf.AddCommand(NewDeleteConfigCommand())
 	conf.AddCommand(NewPlacementRulesCommand())
+	conf.AddCommand(NewConfigSnapshotCommand())
 	return conf
 }
 
@@ -566,6 +573,12 @@ func NewPlacementRulesCommand() *cobra.Command {
 		Run:   putPlacementRulesFunc,
 	}
 	save.Flags().String("in", "rules.json", "the filename contains rules")
+	simulate := &cobra.Command{
+		Use:   "simulate",
+		Short: "simulate saving rules from file without applying them, show the regions and stores to be affected",
+		Run:   simulatePlacementRulesFunc,
+	}
+	simulate.Flags().String("in", "rules.json", "the filename contains rules")
 	ruleGroup := &cobra.Command{
 		Use:   "rule-group",
 		Short: "rule group configurations",
@@ -625,11 +638,65 @@ func NewPlacementRulesCommand() *cobra.Command {
 	}
 	ruleBundleSave.Flags().String("in", "rules.json", "the file contains all group configs and all rules")
 	ruleBundleSave.Flags().Bool("partial", false, "do not drop all old configurations, partial update")
-	ruleBundle.AddCommand(ruleBundleGet, ruleBundleSet, ruleBundleDelete, ruleBundleLoad, ruleBundleSave)
-	c.AddCommand(enable, disable, show, load, save, ruleGroup, ruleBundle)
+	ruleBundleSimulate := &cobra.Command{
+		Use:   "simulate",
+		Short: "simulate saving all group configs and rules from file without applying them, show the regions and stores to be affected",
+		Run:   simulateRuleBundleFunc,
+	}
+	ruleBundleSimulate.Flags().String("in", "rules.json", "the file contains all group configs and all rules")
+	ruleBundleSimulate.Flags().Bool("partial", false, "do not drop all old configurations, partial update")
+	ruleBundle.AddCommand(ruleBundleGet, ruleBundleSet, ruleBundleDelete, ruleBundleLoad, ruleBundleSave, ruleBundleSimulate)
+	c.AddCommand(enable, disable, show, load, save, simulate, ruleGroup, ruleBundle, newRuleTemplateCommand())
 	return c
 }
 
+func newRuleTemplateCommand() *cobra.Command {
+	ruleTemplate := &cobra.Command{
+		Use:   "rule-template",
+		Short: "parameterized rule groups, updating a template re-renders all the instances of it",
+	}
+	ruleTemplateShow := &cobra.Command{
+		Use:   "show [id]",
+		Short: "show rule template(s)",
+		Run:   showRuleTemplateFunc,
+	}
+	ruleTemplateSet := &cobra.Command{
+		Use:   "set",
+		Short: "set rule template from file",
+		Run:   setRuleTemplateFunc,
+	}
+	ruleTemplateSet.Flags().String("in", "template.json", "the file contains one rule template")
+	ruleTemplateDelete := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "delete rule template which is not used by any instance",
+		Run:   deleteRuleTemplateFunc,
+	}
+	instance := &cobra.Command{
+		Use:   "instance",
+		Short: "rule groups instantiated from the templates",
+	}
+	instanceShow := &cobra.Command{
+		Use:   "show [group_id]",
+		Short: "show rule template instance(s)",
+		Run:   showRuleTemplateInstanceFunc,
+	}
+	instanceSet := &cobra.Command{
+		Use:   "set <group_id> <template_id> [<param>=<value>]...",
+		Short: "instantiate the template as the rule group on the key range",
+		Run:   setRuleTemplateInstanceFunc,
+	}
+	instanceSet.Flags().String("start-key", "", "hex format start key of the rules")
+	instanceSet.Flags().String("end-key", "", "hex format end key of the rules")
+	instanceDelete := &cobra.Command{
+		Use:   "delete <group_id>",
+		Short: "delete rule template instance and its rule group",
+		Run:   deleteRuleTemplateInstanceFunc,
+	}
+	instance.AddCommand(instanceShow, instanceSet, instanceDelete)
+	ruleTemplate.AddCommand(ruleTemplateShow, ruleTemplateSet, ruleTemplateDelete, instance)
+	return ruleTemplate
+}
+
 func enablePlacementRulesFunc(cmd *cobra.Command, _ []string) {
 	err := postConfigDataWithPath(cmd, "enable-placement-rules", "true", configPrefix)
 	if err != nil {
@@ -700,20 +767,53 @@ func getPlacementRulesFunc(cmd *cobra.Command, _ []string) {
 }
 
 func putPlacementRulesFunc(cmd *cobra.Command, _ []string) {
+	opts, err := readPlacementRuleOps(cmd)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	b, _ := json.Marshal(opts)
+	_, err = doRequest(cmd, rulesBatchPrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(b)))
+	if err != nil {
+		cmd.Printf("failed to save rules %s: %s\n", b, err)
+		return
+	}
+
+	cmd.Println("Success!")
+}
+
+func simulatePlacementRulesFunc(cmd *cobra.Command, _ []string) {
+	opts, err := readPlacementRuleOps(cmd)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	b, _ := json.Marshal(map[string]any{"batch": opts})
+	res, err := doRequest(cmd, rulesSimulatePrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(b)))
+	if err != nil {
+		cmd.Printf("failed to simulate rules %s: %s\n", b, err)
+		return
+	}
+	cmd.Println(res)
+}
+
+// readPlacementRuleOps reads the rules from the file, the rules whose count is 0
+// are deleted.
+func readPlacementRuleOps(cmd *cobra.Command) ([]*placement.RuleOp, error) {
 	var file string
 	if f := cmd.Flag("in"); f != nil {
 		file = f.Value.String()
 	}
 	content, err := os.ReadFile(file)
 	if err != nil {
-		cmd.Println(err)
-		return
+		return nil, err
 	}
 
 	var opts []*placement.RuleOp
 	if err = json.Unmarshal(content, &opts); err != nil {
-		cmd.Println(err)
-		return
+		return nil, err
 	}
 
 	validOpts := opts[:0]
@@ -726,15 +826,7 @@ func putPlacementRulesFunc(cmd *cobra.Command, _ []string) {
 			validOpts = append(validOpts, op)
 		}
 	}
-
-	b, _ := json.Marshal(validOpts)
-	_, err = doRequest(cmd, rulesBatchPrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(b)))
-	if err != nil {
-		cmd.Printf("failed to save rules %s: %s\n", b, err)
-		return
-	}
-
-	cmd.Println("Success!")
+	return validOpts, nil
 }
 
 func showRuleGroupFunc(cmd *cobra.Command, args []string) {
@@ -864,6 +956,109 @@ func delRuleBundle(cmd *cobra.Command, args []string) {
 	cmd.Println(res)
 }
 
+func showRuleTemplateFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	reqPath := ruleTemplatesPrefix
+	if len(args) > 0 {
+		reqPath = path.Join(ruleTemplatePrefix, url.PathEscape(args[0]))
+	}
+	res, err := doRequest(cmd, reqPath, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func setRuleTemplateFunc(cmd *cobra.Command, _ []string) {
+	var file string
+	if f := cmd.Flag("in"); f != nil {
+		file = f.Value.String()
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	res, err := doRequest(cmd, ruleTemplatePrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewReader(content)))
+	if err != nil {
+		cmd.Printf("failed to save rule template %s: %s\n", content, err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func deleteRuleTemplateFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, path.Join(ruleTemplatePrefix, url.PathEscape(args[0])), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func showRuleTemplateInstanceFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	reqPath := ruleTemplateInstancesPrefix
+	if len(args) > 0 {
+		reqPath = path.Join(ruleTemplateInstancePrefix, url.PathEscape(args[0]))
+	}
+	res, err := doRequest(cmd, reqPath, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func setRuleTemplateInstanceFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	params := make(map[string]string)
+	for _, arg := range args[2:] {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			cmd.Printf("parameter %s should be in the format of <param>=<value>\n", arg)
+			return
+		}
+		params[kv[0]] = kv[1]
+	}
+	startKey, _ := cmd.Flags().GetString("start-key")
+	endKey, _ := cmd.Flags().GetString("end-key")
+	postJSON(cmd, ruleTemplateInstancePrefix, map[string]any{
+		"group_id":    args[0],
+		"template_id": args[1],
+		"start_key":   startKey,
+		"end_key":     endKey,
+		"params":      params,
+	})
+}
+
+func deleteRuleTemplateInstanceFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, path.Join(ruleTemplateInstancePrefix, url.PathEscape(args[0])), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(res)
+}
+
 func loadRuleBundle(cmd *cobra.Command, _ []string) {
 	header := buildHeader(cmd)
 	res, err := doRequest(cmd, ruleBundlePrefix, http.MethodGet, header)
@@ -914,6 +1109,163 @@ func saveRuleBundle(cmd *cobra.Command, _ []string) {
 	cmd.Println(res)
 }
 
+func simulateRuleBundleFunc(cmd *cobra.Command, _ []string) {
+	var file string
+	if f := cmd.Flag("in"); f != nil {
+		file = f.Value.String()
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	var bundles []placement.GroupBundle
+	if err = json.Unmarshal(content, &bundles); err != nil {
+		cmd.Println(err)
+		return
+	}
+	partial, _ := cmd.Flags().GetBool("partial")
+
+	b, _ := json.Marshal(&placement.RuleChange{Bundles: bundles, Override: !partial})
+	res, err := doRequest(cmd, rulesSimulatePrefix, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewReader(b)))
+	if err != nil {
+		cmd.Printf("failed to simulate rule bundles %s: %s\n", content, err)
+		return
+	}
+	cmd.Println(res)
+}
+
+// NewConfigSnapshotCommand returns a snapshot subcommand of configCmd
+func NewConfigSnapshotCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "snapshot <subcommand>",
+		Short: "save, compare and roll back the scheduling configs",
+	}
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "list all saved config snapshots",
+		Run:   listConfigSnapshotsFunc,
+	}
+	show := &cobra.Command{
+		Use:   "show [<version>|current]",
+		Short: "show a config snapshot, the current configs are shown if the version is not specified",
+		Run:   showConfigSnapshotFunc,
+	}
+	save := &cobra.Command{
+		Use:   "save [<comment>]",
+		Short: "save the current scheduling configs as a new config snapshot",
+		Run:   saveConfigSnapshotFunc,
+	}
+	diff := &cobra.Command{
+		Use:   "diff <version>|current [<base-version>|current]",
+		Short: "show the differences from the base config snapshot, which is the current configs by default",
+		Run:   diffConfigSnapshotFunc,
+	}
+	apply := &cobra.Command{
+		Use:   "apply <version>",
+		Short: "apply a saved config snapshot, the configs are restored if it fails",
+		Run:   applyConfigSnapshotFunc,
+	}
+	del := &cobra.Command{
+		Use:   "delete <version>",
+		Short: "delete a saved config snapshot",
+		Run:   deleteConfigSnapshotFunc,
+	}
+	c.AddCommand(list, show, save, diff, apply, del)
+	return c
+}
+
+func listConfigSnapshotsFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, configSnapshotsPrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get config snapshots: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func showConfigSnapshotFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	version := "current"
+	if len(args) == 1 {
+		version = args[0]
+	}
+	res, err := doRequest(cmd, path.Join(configSnapshotsPrefix, version), http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get config snapshot: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func saveConfigSnapshotFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	input := map[string]any{}
+	if len(args) == 1 {
+		input["comment"] = args[0]
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	res, err := doRequest(cmd, configSnapshotsPrefix, http.MethodPost,
+		http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(data)))
+	if err != nil {
+		cmd.Printf("Failed to save config snapshot: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func diffConfigSnapshotFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	reqPath := path.Join(configSnapshotsPrefix, args[0], "diff")
+	if len(args) == 2 {
+		reqPath += "?base=" + url.QueryEscape(args[1])
+	}
+	res, err := doRequest(cmd, reqPath, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to diff config snapshot: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func applyConfigSnapshotFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	postJSON(cmd, path.Join(configSnapshotsPrefix, args[0], "apply"), nil)
+}
+
+func deleteConfigSnapshotFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, path.Join(configSnapshotsPrefix, args[0]), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to delete config snapshot: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
 func buildHeader(cmd *cobra.Command) http.Header {
 	header := http.Header{}
 	forbiddenRedirectToMicroservice, err := cmd.Flags().GetBool(flagFromPD)
@@ -19,15 +19,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/tikv/pd/pkg/keyspace"
+	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/server/apiv2/handlers"
 )
 
 const (
-	keyspacePrefix = "pd/api/v2/keyspaces"
+	keyspacePrefix     = "pd/api/v2/keyspaces"
+	resourcePoolPrefix = "pd/api/v2/resource-pools"
 	// flags
 	nmConfig              = "config"
 	nmLimit               = "limit"
@@ -35,6 +39,9 @@ const (
 	nmRemove              = "remove"
 	nmUpdate              = "update"
 	nmForceRefreshGroupID = "force_refresh_group_id"
+	nmLabels              = "labels"
+	nmReplicas            = "replicas"
+	nmLocationLabels      = "location-labels"
 )
 
 // NewKeyspaceCommand returns a keyspace subcommand of rootCmd.
@@ -48,6 +55,7 @@ func NewKeyspaceCommand() *cobra.Command {
 	cmd.AddCommand(newUpdateKeyspaceConfigCommand())
 	cmd.AddCommand(newUpdateKeyspaceStateCommand())
 	cmd.AddCommand(newListKeyspaceCommand())
+	cmd.AddCommand(newResourcePoolCommand())
 	return cmd
 }
 
@@ -308,3 +316,119 @@ func listKeyspaceCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	cmd.Println(resp)
 }
+
+func newResourcePoolCommand() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "resource-pool <command> [flags]",
+		Short: "resource pool commands, a resource pool confines a range of keyspaces to the stores selected by labels",
+	}
+	show := &cobra.Command{
+		Use:   "show [<pool-name>]",
+		Short: "show all the resource pools or the specified one",
+		Run:   showResourcePoolCommandFunc,
+	}
+	set := &cobra.Command{
+		Use:   "set <pool-name> <start-keyspace-id> <end-keyspace-id> [flags]",
+		Short: "create or update a resource pool",
+		Run:   setResourcePoolCommandFunc,
+	}
+	set.Flags().StringSlice(nmLabels, nil, "the labels of the stores in the pool, "+
+		"specify as comma separated key value pairs, e.g. --labels tenant=a,zone=z1")
+	set.Flags().Int(nmReplicas, 0, "the number of the replicas, the max replicas of the cluster is used if it is 0")
+	set.Flags().StringSlice(nmLocationLabels, nil, "the location labels used to isolate the replicas in the pool")
+	del := &cobra.Command{
+		Use:   "delete <pool-name>",
+		Short: "delete a resource pool",
+		Run:   deleteResourcePoolCommandFunc,
+	}
+	r.AddCommand(show)
+	r.AddCommand(set)
+	r.AddCommand(del)
+	return r
+}
+
+func showResourcePoolCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Usage()
+		return
+	}
+	url := resourcePoolPrefix
+	if len(args) == 1 {
+		url += "/" + args[0]
+	}
+	resp, err := doRequest(cmd, url, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.PrintErrln("Failed to get the resource pool: ", err)
+		return
+	}
+	cmd.Println(resp)
+}
+
+func setResourcePoolCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 3 {
+		cmd.Usage()
+		return
+	}
+	pool := &keyspace.ResourcePool{Name: args[0]}
+	for i, arg := range args[1:] {
+		id, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			cmd.PrintErrf("Failed to set the resource pool: invalid keyspace id %s\n", arg)
+			return
+		}
+		if i == 0 {
+			pool.StartKeyspaceID = uint32(id)
+		} else {
+			pool.EndKeyspaceID = uint32(id)
+		}
+	}
+	labels, err := cmd.Flags().GetStringSlice(nmLabels)
+	if err != nil {
+		cmd.PrintErrln("Failed to parse flag: ", err)
+		return
+	}
+	for _, label := range labels {
+		pair := strings.Split(label, "=")
+		if len(pair) != 2 {
+			cmd.PrintErrf("Failed to set the resource pool: invalid label %s\n", label)
+			return
+		}
+		pool.LabelConstraints = append(pool.LabelConstraints, placement.LabelConstraint{
+			Key:    pair[0],
+			Op:     placement.In,
+			Values: []string{pair[1]},
+		})
+	}
+	if pool.Replicas, err = cmd.Flags().GetInt(nmReplicas); err != nil {
+		cmd.PrintErrln("Failed to parse flag: ", err)
+		return
+	}
+	if pool.LocationLabels, err = cmd.Flags().GetStringSlice(nmLocationLabels); err != nil {
+		cmd.PrintErrln("Failed to parse flag: ", err)
+		return
+	}
+	body, err := json.Marshal(pool)
+	if err != nil {
+		cmd.PrintErrln("Failed to encode the request body: ", err)
+		return
+	}
+	resp, err := doRequest(cmd, resourcePoolPrefix, http.MethodPost, http.Header{}, WithBody(bytes.NewBuffer(body)))
+	if err != nil {
+		cmd.PrintErrln("Failed to set the resource pool: ", err)
+		return
+	}
+	cmd.Println(resp)
+}
+
+func deleteResourcePoolCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	resp, err := doRequest(cmd, fmt.Sprintf("%s/%s", resourcePoolPrefix, args[0]), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.PrintErrln("Failed to delete the resource pool: ", err)
+		return
+	}
+	cmd.Println(resp)
+}
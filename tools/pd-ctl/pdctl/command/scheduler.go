@@ -33,6 +33,9 @@ var (
 	schedulersPrefix          = "pd/api/v1/schedulers"
 	schedulerConfigPrefix     = "pd/api/v1/scheduler-config"
 	schedulerDiagnosticPrefix = "pd/api/v1/schedulers/diagnostic"
+	schedulerDryRunPrefix     = "pd/api/v1/schedulers/dry-run"
+	schedulerTracePrefix      = "pd/api/v1/schedulers/trace"
+	schedulerTimeWindowPrefix = "pd/api/v1/config/scheduler-time-windows"
 	evictLeaderSchedulerName  = "evict-leader-scheduler"
 	grantLeaderSchedulerName  = "grant-leader-scheduler"
 )
@@ -50,9 +53,168 @@ func NewSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewResumeSchedulerCommand())
 	c.AddCommand(NewConfigSchedulerCommand())
 	c.AddCommand(NewDescribeSchedulerCommand())
+	c.AddCommand(NewDryRunSchedulerCommand())
+	c.AddCommand(NewTraceSchedulerCommand())
+	c.AddCommand(NewTimeWindowSchedulerCommand())
 	return c
 }
 
+// NewDryRunSchedulerCommand returns a command to run schedulers without dispatching operators.
+func NewDryRunSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "dry-run [<scheduler>]",
+		Short: "show the operators that schedulers would create without dispatching them",
+		Run:   dryRunSchedulerCommandFunc,
+	}
+	return c
+}
+
+func dryRunSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	url := schedulerDryRunPrefix
+	if len(args) == 1 {
+		url = fmt.Sprintf("%s?name=%s", url, getEscapedSchedulerName(args[0]))
+	}
+	r, err := doRequest(cmd, url, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+// NewTraceSchedulerCommand returns a command to trace why a region is scheduled or not by a scheduler.
+func NewTraceSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "trace <scheduler> <region_id>",
+		Short: "run a scheduler without dispatching operators, and show the plans and the results of all filters about the region",
+		Run:   traceSchedulerCommandFunc,
+	}
+	return c
+}
+
+func traceSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	if _, err := strconv.ParseUint(args[1], 10, 64); err != nil {
+		cmd.Println("region_id should be a number")
+		return
+	}
+	url := fmt.Sprintf("%s/%s/%s", schedulerTracePrefix, getEscapedSchedulerName(args[0]), args[1])
+	r, err := doRequest(cmd, url, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+// NewTimeWindowSchedulerCommand returns a command to manage the time windows of schedulers.
+func NewTimeWindowSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "time-window <subcommand>",
+		Short: "limit schedulers to cron-like time windows, a scheduler is paused outside its time windows",
+	}
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the time windows of all schedulers",
+		Run:   showSchedulerTimeWindowCommandFunc,
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "set <scheduler> <cron> <duration> [<time-zone>]",
+		Short: `set a time window of a scheduler, such as "set balance-region-scheduler '0 1 * * *' 4h"`,
+		Run:   setSchedulerTimeWindowCommandFunc,
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "add <scheduler> <cron> <duration> [<time-zone>]",
+		Short: "add a time window to a scheduler besides its existing time windows",
+		Run:   addSchedulerTimeWindowCommandFunc,
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "delete <scheduler>",
+		Short: "delete all time windows of a scheduler",
+		Run:   deleteSchedulerTimeWindowCommandFunc,
+	})
+	return c
+}
+
+func showSchedulerTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, schedulerTimeWindowPrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func setSchedulerTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	updateSchedulerTimeWindows(cmd, args, false)
+}
+
+func addSchedulerTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	updateSchedulerTimeWindows(cmd, args, true)
+}
+
+func updateSchedulerTimeWindows(cmd *cobra.Command, args []string, appended bool) {
+	if len(args) != 3 && len(args) != 4 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	name := args[0]
+	window := map[string]any{"cron": args[1], "duration": args[2]}
+	if len(args) == 4 {
+		window["time-zone"] = args[3]
+	}
+	windows := []any{window}
+	if appended {
+		r, err := doRequest(cmd, schedulerTimeWindowPrefix, http.MethodGet, http.Header{})
+		if err != nil {
+			cmd.Println(err)
+			return
+		}
+		var all map[string][]any
+		if err := json.Unmarshal([]byte(r), &all); err != nil {
+			cmd.Println(err)
+			return
+		}
+		windows = append(all[name], windows...)
+	}
+	data, err := json.Marshal(windows)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	r, err := doRequest(cmd, schedulerTimeWindowPrefix+"/"+getEscapedSchedulerName(name), http.MethodPost,
+		http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(data)))
+	if err != nil {
+		cmd.Printf("Failed to set the time windows of %s: %s\n", name, err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func deleteSchedulerTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, schedulerTimeWindowPrefix+"/"+getEscapedSchedulerName(args[0]), http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to delete the time windows of %s: %s\n", args[0], err)
+		return
+	}
+	cmd.Println(r)
+}
+
 // NewPauseSchedulerCommand returns a command to pause a scheduler.
 func NewPauseSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -149,6 +311,7 @@ func NewAddSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewShuffleLeaderSchedulerCommand())
 	c.AddCommand(NewShuffleRegionSchedulerCommand())
 	c.AddCommand(NewShuffleHotRegionSchedulerCommand())
+	c.AddCommand(NewShuffleChaosSchedulerCommand())
 	c.AddCommand(NewScatterRangeSchedulerCommand())
 	c.AddCommand(NewScatterRangeSchedulerCommandV2())
 	c.AddCommand(NewBalanceLeaderSchedulerCommand())
@@ -163,6 +326,10 @@ func NewAddSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewBalanceWitnessSchedulerCommand())
 	c.AddCommand(NewTransferWitnessLeaderSchedulerCommand())
 	c.AddCommand(NewBalanceRangeSchedulerCommand())
+	c.AddCommand(NewGlobalBalanceSchedulerCommand())
+	c.AddCommand(NewBalanceCPUSchedulerCommand())
+	c.AddCommand(NewColdRegionMergeSchedulerCommand())
+	c.AddCommand(NewExternalSchedulerCommand())
 	return c
 }
 
@@ -284,6 +451,29 @@ func addSchedulerForShuffleHotRegionCommandFunc(cmd *cobra.Command, args []strin
 	postJSON(cmd, schedulersPrefix, input)
 }
 
+// NewShuffleChaosSchedulerCommand returns a command to add a shuffle-chaos-scheduler.
+func NewShuffleChaosSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "shuffle-chaos-scheduler [light|medium|heavy]",
+		Short: "add a scheduler to shuffle leaders, regions and hot regions with a preset until the run is stopped",
+		Run:   addSchedulerForShuffleChaosCommandFunc,
+	}
+	return c
+}
+
+func addSchedulerForShuffleChaosCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	input := make(map[string]any)
+	input["name"] = cmd.Name()
+	if len(args) == 1 {
+		input["preset"] = args[0]
+	}
+	postJSON(cmd, schedulersPrefix, input)
+}
+
 // NewBalanceLeaderSchedulerCommand returns a command to add a balance-leader-scheduler.
 func NewBalanceLeaderSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -387,6 +577,46 @@ func NewBalanceRangeSchedulerCommand() *cobra.Command {
 	return c
 }
 
+// NewGlobalBalanceSchedulerCommand returns a command to add a global-balance-scheduler.
+func NewGlobalBalanceSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "global-balance-scheduler",
+		Short: "add a scheduler to balance region size of all stores by a global plan",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
+// NewBalanceCPUSchedulerCommand returns a command to add a balance-cpu-scheduler.
+func NewBalanceCPUSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cpu-scheduler",
+		Short: "add a scheduler to balance the read pool and gRPC CPU usage of all stores",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
+// NewColdRegionMergeSchedulerCommand returns a command to add a cold-region-merge-scheduler.
+func NewColdRegionMergeSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "cold-region-merge-scheduler",
+		Short: "add a scheduler to merge the long runs of cold and small regions",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
+// NewExternalSchedulerCommand returns a command to add an external-scheduler.
+func NewExternalSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "external-scheduler <address>",
+		Short: "add a scheduler which gets the operators from the external scheduler service at the address, such as http://127.0.0.1:20180",
+		Run:   addSchedulerForExternalCommandFunc,
+	}
+	return c
+}
+
 // NewTransferWitnessLeaderSchedulerCommand returns a command to add a transfer-witness-leader-shceudler.
 func NewTransferWitnessLeaderSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -425,6 +655,17 @@ func addSchedulerForGrantHotRegionCommandFunc(cmd *cobra.Command, args []string)
 	postJSON(cmd, schedulersPrefix, input)
 }
 
+func addSchedulerForExternalCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	input := make(map[string]any)
+	input["name"] = cmd.Name()
+	input["address"] = args[0]
+	postJSON(cmd, schedulersPrefix, input)
+}
+
 func addSchedulerForBalanceRangeCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 5 {
 		cmd.Println(cmd.UsageString())
@@ -562,8 +803,13 @@ func NewConfigSchedulerCommand() *cobra.Command {
 		newSplitBucketCommand(),
 		newConfigEvictSlowStoreCommand(),
 		newConfigShuffleHotRegionSchedulerCommand(),
+		newConfigShuffleChaosCommand(),
 		newConfigEvictSlowTrendCommand(),
 		newConfigBalanceRangeCommand(),
+		newConfigGlobalBalanceCommand(),
+		newConfigBalanceCPUCommand(),
+		newConfigColdRegionMergeCommand(),
+		newConfigExternalCommand(),
 	)
 	return c
 }
@@ -595,6 +841,212 @@ func newConfigBalanceRangeCommand() *cobra.Command {
 		Run:   listSchedulerConfigCommandFunc,
 	}
 
+	addJob := &cobra.Command{
+		Use:   "add-job [--format=raw|encode|hex] [--timeout=1h] [--priority=0] [--mode=count|write-flow|read-flow] <engine> <role> <alias> <start_key> <end_key>",
+		Short: "add a job to balance region for given range",
+		Run:   addBalanceRangeJobCommandFunc,
+	}
+	addJob.Flags().String("format", "hex", "the key format")
+	addJob.Flags().String("timeout", "1h", "the timeout of the job")
+	addJob.Flags().Int("priority", 0, "the priority of the job, the job with higher priority runs first")
+	addJob.Flags().String("mode", "count", "balance the count of the peers, or the write or read flow of the hot peers")
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	}, addJob, &cobra.Command{
+		Use:   "set-priority <job_id> <priority>",
+		Short: "set the priority of the job",
+		Run:   setBalanceRangeJobPriorityCommandFunc,
+	}, &cobra.Command{
+		Use:   "set-mode <job_id> <count|write-flow|read-flow>",
+		Short: "set the mode of the job",
+		Run:   setBalanceRangeJobModeCommandFunc,
+	}, &cobra.Command{
+		Use:   "progress",
+		Short: "show the progress of the jobs",
+		Run:   showSchedulerProgressCommandFunc,
+	})
+
+	return c
+}
+
+func addBalanceRangeJobCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 5 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	startKey, err := parseKey(cmd.Flags(), args[3])
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	endKey, err := parseKey(cmd.Flags(), args[4])
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	timeout, _ := cmd.Flags().GetString("timeout")
+	priority, _ := cmd.Flags().GetInt("priority")
+	mode, _ := cmd.Flags().GetString("mode")
+
+	input := make(map[string]any)
+	input["engine"] = args[0]
+	input["role"] = args[1]
+	input["alias"] = args[2]
+	input["start-key"] = url.QueryEscape(startKey)
+	input["end-key"] = url.QueryEscape(endKey)
+	input["timeout"] = timeout
+	input["priority"] = priority
+	input["mode"] = mode
+	postJSON(cmd, path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "job"), input)
+}
+
+func setBalanceRangeJobPriorityCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	jobID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	priority, err := strconv.Atoi(args[1])
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	input := map[string]any{"priority": priority}
+	patchJSON(cmd, path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "job", strconv.FormatUint(jobID, 10)), input)
+}
+
+func setBalanceRangeJobModeCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	jobID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		cmd.Println("Error: ", err)
+		return
+	}
+	input := map[string]any{"mode": args[1]}
+	patchJSON(cmd, path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "job", strconv.FormatUint(jobID, 10)), input)
+}
+
+func showSchedulerProgressCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "progress"), http.MethodGet, http.Header{})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			err = errors.New("[404] scheduler not found")
+		}
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func newConfigGlobalBalanceCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "global-balance-scheduler",
+		Short: "global-balance-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	}, &cobra.Command{
+		Use:   "plan",
+		Short: "show the current plan",
+		Run:   showSchedulerPlanCommandFunc,
+	})
+
+	return c
+}
+
+func showSchedulerPlanCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "plan"), http.MethodGet, http.Header{})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			err = errors.New("[404] scheduler not found")
+		}
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func newConfigBalanceCPUCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cpu-scheduler",
+		Short: "balance-cpu-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
+func newConfigColdRegionMergeCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "cold-region-merge-scheduler",
+		Short: "cold-region-merge-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	}, &cobra.Command{
+		Use:   "plan",
+		Short: "show the runs of the cold regions to be merged",
+		Run:   showSchedulerPlanCommandFunc,
+	})
+
+	return c
+}
+
+func newConfigExternalCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "external-scheduler",
+		Short: "external-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
 	c.AddCommand(&cobra.Command{
 		Use:   "show",
 		Short: "show the config item",
@@ -671,6 +1123,10 @@ func newConfigEvictLeaderCommand() *cobra.Command {
 		Use:   "set <key> <value>",
 		Short: "set the config item",
 		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	}, &cobra.Command{
+		Use:   "progress",
+		Short: "show the drain progress of the stores",
+		Run:   showSchedulerProgressCommandFunc,
 	})
 	return c
 }
@@ -799,6 +1255,11 @@ func newConfigGrantHotRegionCommand() *cobra.Command {
 		Short: "set store leader and peers",
 		Run:   func(cmd *cobra.Command, args []string) { setGrantHotRegionCommandFunc(cmd, c.Name(), args) }},
 	)
+	c.AddCommand(&cobra.Command{
+		Use:   "set-policies <policies-json>",
+		Short: "set the policies to pin hot regions to the stores matching the labels, use '[]' to clear them",
+		Run:   func(cmd *cobra.Command, args []string) { setGrantHotRegionPoliciesCommandFunc(cmd, c.Name(), args) }},
+	)
 	return c
 }
 
@@ -828,6 +1289,20 @@ func setGrantHotRegionCommandFunc(cmd *cobra.Command, schedulerName string, args
 	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
 }
 
+func setGrantHotRegionPoliciesCommandFunc(cmd *cobra.Command, schedulerName string, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	var policies []any
+	if err := json.Unmarshal([]byte(args[0]), &policies); err != nil {
+		cmd.Printf("Failed to parse the policies: %s\n", err)
+		return
+	}
+	input := map[string]any{"policies": policies}
+	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
+}
+
 func postSchedulerConfigCommandFunc(cmd *cobra.Command, schedulerName string, args []string) {
 	if len(args) != 2 {
 		cmd.Println(cmd.UsageString())
@@ -840,9 +1315,26 @@ func postSchedulerConfigCommandFunc(cmd *cobra.Command, schedulerName string, ar
 	if err != nil {
 		val = value
 	}
-	if schedulerName == "balance-hot-region-scheduler" && (key == "read-priorities" || key == "write-leader-priorities" || key == "write-peer-priorities") {
+	switch {
+	case schedulerName == "balance-hot-region-scheduler" && (key == "read-priorities" || key == "write-leader-priorities" || key == "write-peer-priorities"):
 		input[key] = strings.Split(value, ",")
-	} else {
+	case schedulerName == "shuffle-chaos-scheduler" && key == "kinds":
+		input[key] = strings.Split(value, ",")
+	case schedulerName == "shuffle-chaos-scheduler" && key == "store-labels":
+		labels := make(map[string]string)
+		for _, kv := range strings.Split(value, ",") {
+			if len(kv) == 0 {
+				continue
+			}
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok {
+				cmd.Println("Error: invalid label " + kv + ", it should be like key=value")
+				return
+			}
+			labels[k] = v
+		}
+		input[key] = labels
+	default:
 		input[key] = val
 	}
 	postJSON(cmd, path.Join(schedulerConfigPrefix, schedulerName, "config"), input)
@@ -956,6 +1448,45 @@ func newConfigShuffleHotRegionSchedulerCommand() *cobra.Command {
 	return c
 }
 
+func newConfigShuffleChaosCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "shuffle-chaos-scheduler",
+		Short: "shuffle-chaos-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "list the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item, such as `set kinds leader,region` and `set store-labels zone=z1,disk=ssd`",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	}, &cobra.Command{
+		Use:   "report",
+		Short: "show the current run and the operators created recently",
+		Run:   showShuffleChaosReportCommandFunc,
+	})
+	return c
+}
+
+func showShuffleChaosReportCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, path.Join(schedulerConfigPrefix, cmd.Parent().Name(), "report"), http.MethodGet, http.Header{})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			err = errors.New("[404] scheduler not found")
+		}
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
 func newConfigEvictSlowTrendCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "evict-slow-trend-scheduler",
@@ -713,6 +713,92 @@ func (suite *configTestSuite) checkPlacementRuleGroups(cluster *pdTests.TestClus
 	})
 }
 
+func (suite *configTestSuite) TestPlacementRuleTemplates() {
+	suite.env.RunTest(suite.checkPlacementRuleTemplates)
+}
+
+func (suite *configTestSuite) checkPlacementRuleTemplates(cluster *pdTests.TestCluster) {
+	re := suite.Require()
+	leaderServer := cluster.GetLeaderServer()
+	pdAddr := leaderServer.GetAddr()
+	cmd := ctl.GetRootCmd()
+
+	store := &metapb.Store{
+		Id:            1,
+		State:         metapb.StoreState_Up,
+		LastHeartbeat: time.Now().UnixNano(),
+	}
+	pdTests.MustPutStore(re, cluster, store)
+	output, err := tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "enable")
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+
+	// test set template
+	f, _ := os.CreateTemp("", "pd_tests")
+	fname := f.Name()
+	f.Close()
+	defer os.RemoveAll(fname)
+	template := `{"id": "learners", "group_index": 5, "rules": [{"id": "learner", "role": "learner", "count": "${count}"}]}`
+	re.NoError(os.WriteFile(fname, []byte(template), 0600))
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "set", "--in="+fname)
+	re.NoError(err)
+	re.Contains(string(output), "Update rule template successfully.")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "show")
+	re.NoError(err)
+	var templates []placement.RuleTemplate
+	re.NoError(json.Unmarshal(output, &templates), string(output))
+	re.Len(templates, 1)
+	re.Equal("learners", templates[0].ID)
+
+	// test instance
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "instance", "set", "g1", "learners", "count")
+	re.NoError(err)
+	re.Contains(string(output), "<param>=<value>")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "instance", "set", "g1", "learners",
+		"count=2", "--start-key=6100000000000000f8", "--end-key=6200000000000000f8")
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "instance", "show", "g1")
+	re.NoError(err)
+	var inst placement.RuleTemplateInstance
+	re.NoError(json.Unmarshal(output, &inst), string(output))
+	re.Equal(placement.RuleTemplateInstance{GroupID: "g1", TemplateID: "learners", StartKeyHex: "6100000000000000f8", EndKeyHex: "6200000000000000f8", Params: map[string]string{"count": "2"}, TemplateVersion: 1}, inst)
+	var rule placement.Rule
+	testutil.Eventually(re, func() bool { // wait for the config to be synced to the scheduling server
+		output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "show", "--group=g1", "--id=learner")
+		re.NoError(err)
+		return json.Unmarshal(output, &rule) == nil && rule.Count == 2
+	})
+	re.Equal(placement.Learner, rule.Role)
+	re.Equal("6100000000000000f8", rule.StartKeyHex)
+
+	// updating the template re-renders the instance
+	re.NoError(os.WriteFile(fname, []byte(strings.Replace(template, `"id": "learner"`, `"id": "learner-${count}"`, 1)), 0600))
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "set", "--in="+fname)
+	re.NoError(err)
+	re.Contains(string(output), "Update rule template successfully.")
+	testutil.Eventually(re, func() bool { // wait for the config to be synced to the scheduling server
+		output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "show", "--group=g1", "--id=")
+		re.NoError(err)
+		var rules []placement.Rule
+		return json.Unmarshal(output, &rules) == nil && len(rules) == 1 && rules[0].ID == "learner-2"
+	})
+
+	// test delete
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "delete", "learners")
+	re.NoError(err)
+	re.Contains(string(output), "is used by group g1")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "instance", "delete", "g1")
+	re.NoError(err)
+	re.Contains(string(output), "Delete rule template instance successfully.")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "delete", "learners")
+	re.NoError(err)
+	re.Contains(string(output), "Delete rule template successfully.")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-template", "show", "learners")
+	re.NoError(err)
+	re.Contains(string(output), "404")
+}
+
 func (suite *configTestSuite) TestPlacementRuleBundle() {
 	suite.env.RunTest(suite.checkPlacementRuleBundle)
 }
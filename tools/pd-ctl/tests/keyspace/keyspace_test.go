@@ -21,12 +21,14 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
+	"github.com/pingcap/kvproto/pkg/metapb"
 
 	"github.com/tikv/pd/pkg/keyspace"
 	"github.com/tikv/pd/pkg/mcs/utils/constant"
@@ -317,3 +319,44 @@ func (suite *keyspaceTestSuite) TestListKeyspace() {
 	}
 	re.Equal("6", resp.NextPageToken)
 }
+
+func (suite *keyspaceTestSuite) TestResourcePool() {
+	re := suite.Require()
+	for id := uint64(1); id <= 3; id++ {
+		pdTests.MustPutStore(re, suite.cluster, &metapb.Store{
+			Id:            id,
+			State:         metapb.StoreState_Up,
+			NodeState:     metapb.NodeState_Serving,
+			Labels:        []*metapb.StoreLabel{{Key: "tenant", Value: "a"}},
+			LastHeartbeat: time.Now().UnixNano(),
+		})
+	}
+	args := []string{"-u", suite.pdAddr, "keyspace", "resource-pool", "set", "a", "1", "100", "--labels", "tenant=a"}
+	output, err := tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.Contains(string(output), "The resource pool is set.")
+	// There are not enough stores for the replicas.
+	args = []string{"-u", suite.pdAddr, "keyspace", "resource-pool", "set", "b", "101", "200", "--labels", "tenant=b"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.Contains(string(output), "not enough capacity")
+
+	args = []string{"-u", suite.pdAddr, "keyspace", "resource-pool", "show", "a"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	var pool keyspace.ResourcePoolStatus
+	re.NoError(json.Unmarshal(output, &pool))
+	re.Equal(uint32(100), pool.EndKeyspaceID)
+	re.Equal([]uint64{1, 2, 3}, pool.StoreIDs)
+
+	args = []string{"-u", suite.pdAddr, "keyspace", "resource-pool", "delete", "a"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	re.Contains(string(output), "The resource pool is deleted.")
+	args = []string{"-u", suite.pdAddr, "keyspace", "resource-pool", "show"}
+	output, err = tests.ExecuteCommand(ctl.GetRootCmd(), args...)
+	re.NoError(err)
+	var pools []*keyspace.ResourcePoolStatus
+	re.NoError(json.Unmarshal(output, &pools))
+	re.Empty(pools)
+}
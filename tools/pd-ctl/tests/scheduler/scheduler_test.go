@@ -534,6 +534,18 @@ func (suite *schedulerTestSuite) checkSchedulerConfig(cluster *pdTests.TestClust
 		mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "evict-leader-scheduler"}, &conf)
 		return conf["batch"] == 5.
 	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "evict-leader-scheduler", "set", "drain-ratio", "0.1"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "evict-leader-scheduler", "set", "drain-interval", "5m"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "evict-leader-scheduler", "set", "drain-ratio", "2"}, nil)
+	re.NotContains(echo, "Success!")
+	testutil.Eventually(re, func() bool {
+		mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "evict-leader-scheduler"}, &conf)
+		return conf["drain-ratio"] == 0.1 && conf["drain-interval"] == "5m0s"
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "evict-leader-scheduler", "progress"}, nil)
+	re.NotContains(echo, "404")
 	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "evict-leader-scheduler-1"}, nil)
 	re.Contains(echo, "Success!")
 	testutil.Eventually(re, func() bool {
@@ -551,20 +563,154 @@ func (suite *schedulerTestSuite) checkSchedulerConfig(cluster *pdTests.TestClust
 	testutil.Eventually(re, func() bool {
 		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler"}, &rangeConf)
 		jobConf = rangeConf[0]
-		return jobConf["role"] == "learner" && jobConf["engine"] == "tiflash" && jobConf["alias"] == "test"
+		// The job begins to run once the scheduler checks whether it is allowed to schedule.
+		return jobConf["role"] == "learner" && jobConf["engine"] == "tiflash" && jobConf["alias"] == "test" &&
+			jobConf["status"] == "running"
 	})
 	re.Equal(float64(time.Hour.Nanoseconds()), jobConf["timeout"])
-	re.Equal("pending", jobConf["status"])
 	ranges := jobConf["ranges"].([]any)[0].(map[string]any)
 	re.Equal(core.HexRegionKeyStr([]byte("a")), ranges["start-key"])
 	re.Equal(core.HexRegionKeyStr([]byte("b")), ranges["end-key"])
 
+	// add another job and update its priority
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler", "add-job", "--format=raw", "--priority=5", "tikv", "leader", "test-2", "c", "d"}, nil)
+	re.Contains(echo, "Success!")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler"}, &rangeConf)
+		return len(rangeConf) == 2 && rangeConf[1]["alias"] == "test-2" && rangeConf[1]["priority"] == 5.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler", "set-priority", "1", "10"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler", "set-priority", "2", "10"}, nil)
+	re.Contains(echo, "404")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler", "set-mode", "1", "write-flow"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler", "set-mode", "1", "unknown"}, nil)
+	re.Contains(echo, "400")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler"}, &rangeConf)
+		return len(rangeConf) == 2 && rangeConf[1]["mode"] == "write-flow"
+	})
+	var progress []map[string]any
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-range-scheduler", "progress"}, &progress)
+		return len(progress) == 2 && progress[1]["priority"] == 10.
+	})
+
 	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "balance-range-scheduler", "--format=raw", "tiflash", "learner", "learner", "a", "b"}, nil)
 	re.Contains(echo, "400")
 	re.Contains(echo, "scheduler already exists")
 	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "balance-range-scheduler"}, nil)
 	re.Contains(echo, "Success!")
 
+	// test global balance scheduler config
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "global-balance-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+	conf = make(map[string]any)
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "global-balance-scheduler", "show"}, &conf)
+		return conf["batch"] == 4. && conf["cross-zone-cost"] == 10.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "global-balance-scheduler", "set", "cross-zone-cost", "20"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "global-balance-scheduler", "set", "batch", "11"}, nil)
+	re.Contains(echo, "400")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "global-balance-scheduler"}, &conf)
+		return conf["batch"] == 4. && conf["cross-zone-cost"] == 20.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "global-balance-scheduler", "plan"}, nil)
+	re.NotContains(echo, "404")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "global-balance-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+
+	// test balance cpu scheduler config
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "balance-cpu-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+	conf = make(map[string]any)
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-cpu-scheduler", "show"}, &conf)
+		return conf["batch"] == 4. && conf["balanced-ratio"] == 0.9
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-cpu-scheduler", "set", "balanced-ratio", "0.8"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-cpu-scheduler", "set", "balanced-ratio", "0.5"}, nil)
+	re.Contains(echo, "400")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-cpu-scheduler"}, &conf)
+		return conf["batch"] == 4. && conf["balanced-ratio"] == 0.8
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "balance-cpu-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+
+	// test cold region merge scheduler config
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "cold-region-merge-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+	conf = make(map[string]any)
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "cold-region-merge-scheduler", "show"}, &conf)
+		return conf["budget-per-hour"] == 10000. && conf["min-run-length"] == 3.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "cold-region-merge-scheduler", "set", "budget-per-hour", "100"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "cold-region-merge-scheduler", "set", "min-run-length", "1"}, nil)
+	re.Contains(echo, "400")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "cold-region-merge-scheduler"}, &conf)
+		return conf["budget-per-hour"] == 100. && conf["min-run-length"] == 3.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "cold-region-merge-scheduler", "plan"}, nil)
+	re.NotContains(echo, "404")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "cold-region-merge-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+
+	// test external scheduler config
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "external-scheduler"}, nil)
+	re.NotContains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "external-scheduler", "127.0.0.1:1"}, nil)
+	re.NotContains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "external-scheduler", "http://127.0.0.1:1"}, nil)
+	re.Contains(echo, "Success!")
+	conf = make(map[string]any)
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "external-scheduler", "show"}, &conf)
+		return conf["address"] == "http://127.0.0.1:1" && conf["timeout"] == "3s" && conf["batch"] == 4.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "external-scheduler", "set", "timeout", "5s"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "external-scheduler", "set", "batch", "100"}, nil)
+	re.Contains(echo, "400")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "external-scheduler"}, &conf)
+		return conf["timeout"] == "5s" && conf["batch"] == 4.
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "external-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+
+	// test shuffle chaos scheduler config
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "add", "shuffle-chaos-scheduler", "medium"}, nil)
+	re.Contains(echo, "Success!")
+	conf = make(map[string]any)
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "shuffle-chaos-scheduler", "show"}, &conf)
+		return conf["preset"] == "medium" && conf["rate"] == 6. && conf["duration"] == "1h0m0s"
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "shuffle-chaos-scheduler", "set", "kinds", "leader,hot-region"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "shuffle-chaos-scheduler", "set", "store-labels", "zone=z1"}, nil)
+	re.Contains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "shuffle-chaos-scheduler", "set", "kinds", "unknown"}, nil)
+	re.Contains(echo, "400")
+	testutil.Eventually(re, func() bool {
+		mightExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "shuffle-chaos-scheduler"}, &conf)
+		return conf["preset"] == "custom" && reflect.DeepEqual(conf["kinds"], []any{"leader", "hot-region"}) &&
+			reflect.DeepEqual(conf["store-labels"], map[string]any{"zone": "z1"})
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "shuffle-chaos-scheduler", "report"}, nil)
+	re.Contains(echo, "operator-count")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "remove", "shuffle-chaos-scheduler"}, nil)
+	re.Contains(echo, "Success!")
+
 	// test balance leader config
 	conf = make(map[string]any)
 	conf1 := make(map[string]any)
@@ -690,6 +836,29 @@ func (suite *schedulerTestSuite) checkGrantHotRegionScheduler(cluster *pdTests.T
 		return compareGrantHotRegionSchedulerConfig(expected3, conf3)
 	})
 
+	// test the policies of grant-hot-region-scheduler
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "grant-hot-region-scheduler", "set-policies", "{"}, nil)
+	re.Contains(echo, "Failed to parse the policies")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "grant-hot-region-scheduler", "set-policies",
+		`[{"name": "p1", "leader-constraints": [{"key": "zone", "op": "eq", "values": ["z1"]}]}]`}, nil)
+	re.NotContains(echo, "Success!")
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "grant-hot-region-scheduler", "set-policies",
+		`[{"name": "p1", "ranges": [{"start-key": "61", "end-key": "62"}], "leader-constraints": [{"key": "zone", "op": "in", "values": ["z1"]}]}]`}, nil)
+	re.Contains(echo, "Success!")
+	testutil.Eventually(re, func() bool {
+		mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "grant-hot-region-scheduler"}, &conf3)
+		policies, ok := conf3["policies"].([]any)
+		return ok && len(policies) == 1 && policies[0].(map[string]any)["name"] == "p1"
+	})
+	echo = mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "grant-hot-region-scheduler", "set-policies", "[]"}, nil)
+	re.Contains(echo, "Success!")
+	testutil.Eventually(re, func() bool {
+		var conf map[string]any
+		mustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "grant-hot-region-scheduler"}, &conf)
+		_, ok := conf["policies"]
+		return !ok
+	})
+
 	checkSchedulerCommand(re, cmd, pdAddr, []string{"-u", pdAddr, "scheduler", "remove", "grant-hot-region-scheduler"}, map[string]bool{
 		"balance-region-scheduler":   true,
 		"balance-leader-scheduler":   true,